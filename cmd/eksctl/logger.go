@@ -13,7 +13,7 @@ import (
 	lol "github.com/kris-nova/lolgopher"
 )
 
-func initLogger(level int, colorValue string, logBuffer *bytes.Buffer, dumpLogsValue bool) {
+func initLogger(level int, colorValue string, logBuffer *bytes.Buffer, dumpLogsValue, logToStderr bool) {
 	logger.Layout = "2006-01-02 15:04:05"
 
 	var bitwiseLevel int
@@ -33,24 +33,32 @@ func initLogger(level int, colorValue string, logBuffer *bytes.Buffer, dumpLogsV
 	}
 	logger.BitwiseLevel = bitwiseLevel
 
+	// stdout is reserved for progress events when they are enabled
+	var stdout, colorOutput io.Writer = os.Stdout, color.Output
+	lolWriter := lol.NewLolWriter()
+	if logToStderr {
+		stdout, colorOutput = os.Stderr, color.Error
+		lolWriter.(*lol.Writer).Output = os.Stderr
+	}
+
 	if dumpLogsValue {
 		switch colorValue {
 		case "fabulous":
-			logger.Writer = io.MultiWriter(lol.NewLolWriter(), logBuffer)
+			logger.Writer = io.MultiWriter(lolWriter, logBuffer)
 		case "true":
-			logger.Writer = io.MultiWriter(color.Output, logBuffer)
+			logger.Writer = io.MultiWriter(colorOutput, logBuffer)
 		default:
-			logger.Writer = io.MultiWriter(os.Stdout, logBuffer)
+			logger.Writer = io.MultiWriter(stdout, logBuffer)
 		}
 
 	} else {
 		switch colorValue {
 		case "fabulous":
-			logger.Writer = lol.NewLolWriter()
+			logger.Writer = lolWriter
 		case "true":
-			logger.Writer = color.Output
+			logger.Writer = colorOutput
 		default:
-			logger.Writer = os.Stdout
+			logger.Writer = stdout
 		}
 	}
 
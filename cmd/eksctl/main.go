@@ -11,21 +11,27 @@ import (
 	"github.com/weaveworks/eksctl/pkg/ctl/register"
 
 	"github.com/weaveworks/eksctl/pkg/actions/anywhere"
+	"github.com/weaveworks/eksctl/pkg/ctl/apply"
 	"github.com/weaveworks/eksctl/pkg/ctl/associate"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/completion"
 	"github.com/weaveworks/eksctl/pkg/ctl/create"
 	"github.com/weaveworks/eksctl/pkg/ctl/delete"
+	"github.com/weaveworks/eksctl/pkg/ctl/diff"
 	"github.com/weaveworks/eksctl/pkg/ctl/disassociate"
 	"github.com/weaveworks/eksctl/pkg/ctl/drain"
 	"github.com/weaveworks/eksctl/pkg/ctl/enable"
+	"github.com/weaveworks/eksctl/pkg/ctl/export"
+	"github.com/weaveworks/eksctl/pkg/ctl/generate"
 	"github.com/weaveworks/eksctl/pkg/ctl/get"
+	"github.com/weaveworks/eksctl/pkg/ctl/replace"
 	"github.com/weaveworks/eksctl/pkg/ctl/scale"
 	"github.com/weaveworks/eksctl/pkg/ctl/set"
 	"github.com/weaveworks/eksctl/pkg/ctl/unset"
 	"github.com/weaveworks/eksctl/pkg/ctl/update"
 	"github.com/weaveworks/eksctl/pkg/ctl/upgrade"
 	"github.com/weaveworks/eksctl/pkg/ctl/utils"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
@@ -36,11 +42,15 @@ func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
 	rootCmd.AddCommand(update.Command(flagGrouping))
 	rootCmd.AddCommand(upgrade.Command(flagGrouping))
 	rootCmd.AddCommand(delete.Command(flagGrouping))
+	rootCmd.AddCommand(diff.Command(flagGrouping))
+	rootCmd.AddCommand(export.Command(flagGrouping))
 	rootCmd.AddCommand(set.Command(flagGrouping))
 	rootCmd.AddCommand(unset.Command(flagGrouping))
 	rootCmd.AddCommand(scale.Command(flagGrouping))
 	rootCmd.AddCommand(drain.Command(flagGrouping))
+	rootCmd.AddCommand(replace.Command(flagGrouping))
 	rootCmd.AddCommand(enable.Command(flagGrouping))
+	rootCmd.AddCommand(generate.Command(flagGrouping))
 	rootCmd.AddCommand(register.Command(flagGrouping))
 	rootCmd.AddCommand(deregister.Command(flagGrouping))
 	rootCmd.AddCommand(utils.Command(flagGrouping))
@@ -48,6 +58,7 @@ func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
 	//Ensures "eksctl --help" presents eksctl anywhere as a command, but adds no subcommands since we invoke the binary.
 	rootCmd.AddCommand(cmdutils.NewVerbCmd("anywhere", "EKS anywhere", ""))
 
+	cmdutils.AddResourceCmd(flagGrouping, rootCmd, apply.Command)
 	cmdutils.AddResourceCmd(flagGrouping, rootCmd, infoCmd)
 	cmdutils.AddResourceCmd(flagGrouping, rootCmd, versionCmd)
 }
@@ -91,12 +102,18 @@ func main() {
 
 	dumpLogsValue := rootCmd.PersistentFlags().BoolP("dumpLogs", "d", false, "dump logs to disk on failure if set to true")
 
+	eventsOutput := rootCmd.PersistentFlags().String("events-output", "", fmt.Sprintf("write progress events to stdout and logs to stderr (valid options: %s)", events.OutputNDJSON))
+
 	logBuffer := new(bytes.Buffer)
 
 	cobra.OnInitialize(func() {
-		initLogger(*loggerLevel, *colorValue, logBuffer, *dumpLogsValue)
+		initLogger(*loggerLevel, *colorValue, logBuffer, *dumpLogsValue, *eventsOutput != "")
 	})
 
+	rootCmd.PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
+		return events.SetOutput(*eventsOutput, os.Stdout)
+	}
+
 	rootCmd.SetUsageFunc(flagGrouping.Usage)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -3,6 +3,8 @@ package accessentry
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/eks"
 
@@ -34,6 +36,12 @@ type Summary struct {
 	AccessPolicies   []api.AccessPolicy `json:"accessPolicies,omitempty"`
 }
 
+// IsManaged reports whether the access entry is created implicitly by EKS or eksctl
+// for nodes, rather than declared under accessConfig.accessEntries.
+func (s Summary) IsManaged() bool {
+	return slices.Contains(s.KubernetesGroups, "system:nodes") || strings.Contains(s.PrincipalARN, ":role/aws-service-role/")
+}
+
 func (aeg *Getter) Get(ctx context.Context, principalARN api.ARN) ([]Summary, error) {
 
 	toBeFetched := []string{principalARN.String()}
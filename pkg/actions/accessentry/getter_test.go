@@ -254,3 +254,19 @@ var _ = Describe("Get", func() {
 		}),
 	)
 })
+
+var _ = DescribeTable("Summary.IsManaged", func(summary accessentry.Summary, expected bool) {
+	Expect(summary.IsManaged()).To(Equal(expected))
+},
+	Entry("node role", accessentry.Summary{
+		PrincipalARN:     "arn:aws:iam::111122223333:role/eksctl-my-cluster-nodegroup-ng-1-NodeInstanceRole",
+		KubernetesGroups: []string{"system:bootstrappers", "system:nodes"},
+	}, true),
+	Entry("service-linked role", accessentry.Summary{
+		PrincipalARN: "arn:aws:iam::111122223333:role/aws-service-role/eks.amazonaws.com/AWSServiceRoleForAmazonEKS",
+	}, true),
+	Entry("declared entry", accessentry.Summary{
+		PrincipalARN:     mockPrincipalArn1,
+		KubernetesGroups: []string{kGroup1},
+	}, false),
+)
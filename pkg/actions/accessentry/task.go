@@ -41,6 +41,8 @@ type accessEntryTask struct {
 
 func (t *accessEntryTask) Describe() string { return t.info }
 
+func (t *accessEntryTask) StackName() string { return MakeStackName(t.clusterName, t.accessEntry) }
+
 func (t *accessEntryTask) Do(errorCh chan error) error {
 	defer close(errorCh)
 	rs := builder.NewAccessEntryResourceSet(t.clusterName, t.accessEntry)
@@ -0,0 +1,181 @@
+package accessentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+// An Updater updates the Kubernetes groups and access policies of existing access entries in place, so that
+// principals do not lose access while their entries are changed.
+type Updater struct {
+	clusterName string
+	eksAPI      awsapi.EKS
+	getter      *Getter
+}
+
+// NewUpdater creates a new Updater.
+func NewUpdater(clusterName string, eksAPI awsapi.EKS) *Updater {
+	return &Updater{
+		clusterName: clusterName,
+		eksAPI:      eksAPI,
+		getter:      NewGetter(clusterName, eksAPI),
+	}
+}
+
+// Update updates the specified access entries to match their Kubernetes groups and access policies.
+func (u *Updater) Update(ctx context.Context, accessEntries []api.AccessEntry) error {
+	taskTree, err := u.UpdateTasks(ctx, accessEntries)
+	if err != nil {
+		return err
+	}
+	if taskTree.Len() == 0 {
+		logger.Info("access entries already up-to-date")
+		return nil
+	}
+
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+		logger.Info("%d error(s) occurred while updating access entries", len(errs))
+		for _, err := range errs {
+			logger.Critical("%s\n", err.Error())
+		}
+		return errors.New("failed to update access entries")
+	}
+	return nil
+}
+
+// UpdateTasks compares the specified access entries with the live entries and returns a TaskTree that associates new
+// policies or policies whose scope changed, disassociates the access policies that are no longer specified, and
+// updates the Kubernetes groups. Policies are associated first so that principals keep the access they are granted.
+// The TaskTree is empty if all entries are up-to-date.
+func (u *Updater) UpdateTasks(ctx context.Context, accessEntries []api.AccessEntry) (*tasks.TaskTree, error) {
+	taskTree := &tasks.TaskTree{
+		Parallel: true,
+	}
+	for _, ae := range accessEntries {
+		principalARN := ae.PrincipalARN.String()
+		live, err := u.getter.getIndividualEntry(ctx, principalARN)
+		if err != nil {
+			var notFoundErr *ekstypes.ResourceNotFoundException
+			if errors.As(err, &notFoundErr) {
+				return nil, fmt.Errorf("access entry for principal ARN %s does not exist; use `eksctl create accessentry` to create it", principalARN)
+			}
+			return nil, err
+		}
+		if entryTasks := u.makeEntryTasks(ctx, ae, live); entryTasks.Len() > 0 {
+			taskTree.Append(entryTasks)
+		}
+	}
+	return taskTree, nil
+}
+
+func (u *Updater) makeEntryTasks(ctx context.Context, desired api.AccessEntry, live Summary) *tasks.TaskTree {
+	principalARN := desired.PrincipalARN.String()
+	entryTasks := &tasks.TaskTree{
+		IsSubTask: true,
+	}
+
+	livePolicies := map[string]api.AccessPolicy{}
+	for _, p := range live.AccessPolicies {
+		livePolicies[p.PolicyARN.String()] = p
+	}
+	for _, p := range desired.AccessPolicies {
+		policyARN := p.PolicyARN.String()
+		livePolicy, ok := livePolicies[policyARN]
+		if ok && formatAccessScope(livePolicy.AccessScope) == formatAccessScope(p.AccessScope) {
+			continue
+		}
+		accessScope := p.AccessScope
+		entryTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("associate access policy %s with %s with principal ARN %s", policyARN, formatAccessScope(accessScope), principalARN),
+			Doer: func() error {
+				if _, err := u.eksAPI.AssociateAccessPolicy(ctx, &eks.AssociateAccessPolicyInput{
+					ClusterName:  aws.String(u.clusterName),
+					PrincipalArn: aws.String(principalARN),
+					PolicyArn:    aws.String(policyARN),
+					AccessScope: &ekstypes.AccessScope{
+						Type:       accessScope.Type,
+						Namespaces: accessScope.Namespaces,
+					},
+				}); err != nil {
+					return fmt.Errorf("associating access policy %s with principal ARN %s: %w", policyARN, principalARN, err)
+				}
+				logger.Info("associated access policy %s with principal ARN %q", policyARN, principalARN)
+				return nil
+			},
+		})
+	}
+
+	desiredPolicies := map[string]api.AccessPolicy{}
+	for _, p := range desired.AccessPolicies {
+		desiredPolicies[p.PolicyARN.String()] = p
+	}
+	for _, p := range live.AccessPolicies {
+		policyARN := p.PolicyARN.String()
+		if _, ok := desiredPolicies[policyARN]; ok {
+			continue
+		}
+		entryTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("disassociate access policy %s from principal ARN %s", policyARN, principalARN),
+			Doer: func() error {
+				if _, err := u.eksAPI.DisassociateAccessPolicy(ctx, &eks.DisassociateAccessPolicyInput{
+					ClusterName:  aws.String(u.clusterName),
+					PrincipalArn: aws.String(principalARN),
+					PolicyArn:    aws.String(policyARN),
+				}); err != nil {
+					return fmt.Errorf("disassociating access policy %s from principal ARN %s: %w", policyARN, principalARN, err)
+				}
+				logger.Info("disassociated access policy %s from principal ARN %q", policyARN, principalARN)
+				return nil
+			},
+		})
+	}
+
+	if !sameGroups(desired.KubernetesGroups, live.KubernetesGroups) {
+		groups := append([]string{}, desired.KubernetesGroups...)
+		entryTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("update Kubernetes groups of principal ARN %s to [%s]", principalARN, strings.Join(groups, ",")),
+			Doer: func() error {
+				if _, err := u.eksAPI.UpdateAccessEntry(ctx, &eks.UpdateAccessEntryInput{
+					ClusterName:      aws.String(u.clusterName),
+					PrincipalArn:     aws.String(principalARN),
+					KubernetesGroups: groups,
+				}); err != nil {
+					return fmt.Errorf("updating Kubernetes groups of principal ARN %s: %w", principalARN, err)
+				}
+				logger.Info("updated Kubernetes groups of principal ARN %q", principalARN)
+				return nil
+			},
+		})
+	}
+	return entryTasks
+}
+
+// formatAccessScope returns a description of the access scope that is independent of the order of its namespaces.
+func formatAccessScope(scope api.AccessScope) string {
+	if scope.Type != ekstypes.AccessScopeTypeNamespace {
+		return fmt.Sprintf("%s scope", scope.Type)
+	}
+	namespaces := slices.Clone(scope.Namespaces)
+	slices.Sort(namespaces)
+	return fmt.Sprintf("namespace scope [%s]", strings.Join(namespaces, ","))
+}
+
+func sameGroups(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,145 @@
+package accessentry_test
+
+import (
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Update", func() {
+	var (
+		mockProvider *mockprovider.MockProvider
+		updater      *accessentry.Updater
+	)
+
+	mockLiveEntry := func(groups []string, policies ...ekstypes.AssociatedAccessPolicy) {
+		mockProvider.MockEKS().
+			On("DescribeAccessEntry", mock.Anything, mock.Anything).
+			Return(&eks.DescribeAccessEntryOutput{
+				AccessEntry: &ekstypes.AccessEntry{
+					PrincipalArn:     aws.String(mockPrincipalArn1),
+					KubernetesGroups: groups,
+				},
+			}, nil)
+		mockProvider.MockEKS().
+			On("ListAssociatedAccessPolicies", mock.Anything, mock.Anything).
+			Return(&eks.ListAssociatedAccessPoliciesOutput{
+				AssociatedAccessPolicies: policies,
+			}, nil)
+	}
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		updater = accessentry.NewUpdater(clusterName, mockProvider.EKS())
+	})
+
+	It("returns no tasks when the access entry is up-to-date", func() {
+		mockLiveEntry([]string{kGroup2, kGroup1}, ekstypes.AssociatedAccessPolicy{
+			PolicyArn: aws.String(mockPolicyArn1),
+			AccessScope: &ekstypes.AccessScope{
+				Type:       ekstypes.AccessScopeTypeNamespace,
+				Namespaces: []string{namespace2, namespace1},
+			},
+		})
+
+		taskTree, err := updater.UpdateTasks(context.Background(), []api.AccessEntry{
+			{
+				PrincipalARN:     api.MustParseARN(mockPrincipalArn1),
+				KubernetesGroups: []string{kGroup1, kGroup2},
+				AccessPolicies: []api.AccessPolicy{
+					{
+						PolicyARN: api.MustParseARN(mockPolicyArn1),
+						AccessScope: api.AccessScope{
+							Type:       ekstypes.AccessScopeTypeNamespace,
+							Namespaces: []string{namespace1, namespace2},
+						},
+					},
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(taskTree.Len()).To(BeZero())
+	})
+
+	It("applies only the changes to access policies and Kubernetes groups", func() {
+		mockLiveEntry([]string{kGroup1},
+			ekstypes.AssociatedAccessPolicy{
+				PolicyArn:   aws.String(mockPolicyArn1),
+				AccessScope: &ekstypes.AccessScope{Type: ekstypes.AccessScopeTypeCluster},
+			},
+			ekstypes.AssociatedAccessPolicy{
+				PolicyArn:   aws.String(mockPolicyArn2),
+				AccessScope: &ekstypes.AccessScope{Type: ekstypes.AccessScopeTypeCluster},
+			},
+		)
+		mockProvider.MockEKS().
+			On("DisassociateAccessPolicy", mock.Anything, &eks.DisassociateAccessPolicyInput{
+				ClusterName:  aws.String(clusterName),
+				PrincipalArn: aws.String(mockPrincipalArn1),
+				PolicyArn:    aws.String(mockPolicyArn1),
+			}).
+			Return(&eks.DisassociateAccessPolicyOutput{}, nil).Once()
+		mockProvider.MockEKS().
+			On("AssociateAccessPolicy", mock.Anything, &eks.AssociateAccessPolicyInput{
+				ClusterName:  aws.String(clusterName),
+				PrincipalArn: aws.String(mockPrincipalArn1),
+				PolicyArn:    aws.String(mockPolicyArn2),
+				AccessScope: &ekstypes.AccessScope{
+					Type:       ekstypes.AccessScopeTypeNamespace,
+					Namespaces: []string{namespace1},
+				},
+			}).
+			Return(&eks.AssociateAccessPolicyOutput{}, nil).Once()
+		mockProvider.MockEKS().
+			On("UpdateAccessEntry", mock.Anything, &eks.UpdateAccessEntryInput{
+				ClusterName:      aws.String(clusterName),
+				PrincipalArn:     aws.String(mockPrincipalArn1),
+				KubernetesGroups: []string{},
+			}).
+			Return(&eks.UpdateAccessEntryOutput{}, nil).Once()
+
+		Expect(updater.Update(context.Background(), []api.AccessEntry{
+			{
+				PrincipalARN: api.MustParseARN(mockPrincipalArn1),
+				AccessPolicies: []api.AccessPolicy{
+					{
+						PolicyARN: api.MustParseARN(mockPolicyArn2),
+						AccessScope: api.AccessScope{
+							Type:       ekstypes.AccessScopeTypeNamespace,
+							Namespaces: []string{namespace1},
+						},
+					},
+				},
+			},
+		})).To(Succeed())
+		mockProvider.MockEKS().AssertExpectations(GinkgoT())
+
+		var calls []string
+		for _, call := range mockProvider.MockEKS().Calls {
+			calls = append(calls, call.Method)
+		}
+		Expect(calls).To(ContainElements("AssociateAccessPolicy", "DisassociateAccessPolicy", "UpdateAccessEntry"))
+		Expect(slices.Index(calls, "AssociateAccessPolicy")).To(BeNumerically("<", slices.Index(calls, "DisassociateAccessPolicy")))
+	})
+
+	It("returns an error if the access entry does not exist", func() {
+		mockProvider.MockEKS().
+			On("DescribeAccessEntry", mock.Anything, mock.Anything).
+			Return(nil, &ekstypes.ResourceNotFoundException{Message: aws.String("not found")})
+
+		_, err := updater.UpdateTasks(context.Background(), []api.AccessEntry{
+			{PrincipalARN: api.MustParseARN(mockPrincipalArn1)},
+		})
+		Expect(err).To(MatchError(ContainSubstring("access entry for principal ARN " + mockPrincipalArn1 + " does not exist")))
+	})
+})
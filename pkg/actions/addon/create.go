@@ -102,8 +102,14 @@ func (a *Manager) Create(ctx context.Context, addon *api.Addon, iamRoleCreator I
 		return err
 	}
 	var configurationValues *string
-	if addon.ConfigurationValues != "" {
-		configurationValues = &addon.ConfigurationValues
+	values := addon.ConfigurationValues
+	if addon.CanonicalName() == api.VPCCNIAddon {
+		if values, err = makeVPCCNIConfigurationValues(a.clusterConfig, values); err != nil {
+			return err
+		}
+	}
+	if values != "" {
+		configurationValues = &values
 	}
 	createAddonInput := &eks.CreateAddonInput{
 		AddonName:           &addon.Name,
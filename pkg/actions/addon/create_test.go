@@ -510,6 +510,46 @@ var _ = Describe("Create", func() {
 			},
 		}),
 
+		Entry("[ConfigurationValues] custom networking is enabled for vpc-cni when vpc.podSubnets is set", createAddonEntry{
+			addon: api.Addon{
+				Name:                api.VPCCNIAddon,
+				ConfigurationValues: `{"env":{"ENI_CONFIG_LABEL_DEF":"failure-domain.beta.kubernetes.io/zone"}}`,
+			},
+			mockK8s: true,
+			mockClusterConfig: func(clusterConfig *api.ClusterConfig) {
+				clusterConfig.Metadata.Region = "us-west-2"
+				clusterConfig.VPC.PodSubnets = &api.PodSubnets{
+					Subnets: api.AZSubnetMapping{
+						"us-west-2a": {ID: "subnet-1", AZ: "us-west-2a"},
+						"us-west-2b": {ID: "subnet-2", AZ: "us-west-2b"},
+					},
+					SecurityGroups: []string{"sg-1"},
+				}
+			},
+			mockEKS: func(provider *mockprovider.MockProvider) {
+				mockDescribeAddon(provider.MockEKS(), nil)
+				mockDescribeAddonVersions(provider.MockEKS(), nil)
+				mockDescribeAddonConfiguration(provider.MockEKS(), []string{"aws-node"}, nil)
+				mockCreateAddon(provider.MockEKS(), nil)
+			},
+			validateCreateAddonInput: func(input *awseks.CreateAddonInput) {
+				Expect(*input.ConfigurationValues).To(MatchJSON(`{
+					"env": {
+						"AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG": "true",
+						"ENI_CONFIG_LABEL_DEF": "failure-domain.beta.kubernetes.io/zone"
+					},
+					"eniConfig": {
+						"create": true,
+						"region": "us-west-2",
+						"subnets": {
+							"us-west-2a": {"id": "subnet-1", "securityGroups": ["sg-1"]},
+							"us-west-2b": {"id": "subnet-2", "securityGroups": ["sg-1"]}
+						}
+					}
+				}`))
+			},
+		}),
+
 		Entry("[Tags] are set", createAddonEntry{
 			addon: api.Addon{
 				Version: "1.0.0",
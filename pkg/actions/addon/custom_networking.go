@@ -0,0 +1,75 @@
+package addon
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	customNetworkEnv  = "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG"
+	eniConfigLabelEnv = "ENI_CONFIG_LABEL_DEF"
+	zoneLabel         = "topology.kubernetes.io/zone"
+)
+
+// makeVPCCNIConfigurationValues returns the configuration values for the VPC CNI addon. When
+// vpc.podSubnets is set, custom networking is enabled and an ENIConfig named after each AZ is
+// created for the pod subnets, so that nodes select the ENIConfig of their zone.
+// Values set in addon.configurationValues take precedence.
+func makeVPCCNIConfigurationValues(clusterConfig *api.ClusterConfig, configurationValues string) (string, error) {
+	if !clusterConfig.HasPodSubnets() {
+		return configurationValues, nil
+	}
+
+	values := map[string]interface{}{}
+	if configurationValues != "" {
+		if err := yaml.Unmarshal([]byte(configurationValues), &values); err != nil {
+			return "", fmt.Errorf("parsing configuration values of %q addon: %w", api.VPCCNIAddon, err)
+		}
+	}
+
+	env, ok := values["env"].(map[string]interface{})
+	if !ok {
+		env = map[string]interface{}{}
+	}
+	for name, value := range map[string]string{
+		customNetworkEnv:  "true",
+		eniConfigLabelEnv: zoneLabel,
+	} {
+		if _, ok := env[name]; !ok {
+			env[name] = value
+		}
+	}
+	values["env"] = env
+
+	if _, ok := values["eniConfig"]; !ok {
+		podSubnets := clusterConfig.VPC.PodSubnets
+		subnets := map[string]interface{}{}
+		for name, subnet := range podSubnets.Subnets {
+			if subnet.ID == "" {
+				return "", fmt.Errorf("ID of pod subnet %q is unknown", name)
+			}
+			eniConfig := map[string]interface{}{
+				"id": subnet.ID,
+			}
+			if len(podSubnets.SecurityGroups) > 0 {
+				eniConfig["securityGroups"] = podSubnets.SecurityGroups
+			}
+			subnets[subnet.AZ] = eniConfig
+		}
+		values["eniConfig"] = map[string]interface{}{
+			"create":  true,
+			"region":  clusterConfig.Metadata.Region,
+			"subnets": subnets,
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("serializing configuration values of %q addon: %w", api.VPCCNIAddon, err)
+	}
+	return string(data), nil
+}
@@ -18,31 +18,35 @@ import (
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
-func CreateAddonTasks(ctx context.Context, cfg *api.ClusterConfig, clusterProvider *eks.ClusterProvider, iamRoleCreator IAMRoleCreator, podIdentityIAMUpdater PodIdentityIAMUpdater, forceAll bool, timeout time.Duration, region string) (*tasks.TaskTree, *tasks.TaskTree, *tasks.GenericTask, []string) {
-	var addons []*api.Addon
-	var autoDefaultAddonNames []string
-	if !cfg.AddonsConfig.DisableDefaultAddons {
-		addons = make([]*api.Addon, len(cfg.Addons))
-		copy(addons, cfg.Addons)
-
-		for addonName, addonInfo := range api.KnownAddons {
-			if addonInfo.IsDefault && !slices.Contains(addonInfo.ExcludedRegions, region) && !slices.ContainsFunc(cfg.Addons, func(a *api.Addon) bool {
-				return strings.EqualFold(a.Name, addonName)
-			}) {
-				if !cfg.IsAutoModeEnabled() || addonInfo.IsDefaultAutoMode {
-					addons = append(addons, &api.Addon{Name: addonName})
-					autoDefaultAddonNames = append(autoDefaultAddonNames, addonName)
-				}
-
+// WithDefaultAddons returns the addons in cfg along with the default addons that are not in cfg, unless default addons
+// are disabled. The names of the default addons that were added are also returned.
+func WithDefaultAddons(cfg *api.ClusterConfig, region string) ([]*api.Addon, []string) {
+	if cfg.AddonsConfig.DisableDefaultAddons {
+		return cfg.Addons, nil
+	}
+	addons := make([]*api.Addon, len(cfg.Addons))
+	copy(addons, cfg.Addons)
+
+	var defaultAddonNames []string
+	for addonName, addonInfo := range api.KnownAddons {
+		if addonInfo.IsDefault && !slices.Contains(addonInfo.ExcludedRegions, region) && !slices.ContainsFunc(cfg.Addons, func(a *api.Addon) bool {
+			return strings.EqualFold(a.Name, addonName)
+		}) {
+			if !cfg.IsAutoModeEnabled() || addonInfo.IsDefaultAutoMode {
+				addons = append(addons, &api.Addon{Name: addonName})
+				defaultAddonNames = append(defaultAddonNames, addonName)
 			}
 		}
-	} else {
-		addons = cfg.Addons
-		if cfg.IsAutoModeEnabled() && len(cfg.NodeGroups) == 0 && len(cfg.ManagedNodeGroups) == 0 {
-			logger.Info("default EKS addons are not required for a cluster using Auto Mode; " +
-				"if nodegroups are not required, consider setting `addonsConfig.disableDefaultAddons: true` during " +
-				"cluster creation, or deleting default addons using `eksctl delete addon`")
-		}
+	}
+	return addons, defaultAddonNames
+}
+
+func CreateAddonTasks(ctx context.Context, cfg *api.ClusterConfig, clusterProvider *eks.ClusterProvider, iamRoleCreator IAMRoleCreator, podIdentityIAMUpdater PodIdentityIAMUpdater, forceAll bool, timeout time.Duration, region string) (*tasks.TaskTree, *tasks.TaskTree, *tasks.GenericTask, []string) {
+	addons, autoDefaultAddonNames := WithDefaultAddons(cfg, region)
+	if cfg.AddonsConfig.DisableDefaultAddons && cfg.IsAutoModeEnabled() && len(cfg.NodeGroups) == 0 && len(cfg.ManagedNodeGroups) == 0 {
+		logger.Info("default EKS addons are not required for a cluster using Auto Mode; " +
+			"if nodegroups are not required, consider setting `addonsConfig.disableDefaultAddons: true` during " +
+			"cluster creation, or deleting default addons using `eksctl delete addon`")
 	}
 
 	var (
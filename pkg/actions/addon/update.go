@@ -29,8 +29,15 @@ func (a *Manager) Update(ctx context.Context, addon *api.Addon, podIdentityIAMUp
 	logger.Debug("addon: %v", addon)
 
 	var configurationValues *string
-	if addon.ConfigurationValues != "" {
-		configurationValues = &addon.ConfigurationValues
+	values := addon.ConfigurationValues
+	if addon.CanonicalName() == api.VPCCNIAddon {
+		var err error
+		if values, err = makeVPCCNIConfigurationValues(a.clusterConfig, values); err != nil {
+			return err
+		}
+	}
+	if values != "" {
+		configurationValues = &values
 	}
 	updateAddonInput := &eks.UpdateAddonInput{
 		AddonName:           &addon.Name,
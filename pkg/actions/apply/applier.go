@@ -0,0 +1,256 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/accessentry"
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/fargate"
+	"github.com/weaveworks/eksctl/pkg/actions/irsa"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	fargateclient "github.com/weaveworks/eksctl/pkg/fargate"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+)
+
+const (
+	drainMaxGracePeriod        = 10 * time.Minute
+	drainPodEvictionWaitPeriod = 10 * time.Second
+)
+
+// ClusterApplier implements Applier by delegating to the existing actions for each resource type.
+type ClusterApplier struct {
+	cfg              *api.ClusterConfig
+	ctl              *eks.ClusterProvider
+	stackManager     manager.StackManager
+	clientSet        kubernetes.Interface
+	oidcManager      *iamoidc.OpenIDConnectManager
+	addonManager     *addon.Manager
+	instanceSelector eks.InstanceSelector
+	waitTimeout      time.Duration
+}
+
+// NewClusterApplier creates a new ClusterApplier.
+func NewClusterApplier(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider, instanceSelector eks.InstanceSelector, waitTimeout time.Duration) (*ClusterApplier, error) {
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+	oidcManager, err := ctl.NewOpenIDConnectManager(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	oidcProviderExists, err := oidcManager.CheckProviderExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+	addonManager, err := addon.New(cfg, ctl.AWSProvider.EKS(), stackManager, oidcProviderExists, oidcManager, func() (kubernetes.Interface, error) {
+		return clientSet, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterApplier{
+		cfg:              cfg,
+		ctl:              ctl,
+		stackManager:     stackManager,
+		clientSet:        clientSet,
+		oidcManager:      oidcManager,
+		addonManager:     addonManager,
+		instanceSelector: instanceSelector,
+		waitTimeout:      waitTimeout,
+	}, nil
+}
+
+// UpdateClusterLogging updates CloudWatch logging for the cluster.
+func (a *ClusterApplier) UpdateClusterLogging(ctx context.Context) error {
+	return a.ctl.UpdateClusterConfigForLogging(ctx, a.cfg)
+}
+
+// CreateNodeGroups creates the specified nodegroups.
+func (a *ClusterApplier) CreateNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error {
+	// nodegroup.Manager filters the nodegroups in the config it is given, so it gets a copy holding only the new nodegroups
+	cfg := *a.cfg
+	cfg.NodeGroups, cfg.ManagedNodeGroups = nodeGroups, managedNodeGroups
+	return nodegroup.New(&cfg, a.ctl, a.clientSet, a.instanceSelector).Create(ctx, nodegroup.CreateOpts{
+		ConfigFileProvided: true,
+	}, filter.NewNodeGroupFilter())
+}
+
+// ScaleManagedNodeGroup scales a managed nodegroup to the size in its config.
+func (a *ClusterApplier) ScaleManagedNodeGroup(ctx context.Context, ng *api.ManagedNodeGroup) error {
+	return nodegroup.New(a.cfg, a.ctl, a.clientSet, a.instanceSelector).Scale(ctx, ng.NodeGroupBase, true)
+}
+
+// DrainNodeGroups drains the specified nodegroups with the defaults of 'eksctl delete nodegroup', respecting
+// PodDisruptionBudgets.
+func (a *ClusterApplier) DrainNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error {
+	var kubeNodeGroups []eks.KubeNodeGroup
+	for _, ng := range nodeGroups {
+		kubeNodeGroups = append(kubeNodeGroups, ng)
+	}
+	for _, ng := range managedNodeGroups {
+		kubeNodeGroups = append(kubeNodeGroups, ng)
+	}
+	drainCtx, cancel := context.WithTimeout(ctx, a.waitTimeout)
+	defer cancel()
+	drainer := &nodegroup.Drainer{
+		ClientSet: a.clientSet,
+	}
+	return drainer.Drain(drainCtx, &nodegroup.DrainInput{
+		NodeGroups:            kubeNodeGroups,
+		MaxGracePeriod:        drainMaxGracePeriod,
+		PodEvictionWaitPeriod: drainPodEvictionWaitPeriod,
+		Parallel:              1,
+	})
+}
+
+// DeleteNodeGroups deletes the specified nodegroups.
+func (a *ClusterApplier) DeleteNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error {
+	deleter := &nodegroup.Deleter{
+		StackHelper:          a.stackManager,
+		NodeGroupDeleter:     a.ctl.AWSProvider.EKS(),
+		ClusterName:          a.cfg.Metadata.Name,
+		AuthConfigMapUpdater: &authConfigMapUpdater{clientSet: a.clientSet},
+	}
+	return deleter.Delete(ctx, nodeGroups, managedNodeGroups, nodegroup.DeleteOptions{
+		Wait:                true,
+		UpdateAuthConfigMap: !(&accessentry.Service{ClusterStateGetter: a.ctl}).IsAWSAuthDisabled(),
+	})
+}
+
+// CreateAddon creates an addon.
+func (a *ClusterApplier) CreateAddon(ctx context.Context, addon *api.Addon) error {
+	return a.addonManager.Create(ctx, addon, &podidentityassociation.IAMRoleCreator{
+		ClusterName:  a.cfg.Metadata.Name,
+		StackCreator: a.stackManager,
+	}, a.waitTimeout)
+}
+
+// UpdateAddon updates an addon.
+func (a *ClusterApplier) UpdateAddon(ctx context.Context, addonToUpdate *api.Addon) error {
+	return a.addonManager.Update(ctx, addonToUpdate, &addon.PodIdentityAssociationUpdater{
+		ClusterName: a.cfg.Metadata.Name,
+		IAMRoleCreator: &podidentityassociation.IAMRoleCreator{
+			ClusterName:  a.cfg.Metadata.Name,
+			StackCreator: a.stackManager,
+		},
+		IAMRoleUpdater: &podidentityassociation.IAMRoleUpdater{
+			StackUpdater: a.stackManager,
+		},
+		EKSPodIdentityDescriber: a.ctl.AWSProvider.EKS(),
+		StackDeleter:            a.stackManager,
+	}, a.waitTimeout)
+}
+
+// DeleteAddon deletes an addon.
+func (a *ClusterApplier) DeleteAddon(ctx context.Context, addon *api.Addon) error {
+	return a.addonManager.Delete(ctx, addon)
+}
+
+// CreateIAMServiceAccounts creates IAM service accounts.
+func (a *ClusterApplier) CreateIAMServiceAccounts(_ context.Context, serviceAccounts []*api.ClusterIAMServiceAccount) error {
+	return a.irsaManager().CreateIAMServiceAccount(serviceAccounts, false)
+}
+
+// DeleteIAMServiceAccounts deletes IAM service accounts.
+func (a *ClusterApplier) DeleteIAMServiceAccounts(ctx context.Context, serviceAccounts []string) error {
+	return a.irsaManager().Delete(ctx, serviceAccounts, false, true)
+}
+
+func (a *ClusterApplier) irsaManager() *irsa.Manager {
+	return irsa.New(a.cfg.Metadata.Name, a.stackManager, a.oidcManager, a.clientSet)
+}
+
+// CreatePodIdentityAssociations creates pod identity associations.
+func (a *ClusterApplier) CreatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error {
+	return podidentityassociation.NewCreator(a.cfg.Metadata.Name, a.stackManager, a.ctl.AWSProvider.EKS(), a.clientSet).
+		CreatePodIdentityAssociations(ctx, podIdentityAssociations)
+}
+
+// UpdatePodIdentityAssociations updates pod identity associations.
+func (a *ClusterApplier) UpdatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error {
+	updater := &podidentityassociation.Updater{
+		ClusterName:  a.cfg.Metadata.Name,
+		StackUpdater: a.stackManager,
+		APIUpdater:   a.ctl.AWSProvider.EKS(),
+	}
+	return updater.Update(ctx, podIdentityAssociations)
+}
+
+// DeletePodIdentityAssociations deletes pod identity associations.
+func (a *ClusterApplier) DeletePodIdentityAssociations(ctx context.Context, podIdentityAssociations []podidentityassociation.Identifier) error {
+	return podidentityassociation.NewDeleter(a.cfg.Metadata.Name, a.stackManager, a.ctl.AWSProvider.EKS(), a.clientSet).
+		Delete(ctx, podIdentityAssociations)
+}
+
+// CreateAccessEntries creates access entries.
+func (a *ClusterApplier) CreateAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error {
+	creator := &accessentryactions.Creator{
+		ClusterName:  a.cfg.Metadata.Name,
+		StackCreator: a.stackManager,
+	}
+	return creator.Create(ctx, accessEntries)
+}
+
+// UpdateAccessEntries updates the Kubernetes groups and access policies of access entries in place.
+func (a *ClusterApplier) UpdateAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error {
+	return accessentryactions.NewUpdater(a.cfg.Metadata.Name, a.ctl.AWSProvider.EKS()).Update(ctx, accessEntries)
+}
+
+// DeleteAccessEntries deletes access entries.
+func (a *ClusterApplier) DeleteAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error {
+	return accessentryactions.NewRemover(a.cfg.Metadata.Name, a.stackManager, a.ctl.AWSProvider.EKS()).Delete(ctx, accessEntries)
+}
+
+// CreateFargateProfiles creates Fargate profiles.
+func (a *ClusterApplier) CreateFargateProfiles(ctx context.Context, profiles []*api.FargateProfile) error {
+	// fargate.Manager creates every profile in the config it is given
+	cfg := *a.cfg
+	cfg.FargateProfiles = profiles
+	return fargate.New(&cfg, a.ctl, a.stackManager).Create(ctx)
+}
+
+// UpdateFargateProfiles replaces Fargate profiles with the configuration in the config, keeping the pods they
+// select on Fargate through a temporary profile like 'eksctl update fargateprofile'.
+func (a *ClusterApplier) UpdateFargateProfiles(ctx context.Context, profiles []*api.FargateProfile) error {
+	// fargate.Manager updates every profile in the config it is given
+	cfg := *a.cfg
+	cfg.FargateProfiles = profiles
+	manager := fargate.New(&cfg, a.ctl, a.stackManager)
+	plan, err := manager.PlanUpdate(ctx, false)
+	if err != nil {
+		return err
+	}
+	return manager.Update(plan)
+}
+
+// DeleteFargateProfiles deletes Fargate profiles.
+func (a *ClusterApplier) DeleteFargateProfiles(ctx context.Context, profileNames []string) error {
+	client := fargateclient.NewFromProvider(a.cfg.Metadata.Name, a.ctl.AWSProvider, a.stackManager)
+	for _, name := range profileNames {
+		if err := client.DeleteProfile(ctx, name, true); err != nil {
+			return fmt.Errorf("deleting Fargate profile %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+type authConfigMapUpdater struct {
+	clientSet kubernetes.Interface
+}
+
+func (a *authConfigMapUpdater) RemoveNodeGroup(ng *api.NodeGroup) error {
+	return authconfigmap.RemoveNodeGroup(a.clientSet, ng)
+}
@@ -0,0 +1,465 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/actions/diff"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+// Applier makes the changes needed to reconcile a cluster with its ClusterConfig.
+// Each method is invoked from a task in the tree built by the Reconciler.
+//
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+//counterfeiter:generate -o fakes/fake_applier.go . Applier
+type Applier interface {
+	UpdateClusterLogging(ctx context.Context) error
+	CreateNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error
+	ScaleManagedNodeGroup(ctx context.Context, ng *api.ManagedNodeGroup) error
+	DrainNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error
+	DeleteNodeGroups(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) error
+	CreateAddon(ctx context.Context, addon *api.Addon) error
+	UpdateAddon(ctx context.Context, addon *api.Addon) error
+	DeleteAddon(ctx context.Context, addon *api.Addon) error
+	CreateIAMServiceAccounts(ctx context.Context, serviceAccounts []*api.ClusterIAMServiceAccount) error
+	DeleteIAMServiceAccounts(ctx context.Context, serviceAccounts []string) error
+	CreatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error
+	UpdatePodIdentityAssociations(ctx context.Context, podIdentityAssociations []api.PodIdentityAssociation) error
+	DeletePodIdentityAssociations(ctx context.Context, podIdentityAssociations []podidentityassociation.Identifier) error
+	CreateAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error
+	UpdateAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error
+	DeleteAccessEntries(ctx context.Context, accessEntries []api.AccessEntry) error
+	CreateFargateProfiles(ctx context.Context, profiles []*api.FargateProfile) error
+	UpdateFargateProfiles(ctx context.Context, profiles []*api.FargateProfile) error
+	DeleteFargateProfiles(ctx context.Context, profileNames []string) error
+}
+
+// Reconciler builds a single task tree that brings the live cluster in line with a ClusterConfig.
+type Reconciler struct {
+	ClusterConfig *api.ClusterConfig
+	Applier       Applier
+	// Prune enables deletion of resources that exist in the cluster but are not declared in the ClusterConfig.
+	Prune bool
+}
+
+// changeSet groups diff items by the action that reconciles them.
+type changeSet struct {
+	updateLogging bool
+
+	createNodeGroups        []*api.NodeGroup
+	createManagedNodeGroups []*api.ManagedNodeGroup
+	scaleManagedNodeGroups  []*api.ManagedNodeGroup
+	deleteNodeGroups        []*api.NodeGroup
+	deleteManagedNodeGroups []*api.ManagedNodeGroup
+
+	createAddons []*api.Addon
+	updateAddons []*api.Addon
+	deleteAddons []*api.Addon
+
+	createServiceAccounts []*api.ClusterIAMServiceAccount
+	deleteServiceAccounts []string
+
+	createPodIdentityAssociations []api.PodIdentityAssociation
+	updatePodIdentityAssociations []api.PodIdentityAssociation
+	deletePodIdentityAssociations []podidentityassociation.Identifier
+
+	createAccessEntries []api.AccessEntry
+	updateAccessEntries []api.AccessEntry
+	deleteAccessEntries []api.AccessEntry
+
+	createFargateProfiles []*api.FargateProfile
+	updateFargateProfiles []*api.FargateProfile
+	deleteFargateProfiles []string
+
+	pruned int
+}
+
+// Tasks returns the task tree that reconciles the given differences. Differences that cannot be reconciled
+// by apply, such as a Kubernetes version change, are logged and left out of the tree.
+func (r *Reconciler) Tasks(ctx context.Context, items []diff.Item) *tasks.TaskTree {
+	cs := r.changeSet(items)
+	if cs.pruned > 0 && !r.Prune {
+		logger.Info("%d resource(s) exist in the cluster but are not declared in the config; rerun with --prune to delete them", cs.pruned)
+	}
+
+	taskTree := &tasks.TaskTree{Parallel: false}
+
+	if cs.updateLogging {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("update CloudWatch logging for cluster %q", r.ClusterConfig.Metadata.Name),
+			Doer: func() error {
+				return r.Applier.UpdateClusterLogging(ctx)
+			},
+		})
+	}
+
+	addonTasks := &tasks.TaskTree{Parallel: true, IsSubTask: true}
+	for _, a := range cs.createAddons {
+		a := a
+		addonTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create addon %q", a.Name),
+			Doer:        func() error { return r.Applier.CreateAddon(ctx, a) },
+		})
+	}
+	for _, a := range cs.updateAddons {
+		a := a
+		addonTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("update addon %q", a.Name),
+			Doer:        func() error { return r.Applier.UpdateAddon(ctx, a) },
+		})
+	}
+	appendIfNotEmpty(taskTree, addonTasks)
+
+	identityTasks := &tasks.TaskTree{Parallel: true, IsSubTask: true}
+	if len(cs.createServiceAccounts) > 0 {
+		identityTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create iamserviceaccount(s) %s", joinServiceAccountNames(cs.createServiceAccounts)),
+			Doer:        func() error { return r.Applier.CreateIAMServiceAccounts(ctx, cs.createServiceAccounts) },
+		})
+	}
+	if len(cs.createPodIdentityAssociations) > 0 {
+		identityTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create %d pod identity association(s)", len(cs.createPodIdentityAssociations)),
+			Doer:        func() error { return r.Applier.CreatePodIdentityAssociations(ctx, cs.createPodIdentityAssociations) },
+		})
+	}
+	if len(cs.updatePodIdentityAssociations) > 0 {
+		identityTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("update %d pod identity association(s)", len(cs.updatePodIdentityAssociations)),
+			Doer:        func() error { return r.Applier.UpdatePodIdentityAssociations(ctx, cs.updatePodIdentityAssociations) },
+		})
+	}
+	if len(cs.createAccessEntries) > 0 {
+		identityTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create %d access entry(ies)", len(cs.createAccessEntries)),
+			Doer:        func() error { return r.Applier.CreateAccessEntries(ctx, cs.createAccessEntries) },
+		})
+	}
+	if len(cs.updateAccessEntries) > 0 {
+		identityTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("update %d access entry(ies)", len(cs.updateAccessEntries)),
+			Doer:        func() error { return r.Applier.UpdateAccessEntries(ctx, cs.updateAccessEntries) },
+		})
+	}
+	// EKS only allows one Fargate profile of a cluster to be created or deleted at a time
+	fargateTasks := &tasks.TaskTree{Parallel: false, IsSubTask: true}
+	if len(cs.createFargateProfiles) > 0 {
+		fargateTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create Fargate profile(s) %s", joinFargateProfileNames(cs.createFargateProfiles)),
+			Doer:        func() error { return r.Applier.CreateFargateProfiles(ctx, cs.createFargateProfiles) },
+		})
+	}
+	if len(cs.updateFargateProfiles) > 0 {
+		fargateTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("update Fargate profile(s) %s", joinFargateProfileNames(cs.updateFargateProfiles)),
+			Doer:        func() error { return r.Applier.UpdateFargateProfiles(ctx, cs.updateFargateProfiles) },
+		})
+	}
+	appendIfNotEmpty(identityTasks, fargateTasks)
+	appendIfNotEmpty(taskTree, identityTasks)
+
+	nodeGroupTasks := &tasks.TaskTree{Parallel: true, IsSubTask: true}
+	if len(cs.createNodeGroups)+len(cs.createManagedNodeGroups) > 0 {
+		nodeGroupTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("create nodegroup(s) %s", joinNodeGroupNames(cs.createNodeGroups, cs.createManagedNodeGroups)),
+			Doer: func() error {
+				return r.Applier.CreateNodeGroups(ctx, cs.createNodeGroups, cs.createManagedNodeGroups)
+			},
+		})
+	}
+	for _, ng := range cs.scaleManagedNodeGroups {
+		ng := ng
+		nodeGroupTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("scale managed nodegroup %q", ng.Name),
+			Doer:        func() error { return r.Applier.ScaleManagedNodeGroup(ctx, ng) },
+		})
+	}
+	appendIfNotEmpty(taskTree, nodeGroupTasks)
+
+	if r.Prune {
+		appendIfNotEmpty(taskTree, r.pruneTasks(ctx, cs))
+	}
+
+	return taskTree
+}
+
+func (r *Reconciler) pruneTasks(ctx context.Context, cs *changeSet) *tasks.TaskTree {
+	pruneTasks := &tasks.TaskTree{Parallel: true, IsSubTask: true}
+	if len(cs.deleteNodeGroups)+len(cs.deleteManagedNodeGroups) > 0 {
+		// nodegroups are drained before they are deleted, like with 'eksctl delete nodegroup'
+		names := joinNodeGroupNames(cs.deleteNodeGroups, cs.deleteManagedNodeGroups)
+		deleteNodeGroupTasks := &tasks.TaskTree{Parallel: false, IsSubTask: true}
+		deleteNodeGroupTasks.Append(
+			&tasks.GenericTask{
+				Description: fmt.Sprintf("drain nodegroup(s) %s", names),
+				Doer: func() error {
+					return r.Applier.DrainNodeGroups(ctx, cs.deleteNodeGroups, cs.deleteManagedNodeGroups)
+				},
+			},
+			&tasks.GenericTask{
+				Description: fmt.Sprintf("delete nodegroup(s) %s", names),
+				Doer: func() error {
+					return r.Applier.DeleteNodeGroups(ctx, cs.deleteNodeGroups, cs.deleteManagedNodeGroups)
+				},
+			},
+		)
+		pruneTasks.Append(deleteNodeGroupTasks)
+	}
+	for _, a := range cs.deleteAddons {
+		a := a
+		pruneTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete addon %q", a.Name),
+			Doer:        func() error { return r.Applier.DeleteAddon(ctx, a) },
+		})
+	}
+	if len(cs.deleteServiceAccounts) > 0 {
+		pruneTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete iamserviceaccount(s) %s", strings.Join(cs.deleteServiceAccounts, ", ")),
+			Doer:        func() error { return r.Applier.DeleteIAMServiceAccounts(ctx, cs.deleteServiceAccounts) },
+		})
+	}
+	if len(cs.deletePodIdentityAssociations) > 0 {
+		pruneTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete %d pod identity association(s)", len(cs.deletePodIdentityAssociations)),
+			Doer:        func() error { return r.Applier.DeletePodIdentityAssociations(ctx, cs.deletePodIdentityAssociations) },
+		})
+	}
+	if len(cs.deleteAccessEntries) > 0 {
+		pruneTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete %d access entry(ies)", len(cs.deleteAccessEntries)),
+			Doer:        func() error { return r.Applier.DeleteAccessEntries(ctx, cs.deleteAccessEntries) },
+		})
+	}
+	if len(cs.deleteFargateProfiles) > 0 {
+		pruneTasks.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("delete Fargate profile(s) %s", strings.Join(cs.deleteFargateProfiles, ", ")),
+			Doer:        func() error { return r.Applier.DeleteFargateProfiles(ctx, cs.deleteFargateProfiles) },
+		})
+	}
+	return pruneTasks
+}
+
+func (r *Reconciler) changeSet(items []diff.Item) *changeSet {
+	cfg := r.ClusterConfig
+	cs := &changeSet{}
+	scaled := map[string]bool{}
+	updatedAddons := map[string]bool{}
+	updatedPodIdentityAssociations := map[string]bool{}
+	updatedAccessEntries := map[string]bool{}
+	updatedProfiles := map[string]bool{}
+
+	for _, item := range items {
+		switch item.ResourceType {
+		case diff.ResourceCluster:
+			switch item.Field {
+			case "cloudWatch.clusterLogging.enableTypes":
+				cs.updateLogging = true
+			case "metadata.version":
+				logger.Warning("cluster version %s differs from %s in the config; run `eksctl upgrade cluster` to upgrade the control plane", item.Live, item.Desired)
+			}
+
+		case diff.ResourceNodeGroup:
+			switch item.Change {
+			case diff.ChangeTypeAdded:
+				cs.createNodeGroups = append(cs.createNodeGroups, findNodeGroup(cfg, item.Name))
+			case diff.ChangeTypeRemoved:
+				cs.pruned++
+				cs.deleteNodeGroups = append(cs.deleteNodeGroups, &api.NodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: item.Name}})
+			case diff.ChangeTypeChanged:
+				logNotReconcilable(item)
+			}
+
+		case diff.ResourceManagedNodeGroup:
+			switch item.Change {
+			case diff.ChangeTypeAdded:
+				cs.createManagedNodeGroups = append(cs.createManagedNodeGroups, findManagedNodeGroup(cfg, item.Name))
+			case diff.ChangeTypeRemoved:
+				cs.pruned++
+				cs.deleteManagedNodeGroups = append(cs.deleteManagedNodeGroups, &api.ManagedNodeGroup{NodeGroupBase: &api.NodeGroupBase{Name: item.Name}})
+			case diff.ChangeTypeChanged:
+				if item.Field == "type" {
+					logNotReconcilable(item)
+				} else if !scaled[item.Name] {
+					scaled[item.Name] = true
+					cs.scaleManagedNodeGroups = append(cs.scaleManagedNodeGroups, findManagedNodeGroup(cfg, item.Name))
+				}
+			}
+
+		case diff.ResourceAddon:
+			switch item.Change {
+			case diff.ChangeTypeAdded:
+				cs.createAddons = append(cs.createAddons, findAddon(cfg, item.Name))
+			case diff.ChangeTypeRemoved:
+				cs.pruned++
+				cs.deleteAddons = append(cs.deleteAddons, &api.Addon{Name: item.Name})
+			case diff.ChangeTypeChanged:
+				if !updatedAddons[item.Name] {
+					updatedAddons[item.Name] = true
+					cs.updateAddons = append(cs.updateAddons, findAddon(cfg, item.Name))
+				}
+			}
+
+		case diff.ResourceIAMServiceAccount:
+			switch item.Change {
+			case diff.ChangeTypeAdded:
+				cs.createServiceAccounts = append(cs.createServiceAccounts, findServiceAccount(cfg, item.Name))
+			case diff.ChangeTypeRemoved:
+				cs.pruned++
+				cs.deleteServiceAccounts = append(cs.deleteServiceAccounts, item.Name)
+			}
+
+		case diff.ResourcePodIdentityAssociation:
+			switch item.Change {
+			case diff.ChangeTypeAdded:
+				cs.createPodIdentityAssociations = append(cs.createPodIdentityAssociations, findPodIdentityAssociation(cfg, item.Name))
+			case diff.ChangeTypeRemoved:
+				cs.pruned++
+				namespace, serviceAccountName, _ := strings.Cut(item.Name, "/")
+				cs.deletePodIdentityAssociations = append(cs.deletePodIdentityAssociations, podidentityassociation.Identifier{
+					Namespace:          namespace,
+					ServiceAccountName: serviceAccountName,
+				})
+			case diff.ChangeTypeChanged:
+				if !updatedPodIdentityAssociations[item.Name] {
+					updatedPodIdentityAssociations[item.Name] = true
+					cs.updatePodIdentityAssociations = append(cs.updatePodIdentityAssociations, findPodIdentityAssociation(cfg, item.Name))
+				}
+			}
+
+		case diff.ResourceAccessEntry:
+			switch item.Change {
+			case diff.ChangeTypeAdded:
+				cs.createAccessEntries = append(cs.createAccessEntries, findAccessEntry(cfg, item.Name))
+			case diff.ChangeTypeRemoved:
+				cs.pruned++
+				cs.deleteAccessEntries = append(cs.deleteAccessEntries, api.AccessEntry{PrincipalARN: api.MustParseARN(item.Name)})
+			case diff.ChangeTypeChanged:
+				if !updatedAccessEntries[item.Name] {
+					updatedAccessEntries[item.Name] = true
+					cs.updateAccessEntries = append(cs.updateAccessEntries, findAccessEntry(cfg, item.Name))
+				}
+			}
+
+		case diff.ResourceFargateProfile:
+			switch item.Change {
+			case diff.ChangeTypeAdded:
+				cs.createFargateProfiles = append(cs.createFargateProfiles, findFargateProfile(cfg, item.Name))
+			case diff.ChangeTypeRemoved:
+				cs.pruned++
+				cs.deleteFargateProfiles = append(cs.deleteFargateProfiles, item.Name)
+			case diff.ChangeTypeChanged:
+				if !updatedProfiles[item.Name] {
+					updatedProfiles[item.Name] = true
+					cs.updateFargateProfiles = append(cs.updateFargateProfiles, findFargateProfile(cfg, item.Name))
+				}
+			}
+		}
+	}
+	return cs
+}
+
+func logNotReconcilable(item diff.Item) {
+	logger.Warning("%s %q: %s cannot be changed from %q to %q by apply; the %s must be replaced", item.ResourceType, item.Name, item.Field, item.Live, item.Desired, item.ResourceType)
+}
+
+func appendIfNotEmpty(taskTree *tasks.TaskTree, subTree *tasks.TaskTree) {
+	if subTree.Len() > 0 {
+		taskTree.Append(subTree)
+	}
+}
+
+func findNodeGroup(cfg *api.ClusterConfig, name string) *api.NodeGroup {
+	for _, ng := range cfg.NodeGroups {
+		if ng.Name == name {
+			return ng
+		}
+	}
+	return nil
+}
+
+func findManagedNodeGroup(cfg *api.ClusterConfig, name string) *api.ManagedNodeGroup {
+	for _, ng := range cfg.ManagedNodeGroups {
+		if ng.Name == name {
+			return ng
+		}
+	}
+	return nil
+}
+
+func findAddon(cfg *api.ClusterConfig, name string) *api.Addon {
+	for _, a := range cfg.Addons {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+func findServiceAccount(cfg *api.ClusterConfig, name string) *api.ClusterIAMServiceAccount {
+	for _, sa := range cfg.IAM.ServiceAccounts {
+		if sa.NameString() == name {
+			return sa
+		}
+	}
+	return nil
+}
+
+func findPodIdentityAssociation(cfg *api.ClusterConfig, name string) api.PodIdentityAssociation {
+	for _, pia := range cfg.IAM.PodIdentityAssociations {
+		if pia.NameString() == name {
+			return pia
+		}
+	}
+	return api.PodIdentityAssociation{}
+}
+
+func findAccessEntry(cfg *api.ClusterConfig, principalARN string) api.AccessEntry {
+	for _, ae := range cfg.AccessConfig.AccessEntries {
+		if ae.PrincipalARN.String() == principalARN {
+			return ae
+		}
+	}
+	return api.AccessEntry{}
+}
+
+func findFargateProfile(cfg *api.ClusterConfig, name string) *api.FargateProfile {
+	for _, fp := range cfg.FargateProfiles {
+		if fp.Name == name {
+			return fp
+		}
+	}
+	return nil
+}
+
+func joinNodeGroupNames(nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup) string {
+	var names []string
+	for _, ng := range nodeGroups {
+		names = append(names, ng.Name)
+	}
+	for _, ng := range managedNodeGroups {
+		names = append(names, ng.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func joinServiceAccountNames(serviceAccounts []*api.ClusterIAMServiceAccount) string {
+	var names []string
+	for _, sa := range serviceAccounts {
+		names = append(names, sa.NameString())
+	}
+	return strings.Join(names, ", ")
+}
+
+func joinFargateProfileNames(profiles []*api.FargateProfile) string {
+	var names []string
+	for _, fp := range profiles {
+		names = append(names, fp.Name)
+	}
+	return strings.Join(names, ", ")
+}
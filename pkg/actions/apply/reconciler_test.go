@@ -0,0 +1,184 @@
+package apply_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/apply"
+	"github.com/weaveworks/eksctl/pkg/actions/apply/fakes"
+	"github.com/weaveworks/eksctl/pkg/actions/diff"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Reconciler", func() {
+	var (
+		cfg         *api.ClusterConfig
+		fakeApplier *fakes.FakeApplier
+		reconciler  *apply.Reconciler
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.NodeGroups = []*api.NodeGroup{{NodeGroupBase: &api.NodeGroupBase{Name: "ng-new"}}}
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{
+			{NodeGroupBase: &api.NodeGroupBase{Name: "mng-new"}},
+			{NodeGroupBase: &api.NodeGroupBase{Name: "mng-scaled"}},
+		}
+		cfg.Addons = []*api.Addon{{Name: "vpc-cni", Version: "1.20.0"}, {Name: "aws-ebs-csi-driver"}}
+		cfg.IAM.PodIdentityAssociations = []api.PodIdentityAssociation{{Namespace: "default", ServiceAccountName: "app"}}
+		cfg.FargateProfiles = []*api.FargateProfile{{Name: "fp-default"}}
+
+		fakeApplier = &fakes.FakeApplier{}
+		reconciler = &apply.Reconciler{
+			ClusterConfig: cfg,
+			Applier:       fakeApplier,
+		}
+	})
+
+	items := []diff.Item{
+		{ResourceType: diff.ResourceCluster, Name: "my-cluster", Change: diff.ChangeTypeChanged, Field: "metadata.version", Desired: "1.33", Live: "1.32"},
+		{ResourceType: diff.ResourceNodeGroup, Name: "ng-new", Change: diff.ChangeTypeAdded},
+		{ResourceType: diff.ResourceNodeGroup, Name: "ng-old", Change: diff.ChangeTypeRemoved},
+		{ResourceType: diff.ResourceManagedNodeGroup, Name: "mng-new", Change: diff.ChangeTypeAdded},
+		{ResourceType: diff.ResourceManagedNodeGroup, Name: "mng-scaled", Change: diff.ChangeTypeChanged, Field: "scaling.minSize", Desired: "2", Live: "1"},
+		{ResourceType: diff.ResourceManagedNodeGroup, Name: "mng-scaled", Change: diff.ChangeTypeChanged, Field: "scaling.maxSize", Desired: "4", Live: "3"},
+		{ResourceType: diff.ResourceAddon, Name: "aws-ebs-csi-driver", Change: diff.ChangeTypeAdded},
+		{ResourceType: diff.ResourceAddon, Name: "aws-efs-csi-driver", Change: diff.ChangeTypeRemoved},
+		{ResourceType: diff.ResourceAddon, Name: "vpc-cni", Change: diff.ChangeTypeChanged, Field: "version", Desired: "1.20.0", Live: "v1.19.0-eksbuild.1"},
+		{ResourceType: diff.ResourcePodIdentityAssociation, Name: "default/app", Change: diff.ChangeTypeChanged, Field: "roleARN"},
+		{ResourceType: diff.ResourcePodIdentityAssociation, Name: "kube-system/old", Change: diff.ChangeTypeRemoved},
+		{ResourceType: diff.ResourceFargateProfile, Name: "fp-default", Change: diff.ChangeTypeChanged, Field: "selectors"},
+	}
+
+	It("creates and updates resources without deleting anything by default", func() {
+		taskTree := reconciler.Tasks(context.Background(), items)
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+
+		Expect(fakeApplier.UpdateClusterLoggingCallCount()).To(Equal(0))
+
+		Expect(fakeApplier.CreateNodeGroupsCallCount()).To(Equal(1))
+		_, nodeGroups, managedNodeGroups := fakeApplier.CreateNodeGroupsArgsForCall(0)
+		Expect(nodeGroups).To(ConsistOf(cfg.NodeGroups[0]))
+		Expect(managedNodeGroups).To(ConsistOf(cfg.ManagedNodeGroups[0]))
+
+		Expect(fakeApplier.ScaleManagedNodeGroupCallCount()).To(Equal(1))
+		_, scaled := fakeApplier.ScaleManagedNodeGroupArgsForCall(0)
+		Expect(scaled).To(Equal(cfg.ManagedNodeGroups[1]))
+
+		Expect(fakeApplier.CreateAddonCallCount()).To(Equal(1))
+		_, created := fakeApplier.CreateAddonArgsForCall(0)
+		Expect(created.Name).To(Equal("aws-ebs-csi-driver"))
+		Expect(fakeApplier.UpdateAddonCallCount()).To(Equal(1))
+		_, updated := fakeApplier.UpdateAddonArgsForCall(0)
+		Expect(updated.Name).To(Equal("vpc-cni"))
+
+		Expect(fakeApplier.UpdatePodIdentityAssociationsCallCount()).To(Equal(1))
+		_, pias := fakeApplier.UpdatePodIdentityAssociationsArgsForCall(0)
+		Expect(pias).To(Equal(cfg.IAM.PodIdentityAssociations))
+
+		Expect(fakeApplier.UpdateFargateProfilesCallCount()).To(Equal(1))
+		_, profiles := fakeApplier.UpdateFargateProfilesArgsForCall(0)
+		Expect(profiles).To(Equal(cfg.FargateProfiles))
+		Expect(fakeApplier.DeleteFargateProfilesCallCount()).To(Equal(0))
+		Expect(fakeApplier.CreateFargateProfilesCallCount()).To(Equal(0))
+
+		Expect(fakeApplier.DeleteNodeGroupsCallCount()).To(Equal(0))
+		Expect(fakeApplier.DeleteAddonCallCount()).To(Equal(0))
+		Expect(fakeApplier.DeletePodIdentityAssociationsCallCount()).To(Equal(0))
+	})
+
+	It("deletes resources not declared in the config when pruning", func() {
+		reconciler.Prune = true
+		taskTree := reconciler.Tasks(context.Background(), items)
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+
+		Expect(fakeApplier.DeleteNodeGroupsCallCount()).To(Equal(1))
+		_, nodeGroups, managedNodeGroups := fakeApplier.DeleteNodeGroupsArgsForCall(0)
+		Expect(nodeGroups).To(HaveLen(1))
+		Expect(nodeGroups[0].Name).To(Equal("ng-old"))
+		Expect(managedNodeGroups).To(BeEmpty())
+
+		Expect(fakeApplier.DeleteAddonCallCount()).To(Equal(1))
+		_, deleted := fakeApplier.DeleteAddonArgsForCall(0)
+		Expect(deleted.Name).To(Equal("aws-efs-csi-driver"))
+
+		Expect(fakeApplier.DeletePodIdentityAssociationsCallCount()).To(Equal(1))
+		_, identifiers := fakeApplier.DeletePodIdentityAssociationsArgsForCall(0)
+		Expect(identifiers).To(Equal([]podidentityassociation.Identifier{{Namespace: "kube-system", ServiceAccountName: "old"}}))
+	})
+
+	It("drains nodegroups before deleting them", func() {
+		reconciler.Prune = true
+		var calls []string
+		fakeApplier.DrainNodeGroupsStub = func(context.Context, []*api.NodeGroup, []*api.ManagedNodeGroup) error {
+			calls = append(calls, "drain")
+			return nil
+		}
+		fakeApplier.DeleteNodeGroupsStub = func(context.Context, []*api.NodeGroup, []*api.ManagedNodeGroup) error {
+			calls = append(calls, "delete")
+			return nil
+		}
+		taskTree := reconciler.Tasks(context.Background(), []diff.Item{
+			{ResourceType: diff.ResourceNodeGroup, Name: "ng-old", Change: diff.ChangeTypeRemoved},
+			{ResourceType: diff.ResourceManagedNodeGroup, Name: "mng-old", Change: diff.ChangeTypeRemoved},
+		})
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+		Expect(calls).To(Equal([]string{"drain", "delete"}))
+		_, nodeGroups, managedNodeGroups := fakeApplier.DrainNodeGroupsArgsForCall(0)
+		Expect(nodeGroups[0].Name).To(Equal("ng-old"))
+		Expect(managedNodeGroups[0].Name).To(Equal("mng-old"))
+	})
+
+	It("does not delete nodegroups which failed to drain", func() {
+		reconciler.Prune = true
+		fakeApplier.DrainNodeGroupsReturns(errors.New("PodDisruptionBudget violated"))
+		taskTree := reconciler.Tasks(context.Background(), []diff.Item{
+			{ResourceType: diff.ResourceNodeGroup, Name: "ng-old", Change: diff.ChangeTypeRemoved},
+		})
+		Expect(taskTree.DoAllSync()).To(ConsistOf(MatchError(ContainSubstring("PodDisruptionBudget violated"))))
+		Expect(fakeApplier.DeleteNodeGroupsCallCount()).To(Equal(0))
+	})
+
+	It("describes the planned changes", func() {
+		reconciler.Prune = true
+		taskTree := reconciler.Tasks(context.Background(), items)
+		Expect(taskTree.Len()).To(Equal(4))
+		description := taskTree.Describe()
+		Expect(description).To(ContainSubstring(`create addon "aws-ebs-csi-driver"`))
+		Expect(description).To(ContainSubstring(`update addon "vpc-cni"`))
+		Expect(description).To(ContainSubstring("create nodegroup(s) ng-new, mng-new"))
+		Expect(description).To(ContainSubstring(`scale managed nodegroup "mng-scaled"`))
+		Expect(description).To(ContainSubstring("drain nodegroup(s) ng-old"))
+		Expect(description).To(ContainSubstring("delete nodegroup(s) ng-old"))
+		Expect(description).NotTo(ContainSubstring("version"))
+		Expect(fakeApplier.Invocations()).To(BeEmpty())
+	})
+
+	It("updates cluster logging", func() {
+		taskTree := reconciler.Tasks(context.Background(), []diff.Item{
+			{ResourceType: diff.ResourceCluster, Name: "my-cluster", Change: diff.ChangeTypeChanged, Field: "cloudWatch.clusterLogging.enableTypes", Desired: "api", Live: ""},
+		})
+		Expect(taskTree.DoAllSync()).To(BeEmpty())
+		Expect(fakeApplier.UpdateClusterLoggingCallCount()).To(Equal(1))
+	})
+
+	It("returns an empty task tree when only non-reconcilable changes are found", func() {
+		taskTree := reconciler.Tasks(context.Background(), []diff.Item{
+			{ResourceType: diff.ResourceManagedNodeGroup, Name: "mng-new", Change: diff.ChangeTypeChanged, Field: "type", Desired: "managed", Live: "unmanaged"},
+		})
+		Expect(taskTree.Len()).To(Equal(0))
+	})
+
+	It("returns errors from the applier", func() {
+		fakeApplier.CreateAddonReturns(errors.New("addon creation failed"))
+		taskTree := reconciler.Tasks(context.Background(), []diff.Item{
+			{ResourceType: diff.ResourceAddon, Name: "aws-ebs-csi-driver", Change: diff.ChangeTypeAdded},
+		})
+		Expect(taskTree.DoAllSync()).To(ConsistOf(MatchError(ContainSubstring("addon creation failed"))))
+	})
+})
@@ -75,7 +75,7 @@ func (c *OwnedCluster) Upgrade(ctx context.Context, dryRun bool) error {
 	return nil
 }
 
-func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int) error {
+func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int, checkpoint *tasks.Checkpoint) error {
 	clusterOperable, err := c.ctl.CanOperate(c.cfg)
 	if err != nil {
 		logger.Debug("failed to check if cluster is operable: %v", err)
@@ -139,7 +139,7 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 			DeleteTasks(ctx, []podidentityassociation.Identifier{})
 	}
 
-	tasks, err := c.stackManager.NewTasksToDeleteClusterWithNodeGroups(ctx, c.clusterStack, allStacks, clusterOperable, newOIDCManager, newTasksToDeleteAddonIAM, newTasksToDeletePodIdentityRoles, c.ctl.Status.ClusterInfo.Cluster, kubernetes.NewCachedClientSet(clientSet), wait, force, func(errs chan error, _ string) error {
+	taskTree, err := c.stackManager.NewTasksToDeleteClusterWithNodeGroups(ctx, c.clusterStack, allStacks, clusterOperable, newOIDCManager, newTasksToDeleteAddonIAM, newTasksToDeletePodIdentityRoles, c.ctl.Status.ClusterInfo.Cluster, kubernetes.NewCachedClientSet(clientSet), wait, force, func(errs chan error, _ string) error {
 		logger.Info("trying to cleanup dangling network interfaces")
 		stack, err := c.stackManager.DescribeClusterStack(ctx)
 		if err != nil {
@@ -160,15 +160,23 @@ func (c *OwnedCluster) Delete(ctx context.Context, _, podEvictionWaitPeriod time
 		return err
 	}
 
-	if tasks.Len() == 0 {
+	if taskTree.Len() == 0 {
 		logger.Warning("no cluster resources were found for %q", c.cfg.Metadata.Name)
+		if err := checkpoint.Clear(); err != nil {
+			logger.Warning("unable to remove state file %s: %v", checkpoint.Path(), err)
+		}
 		return nil
 	}
 
-	logger.Info(tasks.Describe())
-	if errs := tasks.DoAllSync(); len(errs) > 0 {
+	taskTree.Checkpoint = checkpoint
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+		cmdutils.LogResumeHint(checkpoint)
 		return handleErrors(errs, "cluster with nodegroup(s)")
 	}
+	if err := checkpoint.Clear(); err != nil {
+		logger.Warning("unable to remove state file %s: %v", checkpoint.Path(), err)
+	}
 
 	if err := c.deleteKarpenterStackIfExists(ctx); err != nil {
 		return err
@@ -58,7 +58,7 @@ func (c *UnownedCluster) Upgrade(ctx context.Context, dryRun bool) error {
 	return nil
 }
 
-func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int) error {
+func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWaitPeriod time.Duration, wait, force, disableNodegroupEviction bool, parallel int, checkpoint *tasks.Checkpoint) error {
 	clusterName := c.cfg.Metadata.Name
 
 	if err := c.checkClusterExists(ctx, clusterName); err != nil {
@@ -108,11 +108,13 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 
 	// we have to wait for nodegroups to delete before deleting the cluster
 	// so the `wait` value is ignored here
-	if err := c.deleteAndWaitForNodegroupsDeletion(ctx, waitInterval, allStacks); err != nil {
+	if err := c.deleteAndWaitForNodegroupsDeletion(ctx, waitInterval, allStacks, checkpoint); err != nil {
+		cmdutils.LogResumeHint(checkpoint)
 		return err
 	}
 
-	if err := c.deleteIAMAndOIDC(ctx, wait, clusterOperable, clientSet, force); err != nil {
+	if err := c.deleteIAMAndOIDC(ctx, wait, clusterOperable, clientSet, force, checkpoint); err != nil {
+		cmdutils.LogResumeHint(checkpoint)
 		if force {
 			logger.Warning("error occurred during deletion: %v", err)
 		} else {
@@ -123,6 +125,9 @@ func (c *UnownedCluster) Delete(ctx context.Context, waitInterval, podEvictionWa
 	if err := c.deleteCluster(ctx, wait); err != nil {
 		return err
 	}
+	if err := checkpoint.Clear(); err != nil {
+		logger.Warning("unable to remove state file %s: %v", checkpoint.Path(), err)
+	}
 
 	if err := checkForUndeletedStacks(ctx, c.stackManager); err != nil {
 		return err
@@ -164,8 +169,8 @@ func (c *UnownedCluster) checkClusterExists(ctx context.Context, clusterName str
 	return nil
 }
 
-func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, clusterOperable bool, clientSet kubernetes.Interface, force bool) error {
-	tasksTree := &tasks.TaskTree{Parallel: false}
+func (c *UnownedCluster) deleteIAMAndOIDC(ctx context.Context, wait bool, clusterOperable bool, clientSet kubernetes.Interface, force bool, checkpoint *tasks.Checkpoint) error {
+	tasksTree := &tasks.TaskTree{Parallel: false, Checkpoint: checkpoint}
 
 	if clusterOperable {
 		clientSetGetter := kubernetes.NewCachedClientSet(clientSet)
@@ -235,7 +240,7 @@ func (c *UnownedCluster) deleteCluster(ctx context.Context, wait bool) error {
 	}, c.ctl.AWSProvider.WaitTimeout())
 }
 
-func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context, waitInterval time.Duration, allStacks []manager.NodeGroupStack) error {
+func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context, waitInterval time.Duration, allStacks []manager.NodeGroupStack, checkpoint *tasks.Checkpoint) error {
 	clusterName := c.cfg.Metadata.Name
 	eksAPI := c.ctl.AWSProvider.EKS()
 
@@ -276,6 +281,7 @@ func (c *UnownedCluster) deleteAndWaitForNodegroupsDeletion(ctx context.Context,
 
 	// TODO what dis?
 	tasks.PlanMode = false
+	tasks.Checkpoint = checkpoint
 	logger.Info(tasks.Describe())
 	if errs := tasks.DoAllSync(); len(errs) > 0 {
 		return handleErrors(errs, "nodegroup(s)")
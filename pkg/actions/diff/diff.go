@@ -0,0 +1,457 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+// ChangeType describes how a resource declared in a ClusterConfig differs from the live cluster.
+type ChangeType string
+
+const (
+	// ChangeTypeAdded means the resource is declared in the config but does not exist in the cluster.
+	ChangeTypeAdded ChangeType = "added"
+	// ChangeTypeRemoved means the resource exists in the cluster but is not declared in the config.
+	ChangeTypeRemoved ChangeType = "removed"
+	// ChangeTypeChanged means the resource exists in both but a field differs.
+	ChangeTypeChanged ChangeType = "changed"
+)
+
+// Resource types reported in an Item.
+const (
+	ResourceCluster                = "cluster"
+	ResourceNodeGroup              = "nodegroup"
+	ResourceManagedNodeGroup       = "managednodegroup"
+	ResourceAddon                  = "addon"
+	ResourceIAMServiceAccount      = "iamserviceaccount"
+	ResourceAccessEntry            = "accessentry"
+	ResourcePodIdentityAssociation = "podidentityassociation"
+	ResourceFargateProfile         = "fargateprofile"
+)
+
+// Item is a single difference between the config and the live cluster.
+type Item struct {
+	ResourceType string     `json:"resourceType"`
+	Name         string     `json:"name"`
+	Change       ChangeType `json:"change"`
+	Field        string     `json:"field,omitempty"`
+	Desired      string     `json:"desired,omitempty"`
+	Live         string     `json:"live,omitempty"`
+}
+
+// Differ computes the drift between a ClusterConfig and the live cluster.
+type Differ struct {
+	clusterConfig     *api.ClusterConfig
+	clusterProvider   api.ClusterProvider
+	stackManager      manager.StackManager
+	accessEntryGetter accessentry.GetterInterface
+}
+
+// New creates a new Differ.
+func New(clusterConfig *api.ClusterConfig, clusterProvider api.ClusterProvider, stackManager manager.StackManager) *Differ {
+	return &Differ{
+		clusterConfig:     clusterConfig,
+		clusterProvider:   clusterProvider,
+		stackManager:      stackManager,
+		accessEntryGetter: accessentry.NewGetter(clusterConfig.Metadata.Name, clusterProvider.EKS()),
+	}
+}
+
+// Diff returns all differences between the config and the live cluster, ordered by resource type and name.
+func (d *Differ) Diff(ctx context.Context) ([]Item, error) {
+	var items []Item
+	for _, differ := range []func(context.Context) ([]Item, error){
+		d.diffCluster,
+		d.diffNodeGroups,
+		d.diffAddons,
+		d.diffIAMServiceAccounts,
+		d.diffAccessEntries,
+		d.diffPodIdentityAssociations,
+		d.diffFargateProfiles,
+	} {
+		diffItems, err := differ(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, diffItems...)
+	}
+	return items, nil
+}
+
+func (d *Differ) diffCluster(ctx context.Context) ([]Item, error) {
+	clusterName := d.clusterConfig.Metadata.Name
+	if _, err := d.stackManager.DescribeClusterStack(ctx); err != nil {
+		if !manager.IsStackDoesNotExistError(err) {
+			return nil, fmt.Errorf("describing cluster stack: %w", err)
+		}
+		logger.Warning("cluster %q was not created by eksctl, only comparing EKS resources", clusterName)
+	}
+
+	out, err := d.clusterProvider.EKS().DescribeCluster(ctx, &eks.DescribeClusterInput{
+		Name: aws.String(clusterName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing cluster %q: %w", clusterName, err)
+	}
+
+	var items []Item
+	desiredVersion := d.clusterConfig.Metadata.Version
+	if liveVersion := aws.ToString(out.Cluster.Version); desiredVersion != "" && desiredVersion != liveVersion {
+		items = append(items, changed(ResourceCluster, clusterName, "metadata.version", desiredVersion, liveVersion))
+	}
+	if d.clusterConfig.HasClusterCloudWatchLogging() {
+		desiredTypes := d.clusterConfig.CloudWatch.ClusterLogging.EnableTypes
+		if d.clusterConfig.ContainsWildcardCloudWatchLogging() {
+			desiredTypes = api.SupportedCloudWatchClusterLogTypes()
+		}
+		var liveTypes []string
+		if out.Cluster.Logging != nil {
+			for _, setup := range out.Cluster.Logging.ClusterLogging {
+				if aws.ToBool(setup.Enabled) {
+					for _, t := range setup.Types {
+						liveTypes = append(liveTypes, string(t))
+					}
+				}
+			}
+		}
+		if desired, live := sortedCopy(desiredTypes), sortedCopy(liveTypes); !slices.Equal(desired, live) {
+			items = append(items, changed(ResourceCluster, clusterName, "cloudWatch.clusterLogging.enableTypes", strings.Join(desired, ","), strings.Join(live, ",")))
+		}
+	}
+	return items, nil
+}
+
+func (d *Differ) diffNodeGroups(ctx context.Context) ([]Item, error) {
+	stacks, err := d.stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing nodegroup stacks: %w", err)
+	}
+	live := map[string]api.NodeGroupType{}
+	for _, s := range stacks {
+		live[s.NodeGroupName] = s.Type
+	}
+
+	var items []Item
+	desired := map[string]bool{}
+	for _, ng := range d.clusterConfig.NodeGroups {
+		desired[ng.Name] = true
+		items = append(items, diffNodeGroupType(ResourceNodeGroup, ng.Name, api.NodeGroupTypeUnmanaged, live)...)
+	}
+	for _, ng := range d.clusterConfig.ManagedNodeGroups {
+		desired[ng.Name] = true
+		ngItems := diffNodeGroupType(ResourceManagedNodeGroup, ng.Name, api.NodeGroupTypeManaged, live)
+		if len(ngItems) == 0 {
+			scalingItems, err := d.diffManagedNodeGroupScaling(ctx, ng)
+			if err != nil {
+				return nil, err
+			}
+			ngItems = scalingItems
+		}
+		items = append(items, ngItems...)
+	}
+	for _, s := range stacks {
+		if desired[s.NodeGroupName] {
+			continue
+		}
+		resourceType := ResourceNodeGroup
+		if s.Type == api.NodeGroupTypeManaged {
+			resourceType = ResourceManagedNodeGroup
+		}
+		items = append(items, removed(resourceType, s.NodeGroupName))
+	}
+	return sortItems(items), nil
+}
+
+func diffNodeGroupType(resourceType, name string, desiredType api.NodeGroupType, live map[string]api.NodeGroupType) []Item {
+	liveType, ok := live[name]
+	switch {
+	case !ok:
+		return []Item{added(resourceType, name)}
+	case liveType != desiredType:
+		return []Item{changed(resourceType, name, "type", string(desiredType), string(liveType))}
+	}
+	return nil
+}
+
+func (d *Differ) diffManagedNodeGroupScaling(ctx context.Context, ng *api.ManagedNodeGroup) ([]Item, error) {
+	if ng.ScalingConfig == nil {
+		return nil, nil
+	}
+	out, err := d.clusterProvider.EKS().DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+		ClusterName:   aws.String(d.clusterConfig.Metadata.Name),
+		NodegroupName: aws.String(ng.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describing managed nodegroup %q: %w", ng.Name, err)
+	}
+	if out.Nodegroup.ScalingConfig == nil {
+		return nil, nil
+	}
+
+	var items []Item
+	compare := func(field string, desired *int, live *int32) {
+		if desired != nil && live != nil && int32(*desired) != *live {
+			items = append(items, changed(ResourceManagedNodeGroup, ng.Name, field, fmt.Sprint(*desired), fmt.Sprint(*live)))
+		}
+	}
+	compare("minSize", ng.MinSize, out.Nodegroup.ScalingConfig.MinSize)
+	compare("maxSize", ng.MaxSize, out.Nodegroup.ScalingConfig.MaxSize)
+	return items, nil
+}
+
+func (d *Differ) diffAddons(ctx context.Context) ([]Item, error) {
+	eksAPI := d.clusterProvider.EKS()
+	clusterName := d.clusterConfig.Metadata.Name
+	list, err := eksAPI.ListAddons(ctx, &eks.ListAddonsInput{
+		ClusterName: aws.String(clusterName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing addons: %w", err)
+	}
+
+	var items []Item
+	desired := map[string]bool{}
+	for _, a := range d.clusterConfig.Addons {
+		desired[a.Name] = true
+		if !slices.Contains(list.Addons, a.Name) {
+			items = append(items, added(ResourceAddon, a.Name))
+			continue
+		}
+		out, err := eksAPI.DescribeAddon(ctx, &eks.DescribeAddonInput{
+			AddonName:   aws.String(a.Name),
+			ClusterName: aws.String(clusterName),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describing addon %q: %w", a.Name, err)
+		}
+		liveVersion := aws.ToString(out.Addon.AddonVersion)
+		if a.Version != "" && a.Version != "latest" && !addonVersionMatches(a.Version, liveVersion) {
+			items = append(items, changed(ResourceAddon, a.Name, "version", a.Version, liveVersion))
+		}
+		if liveValues := aws.ToString(out.Addon.ConfigurationValues); a.ConfigurationValues != "" && strings.TrimSpace(a.ConfigurationValues) != strings.TrimSpace(liveValues) {
+			items = append(items, changed(ResourceAddon, a.Name, "configurationValues", a.ConfigurationValues, liveValues))
+		}
+	}
+	for _, name := range list.Addons {
+		if desired[name] {
+			continue
+		}
+		// default addons are installed implicitly unless disabled, so they are only reported when explicitly managed
+		if known, ok := api.KnownAddons[name]; ok && known.IsDefault && !d.clusterConfig.AddonsConfig.DisableDefaultAddons {
+			continue
+		}
+		items = append(items, removed(ResourceAddon, name))
+	}
+	return sortItems(items), nil
+}
+
+// addonVersionMatches reports whether the live version satisfies the desired version, which may omit
+// the leading "v" and the "-eksbuild" suffix.
+func addonVersionMatches(desired, live string) bool {
+	desired = strings.TrimPrefix(desired, "v")
+	live = strings.TrimPrefix(live, "v")
+	return live == desired || strings.HasPrefix(live, desired+"-")
+}
+
+func (d *Differ) diffIAMServiceAccounts(ctx context.Context) ([]Item, error) {
+	serviceAccounts, err := d.stackManager.GetIAMServiceAccounts(ctx, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("getting iamserviceaccounts: %w", err)
+	}
+
+	var items []Item
+	live := map[string]bool{}
+	for _, sa := range serviceAccounts {
+		live[sa.NameString()] = true
+	}
+	desired := map[string]bool{}
+	for _, sa := range d.clusterConfig.IAM.ServiceAccounts {
+		desired[sa.NameString()] = true
+		if !live[sa.NameString()] {
+			items = append(items, added(ResourceIAMServiceAccount, sa.NameString()))
+		}
+	}
+	for _, sa := range serviceAccounts {
+		if !desired[sa.NameString()] {
+			items = append(items, removed(ResourceIAMServiceAccount, sa.NameString()))
+		}
+	}
+	return sortItems(items), nil
+}
+
+func (d *Differ) diffAccessEntries(ctx context.Context) ([]Item, error) {
+	if d.clusterConfig.AccessConfig == nil || d.clusterConfig.AccessConfig.AuthenticationMode == "CONFIG_MAP" {
+		return nil, nil
+	}
+	summaries, err := d.accessEntryGetter.Get(ctx, api.ARN{})
+	if err != nil {
+		return nil, fmt.Errorf("getting access entries: %w", err)
+	}
+	live := map[string]accessentry.Summary{}
+	for _, s := range summaries {
+		live[s.PrincipalARN] = s
+	}
+
+	var items []Item
+	desired := map[string]bool{}
+	for _, ae := range d.clusterConfig.AccessConfig.AccessEntries {
+		principalARN := ae.PrincipalARN.String()
+		desired[principalARN] = true
+		s, ok := live[principalARN]
+		if !ok {
+			items = append(items, added(ResourceAccessEntry, principalARN))
+			continue
+		}
+		if desiredGroups, liveGroups := sortedCopy(ae.KubernetesGroups), sortedCopy(s.KubernetesGroups); !slices.Equal(desiredGroups, liveGroups) {
+			items = append(items, changed(ResourceAccessEntry, principalARN, "kubernetesGroups", strings.Join(desiredGroups, ","), strings.Join(liveGroups, ",")))
+		}
+		if desiredPolicies, livePolicies := formatAccessPolicies(ae.AccessPolicies), formatAccessPolicies(s.AccessPolicies); desiredPolicies != livePolicies {
+			items = append(items, changed(ResourceAccessEntry, principalARN, "accessPolicies", desiredPolicies, livePolicies))
+		}
+	}
+	for _, s := range summaries {
+		if desired[s.PrincipalARN] || s.IsManaged() {
+			continue
+		}
+		items = append(items, removed(ResourceAccessEntry, s.PrincipalARN))
+	}
+	return sortItems(items), nil
+}
+
+func formatAccessPolicies(policies []api.AccessPolicy) string {
+	var formatted []string
+	for _, p := range policies {
+		scope := string(p.AccessScope.Type)
+		if len(p.AccessScope.Namespaces) > 0 {
+			scope += "=" + strings.Join(sortedCopy(p.AccessScope.Namespaces), "+")
+		}
+		formatted = append(formatted, fmt.Sprintf("%s(%s)", p.PolicyARN.String(), scope))
+	}
+	sort.Strings(formatted)
+	return strings.Join(formatted, ",")
+}
+
+func (d *Differ) diffPodIdentityAssociations(ctx context.Context) ([]Item, error) {
+	summaries, err := podidentityassociation.NewGetter(d.clusterConfig.Metadata.Name, d.clusterProvider.EKS()).GetPodIdentityAssociations(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]podidentityassociation.Summary{}
+	for _, s := range summaries {
+		live[s.Namespace+"/"+s.ServiceAccountName] = s
+	}
+
+	var items []Item
+	desired := map[string]bool{}
+	for _, pia := range d.clusterConfig.IAM.PodIdentityAssociations {
+		name := pia.NameString()
+		desired[name] = true
+		s, ok := live[name]
+		if !ok {
+			items = append(items, added(ResourcePodIdentityAssociation, name))
+			continue
+		}
+		if pia.RoleARN != "" && pia.RoleARN != s.RoleARN {
+			items = append(items, changed(ResourcePodIdentityAssociation, name, "roleARN", pia.RoleARN, s.RoleARN))
+		}
+	}
+	for _, s := range summaries {
+		name := s.Namespace + "/" + s.ServiceAccountName
+		// associations owned by addons are reconciled as part of the addon
+		if desired[name] || s.OwnerARN != "" {
+			continue
+		}
+		items = append(items, removed(ResourcePodIdentityAssociation, name))
+	}
+	return sortItems(items), nil
+}
+
+func (d *Differ) diffFargateProfiles(ctx context.Context) ([]Item, error) {
+	client := fargate.NewFromProvider(d.clusterConfig.Metadata.Name, d.clusterProvider, d.stackManager)
+	profiles, err := client.ReadProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	live := map[string]*api.FargateProfile{}
+	for _, p := range profiles {
+		live[p.Name] = p
+	}
+
+	var items []Item
+	desired := map[string]bool{}
+	for _, fp := range d.clusterConfig.FargateProfiles {
+		desired[fp.Name] = true
+		p, ok := live[fp.Name]
+		if !ok {
+			items = append(items, added(ResourceFargateProfile, fp.Name))
+			continue
+		}
+		if desiredSelectors, liveSelectors := formatSelectors(fp.Selectors), formatSelectors(p.Selectors); desiredSelectors != liveSelectors {
+			items = append(items, changed(ResourceFargateProfile, fp.Name, "selectors", desiredSelectors, liveSelectors))
+		}
+		if desiredSubnets, liveSubnets := sortedCopy(fp.Subnets), sortedCopy(p.Subnets); len(desiredSubnets) > 0 && !slices.Equal(desiredSubnets, liveSubnets) {
+			items = append(items, changed(ResourceFargateProfile, fp.Name, "subnets", strings.Join(desiredSubnets, ","), strings.Join(liveSubnets, ",")))
+		}
+	}
+	for _, p := range profiles {
+		if !desired[p.Name] {
+			items = append(items, removed(ResourceFargateProfile, p.Name))
+		}
+	}
+	return sortItems(items), nil
+}
+
+func formatSelectors(selectors []api.FargateProfileSelector) string {
+	var formatted []string
+	for _, s := range selectors {
+		var labels []string
+		for k, v := range s.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		formatted = append(formatted, fmt.Sprintf("%s{%s}", s.Namespace, strings.Join(labels, ",")))
+	}
+	sort.Strings(formatted)
+	return strings.Join(formatted, ";")
+}
+
+func added(resourceType, name string) Item {
+	return Item{ResourceType: resourceType, Name: name, Change: ChangeTypeAdded}
+}
+
+func removed(resourceType, name string) Item {
+	return Item{ResourceType: resourceType, Name: name, Change: ChangeTypeRemoved}
+}
+
+func changed(resourceType, name, field, desired, live string) Item {
+	return Item{ResourceType: resourceType, Name: name, Change: ChangeTypeChanged, Field: field, Desired: desired, Live: live}
+}
+
+func sortedCopy(in []string) []string {
+	out := slices.Clone(in)
+	sort.Strings(out)
+	return out
+}
+
+func sortItems(items []Item) []Item {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].ResourceType != items[j].ResourceType {
+			return items[i].ResourceType < items[j].ResourceType
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
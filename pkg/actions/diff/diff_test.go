@@ -0,0 +1,211 @@
+package diff_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/diff"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Diff", func() {
+	const clusterName = "my-cluster"
+
+	var (
+		cfg              *api.ClusterConfig
+		mockProvider     *mockprovider.MockProvider
+		fakeStackManager *fakes.FakeStackManager
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+		cfg.Metadata.Version = "1.32"
+		cfg.AccessConfig.AuthenticationMode = ekstypes.AuthenticationModeApi
+
+		mockProvider = mockprovider.NewMockProvider()
+		fakeStackManager = &fakes.FakeStackManager{}
+		fakeStackManager.DescribeClusterStackReturns(&manager.Stack{StackName: aws.String("eksctl-my-cluster-cluster")}, nil)
+
+		mockProvider.MockEKS().On("DescribeCluster", mock.Anything, mock.Anything).Return(&eks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{Version: aws.String("1.32")},
+		}, nil)
+	})
+
+	mockNoAccessEntriesOrPodIdentityAssociationsOrFargateProfiles := func() {
+		mockProvider.MockEKS().On("ListAccessEntries", mock.Anything, mock.Anything).Return(&eks.ListAccessEntriesOutput{}, nil)
+		mockProvider.MockEKS().On("ListPodIdentityAssociations", mock.Anything, mock.Anything).Return(&eks.ListPodIdentityAssociationsOutput{}, nil)
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(&eks.ListFargateProfilesOutput{}, nil)
+	}
+
+	mockAddons := func(addons ...ekstypes.Addon) {
+		var names []string
+		for _, a := range addons {
+			a := a
+			names = append(names, *a.AddonName)
+			mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.MatchedBy(func(input *eks.DescribeAddonInput) bool {
+				return *input.AddonName == *a.AddonName
+			})).Return(&eks.DescribeAddonOutput{Addon: &a}, nil)
+		}
+		mockProvider.MockEKS().On("ListAddons", mock.Anything, mock.Anything).Return(&eks.ListAddonsOutput{Addons: names}, nil)
+	}
+
+	runDiff := func() ([]diff.Item, error) {
+		return diff.New(cfg, mockProvider, fakeStackManager).Diff(context.Background())
+	}
+
+	It("reports no drift when the config matches the cluster", func() {
+		cfg.NodeGroups = []*api.NodeGroup{{NodeGroupBase: &api.NodeGroupBase{Name: "ng-1"}}}
+		cfg.Addons = []*api.Addon{{Name: "vpc-cni", Version: "1.19.0"}}
+		fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+			{NodeGroupName: "ng-1", Type: api.NodeGroupTypeUnmanaged},
+		}, nil)
+		mockAddons(
+			ekstypes.Addon{AddonName: aws.String("vpc-cni"), AddonVersion: aws.String("v1.19.0-eksbuild.1")},
+			ekstypes.Addon{AddonName: aws.String("coredns"), AddonVersion: aws.String("v1.11.4-eksbuild.2")},
+		)
+		mockNoAccessEntriesOrPodIdentityAssociationsOrFargateProfiles()
+
+		items, err := runDiff()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(BeEmpty())
+	})
+
+	It("reports added, removed and changed resources", func() {
+		cfg.Metadata.Version = "1.33"
+		cfg.NodeGroups = []*api.NodeGroup{{NodeGroupBase: &api.NodeGroupBase{Name: "ng-new"}}}
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{{NodeGroupBase: &api.NodeGroupBase{Name: "mng-1"}}}
+		cfg.Addons = []*api.Addon{{Name: "vpc-cni", Version: "1.20.0"}, {Name: "aws-ebs-csi-driver"}}
+		fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+			{NodeGroupName: "ng-old", Type: api.NodeGroupTypeUnmanaged},
+			{NodeGroupName: "mng-1", Type: api.NodeGroupTypeUnmanaged},
+		}, nil)
+		mockAddons(
+			ekstypes.Addon{AddonName: aws.String("vpc-cni"), AddonVersion: aws.String("v1.19.0-eksbuild.1")},
+			ekstypes.Addon{AddonName: aws.String("aws-efs-csi-driver"), AddonVersion: aws.String("v2.1.0-eksbuild.1")},
+		)
+		mockNoAccessEntriesOrPodIdentityAssociationsOrFargateProfiles()
+
+		items, err := runDiff()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(Equal([]diff.Item{
+			{ResourceType: diff.ResourceCluster, Name: clusterName, Change: diff.ChangeTypeChanged, Field: "metadata.version", Desired: "1.33", Live: "1.32"},
+			{ResourceType: diff.ResourceManagedNodeGroup, Name: "mng-1", Change: diff.ChangeTypeChanged, Field: "type", Desired: "managed", Live: "unmanaged"},
+			{ResourceType: diff.ResourceNodeGroup, Name: "ng-new", Change: diff.ChangeTypeAdded},
+			{ResourceType: diff.ResourceNodeGroup, Name: "ng-old", Change: diff.ChangeTypeRemoved},
+			{ResourceType: diff.ResourceAddon, Name: "aws-ebs-csi-driver", Change: diff.ChangeTypeAdded},
+			{ResourceType: diff.ResourceAddon, Name: "aws-efs-csi-driver", Change: diff.ChangeTypeRemoved},
+			{ResourceType: diff.ResourceAddon, Name: "vpc-cni", Change: diff.ChangeTypeChanged, Field: "version", Desired: "1.20.0", Live: "v1.19.0-eksbuild.1"},
+		}))
+	})
+
+	It("compares access entries, pod identity associations and Fargate profiles", func() {
+		const (
+			adminARN = "arn:aws:iam::111122223333:role/admin"
+			devARN   = "arn:aws:iam::111122223333:role/dev"
+			nodeARN  = "arn:aws:iam::111122223333:role/node"
+		)
+		cfg.AccessConfig.AccessEntries = []api.AccessEntry{
+			{PrincipalARN: api.MustParseARN(adminARN), KubernetesGroups: []string{"admins"}},
+		}
+		cfg.IAM.PodIdentityAssociations = []api.PodIdentityAssociation{
+			{Namespace: "default", ServiceAccountName: "app", RoleARN: "arn:aws:iam::111122223333:role/app"},
+		}
+		cfg.FargateProfiles = []*api.FargateProfile{
+			{Name: "fp-default", Selectors: []api.FargateProfileSelector{{Namespace: "default"}, {Namespace: "kube-system"}}},
+		}
+		fakeStackManager.ListNodeGroupStacksWithStatusesReturns(nil, nil)
+		mockAddons()
+
+		mockProvider.MockEKS().On("ListAccessEntries", mock.Anything, mock.Anything).Return(&eks.ListAccessEntriesOutput{
+			AccessEntries: []string{adminARN, devARN, nodeARN},
+		}, nil)
+		for arn, groups := range map[string][]string{adminARN: {"viewers"}, devARN: nil, nodeARN: {"system:nodes"}} {
+			arn, groups := arn, groups
+			mockProvider.MockEKS().On("DescribeAccessEntry", mock.Anything, mock.MatchedBy(func(input *eks.DescribeAccessEntryInput) bool {
+				return *input.PrincipalArn == arn
+			})).Return(&eks.DescribeAccessEntryOutput{AccessEntry: &ekstypes.AccessEntry{KubernetesGroups: groups}}, nil)
+		}
+		mockProvider.MockEKS().On("ListAssociatedAccessPolicies", mock.Anything, mock.Anything).Return(&eks.ListAssociatedAccessPoliciesOutput{}, nil)
+
+		mockProvider.MockEKS().On("ListPodIdentityAssociations", mock.Anything, mock.Anything).Return(&eks.ListPodIdentityAssociationsOutput{
+			Associations: []ekstypes.PodIdentityAssociationSummary{{AssociationId: aws.String("a-1")}},
+		}, nil)
+		mockProvider.MockEKS().On("DescribePodIdentityAssociation", mock.Anything, mock.Anything).Return(&eks.DescribePodIdentityAssociationOutput{
+			Association: &ekstypes.PodIdentityAssociation{
+				AssociationArn: aws.String("arn:aws:eks:us-west-2:111122223333:podidentityassociation/my-cluster/a-1"),
+				Namespace:      aws.String("default"),
+				ServiceAccount: aws.String("app"),
+				RoleArn:        aws.String("arn:aws:iam::111122223333:role/other"),
+			},
+		}, nil)
+
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(&eks.ListFargateProfilesOutput{
+			FargateProfileNames: []string{"fp-default"},
+		}, nil)
+		mockProvider.MockEKS().On("DescribeFargateProfile", mock.Anything, mock.Anything).Return(&eks.DescribeFargateProfileOutput{
+			FargateProfile: &ekstypes.FargateProfile{
+				FargateProfileName: aws.String("fp-default"),
+				Selectors:          []ekstypes.FargateProfileSelector{{Namespace: aws.String("default")}},
+			},
+		}, nil)
+
+		items, err := runDiff()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(Equal([]diff.Item{
+			{ResourceType: diff.ResourceAccessEntry, Name: adminARN, Change: diff.ChangeTypeChanged, Field: "kubernetesGroups", Desired: "admins", Live: "viewers"},
+			{ResourceType: diff.ResourceAccessEntry, Name: devARN, Change: diff.ChangeTypeRemoved},
+			{ResourceType: diff.ResourcePodIdentityAssociation, Name: "default/app", Change: diff.ChangeTypeChanged, Field: "roleARN", Desired: "arn:aws:iam::111122223333:role/app", Live: "arn:aws:iam::111122223333:role/other"},
+			{ResourceType: diff.ResourceFargateProfile, Name: "fp-default", Change: diff.ChangeTypeChanged, Field: "selectors", Desired: "default{};kube-system{}", Live: "default{}"},
+		}))
+	})
+
+	It("compares IAM service accounts and cluster logging", func() {
+		cfg.CloudWatch.ClusterLogging.EnableTypes = []string{"api", "audit"}
+		cfg.IAM.ServiceAccounts = []*api.ClusterIAMServiceAccount{
+			{ClusterIAMMeta: api.ClusterIAMMeta{Name: "app", Namespace: "default"}},
+		}
+		fakeStackManager.ListNodeGroupStacksWithStatusesReturns(nil, nil)
+		fakeStackManager.GetIAMServiceAccountsReturns([]*api.ClusterIAMServiceAccount{
+			{ClusterIAMMeta: api.ClusterIAMMeta{Name: "old", Namespace: "kube-system"}},
+		}, nil)
+		mockProvider.MockEKS().ExpectedCalls = nil
+		mockProvider.MockEKS().On("DescribeCluster", mock.Anything, mock.Anything).Return(&eks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				Version: aws.String("1.32"),
+				Logging: &ekstypes.Logging{
+					ClusterLogging: []ekstypes.LogSetup{
+						{Enabled: aws.Bool(true), Types: []ekstypes.LogType{ekstypes.LogTypeApi}},
+						{Enabled: aws.Bool(false), Types: []ekstypes.LogType{ekstypes.LogTypeAudit}},
+					},
+				},
+			},
+		}, nil)
+		mockAddons()
+		mockNoAccessEntriesOrPodIdentityAssociationsOrFargateProfiles()
+
+		items, err := runDiff()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(items).To(Equal([]diff.Item{
+			{ResourceType: diff.ResourceCluster, Name: clusterName, Change: diff.ChangeTypeChanged, Field: "cloudWatch.clusterLogging.enableTypes", Desired: "api,audit", Live: "api"},
+			{ResourceType: diff.ResourceIAMServiceAccount, Name: "default/app", Change: diff.ChangeTypeAdded},
+			{ResourceType: diff.ResourceIAMServiceAccount, Name: "kube-system/old", Change: diff.ChangeTypeRemoved},
+		}))
+	})
+
+	It("returns an error when listing nodegroup stacks fails", func() {
+		fakeStackManager.ListNodeGroupStacksWithStatusesReturns(nil, context.DeadlineExceeded)
+		_, err := runDiff()
+		Expect(err).To(MatchError(ContainSubstring("listing nodegroup stacks")))
+	})
+})
@@ -0,0 +1,422 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	"github.com/tidwall/gjson"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/podidentityassociation"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+// Exporter reconstructs the ClusterConfig of a live cluster from its EKS resources and CloudFormation stacks.
+type Exporter struct {
+	clusterName       string
+	region            string
+	clusterProvider   api.ClusterProvider
+	stackManager      manager.StackManager
+	accessEntryGetter accessentry.GetterInterface
+
+	// ownedVPC is set when the cluster's VPC was created by eksctl, in which case subnet IDs are not exported,
+	// as the subnets are deleted along with the cluster.
+	ownedVPC bool
+}
+
+// New creates a new Exporter.
+func New(meta *api.ClusterMeta, clusterProvider api.ClusterProvider, stackManager manager.StackManager) *Exporter {
+	return &Exporter{
+		clusterName:       meta.Name,
+		region:            meta.Region,
+		clusterProvider:   clusterProvider,
+		stackManager:      stackManager,
+		accessEntryGetter: accessentry.NewGetter(meta.Name, clusterProvider.EKS()),
+	}
+}
+
+// Export returns a ClusterConfig that describes the cluster and the resources eksctl manages in it, such that
+// creating a cluster from it produces an equivalent cluster.
+func (e *Exporter) Export(ctx context.Context) (*api.ClusterConfig, error) {
+	cfg := api.NewClusterConfig()
+	cfg.Metadata.Name = e.clusterName
+	cfg.Metadata.Region = e.region
+
+	for _, export := range []func(context.Context, *api.ClusterConfig) error{
+		e.exportCluster,
+		e.exportNodeGroups,
+		e.exportManagedNodeGroups,
+		e.exportAddons,
+		e.exportIAMServiceAccounts,
+		e.exportAccessEntries,
+		e.exportPodIdentityAssociations,
+		e.exportFargateProfiles,
+	} {
+		if err := export(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func (e *Exporter) exportCluster(ctx context.Context, cfg *api.ClusterConfig) error {
+	out, err := e.clusterProvider.EKS().DescribeCluster(ctx, &eks.DescribeClusterInput{
+		Name: aws.String(e.clusterName),
+	})
+	if err != nil {
+		return fmt.Errorf("describing cluster %q: %w", e.clusterName, err)
+	}
+	cluster := out.Cluster
+
+	cfg.Metadata.Version = aws.ToString(cluster.Version)
+	cfg.Metadata.Tags = userTags(cluster.Tags)
+
+	if knc := cluster.KubernetesNetworkConfig; knc != nil {
+		if knc.IpFamily == ekstypes.IpFamilyIpv6 {
+			cfg.KubernetesNetworkConfig.IPFamily = api.IPV6Family
+		}
+		cfg.KubernetesNetworkConfig.ServiceIPv4CIDR = aws.ToString(knc.ServiceIpv4Cidr)
+	}
+	if cluster.AccessConfig != nil {
+		cfg.AccessConfig.AuthenticationMode = cluster.AccessConfig.AuthenticationMode
+	}
+	for _, encryptionConfig := range cluster.EncryptionConfig {
+		if encryptionConfig.Provider != nil {
+			cfg.SecretsEncryption = &api.SecretsEncryption{KeyARN: aws.ToString(encryptionConfig.Provider.KeyArn)}
+		}
+	}
+	if cluster.Logging != nil {
+		for _, setup := range cluster.Logging.ClusterLogging {
+			if aws.ToBool(setup.Enabled) {
+				for _, t := range setup.Types {
+					cfg.CloudWatch.ClusterLogging.EnableTypes = append(cfg.CloudWatch.ClusterLogging.EnableTypes, string(t))
+				}
+			}
+		}
+		sort.Strings(cfg.CloudWatch.ClusterLogging.EnableTypes)
+	}
+	if vpcConfig := cluster.ResourcesVpcConfig; vpcConfig != nil {
+		cfg.VPC.ClusterEndpoints = &api.ClusterEndpoints{
+			PrivateAccess: aws.Bool(vpcConfig.EndpointPrivateAccess),
+			PublicAccess:  aws.Bool(vpcConfig.EndpointPublicAccess),
+		}
+		if !(len(vpcConfig.PublicAccessCidrs) == 1 && vpcConfig.PublicAccessCidrs[0] == "0.0.0.0/0") {
+			cfg.VPC.PublicAccessCIDRs = vpcConfig.PublicAccessCidrs
+		}
+	}
+	return e.exportVPC(ctx, cfg, cluster)
+}
+
+// exportVPC exports the VPC created by eksctl as its CIDR, availability zones and NAT mode, so that creating
+// a cluster from the config creates a new VPC. Any other VPC is exported by ID along with the cluster's subnets.
+func (e *Exporter) exportVPC(ctx context.Context, cfg *api.ClusterConfig, cluster *ekstypes.Cluster) error {
+	stack, err := e.stackManager.DescribeClusterStack(ctx)
+	if err != nil {
+		if !manager.IsStackDoesNotExistError(err) {
+			return fmt.Errorf("describing cluster stack: %w", err)
+		}
+		logger.Warning("cluster %q was not created by eksctl, exporting its VPC and subnets by ID", e.clusterName)
+		return e.exportExistingVPC(ctx, cfg, cluster.ResourcesVpcConfig)
+	}
+	for _, tag := range stack.Tags {
+		if aws.ToString(tag.Key) == api.ClusterOIDCEnabledTag && aws.ToString(tag.Value) == "true" {
+			cfg.IAM.WithOIDC = api.Enabled()
+		}
+	}
+
+	template, err := e.stackManager.GetStackTemplate(ctx, aws.ToString(stack.StackName))
+	if err != nil {
+		return fmt.Errorf("getting CloudFormation template for stack %s: %w", aws.ToString(stack.StackName), err)
+	}
+	resources := gjson.Get(template, "Resources")
+	vpc := resources.Get("VPC")
+	if !vpc.Exists() {
+		return e.exportExistingVPC(ctx, cfg, cluster.ResourcesVpcConfig)
+	}
+	e.ownedVPC = true
+
+	if cidr, err := ipnet.ParseCIDR(vpc.Get("Properties.CidrBlock").String()); err == nil {
+		cfg.VPC.CIDR = cidr
+	}
+	zones := map[string]bool{}
+	hasNATGateway := false
+	resources.ForEach(func(name, resource gjson.Result) bool {
+		switch resource.Get("Type").String() {
+		case "AWS::EC2::Subnet":
+			if az := resource.Get("Properties.AvailabilityZone").String(); az != "" {
+				zones[az] = true
+			}
+		case "AWS::EC2::NatGateway":
+			hasNATGateway = true
+		}
+		return true
+	})
+	for az := range zones {
+		cfg.AvailabilityZones = append(cfg.AvailabilityZones, az)
+	}
+	sort.Strings(cfg.AvailabilityZones)
+
+	switch {
+	case cfg.KubernetesNetworkConfig.IPv6Enabled():
+		cfg.VPC.NAT = nil
+	case resources.Get("NATGateway").Exists():
+		cfg.VPC.NAT = &api.ClusterNAT{Gateway: aws.String(api.ClusterSingleNAT)}
+	case hasNATGateway:
+		cfg.VPC.NAT = &api.ClusterNAT{Gateway: aws.String(api.ClusterHighlyAvailableNAT)}
+	default:
+		cfg.VPC.NAT = &api.ClusterNAT{Gateway: aws.String(api.ClusterDisableNAT)}
+	}
+	return nil
+}
+
+// exportExistingVPC exports a VPC that was not created by eksctl by ID. Subnets that assign public IPs on launch
+// are exported as public subnets.
+func (e *Exporter) exportExistingVPC(ctx context.Context, cfg *api.ClusterConfig, vpcConfig *ekstypes.VpcConfigResponse) error {
+	if vpcConfig == nil {
+		return nil
+	}
+	cfg.VPC.ID = aws.ToString(vpcConfig.VpcId)
+	cfg.VPC.CIDR = nil
+	cfg.VPC.NAT = nil
+	if len(vpcConfig.SubnetIds) == 0 {
+		return nil
+	}
+
+	out, err := e.clusterProvider.EC2().DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+		SubnetIds: vpcConfig.SubnetIds,
+	})
+	if err != nil {
+		return fmt.Errorf("describing subnets of cluster %q: %w", e.clusterName, err)
+	}
+	cfg.VPC.Subnets = &api.ClusterSubnets{
+		Private: api.NewAZSubnetMapping(),
+		Public:  api.NewAZSubnetMapping(),
+	}
+	for _, subnet := range out.Subnets {
+		spec := api.AZSubnetSpec{
+			ID: aws.ToString(subnet.SubnetId),
+			AZ: aws.ToString(subnet.AvailabilityZone),
+		}
+		if aws.ToBool(subnet.MapPublicIpOnLaunch) {
+			cfg.VPC.Subnets.Public[spec.ID] = spec
+		} else {
+			cfg.VPC.Subnets.Private[spec.ID] = spec
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) exportAddons(ctx context.Context, cfg *api.ClusterConfig) error {
+	eksAPI := e.clusterProvider.EKS()
+	list, err := eksAPI.ListAddons(ctx, &eks.ListAddonsInput{
+		ClusterName: aws.String(e.clusterName),
+	})
+	if err != nil {
+		return fmt.Errorf("listing addons: %w", err)
+	}
+	addonNames := append([]string(nil), list.Addons...)
+	sort.Strings(addonNames)
+
+	for _, name := range addonNames {
+		out, err := eksAPI.DescribeAddon(ctx, &eks.DescribeAddonInput{
+			AddonName:   aws.String(name),
+			ClusterName: aws.String(e.clusterName),
+		})
+		if err != nil {
+			return fmt.Errorf("describing addon %q: %w", name, err)
+		}
+		cfg.Addons = append(cfg.Addons, &api.Addon{
+			Name:                  name,
+			Version:               aws.ToString(out.Addon.AddonVersion),
+			ServiceAccountRoleARN: aws.ToString(out.Addon.ServiceAccountRoleArn),
+			ConfigurationValues:   aws.ToString(out.Addon.ConfigurationValues),
+			Tags:                  userTags(out.Addon.Tags),
+		})
+	}
+	return nil
+}
+
+func (e *Exporter) exportIAMServiceAccounts(ctx context.Context, cfg *api.ClusterConfig) error {
+	serviceAccounts, err := e.stackManager.GetIAMServiceAccounts(ctx, "", "")
+	if err != nil {
+		return fmt.Errorf("getting iamserviceaccounts: %w", err)
+	}
+	sort.Slice(serviceAccounts, func(i, j int) bool {
+		return serviceAccounts[i].NameString() < serviceAccounts[j].NameString()
+	})
+
+	partition := api.Partitions.ForRegion(e.region)
+	for _, sa := range serviceAccounts {
+		exported := &api.ClusterIAMServiceAccount{
+			ClusterIAMMeta: sa.ClusterIAMMeta,
+		}
+		if sa.Status != nil && sa.Status.StackName != nil {
+			template, err := e.stackManager.GetStackTemplate(ctx, *sa.Status.StackName)
+			if err != nil {
+				return fmt.Errorf("getting CloudFormation template for stack %s: %w", *sa.Status.StackName, err)
+			}
+			applyRoleTemplate(exported, template, partition)
+		}
+		cfg.IAM.ServiceAccounts = append(cfg.IAM.ServiceAccounts, exported)
+	}
+	if len(cfg.IAM.ServiceAccounts) > 0 {
+		cfg.IAM.WithOIDC = api.Enabled()
+	}
+	return nil
+}
+
+// applyRoleTemplate sets the policies of the role in an iamserviceaccount stack. Inline policies, including those
+// added for well-known policies, are merged into a single policy document.
+func applyRoleTemplate(sa *api.ClusterIAMServiceAccount, template, partition string) {
+	var statements []interface{}
+	gjson.Get(template, "Resources").ForEach(func(_, resource gjson.Result) bool {
+		properties := resource.Get("Properties")
+		switch resource.Get("Type").String() {
+		case "AWS::IAM::Role":
+			for _, policyARN := range properties.Get("ManagedPolicyArns").Array() {
+				if policyARN.IsObject() {
+					policyARN = policyARN.Get(`Fn\:\:Sub`)
+				}
+				sa.AttachPolicyARNs = append(sa.AttachPolicyARNs, strings.ReplaceAll(policyARN.String(), "${AWS::Partition}", partition))
+			}
+			if boundary := properties.Get("PermissionsBoundary"); boundary.Type == gjson.String {
+				sa.PermissionsBoundary = boundary.String()
+			}
+			if roleName := properties.Get("RoleName"); roleName.Type == gjson.String {
+				sa.RoleName = roleName.String()
+			}
+		case "AWS::IAM::Policy":
+			for _, statement := range properties.Get("PolicyDocument.Statement").Array() {
+				statements = append(statements, statement.Value())
+			}
+		}
+		return true
+	})
+	if len(statements) > 0 {
+		sa.AttachPolicy = api.InlineDocument{
+			"Version":   "2012-10-17",
+			"Statement": statements,
+		}
+	}
+}
+
+func (e *Exporter) exportAccessEntries(ctx context.Context, cfg *api.ClusterConfig) error {
+	if cfg.AccessConfig.AuthenticationMode == ekstypes.AuthenticationModeConfigMap {
+		return nil
+	}
+	summaries, err := e.accessEntryGetter.Get(ctx, api.ARN{})
+	if err != nil {
+		return fmt.Errorf("getting access entries: %w", err)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].PrincipalARN < summaries[j].PrincipalARN
+	})
+	for _, s := range summaries {
+		if s.IsManaged() {
+			continue
+		}
+		principalARN, err := arn.Parse(s.PrincipalARN)
+		if err != nil {
+			return fmt.Errorf("parsing principal ARN of access entry: %w", err)
+		}
+		cfg.AccessConfig.AccessEntries = append(cfg.AccessConfig.AccessEntries, api.AccessEntry{
+			PrincipalARN:     api.ARN(principalARN),
+			KubernetesGroups: s.KubernetesGroups,
+			AccessPolicies:   s.AccessPolicies,
+		})
+	}
+	return nil
+}
+
+func (e *Exporter) exportPodIdentityAssociations(ctx context.Context, cfg *api.ClusterConfig) error {
+	summaries, err := podidentityassociation.NewGetter(e.clusterName, e.clusterProvider.EKS()).GetPodIdentityAssociations(ctx, "", "")
+	if err != nil {
+		return err
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Namespace+"/"+summaries[i].ServiceAccountName < summaries[j].Namespace+"/"+summaries[j].ServiceAccountName
+	})
+	addons := map[string]*api.Addon{}
+	for _, a := range cfg.Addons {
+		addons[a.Name] = a
+	}
+	for _, s := range summaries {
+		pia := api.PodIdentityAssociation{
+			Namespace:          s.Namespace,
+			ServiceAccountName: s.ServiceAccountName,
+			RoleARN:            s.RoleARN,
+		}
+		if s.OwnerARN == "" {
+			cfg.IAM.PodIdentityAssociations = append(cfg.IAM.PodIdentityAssociations, pia)
+			continue
+		}
+		// associations owned by addons are exported under the addon that owns them
+		addon, ok := addons[addonNameFromARN(s.OwnerARN)]
+		if !ok {
+			continue
+		}
+		if addon.PodIdentityAssociations == nil {
+			addon.PodIdentityAssociations = &[]api.PodIdentityAssociation{}
+		}
+		*addon.PodIdentityAssociations = append(*addon.PodIdentityAssociations, pia)
+	}
+	return nil
+}
+
+// addonNameFromARN returns the name of the addon identified by an ARN of the form
+// arn:aws:eks:<region>:<account>:addon/<cluster>/<addon>/<id>.
+func addonNameFromARN(addonARN string) string {
+	parts := strings.Split(addonARN, "/")
+	if len(parts) < 3 || !strings.HasSuffix(parts[0], ":addon") {
+		return ""
+	}
+	return parts[2]
+}
+
+func (e *Exporter) exportFargateProfiles(ctx context.Context, cfg *api.ClusterConfig) error {
+	client := fargate.NewFromProvider(e.clusterName, e.clusterProvider, e.stackManager)
+	profiles, err := client.ReadProfiles(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range profiles {
+		p.Status = ""
+		if e.ownedVPC {
+			p.Subnets = nil
+		}
+		// the default pod execution role is created in the cluster stack along with the first profile
+		if strings.Contains(p.PodExecutionRoleARN, ":role/eksctl-"+e.clusterName+"-cluster-") {
+			p.PodExecutionRoleARN = ""
+		}
+		p.Tags = userTags(p.Tags)
+		cfg.FargateProfiles = append(cfg.FargateProfiles, p)
+	}
+	return nil
+}
+
+// userTags returns the tags that were set by the user, omitting those added by AWS or eksctl.
+func userTags(tags map[string]string) map[string]string {
+	var filtered map[string]string
+	for k, v := range tags {
+		if strings.HasPrefix(k, "aws:") || strings.HasPrefix(k, "alpha.eksctl.io/") ||
+			strings.HasPrefix(k, "eksctl.cluster.k8s.io/") || strings.HasPrefix(k, "eksctl.io/") {
+			continue
+		}
+		if filtered == nil {
+			filtered = map[string]string{}
+		}
+		filtered[k] = v
+	}
+	return filtered
+}
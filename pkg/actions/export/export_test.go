@@ -0,0 +1,387 @@
+package export_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/weaveworks/eksctl/pkg/actions/export"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+const clusterStackTemplate = `{
+  "Resources": {
+    "VPC": {"Type": "AWS::EC2::VPC", "Properties": {"CidrBlock": "10.10.0.0/16"}},
+    "SubnetPublicUSWEST2A": {"Type": "AWS::EC2::Subnet", "Properties": {"AvailabilityZone": "us-west-2a"}},
+    "SubnetPrivateUSWEST2B": {"Type": "AWS::EC2::Subnet", "Properties": {"AvailabilityZone": "us-west-2b"}},
+    "NATGateway": {"Type": "AWS::EC2::NatGateway"}
+  }
+}`
+
+const nodeGroupStackTemplate = `{
+  "Resources": {
+    "NodeGroupLaunchTemplate": {
+      "Type": "AWS::EC2::LaunchTemplate",
+      "Properties": {
+        "LaunchTemplateData": {
+          "BlockDeviceMappings": [{"DeviceName": "/dev/xvda", "Ebs": {"VolumeSize": 100, "VolumeType": "gp3"}}],
+          "ImageId": "ami-123",
+          "InstanceType": "m5.large",
+          "MetadataOptions": {"HttpPutResponseHopLimit": 2, "HttpTokens": "required"},
+          "UserData": %q
+        }
+      }
+    },
+    "NodeGroup": {
+      "Type": "AWS::AutoScaling::AutoScalingGroup",
+      "Properties": {
+        "DesiredCapacity": "3",
+        "MinSize": "1",
+        "MaxSize": "5",
+        "VPCZoneIdentifier": {"Fn::Split": [",", {"Fn::ImportValue": "eksctl-my-cluster-cluster::SubnetsPrivate"}]}
+      }
+    }
+  }
+}`
+
+const managedNodeGroupStackTemplate = `{
+  "Resources": {
+    "LaunchTemplate": {
+      "Type": "AWS::EC2::LaunchTemplate",
+      "Properties": {
+        "LaunchTemplateData": {
+          "BlockDeviceMappings": [{"DeviceName": "/dev/xvda", "Ebs": {"VolumeSize": 50, "VolumeType": "gp3", "Iops": 3000}}]
+        }
+      }
+    }
+  }
+}`
+
+const serviceAccountStackTemplate = `{
+  "Resources": {
+    "Role1": {
+      "Type": "AWS::IAM::Role",
+      "Properties": {
+        "ManagedPolicyArns": [
+          "arn:aws:iam::123456789012:policy/custom",
+          {"Fn::Sub": "arn:${AWS::Partition}:iam::aws:policy/AmazonS3ReadOnlyAccess"}
+        ]
+      }
+    },
+    "PolicyEBSCSIController": {
+      "Type": "AWS::IAM::Policy",
+      "Properties": {
+        "PolicyDocument": {"Statement": [{"Action": ["ec2:CreateVolume"], "Effect": "Allow", "Resource": "*"}]}
+      }
+    }
+  }
+}`
+
+var _ = Describe("Export", func() {
+	const clusterName = "my-cluster"
+
+	var (
+		mockProvider     *mockprovider.MockProvider
+		fakeStackManager *fakes.FakeStackManager
+		templates        map[string]string
+	)
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		fakeStackManager = &fakes.FakeStackManager{}
+		templates = map[string]string{}
+		fakeStackManager.GetStackTemplateStub = func(_ context.Context, stackName string) (string, error) {
+			template, ok := templates[stackName]
+			if !ok {
+				return "", fmt.Errorf("unexpected stack %q", stackName)
+			}
+			return template, nil
+		}
+
+		mockProvider.MockEKS().On("DescribeCluster", mock.Anything, mock.Anything).Return(&eks.DescribeClusterOutput{
+			Cluster: &ekstypes.Cluster{
+				Version: aws.String("1.32"),
+				Tags: map[string]string{
+					"team":                 "platform",
+					api.ClusterNameTag:     clusterName,
+					"aws:cloudformation:x": "y",
+				},
+				Logging: &ekstypes.Logging{ClusterLogging: []ekstypes.LogSetup{
+					{Enabled: aws.Bool(true), Types: []ekstypes.LogType{ekstypes.LogTypeAudit, ekstypes.LogTypeApi}},
+					{Enabled: aws.Bool(false), Types: []ekstypes.LogType{ekstypes.LogTypeScheduler}},
+				}},
+				AccessConfig: &ekstypes.AccessConfigResponse{AuthenticationMode: ekstypes.AuthenticationModeApiAndConfigMap},
+				ResourcesVpcConfig: &ekstypes.VpcConfigResponse{
+					VpcId:                 aws.String("vpc-1"),
+					SubnetIds:             []string{"subnet-1", "subnet-2"},
+					EndpointPrivateAccess: true,
+					EndpointPublicAccess:  true,
+					PublicAccessCidrs:     []string{"0.0.0.0/0"},
+				},
+			},
+		}, nil)
+		mockProvider.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything, mock.Anything).Return(&eks.ListNodegroupsOutput{}, nil)
+		mockProvider.MockEKS().On("ListAddons", mock.Anything, mock.Anything).Return(&eks.ListAddonsOutput{}, nil)
+		mockProvider.MockEKS().On("ListAccessEntries", mock.Anything, mock.Anything).Return(&eks.ListAccessEntriesOutput{}, nil)
+		mockProvider.MockEKS().On("ListPodIdentityAssociations", mock.Anything, mock.Anything).Return(&eks.ListPodIdentityAssociationsOutput{}, nil)
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(&eks.ListFargateProfilesOutput{}, nil)
+	})
+
+	runExport := func() (*api.ClusterConfig, error) {
+		meta := &api.ClusterMeta{Name: clusterName, Region: "us-west-2"}
+		return export.New(meta, mockProvider, fakeStackManager).Export(context.Background())
+	}
+
+	Context("cluster created by eksctl", func() {
+		BeforeEach(func() {
+			fakeStackManager.DescribeClusterStackReturns(&manager.Stack{
+				StackName: aws.String("eksctl-my-cluster-cluster"),
+				Tags:      []cfntypes.Tag{{Key: aws.String(api.ClusterOIDCEnabledTag), Value: aws.String("true")}},
+			}, nil)
+			templates["eksctl-my-cluster-cluster"] = clusterStackTemplate
+		})
+
+		It("exports the cluster settings and the VPC created by eksctl", func() {
+			cfg, err := runExport()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Metadata.Name).To(Equal(clusterName))
+			Expect(cfg.Metadata.Region).To(Equal("us-west-2"))
+			Expect(cfg.Metadata.Version).To(Equal("1.32"))
+			Expect(cfg.Metadata.Tags).To(Equal(map[string]string{"team": "platform"}))
+			Expect(cfg.CloudWatch.ClusterLogging.EnableTypes).To(Equal([]string{"api", "audit"}))
+			Expect(cfg.AccessConfig.AuthenticationMode).To(Equal(ekstypes.AuthenticationModeApiAndConfigMap))
+			Expect(*cfg.IAM.WithOIDC).To(BeTrue())
+
+			Expect(cfg.VPC.ID).To(BeEmpty())
+			Expect(cfg.VPC.CIDR.String()).To(Equal("10.10.0.0/16"))
+			Expect(cfg.VPC.Subnets).To(BeNil())
+			Expect(*cfg.VPC.NAT.Gateway).To(Equal(api.ClusterSingleNAT))
+			Expect(*cfg.VPC.ClusterEndpoints.PrivateAccess).To(BeTrue())
+			Expect(cfg.VPC.PublicAccessCIDRs).To(BeEmpty())
+			Expect(cfg.AvailabilityZones).To(Equal([]string{"us-west-2a", "us-west-2b"}))
+		})
+
+		It("exports unmanaged and managed nodegroups", func() {
+			userData := base64.StdEncoding.EncodeToString([]byte(`MIME-Version: 1.0
+Content-Type: application/node.eks.aws
+
+spec:
+  kubelet:
+    flags:
+    - --node-labels=alpha.eksctl.io/nodegroup-name=ng-1,role=worker
+    - --register-with-taints=dedicated=gpu:NoSchedule
+`))
+			templates["eksctl-my-cluster-nodegroup-ng-1"] = fmt.Sprintf(nodeGroupStackTemplate, userData)
+			mngStack := manager.NodeGroupStack{
+				NodeGroupName: "mng-1",
+				Type:          api.NodeGroupTypeManaged,
+				Stack:         &manager.Stack{StackName: aws.String("eksctl-my-cluster-nodegroup-mng-1")},
+			}
+			fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{
+				mngStack,
+				{
+					NodeGroupName: "ng-1",
+					Type:          api.NodeGroupTypeUnmanaged,
+					Stack:         &manager.Stack{StackName: aws.String("eksctl-my-cluster-nodegroup-ng-1")},
+				},
+			}, nil)
+			fakeStackManager.GetManagedNodeGroupTemplateReturns(managedNodeGroupStackTemplate, nil)
+
+			mockProvider.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything, mock.Anything).Unset()
+			mockProvider.MockEKS().On("ListNodegroups", mock.Anything, mock.Anything, mock.Anything).Return(&eks.ListNodegroupsOutput{
+				Nodegroups: []string{"mng-1"},
+			}, nil)
+			mockProvider.MockEKS().On("DescribeNodegroup", mock.Anything, mock.Anything).Return(&eks.DescribeNodegroupOutput{
+				Nodegroup: &ekstypes.Nodegroup{
+					NodegroupName: aws.String("mng-1"),
+					AmiType:       ekstypes.AMITypesAl2023X8664Standard,
+					CapacityType:  ekstypes.CapacityTypesOnDemand,
+					InstanceTypes: []string{"m6i.large"},
+					Subnets:       []string{"subnet-1"},
+					ScalingConfig: &ekstypes.NodegroupScalingConfig{MinSize: aws.Int32(1), MaxSize: aws.Int32(3), DesiredSize: aws.Int32(2)},
+					Labels:        map[string]string{api.NodeGroupNameLabel: "mng-1", "role": "system"},
+					Tags:          map[string]string{"team": "platform", api.NodeGroupNameTag: "mng-1"},
+					UpdateConfig:  &ekstypes.NodegroupUpdateConfig{MaxUnavailable: aws.Int32(1)},
+				},
+			}, nil)
+
+			cfg, err := runExport()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.NodeGroups).To(HaveLen(1))
+			ng := cfg.NodeGroups[0]
+			Expect(ng.Name).To(Equal("ng-1"))
+			Expect(ng.AMIFamily).To(Equal(api.NodeImageFamilyAmazonLinux2023))
+			Expect(ng.AMI).To(BeEmpty())
+			Expect(ng.InstanceType).To(Equal("m5.large"))
+			Expect(*ng.DesiredCapacity).To(Equal(3))
+			Expect(*ng.MinSize).To(Equal(1))
+			Expect(*ng.MaxSize).To(Equal(5))
+			Expect(*ng.VolumeSize).To(Equal(100))
+			Expect(ng.PrivateNetworking).To(BeTrue())
+			Expect(ng.Subnets).To(BeEmpty())
+			Expect(ng.Labels).To(Equal(map[string]string{"role": "worker"}))
+			Expect(ng.Taints).To(ConsistOf(api.NodeGroupTaint{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}))
+
+			Expect(cfg.ManagedNodeGroups).To(HaveLen(1))
+			mng := cfg.ManagedNodeGroups[0]
+			Expect(mng.Name).To(Equal("mng-1"))
+			Expect(mng.InstanceType).To(Equal("m6i.large"))
+			Expect(*mng.VolumeSize).To(Equal(50))
+			Expect(*mng.VolumeIOPS).To(Equal(3000))
+			Expect(mng.Subnets).To(BeEmpty())
+			Expect(mng.Labels).To(Equal(map[string]string{"role": "system"}))
+			Expect(mng.Tags).To(Equal(map[string]string{"team": "platform"}))
+			Expect(*mng.UpdateConfig.MaxUnavailable).To(Equal(1))
+
+			_, options := fakeStackManager.GetManagedNodeGroupTemplateArgsForCall(0)
+			Expect(options.NodeGroupName).To(Equal("mng-1"))
+			Expect(options.Stack).To(Equal(&mngStack))
+		})
+
+		It("exports addons, IAM service accounts, access entries and pod identity associations", func() {
+			mockProvider.MockEKS().On("ListAddons", mock.Anything, mock.Anything).Unset()
+			mockProvider.MockEKS().On("ListAddons", mock.Anything, mock.Anything).Return(&eks.ListAddonsOutput{
+				Addons: []string{"vpc-cni", "aws-ebs-csi-driver"},
+			}, nil)
+			mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.MatchedBy(func(input *eks.DescribeAddonInput) bool {
+				return *input.AddonName == "vpc-cni"
+			})).Return(&eks.DescribeAddonOutput{Addon: &ekstypes.Addon{
+				AddonName:           aws.String("vpc-cni"),
+				AddonVersion:        aws.String("v1.19.0-eksbuild.1"),
+				ConfigurationValues: aws.String(`{"env":{"ENABLE_PREFIX_DELEGATION":"true"}}`),
+			}}, nil)
+			mockProvider.MockEKS().On("DescribeAddon", mock.Anything, mock.MatchedBy(func(input *eks.DescribeAddonInput) bool {
+				return *input.AddonName == "aws-ebs-csi-driver"
+			})).Return(&eks.DescribeAddonOutput{Addon: &ekstypes.Addon{
+				AddonName:    aws.String("aws-ebs-csi-driver"),
+				AddonVersion: aws.String("v1.38.1-eksbuild.1"),
+			}}, nil)
+
+			fakeStackManager.GetIAMServiceAccountsReturns([]*api.ClusterIAMServiceAccount{{
+				ClusterIAMMeta: api.ClusterIAMMeta{Name: "s3-reader", Namespace: "default"},
+				Status:         &api.ClusterIAMServiceAccountStatus{StackName: aws.String("eksctl-my-cluster-addon-iamserviceaccount-default-s3-reader")},
+			}}, nil)
+			templates["eksctl-my-cluster-addon-iamserviceaccount-default-s3-reader"] = serviceAccountStackTemplate
+
+			mockProvider.MockEKS().On("ListAccessEntries", mock.Anything, mock.Anything).Unset()
+			mockProvider.MockEKS().On("ListAccessEntries", mock.Anything, mock.Anything).Return(&eks.ListAccessEntriesOutput{
+				AccessEntries: []string{"arn:aws:iam::123456789012:role/admin", "arn:aws:iam::123456789012:role/node"},
+			}, nil)
+			mockProvider.MockEKS().On("DescribeAccessEntry", mock.Anything, mock.MatchedBy(func(input *eks.DescribeAccessEntryInput) bool {
+				return *input.PrincipalArn == "arn:aws:iam::123456789012:role/admin"
+			})).Return(&eks.DescribeAccessEntryOutput{AccessEntry: &ekstypes.AccessEntry{}}, nil)
+			mockProvider.MockEKS().On("DescribeAccessEntry", mock.Anything, mock.MatchedBy(func(input *eks.DescribeAccessEntryInput) bool {
+				return *input.PrincipalArn == "arn:aws:iam::123456789012:role/node"
+			})).Return(&eks.DescribeAccessEntryOutput{AccessEntry: &ekstypes.AccessEntry{KubernetesGroups: []string{"system:nodes"}}}, nil)
+			mockProvider.MockEKS().On("ListAssociatedAccessPolicies", mock.Anything, mock.MatchedBy(func(input *eks.ListAssociatedAccessPoliciesInput) bool {
+				return *input.PrincipalArn == "arn:aws:iam::123456789012:role/admin"
+			})).Return(&eks.ListAssociatedAccessPoliciesOutput{AssociatedAccessPolicies: []ekstypes.AssociatedAccessPolicy{{
+				PolicyArn:   aws.String("arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"),
+				AccessScope: &ekstypes.AccessScope{Type: ekstypes.AccessScopeTypeCluster},
+			}}}, nil)
+			mockProvider.MockEKS().On("ListAssociatedAccessPolicies", mock.Anything, mock.Anything).Return(&eks.ListAssociatedAccessPoliciesOutput{}, nil)
+
+			mockProvider.MockEKS().On("ListPodIdentityAssociations", mock.Anything, mock.Anything).Unset()
+			mockProvider.MockEKS().On("ListPodIdentityAssociations", mock.Anything, mock.Anything).Return(&eks.ListPodIdentityAssociationsOutput{
+				Associations: []ekstypes.PodIdentityAssociationSummary{{AssociationId: aws.String("a-1")}, {AssociationId: aws.String("a-2")}},
+			}, nil)
+			mockProvider.MockEKS().On("DescribePodIdentityAssociation", mock.Anything, mock.MatchedBy(func(input *eks.DescribePodIdentityAssociationInput) bool {
+				return *input.AssociationId == "a-1"
+			})).Return(&eks.DescribePodIdentityAssociationOutput{Association: &ekstypes.PodIdentityAssociation{
+				AssociationArn: aws.String("arn-1"),
+				Namespace:      aws.String("default"),
+				ServiceAccount: aws.String("app"),
+				RoleArn:        aws.String("arn:aws:iam::123456789012:role/app"),
+			}}, nil)
+			mockProvider.MockEKS().On("DescribePodIdentityAssociation", mock.Anything, mock.MatchedBy(func(input *eks.DescribePodIdentityAssociationInput) bool {
+				return *input.AssociationId == "a-2"
+			})).Return(&eks.DescribePodIdentityAssociationOutput{Association: &ekstypes.PodIdentityAssociation{
+				AssociationArn: aws.String("arn-2"),
+				Namespace:      aws.String("kube-system"),
+				ServiceAccount: aws.String("ebs-csi-controller-sa"),
+				RoleArn:        aws.String("arn:aws:iam::123456789012:role/ebs"),
+				OwnerArn:       aws.String("arn:aws:eks:us-west-2:123456789012:addon/my-cluster/aws-ebs-csi-driver/abcd"),
+			}}, nil)
+
+			cfg, err := runExport()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.Addons).To(HaveLen(2))
+			ebs, vpcCNI := cfg.Addons[0], cfg.Addons[1]
+			Expect(vpcCNI.Name).To(Equal("vpc-cni"))
+			Expect(vpcCNI.Version).To(Equal("v1.19.0-eksbuild.1"))
+			Expect(vpcCNI.ConfigurationValues).To(Equal(`{"env":{"ENABLE_PREFIX_DELEGATION":"true"}}`))
+			Expect(ebs.Name).To(Equal("aws-ebs-csi-driver"))
+			Expect(*ebs.PodIdentityAssociations).To(Equal([]api.PodIdentityAssociation{{
+				Namespace:          "kube-system",
+				ServiceAccountName: "ebs-csi-controller-sa",
+				RoleARN:            "arn:aws:iam::123456789012:role/ebs",
+			}}))
+
+			Expect(cfg.IAM.ServiceAccounts).To(HaveLen(1))
+			sa := cfg.IAM.ServiceAccounts[0]
+			Expect(sa.NameString()).To(Equal("default/s3-reader"))
+			Expect(sa.AttachPolicyARNs).To(Equal([]string{
+				"arn:aws:iam::123456789012:policy/custom",
+				"arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess",
+			}))
+			Expect(sa.AttachPolicy["Statement"]).To(HaveLen(1))
+
+			Expect(cfg.AccessConfig.AccessEntries).To(HaveLen(1))
+			Expect(cfg.AccessConfig.AccessEntries[0].PrincipalARN.String()).To(Equal("arn:aws:iam::123456789012:role/admin"))
+			Expect(cfg.AccessConfig.AccessEntries[0].AccessPolicies).To(HaveLen(1))
+
+			Expect(cfg.IAM.PodIdentityAssociations).To(Equal([]api.PodIdentityAssociation{{
+				Namespace:          "default",
+				ServiceAccountName: "app",
+				RoleARN:            "arn:aws:iam::123456789012:role/app",
+			}}))
+		})
+	})
+
+	Context("cluster not created by eksctl", func() {
+		BeforeEach(func() {
+			fakeStackManager.DescribeClusterStackReturns(nil, &smithy.OperationError{
+				Err: errors.New("ValidationError"),
+			})
+			mockProvider.MockEC2().On("DescribeSubnets", mock.Anything, &ec2.DescribeSubnetsInput{
+				SubnetIds: []string{"subnet-1", "subnet-2"},
+			}).Return(&ec2.DescribeSubnetsOutput{Subnets: []ec2types.Subnet{
+				{SubnetId: aws.String("subnet-1"), AvailabilityZone: aws.String("us-west-2a"), MapPublicIpOnLaunch: aws.Bool(true)},
+				{SubnetId: aws.String("subnet-2"), AvailabilityZone: aws.String("us-west-2b"), MapPublicIpOnLaunch: aws.Bool(false)},
+			}}, nil)
+		})
+
+		It("exports the VPC and subnets by ID", func() {
+			cfg, err := runExport()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.VPC.ID).To(Equal("vpc-1"))
+			Expect(cfg.VPC.CIDR).To(BeNil())
+			Expect(cfg.VPC.NAT).To(BeNil())
+			Expect(cfg.VPC.Subnets.Public).To(Equal(api.AZSubnetMapping{
+				"subnet-1": {ID: "subnet-1", AZ: "us-west-2a"},
+			}))
+			Expect(cfg.VPC.Subnets.Private).To(Equal(api.AZSubnetMapping{
+				"subnet-2": {ID: "subnet-2", AZ: "us-west-2b"},
+			}))
+			Expect(fakeStackManager.GetStackTemplateCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,292 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eks"
+	"github.com/kris-nova/logger"
+	"github.com/tidwall/gjson"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cloudconfig"
+)
+
+func (e *Exporter) exportNodeGroups(ctx context.Context, cfg *api.ClusterConfig) error {
+	stacks, err := e.stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return fmt.Errorf("listing nodegroup stacks: %w", err)
+	}
+	sort.Slice(stacks, func(i, j int) bool {
+		return stacks[i].NodeGroupName < stacks[j].NodeGroupName
+	})
+	for _, s := range stacks {
+		if s.Type == api.NodeGroupTypeManaged || s.Stack == nil {
+			continue
+		}
+		stackName := aws.ToString(s.Stack.StackName)
+		template, err := e.stackManager.GetStackTemplate(ctx, stackName)
+		if err != nil {
+			return fmt.Errorf("getting CloudFormation template for stack %s: %w", stackName, err)
+		}
+		cfg.NodeGroups = append(cfg.NodeGroups, e.nodeGroupFromTemplate(s.NodeGroupName, template))
+	}
+	return nil
+}
+
+func (e *Exporter) nodeGroupFromTemplate(name, template string) *api.NodeGroup {
+	ng := NodeGroupFromTemplate(name, template)
+	if e.ownedVPC {
+		ng.Subnets = nil
+	}
+	return ng
+}
+
+// NodeGroupFromTemplate reconstructs an unmanaged nodegroup from its stack template. The AMI is not pinned, so that
+// creating the nodegroup resolves the latest AMI of its family. Subnets are only set for nodegroups whose subnets
+// were given by ID, as the subnets of a VPC created by eksctl are imported from the cluster stack.
+func NodeGroupFromTemplate(name, template string) *api.NodeGroup {
+	resources := gjson.Get(template, "Resources")
+	launchTemplateData := resources.Get("NodeGroupLaunchTemplate.Properties.LaunchTemplateData")
+	asg := resources.Get("NodeGroup.Properties")
+
+	ng := api.NewNodeGroup()
+	ng.Name = name
+	ng.InstanceType = launchTemplateData.Get("InstanceType").String()
+	if mixed := asg.Get("MixedInstancesPolicy"); mixed.Exists() {
+		distribution := mixed.Get("InstancesDistribution")
+		ng.InstancesDistribution = &api.NodeGroupInstancesDistribution{
+			InstanceTypes:                       stringSlice(mixed.Get("LaunchTemplate.Overrides.#.InstanceType")),
+			OnDemandBaseCapacity:                optionalInt(distribution.Get("OnDemandBaseCapacity")),
+			OnDemandPercentageAboveBaseCapacity: optionalInt(distribution.Get("OnDemandPercentageAboveBaseCapacity")),
+		}
+		if strategy := distribution.Get("SpotAllocationStrategy"); strategy.Exists() {
+			ng.InstancesDistribution.SpotAllocationStrategy = aws.String(strategy.String())
+		}
+		ng.InstanceType = "mixed"
+	}
+	ng.ScalingConfig = &api.ScalingConfig{
+		DesiredCapacity: optionalInt(asg.Get("DesiredCapacity")),
+		MinSize:         optionalInt(asg.Get("MinSize")),
+		MaxSize:         optionalInt(asg.Get("MaxSize")),
+	}
+	applyLaunchTemplateData(ng.NodeGroupBase, launchTemplateData)
+
+	subnets := asg.Get("VPCZoneIdentifier")
+	ng.PrivateNetworking = strings.Contains(subnets.Raw, "SubnetsPrivate")
+	for _, subnet := range subnets.Array() {
+		if subnet.Type == gjson.String {
+			ng.Subnets = append(ng.Subnets, subnet.String())
+		}
+	}
+
+	if err := applyUserData(ng, launchTemplateData.Get("UserData").String()); err != nil {
+		logger.Warning("nodegroup %q: %v; its AMI family, labels and taints were not exported", name, err)
+	}
+	return ng
+}
+
+func (e *Exporter) exportManagedNodeGroups(ctx context.Context, cfg *api.ClusterConfig) error {
+	stacks, err := e.stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return fmt.Errorf("listing nodegroup stacks: %w", err)
+	}
+	managedStacks := map[string]manager.NodeGroupStack{}
+	for _, s := range stacks {
+		if s.Type == api.NodeGroupTypeManaged {
+			managedStacks[s.NodeGroupName] = s
+		}
+	}
+
+	var names []string
+	paginator := eks.NewListNodegroupsPaginator(e.clusterProvider.EKS(), &eks.ListNodegroupsInput{
+		ClusterName: aws.String(e.clusterName),
+	})
+	for paginator.HasMorePages() {
+		out, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("listing managed nodegroups: %w", err)
+		}
+		names = append(names, out.Nodegroups...)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		out, err := e.clusterProvider.EKS().DescribeNodegroup(ctx, &eks.DescribeNodegroupInput{
+			ClusterName:   aws.String(e.clusterName),
+			NodegroupName: aws.String(name),
+		})
+		if err != nil {
+			return fmt.Errorf("describing managed nodegroup %q: %w", name, err)
+		}
+		ng, err := nodegroup.ManagedNodeGroupFromEKS(out.Nodegroup)
+		if err != nil {
+			logger.Warning("skipping managed nodegroup: %v", err)
+			continue
+		}
+		ng.Tags = userTags(out.Nodegroup.Tags)
+		if updateConfig := out.Nodegroup.UpdateConfig; updateConfig != nil {
+			ng.UpdateConfig = &api.NodeGroupUpdateConfig{
+				MaxUnavailable:           int32PtrToInt(updateConfig.MaxUnavailable),
+				MaxUnavailablePercentage: int32PtrToInt(updateConfig.MaxUnavailablePercentage),
+			}
+		}
+
+		s, ok := managedStacks[name]
+		if !ok {
+			if out.Nodegroup.LaunchTemplate != nil {
+				logger.Warning("managed nodegroup %q uses launch template %q, which was not exported", name, aws.ToString(out.Nodegroup.LaunchTemplate.Name))
+			}
+		} else {
+			template, err := e.stackManager.GetManagedNodeGroupTemplate(ctx, manager.GetNodegroupOption{
+				Stack:         &s,
+				NodeGroupName: name,
+			})
+			if err != nil {
+				return fmt.Errorf("getting CloudFormation template of managed nodegroup %q: %w", name, err)
+			}
+			applyLaunchTemplateData(ng.NodeGroupBase, gjson.Get(template, "Resources.LaunchTemplate.Properties.LaunchTemplateData"))
+			if e.ownedVPC {
+				ng.Subnets = nil
+			}
+		}
+		cfg.ManagedNodeGroups = append(cfg.ManagedNodeGroups, ng)
+	}
+	return nil
+}
+
+// applyLaunchTemplateData sets the volume and instance metadata settings of a nodegroup's launch template.
+func applyLaunchTemplateData(ng *api.NodeGroupBase, launchTemplateData gjson.Result) {
+	if !launchTemplateData.Exists() {
+		return
+	}
+	ebs := launchTemplateData.Get("BlockDeviceMappings.0.Ebs")
+	if size := ebs.Get("VolumeSize"); size.Exists() {
+		ng.VolumeSize = aws.Int(int(size.Int()))
+	}
+	if volumeType := ebs.Get("VolumeType"); volumeType.Exists() {
+		ng.VolumeType = aws.String(volumeType.String())
+	}
+	if iops := ebs.Get("Iops"); iops.Exists() {
+		ng.VolumeIOPS = aws.Int(int(iops.Int()))
+	}
+	if throughput := ebs.Get("Throughput"); throughput.Exists() {
+		ng.VolumeThroughput = aws.Int(int(throughput.Int()))
+	}
+	if encrypted := ebs.Get("Encrypted"); encrypted.Exists() {
+		ng.VolumeEncrypted = aws.Bool(encrypted.Bool())
+	}
+	if httpTokens := launchTemplateData.Get("MetadataOptions.HttpTokens"); httpTokens.Exists() {
+		ng.DisableIMDSv1 = aws.Bool(httpTokens.String() == "required")
+	}
+}
+
+var (
+	al2023LabelsRegexp = regexp.MustCompile(`--node-labels=([^\s"']*)`)
+	al2023TaintsRegexp = regexp.MustCompile(`--register-with-taints=([^\s"']*)`)
+	al2LabelsRegexp    = regexp.MustCompile(`(?m)^NODE_LABELS=(.*)$`)
+	al2TaintsRegexp    = regexp.MustCompile(`(?m)^NODE_TAINTS=(.*)$`)
+)
+
+// applyUserData recovers the AMI family, labels and taints of an unmanaged nodegroup from the user data eksctl
+// generated for it.
+func applyUserData(ng *api.NodeGroup, userData string) error {
+	if userData == "" {
+		return fmt.Errorf("launch template has no user data")
+	}
+	if cloudConfig, err := cloudconfig.DecodeCloudConfig(userData); err == nil {
+		for _, file := range cloudConfig.WriteFiles {
+			switch {
+			case strings.HasSuffix(file.Path, "bootstrap.al2.sh"):
+				ng.AMIFamily = api.NodeImageFamilyAmazonLinux2
+			case strings.HasSuffix(file.Path, "kubelet.env"):
+				setLabelsAndTaints(ng, firstSubmatch(al2LabelsRegexp, file.Content), firstSubmatch(al2TaintsRegexp, file.Content))
+			}
+		}
+		if ng.AMIFamily == "" {
+			return fmt.Errorf("unsupported user data")
+		}
+		return nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return fmt.Errorf("decoding user data: %w", err)
+	}
+	content := string(data)
+	switch {
+	case strings.Contains(content, "application/node.eks.aws"):
+		ng.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+		setLabelsAndTaints(ng, firstSubmatch(al2023LabelsRegexp, content), firstSubmatch(al2023TaintsRegexp, content))
+	case strings.Contains(content, "[settings.kubernetes]"):
+		ng.AMIFamily = api.NodeImageFamilyBottlerocket
+		return fmt.Errorf("labels and taints of Bottlerocket nodegroups cannot be recovered from their user data")
+	default:
+		return fmt.Errorf("unsupported user data")
+	}
+	return nil
+}
+
+func firstSubmatch(re *regexp.Regexp, s string) string {
+	if match := re.FindStringSubmatch(s); len(match) > 1 {
+		return match[1]
+	}
+	return ""
+}
+
+// setLabelsAndTaints parses labels of the form key1=value1,key2=value2 and taints of the form
+// key1=value1:Effect,key2=value2:Effect, omitting the labels eksctl adds to every nodegroup.
+func setLabelsAndTaints(ng *api.NodeGroup, labels, taints string) {
+	for _, label := range strings.Split(labels, ",") {
+		k, v, ok := strings.Cut(label, "=")
+		if !ok || k == api.NodeGroupNameLabel || k == api.ClusterNameLabel {
+			continue
+		}
+		if ng.Labels == nil {
+			ng.Labels = map[string]string{}
+		}
+		ng.Labels[k] = v
+	}
+	for _, taint := range strings.Split(taints, ",") {
+		keyValue, effect, ok := strings.Cut(taint, ":")
+		if !ok {
+			continue
+		}
+		k, v, _ := strings.Cut(keyValue, "=")
+		ng.Taints = append(ng.Taints, api.NodeGroupTaint{
+			Key:    k,
+			Value:  v,
+			Effect: corev1.TaintEffect(effect),
+		})
+	}
+}
+
+func stringSlice(result gjson.Result) []string {
+	var values []string
+	for _, v := range result.Array() {
+		values = append(values, v.String())
+	}
+	return values
+}
+
+func optionalInt(result gjson.Result) *int {
+	if !result.Exists() {
+		return nil
+	}
+	return aws.Int(int(result.Int()))
+}
+
+func int32PtrToInt(i *int32) *int {
+	if i == nil {
+		return nil
+	}
+	return aws.Int(int(*i))
+}
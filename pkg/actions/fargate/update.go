@@ -0,0 +1,230 @@
+package fargate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kris-nova/logger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+// ProfileReplacement describes the replacement of an existing Fargate profile
+// by a profile with the desired configuration and the same name.
+type ProfileReplacement struct {
+	Current     *api.FargateProfile
+	Replacement *api.FargateProfile
+	// Temporary is the profile with the desired configuration which replaces
+	// Current while it is recreated, or nil if Current is the temporary profile
+	// left by an interrupted update.
+	Temporary *api.FargateProfile
+	// TemporaryExists is true if Temporary was created by an interrupted update,
+	// and so does not need to be created again.
+	TemporaryExists bool
+	// Gained are the selectors of Replacement which are not selectors of Current.
+	Gained []api.FargateProfileSelector
+	// Lost are the selectors of Current which are not selectors of Replacement.
+	Lost []api.FargateProfileSelector
+}
+
+// UpdatePlan describes the Fargate profiles to replace, and the tasks replacing them.
+type UpdatePlan struct {
+	Replacements []*ProfileReplacement
+	Tasks        *tasks.TaskTree
+}
+
+// PlanUpdate compares the Fargate profiles of the ClusterConfig with the
+// existing ones, and plans the replacement of the profiles which differ, as
+// Fargate profiles are immutable. Fields of a desired profile which are not
+// set default to the ones of the existing profile.
+func (m *Manager) PlanUpdate(ctx context.Context, restartPods bool) (*UpdatePlan, error) {
+	if ok, err := m.ctl.CanOperate(m.cfg); !ok {
+		return nil, fmt.Errorf("couldn't check cluster operable status: %w", err)
+	}
+
+	fargateClient := fargate.NewFromProvider(m.cfg.Metadata.Name, m.ctl.AWSProvider, m.stackManager)
+	existing, err := fargateClient.ReadProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UpdatePlan{
+		Tasks: &tasks.TaskTree{Parallel: false},
+	}
+	for _, desired := range m.cfg.FargateProfiles {
+		current := fargate.FindProfile(existing, desired.Name)
+		if current == nil {
+			return nil, fmt.Errorf("Fargate profile %q not found, use 'eksctl create fargateprofile' to create it", desired.Name)
+		}
+
+		replacement := desired.DeepCopy()
+		if len(replacement.Selectors) == 0 {
+			replacement.Selectors = current.Selectors
+		}
+		if len(replacement.Subnets) == 0 {
+			replacement.Subnets = current.Subnets
+		}
+		if replacement.Tags == nil {
+			replacement.Tags = current.Tags
+		}
+		if replacement.PodExecutionRoleARN == "" {
+			replacement.PodExecutionRoleARN = current.PodExecutionRoleARN
+		}
+		if err := replacement.Validate(); err != nil {
+			return nil, err
+		}
+
+		gained, lost := fargate.DiffSelectors(current.Selectors, replacement.Selectors)
+		if len(gained) == 0 && len(lost) == 0 && equalProfiles(current, replacement) {
+			logger.Info("Fargate profile %q is already up-to-date", current.Name)
+			continue
+		}
+
+		profileReplacement := &ProfileReplacement{
+			Current:     current,
+			Replacement: replacement,
+			Gained:      gained,
+			Lost:        lost,
+		}
+		if current.Name == replacement.Name {
+			profileReplacement.Temporary = replacement.DeepCopy()
+			profileReplacement.Temporary.Name = fargate.TemporaryProfileName(replacement.Name)
+			for _, profile := range existing {
+				if profile.Name == profileReplacement.Temporary.Name {
+					logger.Info("Fargate profile %q was created by an interrupted update of %q and will be reused", profile.Name, current.Name)
+					profileReplacement.TemporaryExists = true
+				}
+			}
+		}
+		plan.Replacements = append(plan.Replacements, profileReplacement)
+		plan.Tasks.Append(m.makeReplaceProfileTasks(ctx, &fargateClient, profileReplacement, restartPods))
+	}
+	return plan, nil
+}
+
+// Update replaces the Fargate profiles of the plan.
+func (m *Manager) Update(plan *UpdatePlan) error {
+	if plan.Tasks.Len() == 0 {
+		logger.Info("no Fargate profiles to update")
+		return nil
+	}
+	logger.Info(plan.Tasks.Describe())
+	if errs := plan.Tasks.DoAllSync(); len(errs) > 0 {
+		for _, err := range errs {
+			logger.Critical("%s\n", err.Error())
+		}
+		return errors.New("failed to update Fargate profile(s)")
+	}
+	return nil
+}
+
+// makeReplaceProfileTasks returns the tasks replacing a profile. As profile
+// names must be unique, a temporary profile with the desired configuration
+// keeps the pods it selects on Fargate while the profile is recreated. When
+// resuming an update interrupted after the profile was deleted, the profile is
+// created before the temporary profile is deleted. The tasks are run
+// sequentially, as EKS only allows one Fargate profile of a cluster to be
+// created or deleted at a time.
+func (m *Manager) makeReplaceProfileTasks(ctx context.Context, fargateClient *fargate.Client, r *ProfileReplacement, restartPods bool) *tasks.TaskTree {
+	createTask := func(description string, profile *api.FargateProfile) tasks.Task {
+		return &tasks.GenericTask{
+			Description: description,
+			Doer: func() error {
+				logger.Info("creating Fargate profile %q on EKS cluster %q", profile.Name, m.cfg.Metadata.Name)
+				if err := fargateClient.CreateProfile(ctx, profile, true); err != nil {
+					return err
+				}
+				logger.Info("created Fargate profile %q on EKS cluster %q", profile.Name, m.cfg.Metadata.Name)
+				return nil
+			},
+		}
+	}
+	deleteTask := func(description, name string) tasks.Task {
+		return &tasks.GenericTask{
+			Description: description,
+			Doer: func() error {
+				logger.Info("deleting Fargate profile %q", name)
+				return fargateClient.DeleteProfile(ctx, name, true)
+			},
+		}
+	}
+
+	taskTree := &tasks.TaskTree{Parallel: false, IsSubTask: true}
+	if r.Current.Name != r.Replacement.Name {
+		taskTree.Append(createTask(fmt.Sprintf("create Fargate profile %q", r.Replacement.Name), r.Replacement))
+		taskTree.Append(deleteTask(fmt.Sprintf("delete temporary Fargate profile %q", r.Current.Name), r.Current.Name))
+	} else {
+		if !r.TemporaryExists {
+			taskTree.Append(createTask(fmt.Sprintf("create temporary Fargate profile %q to replace %q", r.Temporary.Name, r.Current.Name), r.Temporary))
+		}
+		taskTree.Append(deleteTask(fmt.Sprintf("delete Fargate profile %q", r.Current.Name), r.Current.Name))
+		taskTree.Append(createTask(fmt.Sprintf("create Fargate profile %q", r.Replacement.Name), r.Replacement))
+		taskTree.Append(deleteTask(fmt.Sprintf("delete temporary Fargate profile %q", r.Temporary.Name), r.Temporary.Name))
+	}
+	if restartPods {
+		taskTree.Append(&tasks.GenericTask{
+			Description: fmt.Sprintf("restart pods matched by Fargate profile %q which are not running on Fargate", r.Replacement.Name),
+			Doer: func() error {
+				clientSet, err := m.newStdClientSet()
+				if err != nil {
+					return fmt.Errorf("couldn't create kubernetes client: %w", err)
+				}
+				return restartMatchedPods(ctx, clientSet, r)
+			},
+		})
+	}
+	return taskTree
+}
+
+// restartMatchedPods deletes the pods matched by the selectors of the
+// replacement which are not running on Fargate, so that their controllers
+// recreate them on Fargate. Pods without a controller are left untouched. The
+// pods of the profiles deleted by the update, including the pods matched only
+// by the selectors the replacement lost, are deleted by EKS, and are recreated
+// with the replacement if it matches them or outside of Fargate otherwise, so
+// they are not restarted.
+func restartMatchedPods(ctx context.Context, clientSet kubernetes.Interface, r *ProfileReplacement) error {
+	pods, err := clientSet.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing pods: %w", err)
+	}
+	restarted := 0
+	for _, pod := range pods.Items {
+		if _, onFargate := pod.Labels[fargate.ProfileLabel]; onFargate || !fargate.SelectorsMatch(r.Replacement.Selectors, pod.Namespace, pod.Labels) {
+			continue
+		}
+		if metav1.GetControllerOf(&pod) == nil {
+			logger.Warning("not restarting pod %s/%s as it is not managed by a controller", pod.Namespace, pod.Name)
+			continue
+		}
+		if err := clientSet.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("restarting pod %s/%s: %w", pod.Namespace, pod.Name, err)
+		}
+		restarted++
+	}
+	logger.Info("restarted %d pod(s) matched by Fargate profile %q", restarted, r.Replacement.Name)
+	return nil
+}
+
+func equalProfiles(current, replacement *api.FargateProfile) bool {
+	return current.PodExecutionRoleARN == replacement.PodExecutionRoleARN &&
+		equalStrings(current.Subnets, replacement.Subnets) &&
+		(len(current.Tags) == 0 && len(replacement.Tags) == 0 || reflect.DeepEqual(current.Tags, replacement.Tags))
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,256 @@
+package fargate_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/fargate"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Fargate profile update", func() {
+	const clusterName = "my-cluster"
+
+	var (
+		mockProvider   *mockprovider.MockProvider
+		cfg            *api.ClusterConfig
+		fargateManager *fargate.Manager
+		fakeClientSet  *fake.Clientset
+		existing       *ekstypes.FargateProfile
+	)
+
+	mockDescribeFargateProfile := func(profile *ekstypes.FargateProfile) {
+		mockProvider.MockEKS().On("DescribeFargateProfile", mock.Anything, &awseks.DescribeFargateProfileInput{
+			ClusterName:        aws.String(clusterName),
+			FargateProfileName: profile.FargateProfileName,
+		}).Return(&awseks.DescribeFargateProfileOutput{FargateProfile: profile}, nil)
+	}
+
+	newPod := func(name, namespace string, labels map[string]string, controlled bool) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels:    labels,
+			},
+		}
+		if controlled {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: name + "-rs", Controller: aws.Bool(true)}}
+		}
+		return pod
+	}
+
+	BeforeEach(func() {
+		mockProvider = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+		ctl := &eks.ClusterProvider{AWSProvider: mockProvider, Status: &eks.ProviderStatus{
+			ClusterInfo: &eks.ClusterInfo{
+				Cluster: &ekstypes.Cluster{
+					Status:  ekstypes.ClusterStatusActive,
+					Version: aws.String(api.DefaultVersion),
+				},
+			},
+		}}
+		fargateManager = fargate.New(cfg, ctl, new(fakes.FakeStackManager))
+		fakeClientSet = fake.NewSimpleClientset()
+		fargateManager.SetNewClientSet(func() (kubernetes.Interface, error) {
+			return fakeClientSet, nil
+		})
+
+		existing = &ekstypes.FargateProfile{
+			FargateProfileName:  aws.String("fp-dev"),
+			PodExecutionRoleArn: aws.String("arn:aws:iam::111122223333:role/fargate"),
+			Selectors: []ekstypes.FargateProfileSelector{
+				{Namespace: aws.String("dev")},
+				{Namespace: aws.String("staging")},
+			},
+			Subnets: []string{"subnet-1", "subnet-2"},
+			Status:  ekstypes.FargateProfileStatusActive,
+		}
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, &awseks.ListFargateProfilesInput{
+			ClusterName: aws.String(clusterName),
+		}).Return(&awseks.ListFargateProfilesOutput{FargateProfileNames: []string{"fp-dev"}}, nil).Once()
+		mockDescribeFargateProfile(existing)
+	})
+
+	It("plans the replacement of a profile whose selectors changed", func() {
+		cfg.FargateProfiles = []*api.FargateProfile{
+			{
+				Name:      "fp-dev",
+				Selectors: []api.FargateProfileSelector{{Namespace: "dev"}, {Namespace: "qa"}},
+			},
+		}
+
+		plan, err := fargateManager.PlanUpdate(context.Background(), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Replacements).To(HaveLen(1))
+		replacement := plan.Replacements[0]
+		Expect(replacement.Current.Name).To(Equal("fp-dev"))
+		Expect(replacement.Replacement.Name).To(Equal("fp-dev"))
+		Expect(replacement.Temporary.Name).To(Equal("fp-dev-eksctl-update"))
+		Expect(replacement.Temporary.Selectors).To(Equal(replacement.Replacement.Selectors))
+		Expect(replacement.Replacement.Subnets).To(Equal([]string{"subnet-1", "subnet-2"}))
+		Expect(replacement.Replacement.PodExecutionRoleARN).To(Equal("arn:aws:iam::111122223333:role/fargate"))
+		Expect(replacement.Gained).To(Equal([]api.FargateProfileSelector{{Namespace: "qa"}}))
+		Expect(replacement.Lost).To(Equal([]api.FargateProfileSelector{{Namespace: "staging"}}))
+		Expect(plan.Tasks.Describe()).To(ContainSubstring(`
+        create temporary Fargate profile "fp-dev-eksctl-update" to replace "fp-dev",
+        delete Fargate profile "fp-dev",
+        create Fargate profile "fp-dev",
+        delete temporary Fargate profile "fp-dev-eksctl-update",`))
+		Expect(plan.Tasks.Describe()).NotTo(ContainSubstring("restart pods"))
+		mockProvider.MockEKS().AssertNotCalled(GinkgoT(), "CreateFargateProfile", mock.Anything, mock.Anything)
+	})
+
+	It("skips profiles which are up-to-date", func() {
+		cfg.FargateProfiles = []*api.FargateProfile{
+			{
+				Name:      "fp-dev",
+				Selectors: []api.FargateProfileSelector{{Namespace: "staging"}, {Namespace: "dev"}},
+			},
+		}
+
+		plan, err := fargateManager.PlanUpdate(context.Background(), true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Replacements).To(BeEmpty())
+		Expect(fargateManager.Update(plan)).To(Succeed())
+	})
+
+	It("recreates the profile whose update was interrupted from the temporary profile", func() {
+		mockProvider.MockEKS().ExpectedCalls = nil
+		existing.FargateProfileName = aws.String("fp-dev-eksctl-update")
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(&awseks.ListFargateProfilesOutput{
+			FargateProfileNames: []string{"fp-dev-eksctl-update"},
+		}, nil)
+		mockDescribeFargateProfile(existing)
+		cfg.FargateProfiles = []*api.FargateProfile{
+			{
+				Name:      "fp-dev",
+				Selectors: []api.FargateProfileSelector{{Namespace: "dev"}},
+			},
+		}
+
+		plan, err := fargateManager.PlanUpdate(context.Background(), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Replacements).To(HaveLen(1))
+		Expect(plan.Replacements[0].Temporary).To(BeNil())
+		Expect(plan.Tasks.Describe()).To(ContainSubstring(`
+        create Fargate profile "fp-dev",
+        delete temporary Fargate profile "fp-dev-eksctl-update",`))
+	})
+
+	It("reuses the temporary profile created by an interrupted update", func() {
+		mockProvider.MockEKS().ExpectedCalls = nil
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(&awseks.ListFargateProfilesOutput{
+			FargateProfileNames: []string{"fp-dev", "fp-dev-eksctl-update"},
+		}, nil)
+		mockDescribeFargateProfile(existing)
+		mockDescribeFargateProfile(&ekstypes.FargateProfile{
+			FargateProfileName:  aws.String("fp-dev-eksctl-update"),
+			PodExecutionRoleArn: existing.PodExecutionRoleArn,
+			Selectors:           []ekstypes.FargateProfileSelector{{Namespace: aws.String("dev")}},
+			Subnets:             existing.Subnets,
+			Status:              ekstypes.FargateProfileStatusActive,
+		})
+		cfg.FargateProfiles = []*api.FargateProfile{
+			{
+				Name:      "fp-dev",
+				Selectors: []api.FargateProfileSelector{{Namespace: "dev"}},
+			},
+		}
+
+		plan, err := fargateManager.PlanUpdate(context.Background(), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Replacements).To(HaveLen(1))
+		Expect(plan.Replacements[0].Current.Name).To(Equal("fp-dev"))
+		Expect(plan.Replacements[0].TemporaryExists).To(BeTrue())
+		Expect(plan.Tasks.Describe()).NotTo(ContainSubstring("create temporary Fargate profile"))
+		Expect(plan.Tasks.Describe()).To(ContainSubstring(`
+        delete Fargate profile "fp-dev",
+        create Fargate profile "fp-dev",
+        delete temporary Fargate profile "fp-dev-eksctl-update",`))
+	})
+
+	It("returns an error if the profile does not exist", func() {
+		cfg.FargateProfiles = []*api.FargateProfile{{Name: "fp-prod", Tags: map[string]string{"team": "a"}}}
+
+		_, err := fargateManager.PlanUpdate(context.Background(), false)
+		Expect(err).To(MatchError(`Fargate profile "fp-prod" not found, use 'eksctl create fargateprofile' to create it`))
+	})
+
+	It("replaces the profile and then restarts the pods it matches which are not running on Fargate", func() {
+		cfg.FargateProfiles = []*api.FargateProfile{
+			{
+				Name:      "fp-dev",
+				Selectors: []api.FargateProfileSelector{{Namespace: "dev"}},
+			},
+		}
+		onProfile := map[string]string{"eks.amazonaws.com/fargate-profile": "fp-dev"}
+		for _, pod := range []*corev1.Pod{
+			newPod("web", "dev", onProfile, true),
+			newPod("api", "staging", onProfile, true),
+			newPod("debug", "staging", onProfile, false),
+			newPod("worker", "staging", nil, true),
+			newPod("frontend", "dev", nil, true),
+			newPod("job", "dev", nil, false),
+		} {
+			_, err := fakeClientSet.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		for _, name := range []string{"fp-dev-eksctl-update", "fp-dev"} {
+			mockProvider.MockEKS().On("CreateFargateProfile", mock.Anything, &awseks.CreateFargateProfileInput{
+				ClusterName:         aws.String(clusterName),
+				FargateProfileName:  aws.String(name),
+				PodExecutionRoleArn: aws.String("arn:aws:iam::111122223333:role/fargate"),
+				Selectors:           []ekstypes.FargateProfileSelector{{Namespace: aws.String("dev")}},
+				Subnets:             []string{"subnet-1", "subnet-2"},
+			}).Return(&awseks.CreateFargateProfileOutput{}, nil).Once()
+			mockProvider.MockEKS().On("DeleteFargateProfile", mock.Anything, &awseks.DeleteFargateProfileInput{
+				ClusterName:        aws.String(clusterName),
+				FargateProfileName: aws.String(name),
+			}).Return(&awseks.DeleteFargateProfileOutput{}, nil).Once()
+		}
+		mockDescribeFargateProfile(&ekstypes.FargateProfile{
+			FargateProfileName: aws.String("fp-dev-eksctl-update"),
+			Status:             ekstypes.FargateProfileStatusActive,
+		})
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(&awseks.ListFargateProfilesOutput{
+			FargateProfileNames: []string{"fp-dev-eksctl-update"},
+		}, nil).Once()
+		mockProvider.MockEKS().On("ListFargateProfiles", mock.Anything, mock.Anything).Return(&awseks.ListFargateProfilesOutput{
+			FargateProfileNames: []string{"fp-dev"},
+		}, nil)
+
+		plan, err := fargateManager.PlanUpdate(context.Background(), true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Tasks.Describe()).To(ContainSubstring(`
+        delete temporary Fargate profile "fp-dev-eksctl-update",
+        restart pods matched by Fargate profile "fp-dev" which are not running on Fargate,`))
+		Expect(fargateManager.Update(plan)).To(Succeed())
+		mockProvider.MockEKS().AssertExpectations(GinkgoT())
+
+		pods, err := fakeClientSet.CoreV1().Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+		Expect(names).To(ConsistOf("web", "api", "debug", "worker", "job"))
+	})
+})
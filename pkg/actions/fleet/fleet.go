@@ -0,0 +1,132 @@
+package fleet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+// ProfileAnnotation is the annotation of a cluster in a fleet that sets the AWS profile used to access it,
+// allowing a fleet to span multiple accounts
+const ProfileAnnotation = "eksctl.io/aws-profile"
+
+// ClusterConfigListKind is the kind of a fleet file
+const ClusterConfigListKind = "ClusterConfigList"
+
+// Result is the outcome of running an operation on a cluster of a fleet
+type Result struct {
+	Cluster  string        `json:"cluster"`
+	Region   string        `json:"region"`
+	Profile  string        `json:"profile,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Error    error         `json:"-"`
+}
+
+// Succeeded returns true if the operation succeeded
+func (r Result) Succeeded() bool {
+	return r.Error == nil
+}
+
+// Load reads a ClusterConfigList from path, and parses each of its items as a ClusterConfig
+func Load(path string) ([]*api.ClusterConfig, error) {
+	if err := api.Register(); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading fleet file %q: %w", path, err)
+	}
+	// items are kept raw so that they can be parsed the same way as a config file, to detect unknown fields
+	var list struct {
+		metav1.TypeMeta `json:",inline"`
+		Items           []map[string]interface{} `json:"items"`
+	}
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("loading fleet file %q: %w", path, err)
+	}
+	if list.Kind != ClusterConfigListKind || list.APIVersion != api.SchemeGroupVersion.String() {
+		return nil, fmt.Errorf("fleet file %q must have kind %q and apiVersion %q", path, ClusterConfigListKind, api.SchemeGroupVersion.String())
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("fleet file %q has no items", path)
+	}
+
+	type clusterKey struct{ name, region string }
+	seen := map[clusterKey]bool{}
+	clusters := make([]*api.ClusterConfig, 0, len(list.Items))
+	for i, item := range list.Items {
+		typeMeta := api.ClusterConfigTypeMeta()
+		item["apiVersion"], item["kind"] = typeMeta.APIVersion, typeMeta.Kind
+		itemData, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := eks.ParseConfig(itemData)
+		if err != nil {
+			return nil, fmt.Errorf("loading items[%d] of fleet file %q: %w", i, path, err)
+		}
+		if cfg.Metadata.Name == "" || cfg.Metadata.Region == "" {
+			return nil, fmt.Errorf("items[%d].metadata.name and items[%d].metadata.region must be set in fleet file %q", i, i, path)
+		}
+		key := clusterKey{name: cfg.Metadata.Name, region: cfg.Metadata.Region}
+		if seen[key] {
+			return nil, fmt.Errorf("cluster %q in region %q is specified more than once in fleet file %q", key.name, key.region, path)
+		}
+		seen[key] = true
+		clusters = append(clusters, cfg)
+	}
+	return clusters, nil
+}
+
+// Run runs fn for each cluster, with at most maxConcurrent clusters at a time. A failure does not stop the
+// remaining clusters; the results are returned in the order of the clusters.
+func Run(ctx context.Context, clusters []*api.ClusterConfig, maxConcurrent int, fn func(ctx context.Context, cfg *api.ClusterConfig) error) []Result {
+	results := make([]Result, len(clusters))
+	var g errgroup.Group
+	g.SetLimit(maxConcurrent)
+	var mu sync.Mutex
+	for i, cfg := range clusters {
+		g.Go(func() error {
+			logger.Info("running on cluster %q in region %q", cfg.Metadata.Name, cfg.Metadata.Region)
+			start := time.Now()
+			err := fn(ctx, cfg)
+			if err != nil {
+				logger.Critical("failed on cluster %q in region %q: %v", cfg.Metadata.Name, cfg.Metadata.Region, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = Result{
+				Cluster:  cfg.Metadata.Name,
+				Region:   cfg.Metadata.Region,
+				Profile:  cfg.Metadata.Annotations[ProfileAnnotation],
+				Duration: time.Since(start).Round(time.Second),
+				Error:    err,
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return results
+}
+
+// CountFailed returns the number of failed results
+func CountFailed(results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if !r.Succeeded() {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,150 @@
+package fleet_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/fleet"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Fleet", func() {
+	Describe("Load", func() {
+		writeFleet := func(content string) string {
+			path := filepath.Join(GinkgoT().TempDir(), "fleet.yaml")
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			return path
+		}
+
+		It("parses each item as a ClusterConfig", func() {
+			clusters, err := fleet.Load(writeFleet(`apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfigList
+items:
+- metadata:
+    name: cluster-1
+    region: us-west-2
+  addons:
+  - name: vpc-cni
+- apiVersion: eksctl.io/v1alpha5
+  kind: ClusterConfig
+  metadata:
+    name: cluster-1
+    region: eu-west-1
+    annotations:
+      eksctl.io/aws-profile: production
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters).To(HaveLen(2))
+			Expect(clusters[0].Metadata.Name).To(Equal("cluster-1"))
+			Expect(clusters[0].Addons).To(HaveLen(1))
+			Expect(clusters[1].Metadata.Region).To(Equal("eu-west-1"))
+			Expect(clusters[1].Metadata.Annotations).To(HaveKeyWithValue(fleet.ProfileAnnotation, "production"))
+		})
+
+		DescribeTable("invalid fleet files", func(content, expectedErr string) {
+			_, err := fleet.Load(writeFleet(content))
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+			Entry("wrong kind", `apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfig
+metadata:
+  name: cluster-1
+`, `must have kind "ClusterConfigList"`),
+			Entry("no items", `apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfigList
+items: []
+`, "has no items"),
+			Entry("unknown field", `apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfigList
+items:
+- metadata:
+    name: cluster-1
+    region: us-west-2
+  unknownField: true
+`, "loading items[0]"),
+			Entry("missing region", `apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfigList
+items:
+- metadata:
+    name: cluster-1
+`, "items[0].metadata.name and items[0].metadata.region must be set"),
+			Entry("duplicate cluster", `apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfigList
+items:
+- metadata:
+    name: cluster-1
+    region: us-west-2
+- metadata:
+    name: cluster-1
+    region: us-west-2
+`, `cluster "cluster-1" in region "us-west-2" is specified more than once`),
+		)
+	})
+
+	Describe("Run", func() {
+		var clusters []*api.ClusterConfig
+
+		BeforeEach(func() {
+			clusters = nil
+			for _, name := range []string{"cluster-1", "cluster-2", "cluster-3", "cluster-4"} {
+				cfg := api.NewClusterConfig()
+				cfg.Metadata.Name = name
+				cfg.Metadata.Region = "us-west-2"
+				clusters = append(clusters, cfg)
+			}
+		})
+
+		It("runs on every cluster even if some fail, and returns the results in order", func() {
+			results := fleet.Run(context.Background(), clusters, 2, func(_ context.Context, cfg *api.ClusterConfig) error {
+				if cfg.Metadata.Name == "cluster-2" {
+					return errors.New("upgrade failed")
+				}
+				return nil
+			})
+			Expect(results).To(HaveLen(4))
+			for i, r := range results {
+				Expect(r.Cluster).To(Equal(clusters[i].Metadata.Name))
+			}
+			Expect(results[1].Error).To(MatchError("upgrade failed"))
+			Expect(results[3].Succeeded()).To(BeTrue())
+			Expect(fleet.CountFailed(results)).To(Equal(1))
+		})
+
+		It("runs at most maxConcurrent clusters at a time", func() {
+			var (
+				mu                     sync.Mutex
+				running, maxConcurrent int
+			)
+			release := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				Eventually(func() int {
+					mu.Lock()
+					defer mu.Unlock()
+					return running
+				}).Should(Equal(2))
+				close(release)
+			}()
+			fleet.Run(context.Background(), clusters, 2, func(context.Context, *api.ClusterConfig) error {
+				mu.Lock()
+				running++
+				if running > maxConcurrent {
+					maxConcurrent = running
+				}
+				mu.Unlock()
+				<-release
+				mu.Lock()
+				running--
+				mu.Unlock()
+				return nil
+			})
+			Expect(maxConcurrent).To(Equal(2))
+		})
+	})
+})
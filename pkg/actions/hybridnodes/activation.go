@@ -0,0 +1,172 @@
+package hybridnodes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+)
+
+const (
+	// maxActivationExpiration is the longest an SSM activation can remain valid for.
+	maxActivationExpiration = 30 * 24 * time.Hour
+	// maxRegistrationLimit is the maximum number of managed instances an SSM activation can register.
+	maxRegistrationLimit = 1000
+)
+
+// ActivationOptions controls the creation of an activation for hybrid nodes.
+type ActivationOptions struct {
+	// RegistrationLimit is the number of hosts an SSM activation can register.
+	RegistrationLimit int32
+	// Expiration is how long an SSM activation can be used to register hosts for.
+	Expiration time.Duration
+	// NodeName is the name of the node with IAM Roles Anywhere, which must be unique to each host.
+	NodeName string
+	// CertificatePath is the path of the certificate of the host with IAM Roles Anywhere.
+	CertificatePath string
+	// PrivateKeyPath is the path of the private key of the host with IAM Roles Anywhere.
+	PrivateKeyPath string
+}
+
+// An ActivationCreator creates the credentials hybrid nodes use to assume the remote nodes role set up by
+// remoteNetworkConfig.iam, and returns the nodeadm config to join the cluster with them. With SSM, an SSM hybrid
+// activation is created for the role. With IAM Roles Anywhere, the trust anchor and profile created with the cluster
+// are used, as hosts authenticate with certificates issued by the CA of the trust anchor.
+type ActivationCreator struct {
+	ClusterConfig *api.ClusterConfig
+	StackManager  manager.StackManager
+	SSM           awsapi.SSM
+}
+
+// remoteNodesIAM holds the IAM resources for remote nodes.
+type remoteNodesIAM struct {
+	provider       string
+	roleARN        string
+	trustAnchorARN string
+	profileARN     string
+}
+
+// Create creates an activation for hybrid nodes and returns their nodeadm config.
+func (a *ActivationCreator) Create(ctx context.Context, options ActivationOptions) (*NodeConfig, error) {
+	cfg := a.ClusterConfig
+	remoteIAM, err := a.getRemoteNodesIAM(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var hybrid HybridOptions
+	switch remoteIAM.provider {
+	case api.SSMProvider:
+		activation, err := a.createSSMActivation(ctx, remoteIAM.roleARN, options)
+		if err != nil {
+			return nil, err
+		}
+		hybrid.SSM = activation
+	case api.IRAProvider:
+		if options.NodeName == "" {
+			return nil, errors.New("a node name must be set for hybrid nodes using IAM Roles Anywhere")
+		}
+		if remoteIAM.trustAnchorARN == "" || remoteIAM.profileARN == "" {
+			return nil, fmt.Errorf("no IAM Roles Anywhere trust anchor and profile found for cluster %q; they are only created by eksctl when remoteNetworkConfig.iam.roleARN is not set", cfg.Metadata.Name)
+		}
+		hybrid.IAMRolesAnywhere = &IAMRolesAnywhere{
+			NodeName:        options.NodeName,
+			TrustAnchorARN:  remoteIAM.trustAnchorARN,
+			ProfileARN:      remoteIAM.profileARN,
+			RoleARN:         remoteIAM.roleARN,
+			CertificatePath: options.CertificatePath,
+			PrivateKeyPath:  options.PrivateKeyPath,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported remote nodes IAM provider %q", remoteIAM.provider)
+	}
+	return newNodeConfig(cfg.Metadata.Name, cfg.Metadata.Region, hybrid), nil
+}
+
+// getRemoteNodesIAM returns the IAM resources for remote nodes from remoteNetworkConfig.iam, or else from the
+// outputs of the cluster stack.
+func (a *ActivationCreator) getRemoteNodesIAM(ctx context.Context) (remoteNodesIAM, error) {
+	cfg := a.ClusterConfig
+	stack, err := a.StackManager.DescribeClusterStackIfExists(ctx)
+	if err != nil {
+		return remoteNodesIAM{}, fmt.Errorf("describing cluster stack: %w", err)
+	}
+	stackOutputs := map[string]string{}
+	if stack != nil {
+		for _, o := range stack.Outputs {
+			stackOutputs[aws.ToString(o.OutputKey)] = aws.ToString(o.OutputValue)
+		}
+	}
+
+	remoteIAM := remoteNodesIAM{
+		roleARN:        stackOutputs[outputs.RemoteNodesRoleARN],
+		trustAnchorARN: stackOutputs[outputs.RemoteNodesTrustAnchorARN],
+		profileARN:     stackOutputs[outputs.RemoteNodesAnywhereProfileARN],
+	}
+	if rnc := cfg.RemoteNetworkConfig; rnc != nil && rnc.IAM != nil {
+		if rnc.IAM.Provider != nil {
+			remoteIAM.provider = strings.ToLower(*rnc.IAM.Provider)
+		}
+		if api.IsSetAndNonEmptyString(rnc.IAM.RoleARN) {
+			remoteIAM.roleARN = *rnc.IAM.RoleARN
+		}
+	}
+	if remoteIAM.provider == "" {
+		remoteIAM.provider = api.SSMProvider
+		if remoteIAM.trustAnchorARN != "" {
+			remoteIAM.provider = api.IRAProvider
+		}
+	}
+	if remoteIAM.roleARN == "" {
+		return remoteNodesIAM{}, fmt.Errorf("no IAM role for remote nodes found for cluster %q; set remoteNetworkConfig.iam.roleARN", cfg.Metadata.Name)
+	}
+	return remoteIAM, nil
+}
+
+func (a *ActivationCreator) createSSMActivation(ctx context.Context, roleARN string, options ActivationOptions) (*SSM, error) {
+	cfg := a.ClusterConfig
+	if options.RegistrationLimit < 1 || options.RegistrationLimit > maxRegistrationLimit {
+		return nil, fmt.Errorf("the registration limit must be between 1 and %d", maxRegistrationLimit)
+	}
+	if options.Expiration <= 0 || options.Expiration > maxActivationExpiration {
+		return nil, fmt.Errorf("the expiration of an SSM activation must be positive and at most %s", maxActivationExpiration)
+	}
+	parsedARN, err := arn.Parse(roleARN)
+	if err != nil {
+		return nil, fmt.Errorf("parsing remote nodes role ARN %q: %w", roleARN, err)
+	}
+
+	expirationDate := time.Now().Add(options.Expiration)
+	output, err := a.SSM.CreateActivation(ctx, &ssm.CreateActivationInput{
+		IamRole:           aws.String(strings.TrimPrefix(parsedARN.Resource, "role/")),
+		Description:       aws.String(fmt.Sprintf("EKS hybrid nodes of cluster %s", cfg.Metadata.Name)),
+		RegistrationLimit: aws.Int32(options.RegistrationLimit),
+		ExpirationDate:    aws.Time(expirationDate),
+		Tags: []ssmtypes.Tag{
+			{
+				Key:   aws.String(api.ClusterNameTag),
+				Value: aws.String(cfg.Metadata.Name),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating SSM activation: %w", err)
+	}
+	logger.Info("created SSM activation %q, which can register %d host(s) until %s", aws.ToString(output.ActivationId), options.RegistrationLimit, expirationDate.Format(time.RFC3339))
+	return &SSM{
+		ActivationCode: aws.ToString(output.ActivationCode),
+		ActivationID:   aws.ToString(output.ActivationId),
+	}, nil
+}
@@ -0,0 +1,161 @@
+package hybridnodes_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions/hybridnodes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	managerfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Create hybrid node activation", func() {
+	const (
+		roleARN        = "arn:aws:iam::111122223333:role/eksctl-hybrid-RemoteNodesRole"
+		trustAnchorARN = "arn:aws:rolesanywhere:us-west-2:111122223333:trust-anchor/abc"
+		profileARN     = "arn:aws:rolesanywhere:us-west-2:111122223333:profile/def"
+	)
+
+	var (
+		cfg          *api.ClusterConfig
+		stackManager *managerfakes.FakeStackManager
+		provider     *mockprovider.MockProvider
+		creator      *hybridnodes.ActivationCreator
+		options      hybridnodes.ActivationOptions
+	)
+
+	clusterStack := func(outputValues map[string]string) *manager.Stack {
+		stack := &manager.Stack{}
+		for key, value := range outputValues {
+			stack.Outputs = append(stack.Outputs, cfntypes.Output{
+				OutputKey:   aws.String(key),
+				OutputValue: aws.String(value),
+			})
+		}
+		return stack
+	}
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "hybrid"
+		cfg.Metadata.Region = "us-west-2"
+		stackManager = &managerfakes.FakeStackManager{}
+		provider = mockprovider.NewMockProvider()
+		creator = &hybridnodes.ActivationCreator{
+			ClusterConfig: cfg,
+			StackManager:  stackManager,
+			SSM:           provider.SSM(),
+		}
+		options = hybridnodes.ActivationOptions{
+			RegistrationLimit: 2,
+			Expiration:        time.Hour,
+			CertificatePath:   "/etc/iam/pki/server.pem",
+			PrivateKeyPath:    "/etc/iam/pki/server.key",
+		}
+	})
+
+	When("remote nodes use SSM", func() {
+		BeforeEach(func() {
+			stackManager.DescribeClusterStackIfExistsReturns(clusterStack(map[string]string{
+				outputs.RemoteNodesRoleARN: roleARN,
+			}), nil)
+		})
+
+		It("creates an SSM activation for the remote nodes role", func() {
+			provider.MockSSM().On("CreateActivation", mock.Anything, mock.MatchedBy(func(input *ssm.CreateActivationInput) bool {
+				return aws.ToString(input.IamRole) == "eksctl-hybrid-RemoteNodesRole" &&
+					aws.ToInt32(input.RegistrationLimit) == 2 &&
+					len(input.Tags) == 1 && aws.ToString(input.Tags[0].Value) == "hybrid"
+			}), mock.Anything).Return(&ssm.CreateActivationOutput{
+				ActivationId:   aws.String("activation-id"),
+				ActivationCode: aws.String("activation-code"),
+			}, nil).Once()
+
+			nodeConfig, err := creator.Create(context.Background(), options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodeConfig.Kind).To(Equal("NodeConfig"))
+			Expect(nodeConfig.Spec.Cluster).To(Equal(hybridnodes.ClusterDetails{
+				Name:   "hybrid",
+				Region: "us-west-2",
+			}))
+			Expect(nodeConfig.Spec.Hybrid.SSM).To(Equal(&hybridnodes.SSM{
+				ActivationCode: "activation-code",
+				ActivationID:   "activation-id",
+			}))
+			Expect(nodeConfig.Spec.Hybrid.IAMRolesAnywhere).To(BeNil())
+			provider.MockSSM().AssertExpectations(GinkgoT())
+		})
+
+		It("rejects an expiration longer than SSM allows", func() {
+			options.Expiration = 31 * 24 * time.Hour
+			_, err := creator.Create(context.Background(), options)
+			Expect(err).To(MatchError(ContainSubstring("the expiration of an SSM activation must be positive")))
+		})
+
+		It("rejects an invalid registration limit", func() {
+			options.RegistrationLimit = 0
+			_, err := creator.Create(context.Background(), options)
+			Expect(err).To(MatchError("the registration limit must be between 1 and 1000"))
+		})
+	})
+
+	When("remote nodes use IAM Roles Anywhere", func() {
+		BeforeEach(func() {
+			stackManager.DescribeClusterStackIfExistsReturns(clusterStack(map[string]string{
+				outputs.RemoteNodesRoleARN:            roleARN,
+				outputs.RemoteNodesTrustAnchorARN:     trustAnchorARN,
+				outputs.RemoteNodesAnywhereProfileARN: profileARN,
+			}), nil)
+			options.NodeName = "host-1"
+		})
+
+		It("uses the trust anchor and profile of the cluster", func() {
+			nodeConfig, err := creator.Create(context.Background(), options)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodeConfig.Spec.Hybrid.SSM).To(BeNil())
+			Expect(nodeConfig.Spec.Hybrid.IAMRolesAnywhere).To(Equal(&hybridnodes.IAMRolesAnywhere{
+				NodeName:        "host-1",
+				TrustAnchorARN:  trustAnchorARN,
+				ProfileARN:      profileARN,
+				RoleARN:         roleARN,
+				CertificatePath: "/etc/iam/pki/server.pem",
+				PrivateKeyPath:  "/etc/iam/pki/server.key",
+			}))
+		})
+
+		It("requires a node name", func() {
+			options.NodeName = ""
+			_, err := creator.Create(context.Background(), options)
+			Expect(err).To(MatchError("a node name must be set for hybrid nodes using IAM Roles Anywhere"))
+		})
+	})
+
+	It("fails when IAM Roles Anywhere is used with a role not created by eksctl", func() {
+		stackManager.DescribeClusterStackIfExistsReturns(nil, nil)
+		cfg.RemoteNetworkConfig = &api.RemoteNetworkConfig{
+			IAM: &api.RemoteNodesIAM{
+				Provider: aws.String("IRA"),
+				RoleARN:  aws.String(roleARN),
+			},
+		}
+		options.NodeName = "host-1"
+		_, err := creator.Create(context.Background(), options)
+		Expect(err).To(MatchError(ContainSubstring("no IAM Roles Anywhere trust anchor and profile found")))
+	})
+
+	It("fails when no remote nodes role is found", func() {
+		stackManager.DescribeClusterStackIfExistsReturns(nil, nil)
+		_, err := creator.Create(context.Background(), options)
+		Expect(err).To(MatchError(`no IAM role for remote nodes found for cluster "hybrid"; set remoteNetworkConfig.iam.roleARN`))
+	})
+})
@@ -0,0 +1,178 @@
+package hybridnodes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// computeTypeLabel is set to hybrid on the nodes registered by nodeadm on hybrid hosts.
+	computeTypeLabel = "eks.amazonaws.com/compute-type=hybrid"
+	// managedInstancePrefix is the prefix of the IDs of SSM managed instances, used as node names with SSM.
+	managedInstancePrefix = "mi-"
+	// maxInstanceIDsPerFilter is the maximum number of values of an SSM instance information filter.
+	maxInstanceIDsPerFilter = 50
+)
+
+// A Node is a hybrid node registered with the cluster.
+type Node struct {
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	KubeletVersion string `json:"kubeletVersion"`
+	InternalIP     string `json:"internalIP,omitempty"`
+	// SSMPingStatus is the status of the SSM agent of nodes registered with an SSM activation.
+	SSMPingStatus string `json:"ssmPingStatus,omitempty"`
+	// ActivationID is the ID of the SSM activation the node was registered with.
+	ActivationID string `json:"activationID,omitempty"`
+	// ActivationStatus is either active or expired for nodes registered with an SSM activation.
+	ActivationStatus string `json:"activationStatus,omitempty"`
+}
+
+// A Getter lists the hybrid nodes of a cluster, along with the SSM activations they were registered with.
+type Getter struct {
+	ClientSet kubernetes.Interface
+	SSM       awsapi.SSM
+}
+
+// Get returns the hybrid nodes of the cluster.
+func (g *Getter) Get(ctx context.Context) ([]Node, error) {
+	nodeList, err := g.ClientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: computeTypeLabel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing hybrid nodes: %w", err)
+	}
+
+	var (
+		nodes       []Node
+		instanceIDs []string
+	)
+	for _, n := range nodeList.Items {
+		nodes = append(nodes, Node{
+			Name:           n.Name,
+			Status:         nodeStatus(n),
+			KubeletVersion: n.Status.NodeInfo.KubeletVersion,
+			InternalIP:     nodeInternalIP(n),
+		})
+		if strings.HasPrefix(n.Name, managedInstancePrefix) {
+			instanceIDs = append(instanceIDs, n.Name)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	if len(instanceIDs) == 0 {
+		return nodes, nil
+	}
+
+	instances, err := g.describeManagedInstances(ctx, instanceIDs)
+	if err != nil {
+		return nil, err
+	}
+	var activationIDs []string
+	for _, instance := range instances {
+		if id := aws.ToString(instance.ActivationId); id != "" {
+			activationIDs = append(activationIDs, id)
+		}
+	}
+	expired, err := g.getExpiredActivations(ctx, activationIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, n := range nodes {
+		instance, ok := instances[n.Name]
+		if !ok {
+			continue
+		}
+		nodes[i].SSMPingStatus = string(instance.PingStatus)
+		nodes[i].ActivationID = aws.ToString(instance.ActivationId)
+		if isExpired, ok := expired[nodes[i].ActivationID]; ok {
+			nodes[i].ActivationStatus = "active"
+			if isExpired {
+				nodes[i].ActivationStatus = "expired"
+			}
+		}
+	}
+	return nodes, nil
+}
+
+func (g *Getter) describeManagedInstances(ctx context.Context, instanceIDs []string) (map[string]ssmtypes.InstanceInformation, error) {
+	instances := map[string]ssmtypes.InstanceInformation{}
+	for start := 0; start < len(instanceIDs); start += maxInstanceIDsPerFilter {
+		end := min(start+maxInstanceIDsPerFilter, len(instanceIDs))
+		paginator := ssm.NewDescribeInstanceInformationPaginator(g.SSM, &ssm.DescribeInstanceInformationInput{
+			Filters: []ssmtypes.InstanceInformationStringFilter{
+				{
+					Key:    aws.String("InstanceIds"),
+					Values: instanceIDs[start:end],
+				},
+			},
+		})
+		for paginator.HasMorePages() {
+			output, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("describing SSM managed instances: %w", err)
+			}
+			for _, instance := range output.InstanceInformationList {
+				instances[aws.ToString(instance.InstanceId)] = instance
+			}
+		}
+	}
+	return instances, nil
+}
+
+// getExpiredActivations reports whether each of the given SSM activations has expired. Deleted activations are omitted.
+func (g *Getter) getExpiredActivations(ctx context.Context, activationIDs []string) (map[string]bool, error) {
+	expired := map[string]bool{}
+	if len(activationIDs) == 0 {
+		return expired, nil
+	}
+	paginator := ssm.NewDescribeActivationsPaginator(g.SSM, &ssm.DescribeActivationsInput{
+		Filters: []ssmtypes.DescribeActivationsFilter{
+			{
+				FilterKey:    ssmtypes.DescribeActivationsFilterKeysActivationIds,
+				FilterValues: activationIDs,
+			},
+		},
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("describing SSM activations: %w", err)
+		}
+		for _, activation := range output.ActivationList {
+			expired[aws.ToString(activation.ActivationId)] = activation.Expired
+		}
+	}
+	return expired, nil
+}
+
+func nodeStatus(node corev1.Node) string {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+			return "Ready"
+		}
+	}
+	return "NotReady"
+}
+
+func nodeInternalIP(node corev1.Node) string {
+	for _, address := range node.Status.Addresses {
+		if address.Type == corev1.NodeInternalIP {
+			return address.Address
+		}
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package hybridnodes_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/hybridnodes"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Get hybrid nodes", func() {
+	newNode := func(name, computeType string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"eks.amazonaws.com/compute-type": computeType,
+				},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: ready,
+					},
+				},
+				Addresses: []corev1.NodeAddress{
+					{
+						Type:    corev1.NodeInternalIP,
+						Address: "10.80.0.1",
+					},
+				},
+				NodeInfo: corev1.NodeSystemInfo{
+					KubeletVersion: "v1.32.0-eks-1234",
+				},
+			},
+		}
+	}
+
+	It("lists hybrid nodes along with their SSM activations", func() {
+		clientSet := fake.NewSimpleClientset(
+			newNode("mi-0123", "hybrid", corev1.ConditionTrue),
+			newNode("host-1", "hybrid", corev1.ConditionFalse),
+			newNode("ip-192-168-1-1", "ec2", corev1.ConditionTrue),
+		)
+		provider := mockprovider.NewMockProvider()
+		provider.MockSSM().On("DescribeInstanceInformation", mock.Anything, mock.MatchedBy(func(input *ssm.DescribeInstanceInformationInput) bool {
+			return len(input.Filters) == 1 && len(input.Filters[0].Values) == 1 && input.Filters[0].Values[0] == "mi-0123"
+		}), mock.Anything).Return(&ssm.DescribeInstanceInformationOutput{
+			InstanceInformationList: []ssmtypes.InstanceInformation{
+				{
+					InstanceId:   aws.String("mi-0123"),
+					ActivationId: aws.String("activation-id"),
+					PingStatus:   ssmtypes.PingStatusOnline,
+				},
+			},
+		}, nil).Once()
+		provider.MockSSM().On("DescribeActivations", mock.Anything, mock.MatchedBy(func(input *ssm.DescribeActivationsInput) bool {
+			return len(input.Filters) == 1 && input.Filters[0].FilterValues[0] == "activation-id"
+		}), mock.Anything).Return(&ssm.DescribeActivationsOutput{
+			ActivationList: []ssmtypes.Activation{
+				{
+					ActivationId: aws.String("activation-id"),
+					Expired:      true,
+				},
+			},
+		}, nil).Once()
+
+		getter := &hybridnodes.Getter{
+			ClientSet: clientSet,
+			SSM:       provider.SSM(),
+		}
+		nodes, err := getter.Get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(Equal([]hybridnodes.Node{
+			{
+				Name:           "host-1",
+				Status:         "NotReady",
+				KubeletVersion: "v1.32.0-eks-1234",
+				InternalIP:     "10.80.0.1",
+			},
+			{
+				Name:             "mi-0123",
+				Status:           "Ready",
+				KubeletVersion:   "v1.32.0-eks-1234",
+				InternalIP:       "10.80.0.1",
+				SSMPingStatus:    "Online",
+				ActivationID:     "activation-id",
+				ActivationStatus: "expired",
+			},
+		}))
+		provider.MockSSM().AssertExpectations(GinkgoT())
+	})
+
+	It("does not call SSM when there are no SSM managed nodes", func() {
+		provider := mockprovider.NewMockProvider()
+		getter := &hybridnodes.Getter{
+			ClientSet: fake.NewSimpleClientset(newNode("host-1", "hybrid", corev1.ConditionTrue)),
+			SSM:       provider.SSM(),
+		}
+		nodes, err := getter.Get(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(1))
+		provider.MockSSM().AssertNotCalled(GinkgoT(), "DescribeInstanceInformation", mock.Anything, mock.Anything, mock.Anything)
+	})
+})
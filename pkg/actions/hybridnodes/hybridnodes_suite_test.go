@@ -0,0 +1,13 @@
+package hybridnodes_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestHybridNodes(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Hybrid Nodes Suite")
+}
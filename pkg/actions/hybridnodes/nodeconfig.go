@@ -0,0 +1,64 @@
+package hybridnodes
+
+import (
+	nodeadmapi "github.com/awslabs/amazon-eks-ami/nodeadm/api"
+	nodeadm "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeConfig is the nodeadm configuration of a hybrid node. It only covers the fields used by hybrid nodes, which are
+// not part of the version of the nodeadm API vendored by eksctl.
+type NodeConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	Spec            NodeConfigSpec `json:"spec"`
+}
+
+// NodeConfigSpec is the spec of a hybrid node NodeConfig.
+type NodeConfigSpec struct {
+	Cluster ClusterDetails `json:"cluster"`
+	Hybrid  HybridOptions  `json:"hybrid"`
+}
+
+// ClusterDetails identifies the cluster a hybrid node joins.
+type ClusterDetails struct {
+	Name   string `json:"name"`
+	Region string `json:"region"`
+}
+
+// HybridOptions configures the credential provider of a hybrid node; exactly one of SSM and IAMRolesAnywhere is set.
+type HybridOptions struct {
+	SSM              *SSM              `json:"ssm,omitempty"`
+	IAMRolesAnywhere *IAMRolesAnywhere `json:"iamRolesAnywhere,omitempty"`
+}
+
+// SSM holds the SSM hybrid activation used to register a host as a managed instance.
+type SSM struct {
+	ActivationCode string `json:"activationCode"`
+	ActivationID   string `json:"activationId"`
+}
+
+// IAMRolesAnywhere holds the IAM Roles Anywhere settings used by a host to get credentials for the remote nodes role.
+type IAMRolesAnywhere struct {
+	NodeName        string `json:"nodeName"`
+	TrustAnchorARN  string `json:"trustAnchorArn"`
+	ProfileARN      string `json:"profileArn"`
+	RoleARN         string `json:"roleArn"`
+	CertificatePath string `json:"certificatePath,omitempty"`
+	PrivateKeyPath  string `json:"privateKeyPath,omitempty"`
+}
+
+func newNodeConfig(clusterName, region string, hybrid HybridOptions) *NodeConfig {
+	return &NodeConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       nodeadmapi.KindNodeConfig,
+			APIVersion: nodeadm.GroupVersion.String(),
+		},
+		Spec: NodeConfigSpec{
+			Cluster: ClusterDetails{
+				Name:   clusterName,
+				Region: region,
+			},
+			Hybrid: hybrid,
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/karpenter"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+// Delete removes Karpenter from the cluster. NodePools are deleted first so that Karpenter drains and
+// terminates their nodes, then the chart is uninstalled, and the IAM resources and the interruption queue
+// are deleted along with the Karpenter stack.
+func (i *Installer) Delete(ctx context.Context) error {
+	parsedARN, err := arn.Parse(i.Config.Status.ARN)
+	if err != nil {
+		return fmt.Errorf("unexpected or invalid ARN: %q, %w", i.Config.Status.ARN, err)
+	}
+	stack, err := i.getKarpenterStack(ctx)
+	if err != nil {
+		return err
+	}
+
+	installedVersion := getKarpenterTagValue(stack.Tags, api.KarpenterVersionTag)
+	if err := i.KarpenterInstaller.Uninstall(ctx, installedVersion, i.CTL.AWSProvider.WaitTimeout()); err != nil {
+		return err
+	}
+
+	clientSetGetter := &kubernetes.CallbackClientSet{
+		Callback: func() (kubernetes.Interface, error) {
+			return i.ClientSet, nil
+		},
+	}
+	serviceAccountName := fmt.Sprintf("%s/%s", karpenter.DefaultNamespace, karpenter.DefaultServiceAccountName)
+	serviceAccountTaskTree, err := i.StackManager.NewTasksToDeleteIAMServiceAccounts(ctx, []string{serviceAccountName}, clientSetGetter, true)
+	if err != nil {
+		return fmt.Errorf("failed to create tasks to delete service account: %w", err)
+	}
+	if err := doTasks(serviceAccountTaskTree, "delete"); err != nil {
+		return fmt.Errorf("failed to delete service account: %w", err)
+	}
+
+	acm, err := authconfigmap.NewFromClientSet(i.ClientSet)
+	if err != nil {
+		return fmt.Errorf("failed to create client for auth config: %w", err)
+	}
+	if err := acm.RemoveIdentity(i.nodeRoleARN(parsedARN), true); err != nil {
+		return fmt.Errorf("failed to remove identity: %w", err)
+	}
+	if err := acm.Save(); err != nil {
+		return fmt.Errorf("failed to save the identity config: %w", err)
+	}
+
+	logger.Info("deleting Karpenter stack %q", *stack.StackName)
+	if err := i.StackManager.DeleteStackSync(ctx, stack); err != nil {
+		return fmt.Errorf("failed to delete Karpenter stack: %w", err)
+	}
+	logger.Success("Karpenter was removed from cluster %q", i.Config.Metadata.Name)
+	return nil
+}
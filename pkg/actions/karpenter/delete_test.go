@@ -0,0 +1,119 @@
+package karpenter_test
+
+import (
+	"context"
+	"errors"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	managerfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	karpenterfakes "github.com/weaveworks/eksctl/pkg/karpenter/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
+)
+
+var _ = Describe("Delete", func() {
+	const nodeRoleARN = "arn:aws:iam::123456789012:role/eksctl-KarpenterNodeRole-my-cluster"
+	var (
+		fakeStackManager       *managerfakes.FakeStackManager
+		fakeKarpenterInstaller *karpenterfakes.FakeChartInstaller
+		fakeClientSet          *fake.Clientset
+		installer              *karpenteractions.Installer
+	)
+
+	BeforeEach(func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Status = &api.ClusterStatus{
+			ARN: "arn:aws:eks:us-west-2:123456789012:cluster/my-cluster",
+		}
+		cfg.Karpenter = &api.Karpenter{}
+		fakeStackManager = &managerfakes.FakeStackManager{}
+		fakeStackManager.GetKarpenterStackReturns(karpenterStack("1.2.1"), nil)
+		fakeStackManager.NewTasksToDeleteIAMServiceAccountsReturns(&tasks.TaskTree{}, nil)
+		fakeKarpenterInstaller = &karpenterfakes.FakeChartInstaller{}
+		fakeClientSet = fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "aws-auth",
+				Namespace: "kube-system",
+				UID:       "aws-auth",
+			},
+			Data: map[string]string{
+				"mapRoles": `- rolearn: ` + nodeRoleARN + `
+  username: system:node:{{EC2PrivateDNSName}}
+  groups:
+  - system:bootstrappers
+  - system:nodes
+- rolearn: arn:aws:iam::123456789012:role/other
+  username: other
+`,
+			},
+		})
+		installer = &karpenteractions.Installer{
+			StackManager: fakeStackManager,
+			CTL: &eks.ClusterProvider{
+				AWSProvider: mockprovider.NewMockProvider(),
+				Status: &eks.ProviderStatus{
+					ClusterInfo: &eks.ClusterInfo{
+						Cluster: testutils.NewFakeCluster("my-cluster", ekstypes.ClusterStatusActive),
+					},
+				},
+			},
+			Config:             cfg,
+			KarpenterInstaller: fakeKarpenterInstaller,
+			ClientSet:          fakeClientSet,
+		}
+	})
+
+	It("uninstalls Karpenter and deletes its resources", func() {
+		Expect(installer.Delete(context.Background())).To(Succeed())
+
+		Expect(fakeKarpenterInstaller.UninstallCallCount()).To(Equal(1))
+		_, installedVersion, _ := fakeKarpenterInstaller.UninstallArgsForCall(0)
+		Expect(installedVersion).To(Equal("1.2.1"))
+
+		Expect(fakeStackManager.NewTasksToDeleteIAMServiceAccountsCallCount()).To(Equal(1))
+		_, serviceAccounts, _, wait := fakeStackManager.NewTasksToDeleteIAMServiceAccountsArgsForCall(0)
+		Expect(serviceAccounts).To(ConsistOf("karpenter/karpenter"))
+		Expect(wait).To(BeTrue())
+
+		cm, err := fakeClientSet.CoreV1().ConfigMaps("kube-system").Get(context.Background(), "aws-auth", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cm.Data["mapRoles"]).NotTo(ContainSubstring(nodeRoleARN))
+		Expect(cm.Data["mapRoles"]).To(ContainSubstring("arn:aws:iam::123456789012:role/other"))
+
+		Expect(fakeStackManager.DeleteStackSyncCallCount()).To(Equal(1))
+		_, stack := fakeStackManager.DeleteStackSyncArgsForCall(0)
+		Expect(*stack.StackName).To(Equal("eksctl-my-cluster-karpenter"))
+	})
+
+	When("uninstalling Karpenter fails", func() {
+		BeforeEach(func() {
+			fakeKarpenterInstaller.UninstallReturns(errors.New("nope"))
+		})
+
+		It("does not delete the Karpenter stack", func() {
+			Expect(installer.Delete(context.Background())).To(MatchError("nope"))
+			Expect(fakeStackManager.DeleteStackSyncCallCount()).To(BeZero())
+		})
+	})
+
+	When("deleting the Karpenter stack fails", func() {
+		BeforeEach(func() {
+			fakeStackManager.DeleteStackSyncReturns(errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(installer.Delete(context.Background())).To(MatchError("failed to delete Karpenter stack: nope"))
+		})
+	})
+})
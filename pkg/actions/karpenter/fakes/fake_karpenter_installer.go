@@ -9,6 +9,17 @@ import (
 )
 
 type FakeInstallerTaskCreator struct {
+	ApplyNodePoolsStub        func(context.Context) error
+	applyNodePoolsMutex       sync.RWMutex
+	applyNodePoolsArgsForCall []struct {
+		arg1 context.Context
+	}
+	applyNodePoolsReturns struct {
+		result1 error
+	}
+	applyNodePoolsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateStub        func(context.Context) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
@@ -20,10 +31,93 @@ type FakeInstallerTaskCreator struct {
 	createReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteStub        func(context.Context) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		arg1 context.Context
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	deleteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpgradeStub        func(context.Context) error
+	upgradeMutex       sync.RWMutex
+	upgradeArgsForCall []struct {
+		arg1 context.Context
+	}
+	upgradeReturns struct {
+		result1 error
+	}
+	upgradeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeInstallerTaskCreator) ApplyNodePools(arg1 context.Context) error {
+	fake.applyNodePoolsMutex.Lock()
+	ret, specificReturn := fake.applyNodePoolsReturnsOnCall[len(fake.applyNodePoolsArgsForCall)]
+	fake.applyNodePoolsArgsForCall = append(fake.applyNodePoolsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ApplyNodePoolsStub
+	fakeReturns := fake.applyNodePoolsReturns
+	fake.recordInvocation("ApplyNodePools", []interface{}{arg1})
+	fake.applyNodePoolsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeInstallerTaskCreator) ApplyNodePoolsCallCount() int {
+	fake.applyNodePoolsMutex.RLock()
+	defer fake.applyNodePoolsMutex.RUnlock()
+	return len(fake.applyNodePoolsArgsForCall)
+}
+
+func (fake *FakeInstallerTaskCreator) ApplyNodePoolsCalls(stub func(context.Context) error) {
+	fake.applyNodePoolsMutex.Lock()
+	defer fake.applyNodePoolsMutex.Unlock()
+	fake.ApplyNodePoolsStub = stub
+}
+
+func (fake *FakeInstallerTaskCreator) ApplyNodePoolsArgsForCall(i int) context.Context {
+	fake.applyNodePoolsMutex.RLock()
+	defer fake.applyNodePoolsMutex.RUnlock()
+	argsForCall := fake.applyNodePoolsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeInstallerTaskCreator) ApplyNodePoolsReturns(result1 error) {
+	fake.applyNodePoolsMutex.Lock()
+	defer fake.applyNodePoolsMutex.Unlock()
+	fake.ApplyNodePoolsStub = nil
+	fake.applyNodePoolsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstallerTaskCreator) ApplyNodePoolsReturnsOnCall(i int, result1 error) {
+	fake.applyNodePoolsMutex.Lock()
+	defer fake.applyNodePoolsMutex.Unlock()
+	fake.ApplyNodePoolsStub = nil
+	if fake.applyNodePoolsReturnsOnCall == nil {
+		fake.applyNodePoolsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.applyNodePoolsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeInstallerTaskCreator) Create(arg1 context.Context) error {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
@@ -85,11 +179,139 @@ func (fake *FakeInstallerTaskCreator) CreateReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeInstallerTaskCreator) Delete(arg1 context.Context) error {
+	fake.deleteMutex.Lock()
+	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DeleteStub
+	fakeReturns := fake.deleteReturns
+	fake.recordInvocation("Delete", []interface{}{arg1})
+	fake.deleteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeInstallerTaskCreator) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeInstallerTaskCreator) DeleteCalls(stub func(context.Context) error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = stub
+}
+
+func (fake *FakeInstallerTaskCreator) DeleteArgsForCall(i int) context.Context {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	argsForCall := fake.deleteArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeInstallerTaskCreator) DeleteReturns(result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstallerTaskCreator) DeleteReturnsOnCall(i int, result1 error) {
+	fake.deleteMutex.Lock()
+	defer fake.deleteMutex.Unlock()
+	fake.DeleteStub = nil
+	if fake.deleteReturnsOnCall == nil {
+		fake.deleteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstallerTaskCreator) Upgrade(arg1 context.Context) error {
+	fake.upgradeMutex.Lock()
+	ret, specificReturn := fake.upgradeReturnsOnCall[len(fake.upgradeArgsForCall)]
+	fake.upgradeArgsForCall = append(fake.upgradeArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.UpgradeStub
+	fakeReturns := fake.upgradeReturns
+	fake.recordInvocation("Upgrade", []interface{}{arg1})
+	fake.upgradeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeInstallerTaskCreator) UpgradeCallCount() int {
+	fake.upgradeMutex.RLock()
+	defer fake.upgradeMutex.RUnlock()
+	return len(fake.upgradeArgsForCall)
+}
+
+func (fake *FakeInstallerTaskCreator) UpgradeCalls(stub func(context.Context) error) {
+	fake.upgradeMutex.Lock()
+	defer fake.upgradeMutex.Unlock()
+	fake.UpgradeStub = stub
+}
+
+func (fake *FakeInstallerTaskCreator) UpgradeArgsForCall(i int) context.Context {
+	fake.upgradeMutex.RLock()
+	defer fake.upgradeMutex.RUnlock()
+	argsForCall := fake.upgradeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeInstallerTaskCreator) UpgradeReturns(result1 error) {
+	fake.upgradeMutex.Lock()
+	defer fake.upgradeMutex.Unlock()
+	fake.UpgradeStub = nil
+	fake.upgradeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeInstallerTaskCreator) UpgradeReturnsOnCall(i int, result1 error) {
+	fake.upgradeMutex.Lock()
+	defer fake.upgradeMutex.Unlock()
+	fake.UpgradeStub = nil
+	if fake.upgradeReturnsOnCall == nil {
+		fake.upgradeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.upgradeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeInstallerTaskCreator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.applyNodePoolsMutex.RLock()
+	defer fake.applyNodePoolsMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	fake.upgradeMutex.RLock()
+	defer fake.upgradeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -5,17 +5,23 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/kris-nova/logger"
+	"k8s.io/apimachinery/pkg/runtime"
 	kubeclient "k8s.io/client-go/kubernetes"
+	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/eks"
 	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
 	"github.com/weaveworks/eksctl/pkg/karpenter"
 	"github.com/weaveworks/eksctl/pkg/karpenter/providers/helm"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 	"github.com/weaveworks/eksctl/pkg/utils/waiters"
 )
@@ -26,6 +32,9 @@ import (
 //counterfeiter:generate -o fakes/fake_karpenter_installer.go . InstallerTaskCreator
 type InstallerTaskCreator interface {
 	Create(ctx context.Context) error
+	ApplyNodePools(ctx context.Context) error
+	Upgrade(ctx context.Context) error
+	Delete(ctx context.Context) error
 }
 
 // Installer contains all necessary dependencies for the Karpenter Install tasks and others.
@@ -50,10 +59,15 @@ func NewInstaller(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterP
 	if err != nil {
 		return nil, err
 	}
+	dynamicClient, err := ctl.NewDynamicClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
 	karpenterInstaller := karpenter.NewKarpenterInstaller(karpenter.Options{
 		HelmInstaller: helmInstaller,
 		Namespace:     karpenter.DefaultNamespace,
 		ClusterConfig: cfg,
+		DynamicClient: dynamicClient,
 	})
 	oidc, err := ctl.NewOpenIDConnectManager(ctx, cfg)
 	if err != nil {
@@ -80,14 +94,58 @@ func NewInstaller(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterP
 	}, nil
 }
 
-func doTasks(taskTree *tasks.TaskTree) error {
+// NewInstallerForExistingCluster creates a new Karpenter installer for a cluster that was created previously.
+func NewInstallerForExistingCluster(ctx context.Context, cfg *api.ClusterConfig, ctl *eks.ClusterProvider) (InstallerTaskCreator, error) {
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return nil, err
+	}
+	config := kubeconfig.NewForKubectl(cfg, eks.GetUsername(ctl.Status.IAMRoleARN), "", ctl.AWSProvider.Profile().Name)
+	kubeConfigBytes, err := runtime.Encode(clientcmdlatest.Codec, config)
+	if err != nil {
+		return nil, fmt.Errorf("generating kubeconfig: %w", err)
+	}
+	return NewInstaller(ctx, cfg, ctl, ctl.NewStackManager(cfg), clientSet, kubernetes.NewRESTClientGetter(karpenter.DefaultNamespace, string(kubeConfigBytes)))
+}
+
+func doTasks(taskTree *tasks.TaskTree, action string) error {
 	logger.Info(taskTree.Describe())
 	if errs := taskTree.DoAllSync(); len(errs) > 0 {
-		logger.Info("%d error(s) occurred while installing Karpenter, you may wish to check your Cluster for further information", len(errs))
+		logger.Info("%d error(s) occurred while %s Karpenter, you may wish to check your Cluster for further information", len(errs), action)
 		for _, err := range errs {
 			logger.Critical("%s\n", err.Error())
 		}
-		return fmt.Errorf("failed to install Karpenter on cluster")
+		return fmt.Errorf("failed to %s Karpenter on cluster", action)
 	}
 	return nil
 }
+
+// instanceProfileName returns the name of the instance profile used by Karpenter nodes.
+func (i *Installer) instanceProfileName() string {
+	if i.Config.Karpenter.DefaultInstanceProfile != nil {
+		return aws.ToString(i.Config.Karpenter.DefaultInstanceProfile)
+	}
+	return fmt.Sprintf("eksctl-%s-%s", builder.KarpenterNodeInstanceProfile, i.Config.Metadata.Name)
+}
+
+// serviceAccountRoleName returns the name of the IAM role of the Karpenter service account.
+func (i *Installer) serviceAccountRoleName() string {
+	return fmt.Sprintf("eksctl-%s-iamservice-role", i.Config.Metadata.Name)
+}
+
+// nodeRoleARN returns the ARN of the IAM role used by Karpenter nodes.
+func (i *Installer) nodeRoleARN(parsedARN arn.ARN) string {
+	return fmt.Sprintf("arn:%s:iam::%s:role/eksctl-%s-%s", parsedARN.Partition, parsedARN.AccountID, builder.KarpenterNodeRoleName, i.Config.Metadata.Name)
+}
+
+// getKarpenterStack returns the stack created by eksctl when installing Karpenter.
+func (i *Installer) getKarpenterStack(ctx context.Context) (*manager.Stack, error) {
+	stack, err := i.StackManager.GetKarpenterStack(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Karpenter stack: %w", err)
+	}
+	if stack == nil {
+		return nil, fmt.Errorf("no Karpenter stack found for cluster %q, Karpenter must be installed by eksctl", i.Config.Metadata.Name)
+	}
+	return stack, nil
+}
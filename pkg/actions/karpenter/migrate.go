@@ -0,0 +1,348 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kris-nova/logger"
+	"github.com/tidwall/gjson"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/weaveworks/eksctl/pkg/actions/export"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+const (
+	clusterAutoscalerEnabledTag = "k8s.io/cluster-autoscaler/enabled"
+	clusterAutoscalerName       = "cluster-autoscaler"
+
+	instanceTypeLabel = "node.kubernetes.io/instance-type"
+	capacityTypeLabel = "karpenter.sh/capacity-type"
+
+	minMigrationVersion = "1.0.0"
+)
+
+// NodeGroupScaler scales nodegroups.
+//
+//counterfeiter:generate -o fakes/fake_nodegroup_scaler.go . NodeGroupScaler
+type NodeGroupScaler interface {
+	Scale(ctx context.Context, ng *api.NodeGroupBase, wait bool) error
+}
+
+// MigrateOptions controls the migration of nodegroups to Karpenter.
+type MigrateOptions struct {
+	// NodeGroups limits the migration to the named nodegroups; all nodegroups scaled by Cluster Autoscaler are migrated if empty.
+	NodeGroups []string
+	// Drain controls how the nodegroups are drained; its NodeGroups are ignored.
+	Drain   nodegroup.DrainInput
+	Approve bool
+}
+
+// A Migrator moves the workloads of unmanaged nodegroups scaled by Cluster Autoscaler to Karpenter. A NodePool and an
+// EC2NodeClass matching each nodegroup are created, installing Karpenter if needed, then the nodegroups are drained and
+// scaled to zero one at a time, so that Karpenter launches nodes for their pods as they are evicted. The nodegroups
+// themselves are kept and can be deleted once the migration is complete.
+type Migrator struct {
+	ClusterConfig *api.ClusterConfig
+	StackManager  manager.StackManager
+	ClientSet     kubernetes.Interface
+	Installer     InstallerTaskCreator
+	Drainer       nodegroup.NodeGroupDrainer
+	Scaler        NodeGroupScaler
+	// Out receives the generated NodePools in plan mode.
+	Out io.Writer
+}
+
+// Migrate migrates the nodegroups selected by options to Karpenter.
+func (m *Migrator) Migrate(ctx context.Context, options MigrateOptions) error {
+	cfg := m.ClusterConfig
+	plan := !options.Approve
+
+	autoscaled, err := m.autoscaledNodeGroups(ctx)
+	if err != nil {
+		return err
+	}
+	nodeGroups, err := selectNodeGroups(autoscaled, options.NodeGroups)
+	if err != nil {
+		return err
+	}
+	if len(nodeGroups) == 0 {
+		logger.Info("no unmanaged nodegroups scaled by Cluster Autoscaler found in cluster %q", cfg.Metadata.Name)
+		return nil
+	}
+
+	stack, err := m.StackManager.GetKarpenterStack(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get Karpenter stack: %w", err)
+	}
+	if cfg.Karpenter == nil {
+		cfg.Karpenter = &api.Karpenter{}
+	}
+	installed := stack != nil
+	if installed {
+		installedVersion := getKarpenterTagValue(stack.Tags, api.KarpenterVersionTag)
+		if c, err := utils.CompareVersions(installedVersion, minMigrationVersion); err == nil && c < 0 {
+			return fmt.Errorf("NodePools require Karpenter %s or later but %s is installed, use 'eksctl upgrade karpenter' first", minMigrationVersion, installedVersion)
+		}
+		cfg.Karpenter.Version = installedVersion
+	} else if cfg.Karpenter.Version == "" {
+		return fmt.Errorf("Karpenter is not installed on cluster %q, set the version to install with --karpenter-version", cfg.Metadata.Name)
+	}
+
+	nodePools, err := addNodePools(cfg, nodeGroups)
+	if err != nil {
+		return err
+	}
+	if err := api.ValidateKarpenterConfig(cfg); err != nil {
+		return err
+	}
+
+	deployments, err := m.clusterAutoscalerDeployments(ctx)
+	if err != nil {
+		return err
+	}
+	scaleDownAutoscaler := len(nodeGroups) == len(autoscaled)
+
+	names := nodeGroupNames(nodeGroups)
+	if installed {
+		cmdutils.LogIntendedAction(plan, "create Karpenter NodePools and EC2NodeClasses %v in cluster %q", names, cfg.Metadata.Name)
+	} else {
+		cmdutils.LogIntendedAction(plan, "install Karpenter %s with NodePools and EC2NodeClasses %v in cluster %q", cfg.Karpenter.Version, names, cfg.Metadata.Name)
+	}
+	for _, d := range deployments {
+		if scaleDownAutoscaler {
+			cmdutils.LogIntendedAction(plan, "scale deployment %s/%s to 0 replicas", d.Namespace, d.Name)
+		} else {
+			logger.Warning("deployment %s/%s will keep running, as not all nodegroups scaled by Cluster Autoscaler are migrated", d.Namespace, d.Name)
+		}
+	}
+	for _, ng := range nodeGroups {
+		cmdutils.LogIntendedAction(plan, "drain nodegroup %q and scale it to 0 nodes", ng.Name)
+	}
+	if plan {
+		if m.Out != nil {
+			if err := printNodePools(m.Out, nodePools); err != nil {
+				return err
+			}
+		}
+		cmdutils.LogPlanModeWarning(true)
+		return nil
+	}
+
+	if installed {
+		if err := m.Installer.ApplyNodePools(ctx); err != nil {
+			return fmt.Errorf("creating Karpenter NodePools: %w", err)
+		}
+	} else if err := m.Installer.Create(ctx); err != nil {
+		return fmt.Errorf("installing Karpenter: %w", err)
+	}
+	logger.Success("created Karpenter NodePools and EC2NodeClasses %v", names)
+
+	if scaleDownAutoscaler {
+		for _, d := range deployments {
+			d.Spec.Replicas = aws.Int32(0)
+			if _, err := m.ClientSet.AppsV1().Deployments(d.Namespace).Update(ctx, &d, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("scaling down deployment %s/%s: %w", d.Namespace, d.Name, err)
+			}
+			logger.Info("scaled deployment %s/%s to 0 replicas", d.Namespace, d.Name)
+		}
+	}
+
+	for _, ng := range nodeGroups {
+		drainInput := options.Drain
+		drainInput.NodeGroups = cmdutils.ToKubeNodeGroups([]*api.NodeGroup{ng}, nil)
+		if err := m.Drainer.Drain(ctx, &drainInput); err != nil {
+			return fmt.Errorf("draining nodegroup %q: %w", ng.Name, err)
+		}
+		if err := m.Scaler.Scale(ctx, &api.NodeGroupBase{
+			Name: ng.Name,
+			ScalingConfig: &api.ScalingConfig{
+				DesiredCapacity: aws.Int(0),
+				MinSize:         aws.Int(0),
+				MaxSize:         aws.Int(0),
+			},
+		}, false); err != nil {
+			return err
+		}
+		logger.Success("migrated nodegroup %q to Karpenter", ng.Name)
+	}
+	logger.Info("the nodegroups were scaled to 0 nodes and can be deleted with 'eksctl delete nodegroup'")
+	return nil
+}
+
+// autoscaledNodeGroups reconstructs the unmanaged nodegroups whose auto scaling groups carry the Cluster Autoscaler
+// discovery tag, i.e. those created with the autoScaler well-known policy.
+func (m *Migrator) autoscaledNodeGroups(ctx context.Context) ([]*api.NodeGroup, error) {
+	stacks, err := m.StackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing nodegroup stacks: %w", err)
+	}
+	sort.Slice(stacks, func(i, j int) bool {
+		return stacks[i].NodeGroupName < stacks[j].NodeGroupName
+	})
+	var nodeGroups []*api.NodeGroup
+	for _, s := range stacks {
+		if s.Type != api.NodeGroupTypeUnmanaged || s.Stack == nil {
+			continue
+		}
+		stackName := aws.ToString(s.Stack.StackName)
+		template, err := m.StackManager.GetStackTemplate(ctx, stackName)
+		if err != nil {
+			return nil, fmt.Errorf("getting CloudFormation template for stack %s: %w", stackName, err)
+		}
+		if !hasTag(gjson.Get(template, "Resources.NodeGroup.Properties.Tags"), clusterAutoscalerEnabledTag) {
+			continue
+		}
+		nodeGroups = append(nodeGroups, export.NodeGroupFromTemplate(s.NodeGroupName, template))
+	}
+	return nodeGroups, nil
+}
+
+func hasTag(tags gjson.Result, key string) bool {
+	for _, tag := range tags.Array() {
+		if tag.Get("Key").String() == key {
+			return true
+		}
+	}
+	return false
+}
+
+func selectNodeGroups(nodeGroups []*api.NodeGroup, names []string) ([]*api.NodeGroup, error) {
+	if len(names) == 0 {
+		return nodeGroups, nil
+	}
+	byName := map[string]*api.NodeGroup{}
+	for _, ng := range nodeGroups {
+		byName[ng.Name] = ng
+	}
+	var selected []*api.NodeGroup
+	for _, name := range names {
+		ng, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("nodegroup %q is not an unmanaged nodegroup scaled by Cluster Autoscaler", name)
+		}
+		selected = append(selected, ng)
+	}
+	return selected, nil
+}
+
+// addNodePools adds a Karpenter NodePool for each nodegroup to the cluster config and returns them.
+func addNodePools(cfg *api.ClusterConfig, nodeGroups []*api.NodeGroup) ([]api.KarpenterNodePool, error) {
+	existing := map[string]struct{}{}
+	for _, np := range cfg.Karpenter.NodePools {
+		existing[np.Name] = struct{}{}
+	}
+	var nodePools []api.KarpenterNodePool
+	for _, ng := range nodeGroups {
+		if _, ok := existing[ng.Name]; ok {
+			return nil, fmt.Errorf("karpenter.nodePools already contains a NodePool named %q", ng.Name)
+		}
+		if ng.AMIFamily == api.NodeImageFamilyBottlerocket {
+			logger.Warning("the labels and taints of Bottlerocket nodegroup %q cannot be recovered, add them to its NodePool if needed", ng.Name)
+		}
+		nodePools = append(nodePools, nodePoolFromNodeGroup(cfg, ng))
+	}
+	cfg.Karpenter.NodePools = append(cfg.Karpenter.NodePools, nodePools...)
+	return nodePools, nil
+}
+
+// nodePoolFromNodeGroup makes a NodePool that launches nodes equivalent to those of the nodegroup.
+func nodePoolFromNodeGroup(cfg *api.ClusterConfig, ng *api.NodeGroup) api.KarpenterNodePool {
+	np := api.KarpenterNodePool{
+		Name:   ng.Name,
+		Labels: ng.Labels,
+		Taints: ng.Taints,
+	}
+
+	instanceTypes := []string{ng.InstanceType}
+	capacityTypes := []string{"on-demand"}
+	if d := ng.InstancesDistribution; d != nil {
+		instanceTypes = d.InstanceTypes
+		if d.OnDemandPercentageAboveBaseCapacity != nil && *d.OnDemandPercentageAboveBaseCapacity < 100 {
+			capacityTypes = []string{"spot", "on-demand"}
+		}
+	}
+	if len(instanceTypes) > 0 && instanceTypes[0] != "" {
+		np.Requirements = append(np.Requirements, api.KarpenterRequirement{
+			Key:      instanceTypeLabel,
+			Operator: "In",
+			Values:   instanceTypes,
+		})
+	}
+	np.Requirements = append(np.Requirements, api.KarpenterRequirement{
+		Key:      capacityTypeLabel,
+		Operator: "In",
+		Values:   capacityTypes,
+	})
+
+	var alias string
+	switch ng.AMIFamily {
+	case api.NodeImageFamilyAmazonLinux2023:
+		alias = "al2023@latest"
+	case api.NodeImageFamilyAmazonLinux2:
+		alias = "al2@latest"
+	case api.NodeImageFamilyBottlerocket:
+		alias = "bottlerocket@latest"
+	}
+	if alias != "" {
+		np.AMISelectorTerms = []api.KarpenterAMISelectorTerm{{Alias: alias}}
+	}
+
+	switch {
+	case len(ng.Subnets) > 0:
+		np.Subnets = ng.Subnets
+	case !ng.PrivateNetworking && cfg.VPC != nil && cfg.VPC.Subnets != nil:
+		for _, subnet := range cfg.VPC.Subnets.Public {
+			np.Subnets = append(np.Subnets, subnet.ID)
+		}
+		sort.Strings(np.Subnets)
+	}
+	return np
+}
+
+// clusterAutoscalerDeployments returns the running Cluster Autoscaler deployments in kube-system.
+func (m *Migrator) clusterAutoscalerDeployments(ctx context.Context) ([]appsv1.Deployment, error) {
+	list, err := m.ClientSet.AppsV1().Deployments(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing deployments in %s: %w", metav1.NamespaceSystem, err)
+	}
+	var deployments []appsv1.Deployment
+	for _, d := range list.Items {
+		if strings.Contains(d.Name, clusterAutoscalerName) && (d.Spec.Replicas == nil || *d.Spec.Replicas > 0) {
+			deployments = append(deployments, d)
+		}
+	}
+	return deployments, nil
+}
+
+func nodeGroupNames(nodeGroups []*api.NodeGroup) []string {
+	var names []string
+	for _, ng := range nodeGroups {
+		names = append(names, ng.Name)
+	}
+	return names
+}
+
+func printNodePools(w io.Writer, nodePools []api.KarpenterNodePool) error {
+	data, err := yaml.Marshal(map[string]interface{}{
+		"karpenter": map[string]interface{}{
+			"nodePools": nodePools,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling NodePools: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
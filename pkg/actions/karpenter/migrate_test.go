@@ -0,0 +1,261 @@
+package karpenter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter/fakes"
+	nodegroupfakes "github.com/weaveworks/eksctl/pkg/actions/nodegroup/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	managerfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+)
+
+const autoscaledNodeGroupTemplate = `{
+  "Resources": {
+    "NodeGroupLaunchTemplate": {
+      "Properties": {
+        "LaunchTemplateData": {
+          "InstanceType": "m5.large",
+          "UserData": %q
+        }
+      }
+    },
+    "NodeGroup": {
+      "Properties": {
+        "MinSize": "1",
+        "MaxSize": "5",
+        "VPCZoneIdentifier": %s,
+        "Tags": [
+          {"Key": "k8s.io/cluster-autoscaler/enabled", "Value": "true", "PropagateAtLaunch": "false"},
+          {"Key": "k8s.io/cluster-autoscaler/my-cluster", "Value": "owned", "PropagateAtLaunch": "false"}
+        ]
+      }
+    }
+  }
+}`
+
+var _ = Describe("Migrate", func() {
+	var (
+		cfg              *api.ClusterConfig
+		fakeStackManager *managerfakes.FakeStackManager
+		fakeInstaller    *fakes.FakeInstallerTaskCreator
+		fakeDrainer      *nodegroupfakes.FakeNodeGroupDrainer
+		fakeScaler       *fakes.FakeNodeGroupScaler
+		fakeClientSet    *fake.Clientset
+		templates        map[string]string
+		out              *bytes.Buffer
+		migrator         *karpenteractions.Migrator
+	)
+
+	userData := base64.StdEncoding.EncodeToString([]byte(`MIME-Version: 1.0
+Content-Type: application/node.eks.aws
+
+spec:
+  kubelet:
+    flags:
+    - --node-labels=alpha.eksctl.io/nodegroup-name=ng-1,role=worker
+    - --register-with-taints=dedicated=worker:NoSchedule
+`))
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.IAM.WithOIDC = api.Enabled()
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Public: api.AZSubnetMapping{
+				"us-west-2b": api.AZSubnetSpec{ID: "subnet-public-b"},
+				"us-west-2a": api.AZSubnetSpec{ID: "subnet-public-a"},
+			},
+		}
+
+		templates = map[string]string{
+			"eksctl-my-cluster-nodegroup-ng-1": fmt.Sprintf(autoscaledNodeGroupTemplate, userData, `{"Fn::Split": [",", {"Fn::ImportValue": "eksctl-my-cluster-cluster::SubnetsPrivate"}]}`),
+			"eksctl-my-cluster-nodegroup-ng-2": fmt.Sprintf(autoscaledNodeGroupTemplate, userData, `{"Fn::Split": [",", {"Fn::ImportValue": "eksctl-my-cluster-cluster::SubnetsPublic"}]}`),
+			"eksctl-my-cluster-nodegroup-ng-3": `{"Resources": {"NodeGroup": {"Properties": {"Tags": []}}}}`,
+		}
+		fakeStackManager = &managerfakes.FakeStackManager{}
+		var stacks []manager.NodeGroupStack
+		for _, name := range []string{"ng-2", "ng-1", "ng-3"} {
+			stacks = append(stacks, manager.NodeGroupStack{
+				NodeGroupName: name,
+				Type:          api.NodeGroupTypeUnmanaged,
+				Stack:         &manager.Stack{StackName: aws.String("eksctl-my-cluster-nodegroup-" + name)},
+			})
+		}
+		stacks = append(stacks, manager.NodeGroupStack{
+			NodeGroupName: "mng-1",
+			Type:          api.NodeGroupTypeManaged,
+			Stack:         &manager.Stack{StackName: aws.String("eksctl-my-cluster-nodegroup-mng-1")},
+		})
+		fakeStackManager.ListNodeGroupStacksWithStatusesReturns(stacks, nil)
+		fakeStackManager.GetStackTemplateStub = func(_ context.Context, name string) (string, error) {
+			return templates[name], nil
+		}
+		fakeStackManager.GetKarpenterStackReturns(karpenterStack("1.2.1"), nil)
+
+		fakeClientSet = fake.NewSimpleClientset(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster-autoscaler",
+				Namespace: metav1.NamespaceSystem,
+			},
+			Spec: appsv1.DeploymentSpec{Replicas: aws.Int32(1)},
+		})
+		fakeInstaller = &fakes.FakeInstallerTaskCreator{}
+		fakeDrainer = &nodegroupfakes.FakeNodeGroupDrainer{}
+		fakeScaler = &fakes.FakeNodeGroupScaler{}
+		out = &bytes.Buffer{}
+		migrator = &karpenteractions.Migrator{
+			ClusterConfig: cfg,
+			StackManager:  fakeStackManager,
+			ClientSet:     fakeClientSet,
+			Installer:     fakeInstaller,
+			Drainer:       fakeDrainer,
+			Scaler:        fakeScaler,
+			Out:           out,
+		}
+	})
+
+	autoscalerReplicas := func() int32 {
+		d, err := fakeClientSet.AppsV1().Deployments(metav1.NamespaceSystem).Get(context.Background(), "cluster-autoscaler", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		return *d.Spec.Replicas
+	}
+
+	It("creates NodePools matching the nodegroups scaled by Cluster Autoscaler", func() {
+		Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{Approve: true})).To(Succeed())
+
+		Expect(cfg.Karpenter.Version).To(Equal("1.2.1"))
+		Expect(cfg.Karpenter.NodePools).To(Equal([]api.KarpenterNodePool{
+			{
+				Name: "ng-1",
+				Requirements: []api.KarpenterRequirement{
+					{Key: "node.kubernetes.io/instance-type", Operator: "In", Values: []string{"m5.large"}},
+					{Key: "karpenter.sh/capacity-type", Operator: "In", Values: []string{"on-demand"}},
+				},
+				Labels:           map[string]string{"role": "worker"},
+				Taints:           []api.NodeGroupTaint{{Key: "dedicated", Value: "worker", Effect: corev1.TaintEffectNoSchedule}},
+				AMISelectorTerms: []api.KarpenterAMISelectorTerm{{Alias: "al2023@latest"}},
+			},
+			{
+				Name: "ng-2",
+				Requirements: []api.KarpenterRequirement{
+					{Key: "node.kubernetes.io/instance-type", Operator: "In", Values: []string{"m5.large"}},
+					{Key: "karpenter.sh/capacity-type", Operator: "In", Values: []string{"on-demand"}},
+				},
+				Labels:           map[string]string{"role": "worker"},
+				Taints:           []api.NodeGroupTaint{{Key: "dedicated", Value: "worker", Effect: corev1.TaintEffectNoSchedule}},
+				AMISelectorTerms: []api.KarpenterAMISelectorTerm{{Alias: "al2023@latest"}},
+				Subnets:          []string{"subnet-public-a", "subnet-public-b"},
+			},
+		}))
+		Expect(fakeInstaller.ApplyNodePoolsCallCount()).To(Equal(1))
+		Expect(fakeInstaller.CreateCallCount()).To(BeZero())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("scales down Cluster Autoscaler, then drains and scales each nodegroup to 0 nodes", func() {
+		Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{Approve: true})).To(Succeed())
+
+		Expect(autoscalerReplicas()).To(BeZero())
+		Expect(fakeDrainer.DrainCallCount()).To(Equal(2))
+		Expect(fakeScaler.ScaleCallCount()).To(Equal(2))
+		for i, name := range []string{"ng-1", "ng-2"} {
+			_, drainInput := fakeDrainer.DrainArgsForCall(i)
+			Expect(drainInput.NodeGroups).To(HaveLen(1))
+			Expect(drainInput.NodeGroups[0].NameString()).To(Equal(name))
+			_, ng, wait := fakeScaler.ScaleArgsForCall(i)
+			Expect(ng.Name).To(Equal(name))
+			Expect(*ng.MinSize).To(BeZero())
+			Expect(*ng.MaxSize).To(BeZero())
+			Expect(*ng.DesiredCapacity).To(BeZero())
+			Expect(wait).To(BeFalse())
+		}
+	})
+
+	It("only migrates the given nodegroups and keeps Cluster Autoscaler running", func() {
+		Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{
+			NodeGroups: []string{"ng-2"},
+			Approve:    true,
+		})).To(Succeed())
+
+		Expect(cfg.Karpenter.NodePools).To(HaveLen(1))
+		Expect(cfg.Karpenter.NodePools[0].Name).To(Equal("ng-2"))
+		Expect(autoscalerReplicas()).To(Equal(int32(1)))
+		Expect(fakeDrainer.DrainCallCount()).To(Equal(1))
+		Expect(fakeScaler.ScaleCallCount()).To(Equal(1))
+	})
+
+	It("errors if a given nodegroup is not scaled by Cluster Autoscaler", func() {
+		Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{
+			NodeGroups: []string{"ng-3"},
+			Approve:    true,
+		})).To(MatchError(`nodegroup "ng-3" is not an unmanaged nodegroup scaled by Cluster Autoscaler`))
+	})
+
+	It("stops at the first nodegroup that fails to drain", func() {
+		fakeDrainer.DrainReturns(errors.New("nope"))
+		Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{Approve: true})).
+			To(MatchError(`draining nodegroup "ng-1": nope`))
+		Expect(fakeDrainer.DrainCallCount()).To(Equal(1))
+		Expect(fakeScaler.ScaleCallCount()).To(BeZero())
+	})
+
+	When("in plan mode", func() {
+		It("prints the NodePools without making changes", func() {
+			Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{})).To(Succeed())
+
+			Expect(out.String()).To(ContainSubstring("karpenter:\n  nodePools:\n  - amiSelectorTerms:\n    - alias: al2023@latest\n"))
+			Expect(out.String()).To(ContainSubstring("name: ng-1"))
+			Expect(out.String()).To(ContainSubstring("name: ng-2"))
+			Expect(fakeInstaller.Invocations()).To(BeEmpty())
+			Expect(fakeDrainer.DrainCallCount()).To(BeZero())
+			Expect(fakeScaler.ScaleCallCount()).To(BeZero())
+			Expect(autoscalerReplicas()).To(Equal(int32(1)))
+		})
+	})
+
+	When("Karpenter is not installed", func() {
+		BeforeEach(func() {
+			fakeStackManager.GetKarpenterStackReturns(nil, nil)
+		})
+
+		It("installs Karpenter with the NodePools", func() {
+			cfg.Karpenter = &api.Karpenter{Version: "1.2.1"}
+			Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{Approve: true})).To(Succeed())
+			Expect(fakeInstaller.CreateCallCount()).To(Equal(1))
+			Expect(fakeInstaller.ApplyNodePoolsCallCount()).To(BeZero())
+			Expect(cfg.Karpenter.NodePools).To(HaveLen(2))
+		})
+
+		It("errors without a version to install", func() {
+			Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{Approve: true})).
+				To(MatchError(`Karpenter is not installed on cluster "my-cluster", set the version to install with --karpenter-version`))
+			Expect(fakeInstaller.CreateCallCount()).To(BeZero())
+		})
+	})
+
+	When("the installed version of Karpenter does not support NodePools", func() {
+		BeforeEach(func() {
+			fakeStackManager.GetKarpenterStackReturns(karpenterStack("0.37.0"), nil)
+		})
+
+		It("errors", func() {
+			Expect(migrator.Migrate(context.Background(), karpenteractions.MigrateOptions{Approve: true})).
+				To(MatchError("NodePools require Karpenter 1.0.0 or later but 0.37.0 is installed, use 'eksctl upgrade karpenter' first"))
+			Expect(fakeInstaller.Invocations()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/karpenter"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+const (
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByHelm  = "Helm"
+)
+
+// Upgrade upgrades Karpenter to the version in the cluster config. The Karpenter stack is updated first, so that
+// its IAM resources match the config, then the chart and its CRDs are upgraded.
+func (i *Installer) Upgrade(ctx context.Context) error {
+	parsedARN, err := arn.Parse(i.Config.Status.ARN)
+	if err != nil {
+		return fmt.Errorf("unexpected or invalid ARN: %q, %w", i.Config.Status.ARN, err)
+	}
+	stack, err := i.getKarpenterStack(ctx)
+	if err != nil {
+		return err
+	}
+
+	installedVersion := getKarpenterTagValue(stack.Tags, api.KarpenterVersionTag)
+	if c, err := utils.CompareVersions(i.Config.Karpenter.Version, installedVersion); err == nil && c < 0 {
+		return fmt.Errorf("cannot downgrade Karpenter from version %s to %s", installedVersion, i.Config.Karpenter.Version)
+	}
+
+	template, err := i.StackManager.GetStackTemplate(ctx, *stack.StackName)
+	if err != nil {
+		return fmt.Errorf("failed to get Karpenter stack template: %w", err)
+	}
+	if i.Config.Karpenter.WithSpotInterruptionQueue == nil {
+		// keep the interruption queue of the installed version
+		i.Config.Karpenter.WithSpotInterruptionQueue = aws.Bool(strings.Contains(template, builder.KarpenterInterruptionQueue))
+	}
+	if i.Config.Karpenter.CreateServiceAccount == nil {
+		// keep the service account created by the chart of the installed version
+		createServiceAccount, err := i.isServiceAccountManagedByHelm(ctx)
+		if err != nil {
+			return err
+		}
+		i.Config.Karpenter.CreateServiceAccount = aws.Bool(createServiceAccount)
+	}
+
+	instanceProfileName := i.instanceProfileName()
+	if err := i.updateKarpenterStack(ctx, stack, instanceProfileName); err != nil {
+		return err
+	}
+
+	roleARN := fmt.Sprintf("arn:%s:iam::%s:role/%s", parsedARN.Partition, parsedARN.AccountID, i.serviceAccountRoleName())
+	return i.KarpenterInstaller.Upgrade(ctx, roleARN, instanceProfileName)
+}
+
+// updateKarpenterStack updates the Karpenter stack with the resources of the cluster config and the new version.
+func (i *Installer) updateKarpenterStack(ctx context.Context, stack *manager.Stack, instanceProfileName string) error {
+	resourceSet := builder.NewKarpenterResourceSet(i.Config, instanceProfileName)
+	if err := resourceSet.AddAllResources(); err != nil {
+		return err
+	}
+	templateBody, err := resourceSet.RenderJSON()
+	if err != nil {
+		return fmt.Errorf("failed to render Karpenter stack template: %w", err)
+	}
+	stack.Tags = setKarpenterTagValue(stack.Tags, api.KarpenterVersionTag, i.Config.Karpenter.Version)
+	if err := i.StackManager.UpdateStack(ctx, manager.UpdateStackOptions{
+		Stack:         stack,
+		ChangeSetName: i.StackManager.MakeChangeSetName("update-karpenter"),
+		Description:   fmt.Sprintf("updating Karpenter stack %q for version %s", *stack.StackName, i.Config.Karpenter.Version),
+		TemplateData:  manager.TemplateBody(templateBody),
+		Wait:          true,
+	}); err != nil {
+		return fmt.Errorf("failed to update Karpenter stack: %w", err)
+	}
+	return nil
+}
+
+// isServiceAccountManagedByHelm returns true if the Karpenter service account was created by the chart.
+func (i *Installer) isServiceAccountManagedByHelm(ctx context.Context) (bool, error) {
+	sa, err := i.ClientSet.CoreV1().ServiceAccounts(karpenter.DefaultNamespace).Get(ctx, karpenter.DefaultServiceAccountName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Warning("service account %s/%s not found", karpenter.DefaultNamespace, karpenter.DefaultServiceAccountName)
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get Karpenter service account: %w", err)
+	}
+	return sa.Labels[managedByLabel] == managedByHelm, nil
+}
+
+// setKarpenterTagValue sets the value of a tag, adding the tag if it is missing.
+func setKarpenterTagValue(tags []cfntypes.Tag, key, value string) []cfntypes.Tag {
+	for i, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			tags[i].Value = aws.String(value)
+			return tags
+		}
+	}
+	return append(tags, cfntypes.Tag{Key: aws.String(key), Value: aws.String(value)})
+}
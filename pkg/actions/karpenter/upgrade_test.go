@@ -0,0 +1,144 @@
+package karpenter_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	karpenteractions "github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	managerfakes "github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	karpenterfakes "github.com/weaveworks/eksctl/pkg/karpenter/fakes"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+func karpenterStack(version string) *manager.Stack {
+	return &manager.Stack{
+		StackName: aws.String("eksctl-my-cluster-karpenter"),
+		Tags: []cfntypes.Tag{
+			{Key: aws.String(api.KarpenterNameTag), Value: aws.String("my-cluster")},
+			{Key: aws.String(api.KarpenterVersionTag), Value: aws.String(version)},
+		},
+	}
+}
+
+var _ = Describe("Upgrade", func() {
+	var (
+		cfg                    *api.ClusterConfig
+		fakeStackManager       *managerfakes.FakeStackManager
+		fakeKarpenterInstaller *karpenterfakes.FakeChartInstaller
+		fakeClientSet          *fake.Clientset
+		installer              *karpenteractions.Installer
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Status = &api.ClusterStatus{
+			ARN: "arn:aws:eks:us-west-2:123456789012:cluster/my-cluster",
+		}
+		cfg.Karpenter = &api.Karpenter{
+			Version: "1.2.1",
+		}
+		fakeStackManager = &managerfakes.FakeStackManager{}
+		fakeStackManager.GetKarpenterStackReturns(karpenterStack("0.37.0"), nil)
+		fakeStackManager.GetStackTemplateReturns(`{"Resources": {"KarpenterInterruptionQueue": {}}}`, nil)
+		fakeStackManager.MakeChangeSetNameReturns("eksctl-update-karpenter")
+		fakeKarpenterInstaller = &karpenterfakes.FakeChartInstaller{}
+		fakeClientSet = fake.NewSimpleClientset(&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "karpenter",
+				Namespace: "karpenter",
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "Helm"},
+			},
+		})
+		installer = &karpenteractions.Installer{
+			StackManager: fakeStackManager,
+			CTL: &eks.ClusterProvider{
+				AWSProvider: mockprovider.NewMockProvider(),
+				Status: &eks.ProviderStatus{
+					ClusterInfo: &eks.ClusterInfo{
+						Cluster: testutils.NewFakeCluster("my-cluster", ekstypes.ClusterStatusActive),
+					},
+				},
+			},
+			Config:             cfg,
+			KarpenterInstaller: fakeKarpenterInstaller,
+			ClientSet:          fakeClientSet,
+		}
+	})
+
+	It("updates the Karpenter stack and upgrades the chart", func() {
+		Expect(installer.Upgrade(context.Background())).To(Succeed())
+
+		Expect(fakeStackManager.UpdateStackCallCount()).To(Equal(1))
+		_, options := fakeStackManager.UpdateStackArgsForCall(0)
+		Expect(options.ChangeSetName).To(Equal("eksctl-update-karpenter"))
+		Expect(options.Wait).To(BeTrue())
+		Expect(options.Stack.Tags).To(ContainElement(cfntypes.Tag{
+			Key:   aws.String(api.KarpenterVersionTag),
+			Value: aws.String("1.2.1"),
+		}))
+		Expect(string(options.TemplateData.(manager.TemplateBody))).To(ContainSubstring(builder.KarpenterInterruptionQueue))
+
+		Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(Equal(1))
+		_, roleARN, instanceProfileName := fakeKarpenterInstaller.UpgradeArgsForCall(0)
+		Expect(roleARN).To(Equal("arn:aws:iam::123456789012:role/eksctl-my-cluster-iamservice-role"))
+		Expect(instanceProfileName).To(Equal("eksctl-KarpenterNodeInstanceProfile-my-cluster"))
+		Expect(*cfg.Karpenter.WithSpotInterruptionQueue).To(BeTrue())
+		Expect(*cfg.Karpenter.CreateServiceAccount).To(BeTrue())
+	})
+
+	It("keeps the settings of the installed version unless they are set", func() {
+		fakeStackManager.GetStackTemplateReturns(`{"Resources": {}}`, nil)
+		cfg.Karpenter.CreateServiceAccount = api.Disabled()
+		Expect(installer.Upgrade(context.Background())).To(Succeed())
+		Expect(*cfg.Karpenter.WithSpotInterruptionQueue).To(BeFalse())
+		Expect(*cfg.Karpenter.CreateServiceAccount).To(BeFalse())
+	})
+
+	When("Karpenter was not installed by eksctl", func() {
+		BeforeEach(func() {
+			fakeStackManager.GetKarpenterStackReturns(nil, nil)
+		})
+
+		It("errors", func() {
+			Expect(installer.Upgrade(context.Background())).To(MatchError(`no Karpenter stack found for cluster "my-cluster", Karpenter must be installed by eksctl`))
+			Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(BeZero())
+		})
+	})
+
+	When("the version is lower than the installed version", func() {
+		BeforeEach(func() {
+			fakeStackManager.GetKarpenterStackReturns(karpenterStack("1.3.0"), nil)
+		})
+
+		It("errors", func() {
+			Expect(installer.Upgrade(context.Background())).To(MatchError("cannot downgrade Karpenter from version 1.3.0 to 1.2.1"))
+			Expect(fakeStackManager.UpdateStackCallCount()).To(BeZero())
+		})
+	})
+
+	When("updating the stack fails", func() {
+		BeforeEach(func() {
+			fakeStackManager.UpdateStackReturns(errors.New("nope"))
+		})
+
+		It("does not upgrade the chart", func() {
+			Expect(installer.Upgrade(context.Background())).To(MatchError("failed to update Karpenter stack: nope"))
+			Expect(fakeKarpenterInstaller.UpgradeCallCount()).To(BeZero())
+		})
+	})
+})
@@ -40,6 +40,8 @@ type CreateOpts struct {
 	SkipOutdatedAddonsCheck   bool
 	ConfigFileProvided        bool
 	Parallelism               int
+	// Resume skips the tasks completed by a previous run that failed.
+	Resume bool
 }
 
 type DryRunSettings struct {
@@ -49,6 +51,12 @@ type DryRunSettings struct {
 
 // Create creates a new nodegroup with the given options.
 func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilter filter.NodegroupFilter) error {
+	return m.create(ctx, options, nodegroupFilter, true)
+}
+
+// create creates the nodegroups in the cluster config; when resumable is true, the completed tasks are recorded in a
+// checkpoint so that a failed creation can be resumed.
+func (m *Manager) create(ctx context.Context, options CreateOpts, nodegroupFilter filter.NodegroupFilter, resumable bool) error {
 	cfg := m.cfg
 	meta := cfg.Metadata
 	ctl := m.ctl
@@ -183,13 +191,28 @@ func (m *Manager) Create(ctx context.Context, options CreateOpts, nodegroupFilte
 		return cmdutils.PrintNodeGroupDryRunConfig(clusterConfigCopy, options.DryRunSettings.OutStream)
 	}
 
-	if err := m.nodeCreationTasks(ctx, isOwnedCluster, skipEgressRules, options.UpdateAuthConfigMap, options.Parallelism); err != nil {
+	var checkpoint *tasks.Checkpoint
+	if resumable {
+		if checkpoint, err = tasks.NewCheckpoint(meta.Name, meta.Region, "create nodegroup", options.Resume); err != nil {
+			return err
+		}
+		checkpoint.SetPrepareStack(func(stackName string) (bool, error) {
+			stack, err := manager.PrepareStackForRetry(ctx, m.stackManager, stackName)
+			return stack != nil, err
+		})
+	}
+
+	if err := m.nodeCreationTasks(ctx, isOwnedCluster, skipEgressRules, options.UpdateAuthConfigMap, options.Parallelism, checkpoint); err != nil {
+		cmdutils.LogResumeHint(checkpoint)
 		return err
 	}
 
-	if err := m.postNodeCreationTasks(ctx, m.clientSet, options); err != nil {
+	if err := m.postNodeCreationTasks(ctx, m.clientSet, options, checkpoint); err != nil {
 		return err
 	}
+	if err := checkpoint.Clear(); err != nil {
+		logger.Warning("unable to remove state file %s: %v", checkpoint.Path(), err)
+	}
 
 	if err := eks.ValidateExistingNodeGroupsForCompatibility(ctx, cfg, m.stackManager); err != nil {
 		logger.Critical("failed checking nodegroups", err.Error())
@@ -215,12 +238,13 @@ func makeOutpostsService(clusterConfig *api.ClusterConfig, provider api.ClusterP
 	}
 }
 
-func (m *Manager) nodeCreationTasks(ctx context.Context, isOwnedCluster, skipEgressRules bool, updateAuthConfigMap *bool, parallelism int) error {
+func (m *Manager) nodeCreationTasks(ctx context.Context, isOwnedCluster, skipEgressRules bool, updateAuthConfigMap *bool, parallelism int, checkpoint *tasks.Checkpoint) error {
 	cfg := m.cfg
 	meta := cfg.Metadata
 
 	taskTree := &tasks.TaskTree{
-		Parallel: false,
+		Parallel:   false,
+		Checkpoint: checkpoint,
 	}
 
 	if isOwnedCluster {
@@ -283,13 +307,15 @@ func (m *Manager) nodeCreationTasks(ctx context.Context, isOwnedCluster, skipEgr
 	return eks.DoAllNodegroupStackTasks(taskTree, meta.Region, meta.Name)
 }
 
-func (m *Manager) postNodeCreationTasks(ctx context.Context, clientSet kubernetes.Interface, options CreateOpts) error {
+func (m *Manager) postNodeCreationTasks(ctx context.Context, clientSet kubernetes.Interface, options CreateOpts, checkpoint *tasks.Checkpoint) error {
 	tasks := m.ctl.ClusterTasksForNodeGroups(m.cfg, options.InstallNeuronDevicePlugin, options.InstallNvidiaDevicePlugin)
+	tasks.Checkpoint = checkpoint
 	logger.Info(tasks.Describe())
 	errs := tasks.DoAllSync()
 	if len(errs) > 0 {
 		logger.Info("%d error(s) occurred and nodegroups haven't been created properly, you may wish to check CloudFormation console", len(errs))
 		logger.Info("to cleanup resources, run 'eksctl delete nodegroup --region=%s --cluster=%s --name=<name>' for each of the failed nodegroups", m.cfg.Metadata.Region, m.cfg.Metadata.Name)
+		cmdutils.LogResumeHint(checkpoint)
 		for _, err := range errs {
 			if err != nil {
 				logger.Critical("%s\n", err.Error())
@@ -24,6 +24,8 @@ type DrainInput struct {
 	Undo                  bool
 	DisableEviction       bool
 	Parallel              int
+	// Batch drains the nodes of each nodegroup in batches, waiting for evicted workloads to recover between batches.
+	Batch drain.BatchOptions
 }
 
 // A Drainer drains nodegroups.
@@ -46,6 +48,7 @@ func (d *Drainer) Drain(ctx context.Context, input *DrainInput) error {
 		nodegroup := nodegroup
 		g.Go(func() error {
 			nodeGroupDrainer := drain.NewNodeGroupDrainer(d.ClientSet, nodegroup, input.MaxGracePeriod, input.NodeDrainWaitPeriod, input.PodEvictionWaitPeriod, input.Undo, input.DisableEviction, input.Parallel)
+			nodeGroupDrainer.SetBatchOptions(input.Batch)
 			return nodeGroupDrainer.Drain(ctx, sem)
 		})
 	}
@@ -54,3 +57,17 @@ func (d *Drainer) Drain(ctx context.Context, input *DrainInput) error {
 	}
 	return nil
 }
+
+// Plan reports what draining the nodegroups would do to each pod, without cordoning nodes or evicting pods.
+func (d *Drainer) Plan(ctx context.Context, input *DrainInput) ([]drain.NodePlan, error) {
+	var plans []drain.NodePlan
+	for _, nodegroup := range input.NodeGroups {
+		nodeGroupDrainer := drain.NewNodeGroupDrainer(d.ClientSet, nodegroup, input.MaxGracePeriod, input.NodeDrainWaitPeriod, input.PodEvictionWaitPeriod, input.Undo, input.DisableEviction, input.Parallel)
+		nodePlans, err := nodeGroupDrainer.Plan(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("planning drain of nodegroup %q: %w", nodegroup.NameString(), err)
+		}
+		plans = append(plans, nodePlans...)
+	}
+	return plans, nil
+}
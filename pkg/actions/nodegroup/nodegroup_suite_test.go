@@ -7,6 +7,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
 func TestNodegroup(t *testing.T) {
@@ -20,6 +22,8 @@ var (
 )
 
 var _ = BeforeSuite(func() {
+	// keep the state files of task checkpoints out of the home directory
+	GinkgoT().Setenv(tasks.EksctlStateDirEnvName, GinkgoT().TempDir())
 	//Does not have ForceUpdateEnabled specified
 	al2WithoutForceTemplate = mustReadFile("testdata/al2-no-force-template.json")
 	//ForceUpdateEnabled set to false
@@ -0,0 +1,342 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/managed"
+	"github.com/weaveworks/eksctl/pkg/utils/names"
+)
+
+// NodeGroupCreator creates nodegroups.
+//
+//counterfeiter:generate -o fakes/fake_nodegroup_creator.go . NodeGroupCreator
+type NodeGroupCreator interface {
+	// NodeGroupExists reports whether a nodegroup with the given name exists in the cluster.
+	NodeGroupExists(ctx context.Context, name string) (bool, error)
+	// CreateNodeGroup creates a nodegroup and waits for its nodes to become ready.
+	CreateNodeGroup(ctx context.Context, np api.NodePool) error
+}
+
+// NodeGroupDrainer drains nodegroups.
+//
+//counterfeiter:generate -o fakes/fake_nodegroup_drainer.go . NodeGroupDrainer
+type NodeGroupDrainer interface {
+	Drain(ctx context.Context, input *DrainInput) error
+}
+
+// NodeGroupRemover deletes nodegroups.
+//
+//counterfeiter:generate -o fakes/fake_nodegroup_remover.go . NodeGroupRemover
+type NodeGroupRemover interface {
+	Delete(ctx context.Context, nodeGroups []*api.NodeGroup, managedNodeGroups []*api.ManagedNodeGroup, options DeleteOptions) error
+}
+
+// ReplaceOptions controls the replacement of a nodegroup.
+type ReplaceOptions struct {
+	// NewName is the name of the replacement nodegroup; a name is generated from the existing nodegroup's name if empty.
+	NewName string
+	// AMI overrides the AMI of the replacement nodegroup.
+	AMI string
+	// InstanceType overrides the instance type of the replacement nodegroup.
+	InstanceType string
+	// Drain controls how the existing nodegroup is drained; its NodeGroups are ignored.
+	Drain DrainInput
+	// Wait for the deletion of the existing nodegroup to complete.
+	Wait bool
+	// UpdateAuthConfigMap removes self-managed nodegroups that do not use access entries from the aws-auth ConfigMap.
+	UpdateAuthConfigMap bool
+	Plan                bool
+}
+
+// A Replacer replaces a nodegroup with a copy of it running under a new name. The replacement is created first and,
+// once its nodes are ready, the existing nodegroup is drained and deleted. If the replacement fails to become ready,
+// it is deleted and the existing nodegroup is left untouched.
+type Replacer struct {
+	ClusterConfig *api.ClusterConfig
+	Creator       NodeGroupCreator
+	Drainer       NodeGroupDrainer
+	Remover       NodeGroupRemover
+}
+
+// generatedSuffix matches the suffix added to the names of replacement nodegroups, so that replacing a nodegroup
+// more than once does not keep growing its name.
+var generatedSuffix = regexp.MustCompile(`-r[a-f0-9]{5}$`)
+
+// Replace replaces the given nodegroup, which must exist in the cluster, and returns its replacement.
+func (r *Replacer) Replace(ctx context.Context, existing api.NodePool, options ReplaceOptions) (api.NodePool, error) {
+	cfg := r.ClusterConfig
+	existingName := existing.BaseNodeGroup().Name
+
+	replacement, err := r.newReplacement(existing, options)
+	if err != nil {
+		return nil, err
+	}
+	replacementName := replacement.BaseNodeGroup().Name
+	exists, err := r.Creator.NodeGroupExists(ctx, replacementName)
+	if err != nil {
+		return nil, fmt.Errorf("checking if nodegroup %q exists: %w", replacementName, err)
+	}
+	if exists {
+		return nil, fmt.Errorf("nodegroup %q already exists; use --new-name to choose a different name for the replacement", replacementName)
+	}
+
+	cmdutils.LogIntendedAction(options.Plan, "create nodegroup %q to replace nodegroup %q in cluster %q", replacementName, existingName, cfg.Metadata.Name)
+	cmdutils.LogIntendedAction(options.Plan, "drain and delete nodegroup %q once the nodes of %q are ready", existingName, replacementName)
+	if options.Plan {
+		cmdutils.LogPlanModeWarning(true)
+		return replacement, nil
+	}
+
+	if err := r.Creator.CreateNodeGroup(ctx, replacement); err != nil {
+		logger.Warning("nodegroup %q failed to become ready, rolling back: %v", replacementName, err)
+		if rollbackErr := r.rollback(ctx, replacement, options); rollbackErr != nil {
+			return nil, fmt.Errorf("creating nodegroup %q: %w; rolling back also failed, delete the nodegroup manually: %v", replacementName, err, rollbackErr)
+		}
+		return nil, fmt.Errorf("creating nodegroup %q: %w; the replacement was deleted and nodegroup %q was left untouched", replacementName, err, existingName)
+	}
+	logger.Success("nodegroup %q is ready", replacementName)
+
+	drainInput := options.Drain
+	drainInput.NodeGroups = toKubeNodeGroups(existing)
+	if err := r.Drainer.Drain(ctx, &drainInput); err != nil {
+		return nil, fmt.Errorf("draining nodegroup %q: %w; both nodegroups were kept, use 'eksctl drain nodegroup' and 'eksctl delete nodegroup' to complete the replacement", existingName, err)
+	}
+
+	if err := r.delete(ctx, existing, DeleteOptions{Wait: options.Wait, UpdateAuthConfigMap: options.UpdateAuthConfigMap}); err != nil {
+		return nil, err
+	}
+	logger.Success("replaced nodegroup %q with %q in cluster %q", existingName, replacementName, cfg.Metadata.Name)
+	return replacement, nil
+}
+
+func (r *Replacer) newReplacement(existing api.NodePool, options ReplaceOptions) (api.NodePool, error) {
+	name := options.NewName
+	if name == "" {
+		name = fmt.Sprintf("%s-r%s", generatedSuffix.ReplaceAllString(existing.BaseNodeGroup().Name, ""), names.RandomName(5, "abcdef0123456789"))
+	}
+	if name == existing.BaseNodeGroup().Name {
+		return nil, errors.New("the replacement nodegroup must have a different name")
+	}
+
+	cfg := r.ClusterConfig
+	switch ng := existing.(type) {
+	case *api.NodeGroup:
+		replacement := ng.DeepCopy()
+		if options.InstanceType != "" {
+			if replacement.InstancesDistribution != nil {
+				return nil, fmt.Errorf("cannot override the instance type of nodegroup %q as it uses instancesDistribution", ng.Name)
+			}
+			replacement.InstanceType = options.InstanceType
+		}
+		prepareReplacement(replacement.NodeGroupBase, name, options)
+		if err := api.ValidateNodeGroup(0, replacement, cfg); err != nil {
+			return nil, err
+		}
+		api.SetNodeGroupDefaults(replacement, cfg.Metadata, cfg.IsControlPlaneOnOutposts())
+		return replacement, nil
+
+	case *api.ManagedNodeGroup:
+		replacement := ng.DeepCopy()
+		if options.InstanceType != "" {
+			replacement.InstanceType = options.InstanceType
+			replacement.InstanceTypes = nil
+		}
+		prepareReplacement(replacement.NodeGroupBase, name, options)
+		api.SetManagedNodeGroupDefaults(replacement, cfg.Metadata, cfg.IsControlPlaneOnOutposts())
+		if err := api.ValidateManagedNodeGroup(0, replacement); err != nil {
+			return nil, err
+		}
+		return replacement, nil
+
+	default:
+		return nil, fmt.Errorf("unexpected nodegroup type %T", existing)
+	}
+}
+
+func prepareReplacement(ng *api.NodeGroupBase, name string, options ReplaceOptions) {
+	ng.Name = name
+	if _, ok := ng.Labels[api.NodeGroupNameLabel]; ok {
+		ng.Labels[api.NodeGroupNameLabel] = name
+	}
+	if options.AMI != "" {
+		ng.AMI = options.AMI
+	}
+}
+
+func (r *Replacer) rollback(ctx context.Context, replacement api.NodePool, options ReplaceOptions) error {
+	exists, err := r.Creator.NodeGroupExists(ctx, replacement.BaseNodeGroup().Name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	return r.delete(ctx, replacement, DeleteOptions{Wait: true, UpdateAuthConfigMap: options.UpdateAuthConfigMap})
+}
+
+func (r *Replacer) delete(ctx context.Context, np api.NodePool, options DeleteOptions) error {
+	nodeGroups, managedNodeGroups := splitNodePool(np)
+	return r.Remover.Delete(ctx, nodeGroups, managedNodeGroups, options)
+}
+
+func splitNodePool(np api.NodePool) ([]*api.NodeGroup, []*api.ManagedNodeGroup) {
+	switch ng := np.(type) {
+	case *api.NodeGroup:
+		return []*api.NodeGroup{ng}, nil
+	case *api.ManagedNodeGroup:
+		return nil, []*api.ManagedNodeGroup{ng}
+	}
+	return nil, nil
+}
+
+func toKubeNodeGroups(np api.NodePool) []eks.KubeNodeGroup {
+	return cmdutils.ToKubeNodeGroups(splitNodePool(np))
+}
+
+// NodeGroupExists reports whether a nodegroup with the given name exists in the cluster.
+func (m *Manager) NodeGroupExists(ctx context.Context, name string) (bool, error) {
+	stacks, err := m.stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return false, err
+	}
+	if findStack(stacks, name) != nil {
+		return true, nil
+	}
+	if _, err := m.ctl.AWSProvider.EKS().DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+		ClusterName:   aws.String(m.cfg.Metadata.Name),
+		NodegroupName: aws.String(name),
+	}); err != nil {
+		if managed.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateNodeGroup creates a single nodegroup and waits for its nodes to become ready.
+func (m *Manager) CreateNodeGroup(ctx context.Context, np api.NodePool) error {
+	m.cfg.NodeGroups, m.cfg.ManagedNodeGroups = splitNodePool(np)
+	if err := m.create(ctx, CreateOpts{}, filter.NewNodeGroupFilter(), false); err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, m.ctl.AWSProvider.WaitTimeout())
+	defer cancel()
+	for _, ng := range toKubeNodeGroups(np) {
+		if err := eks.WaitForNodes(timeoutCtx, m.clientSet, ng); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetManagedNodeGroup returns the spec of an existing managed nodegroup, as described by the EKS API. Settings that
+// are only recorded in the nodegroup's launch template, such as the volume size, are not included.
+func (m *Manager) GetManagedNodeGroup(ctx context.Context, name string) (*api.ManagedNodeGroup, error) {
+	output, err := m.ctl.AWSProvider.EKS().DescribeNodegroup(ctx, &awseks.DescribeNodegroupInput{
+		ClusterName:   aws.String(m.cfg.Metadata.Name),
+		NodegroupName: aws.String(name),
+	})
+	if err != nil {
+		if managed.IsNotFound(err) {
+			return nil, fmt.Errorf("could not find managed nodegroup %q", name)
+		}
+		return nil, fmt.Errorf("describing nodegroup %q: %w", name, err)
+	}
+	return ManagedNodeGroupFromEKS(output.Nodegroup)
+}
+
+// ManagedNodeGroupFromEKS converts a managed nodegroup described by the EKS API to its spec.
+func ManagedNodeGroupFromEKS(nodeGroup *ekstypes.Nodegroup) (*api.ManagedNodeGroup, error) {
+	name := aws.ToString(nodeGroup.NodegroupName)
+	amiFamily, err := amiFamilyForAMIType(nodeGroup.AmiType)
+	if err != nil {
+		return nil, fmt.Errorf("nodegroup %q: %w", name, err)
+	}
+
+	ng := api.NewManagedNodeGroup()
+	ng.Name = name
+	ng.AMIFamily = amiFamily
+	ng.Subnets = nodeGroup.Subnets
+	if nodeGroup.DiskSize != nil {
+		ng.VolumeSize = aws.Int(int(*nodeGroup.DiskSize))
+	}
+	ng.Spot = nodeGroup.CapacityType == ekstypes.CapacityTypesSpot
+	if len(nodeGroup.InstanceTypes) == 1 {
+		ng.InstanceType = nodeGroup.InstanceTypes[0]
+	} else {
+		ng.InstanceTypes = nodeGroup.InstanceTypes
+	}
+	if sc := nodeGroup.ScalingConfig; sc != nil {
+		ng.ScalingConfig = &api.ScalingConfig{
+			DesiredCapacity: aws.Int(int(aws.ToInt32(sc.DesiredSize))),
+			MinSize:         aws.Int(int(aws.ToInt32(sc.MinSize))),
+			MaxSize:         aws.Int(int(aws.ToInt32(sc.MaxSize))),
+		}
+	}
+	for k, v := range nodeGroup.Labels {
+		if k == api.NodeGroupNameLabel || k == api.ClusterNameLabel {
+			continue
+		}
+		if ng.Labels == nil {
+			ng.Labels = map[string]string{}
+		}
+		ng.Labels[k] = v
+	}
+	for _, t := range nodeGroup.Taints {
+		ng.Taints = append(ng.Taints, api.NodeGroupTaint{
+			Key:    aws.ToString(t.Key),
+			Value:  aws.ToString(t.Value),
+			Effect: taintEffect(t.Effect),
+		})
+	}
+	return ng, nil
+}
+
+func amiFamilyForAMIType(amiType ekstypes.AMITypes) (string, error) {
+	switch t := string(amiType); {
+	case strings.HasPrefix(t, "AL2023_"):
+		return api.NodeImageFamilyAmazonLinux2023, nil
+	case strings.HasPrefix(t, "AL2_"):
+		return api.NodeImageFamilyAmazonLinux2, nil
+	case strings.HasPrefix(t, "BOTTLEROCKET_"):
+		return api.NodeImageFamilyBottlerocket, nil
+	case amiType == ekstypes.AMITypesWindowsCore2019X8664:
+		return api.NodeImageFamilyWindowsServer2019CoreContainer, nil
+	case amiType == ekstypes.AMITypesWindowsFull2019X8664:
+		return api.NodeImageFamilyWindowsServer2019FullContainer, nil
+	case amiType == ekstypes.AMITypesWindowsCore2022X8664:
+		return api.NodeImageFamilyWindowsServer2022CoreContainer, nil
+	case amiType == ekstypes.AMITypesWindowsFull2022X8664:
+		return api.NodeImageFamilyWindowsServer2022FullContainer, nil
+	default:
+		return "", fmt.Errorf("cannot determine the AMI family for AMI type %q; pass the config file the nodegroup was created from", amiType)
+	}
+}
+
+func taintEffect(effect ekstypes.TaintEffect) corev1.TaintEffect {
+	switch effect {
+	case ekstypes.TaintEffectNoSchedule:
+		return corev1.TaintEffectNoSchedule
+	case ekstypes.TaintEffectPreferNoSchedule:
+		return corev1.TaintEffectPreferNoSchedule
+	case ekstypes.TaintEffectNoExecute:
+		return corev1.TaintEffectNoExecute
+	}
+	return corev1.TaintEffect(effect)
+}
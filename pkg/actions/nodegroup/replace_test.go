@@ -0,0 +1,242 @@
+package nodegroup_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup/fakes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Replace", func() {
+	var (
+		cfg      *api.ClusterConfig
+		creator  *fakes.FakeNodeGroupCreator
+		drainer  *fakes.FakeNodeGroupDrainer
+		remover  *fakes.FakeNodeGroupRemover
+		replacer *nodegroup.Replacer
+		existing *api.NodeGroup
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Version = api.DefaultVersion
+
+		existing = api.NewNodeGroup()
+		existing.Name = "ng-1"
+		existing.InstanceType = "m5.large"
+		existing.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+		existing.Labels = map[string]string{"role": "workers"}
+		api.SetNodeGroupDefaults(existing, cfg.Metadata, false)
+		Expect(existing.Labels).To(HaveKeyWithValue(api.NodeGroupNameLabel, "ng-1"))
+
+		creator = &fakes.FakeNodeGroupCreator{}
+		drainer = &fakes.FakeNodeGroupDrainer{}
+		remover = &fakes.FakeNodeGroupRemover{}
+		replacer = &nodegroup.Replacer{
+			ClusterConfig: cfg,
+			Creator:       creator,
+			Drainer:       drainer,
+			Remover:       remover,
+		}
+	})
+
+	It("creates the replacement before draining and deleting the existing nodegroup", func() {
+		replacement, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{
+			NewName:      "ng-2",
+			AMI:          "ami-123",
+			InstanceType: "m6i.large",
+			Drain:        nodegroup.DrainInput{Parallel: 2, MaxGracePeriod: time.Minute},
+			Wait:         true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		ng, ok := replacement.(*api.NodeGroup)
+		Expect(ok).To(BeTrue())
+		Expect(ng.Name).To(Equal("ng-2"))
+		Expect(ng.AMI).To(Equal("ami-123"))
+		Expect(ng.InstanceType).To(Equal("m6i.large"))
+		Expect(ng.Labels).To(Equal(map[string]string{
+			"role":                 "workers",
+			api.NodeGroupNameLabel: "ng-2",
+			api.ClusterNameLabel:   "test-cluster",
+		}))
+		Expect(existing.Name).To(Equal("ng-1"))
+		Expect(existing.InstanceType).To(Equal("m5.large"))
+
+		Expect(creator.CreateNodeGroupCallCount()).To(Equal(1))
+		_, created := creator.CreateNodeGroupArgsForCall(0)
+		Expect(created).To(BeIdenticalTo(replacement))
+
+		Expect(drainer.DrainCallCount()).To(Equal(1))
+		_, drainInput := drainer.DrainArgsForCall(0)
+		Expect(drainInput.Parallel).To(Equal(2))
+		Expect(drainInput.MaxGracePeriod).To(Equal(time.Minute))
+		Expect(drainInput.NodeGroups).To(HaveLen(1))
+		Expect(drainInput.NodeGroups[0].NameString()).To(Equal("ng-1"))
+
+		Expect(remover.DeleteCallCount()).To(Equal(1))
+		_, nodeGroups, managedNodeGroups, options := remover.DeleteArgsForCall(0)
+		Expect(nodeGroups).To(ConsistOf(existing))
+		Expect(managedNodeGroups).To(BeEmpty())
+		Expect(options.Wait).To(BeTrue())
+	})
+
+	It("generates a name for the replacement", func() {
+		existing.Name = "ng-1-r0a1b2"
+		replacement, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replacement.BaseNodeGroup().Name).To(MatchRegexp(`^ng-1-r[a-f0-9]{5}$`))
+	})
+
+	It("replaces managed nodegroups", func() {
+		existing := api.NewManagedNodeGroup()
+		existing.Name = "mng-1"
+		existing.InstanceTypes = []string{"m5.large", "m5a.large"}
+		api.SetManagedNodeGroupDefaults(existing, cfg.Metadata, false)
+
+		replacement, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{
+			NewName:      "mng-2",
+			InstanceType: "m6i.large",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replacement).To(BeAssignableToTypeOf(&api.ManagedNodeGroup{}))
+		Expect(replacement.InstanceTypeList()).To(Equal([]string{"m6i.large"}))
+
+		_, nodeGroups, managedNodeGroups, _ := remover.DeleteArgsForCall(0)
+		Expect(nodeGroups).To(BeEmpty())
+		Expect(managedNodeGroups).To(ConsistOf(existing))
+	})
+
+	It("deletes the replacement and keeps the existing nodegroup if the new nodes do not become ready", func() {
+		creator.CreateNodeGroupReturns(errors.New("timed out waiting for nodes"))
+		creator.NodeGroupExistsReturnsOnCall(1, true, nil)
+
+		_, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{NewName: "ng-2"})
+		Expect(err).To(MatchError(`creating nodegroup "ng-2": timed out waiting for nodes; the replacement was deleted and nodegroup "ng-1" was left untouched`))
+
+		Expect(drainer.DrainCallCount()).To(BeZero())
+		Expect(remover.DeleteCallCount()).To(Equal(1))
+		_, nodeGroups, _, options := remover.DeleteArgsForCall(0)
+		Expect(nodeGroups).To(HaveLen(1))
+		Expect(nodeGroups[0].Name).To(Equal("ng-2"))
+		Expect(options.Wait).To(BeTrue())
+	})
+
+	It("does not delete anything if the replacement was never created", func() {
+		creator.CreateNodeGroupReturns(errors.New("invalid subnets"))
+
+		_, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{NewName: "ng-2"})
+		Expect(err).To(MatchError(ContainSubstring("invalid subnets")))
+		Expect(remover.DeleteCallCount()).To(BeZero())
+	})
+
+	It("keeps both nodegroups if draining fails", func() {
+		drainer.DrainReturns(errors.New("PDB violation"))
+
+		_, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{NewName: "ng-2"})
+		Expect(err).To(MatchError(ContainSubstring(`draining nodegroup "ng-1": PDB violation; both nodegroups were kept`)))
+		Expect(remover.DeleteCallCount()).To(BeZero())
+	})
+
+	It("does not make any changes in plan mode", func() {
+		_, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{NewName: "ng-2", Plan: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creator.CreateNodeGroupCallCount()).To(BeZero())
+		Expect(drainer.DrainCallCount()).To(BeZero())
+		Expect(remover.DeleteCallCount()).To(BeZero())
+	})
+
+	It("returns an error if the replacement already exists", func() {
+		creator.NodeGroupExistsReturns(true, nil)
+		_, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{NewName: "ng-2"})
+		Expect(err).To(MatchError(ContainSubstring(`nodegroup "ng-2" already exists`)))
+		Expect(creator.CreateNodeGroupCallCount()).To(BeZero())
+	})
+
+	It("returns an error if the replacement has the same name", func() {
+		_, err := replacer.Replace(context.Background(), existing, nodegroup.ReplaceOptions{NewName: "ng-1"})
+		Expect(err).To(MatchError("the replacement nodegroup must have a different name"))
+	})
+})
+
+var _ = Describe("GetManagedNodeGroup", func() {
+	var (
+		p *mockprovider.MockProvider
+		m *nodegroup.Manager
+	)
+
+	BeforeEach(func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		p = mockprovider.NewMockProvider()
+		m = nodegroup.New(cfg, &eks.ClusterProvider{AWSProvider: p}, fake.NewSimpleClientset(), nil)
+	})
+
+	mockDescribeNodegroup := func(ng *ekstypes.Nodegroup) {
+		p.MockEKS().On("DescribeNodegroup", mock.Anything, &awseks.DescribeNodegroupInput{
+			ClusterName:   aws.String("test-cluster"),
+			NodegroupName: aws.String("mng-1"),
+		}).Return(&awseks.DescribeNodegroupOutput{Nodegroup: ng}, nil)
+	}
+
+	It("builds the spec from the EKS API", func() {
+		mockDescribeNodegroup(&ekstypes.Nodegroup{
+			NodegroupName: aws.String("mng-1"),
+			AmiType:       ekstypes.AMITypesAl2023X8664Standard,
+			CapacityType:  ekstypes.CapacityTypesSpot,
+			InstanceTypes: []string{"m5.large", "m5a.large"},
+			Subnets:       []string{"subnet-1", "subnet-2"},
+			ScalingConfig: &ekstypes.NodegroupScalingConfig{
+				DesiredSize: aws.Int32(3),
+				MinSize:     aws.Int32(1),
+				MaxSize:     aws.Int32(5),
+			},
+			Labels: map[string]string{
+				"role":                 "workers",
+				api.NodeGroupNameLabel: "mng-1",
+			},
+			Taints: []ekstypes.Taint{{
+				Key:    aws.String("dedicated"),
+				Value:  aws.String("gpu"),
+				Effect: ekstypes.TaintEffectNoSchedule,
+			}},
+		})
+
+		ng, err := m.GetManagedNodeGroup(context.Background(), "mng-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ng.Name).To(Equal("mng-1"))
+		Expect(ng.AMIFamily).To(Equal(api.NodeImageFamilyAmazonLinux2023))
+		Expect(ng.Spot).To(BeTrue())
+		Expect(ng.InstanceTypes).To(Equal([]string{"m5.large", "m5a.large"}))
+		Expect(ng.Subnets).To(Equal([]string{"subnet-1", "subnet-2"}))
+		Expect(*ng.ScalingConfig.DesiredCapacity).To(Equal(3))
+		Expect(*ng.ScalingConfig.MinSize).To(Equal(1))
+		Expect(*ng.ScalingConfig.MaxSize).To(Equal(5))
+		Expect(ng.Labels).To(Equal(map[string]string{"role": "workers"}))
+		Expect(ng.Taints).To(Equal([]api.NodeGroupTaint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}))
+	})
+
+	It("returns an error for nodegroups using a custom AMI", func() {
+		mockDescribeNodegroup(&ekstypes.Nodegroup{
+			NodegroupName: aws.String("mng-1"),
+			AmiType:       ekstypes.AMITypesCustom,
+		})
+		_, err := m.GetManagedNodeGroup(context.Background(), "mng-1")
+		Expect(err).To(MatchError(ContainSubstring(`cannot determine the AMI family for AMI type "CUSTOM"`)))
+	})
+})
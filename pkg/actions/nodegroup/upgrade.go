@@ -52,14 +52,29 @@ type UpgradeOptions struct {
 }
 
 func (m *Manager) Upgrade(ctx context.Context, options UpgradeOptions) error {
-	stacks, err := m.stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	nodegroup, stack, err := m.describeUpgradableNodegroup(ctx, options)
 	if err != nil {
 		return err
 	}
 
+	if stack != nil {
+		options.Stack = stack
+		return m.upgradeUsingStack(ctx, options, nodegroup)
+	}
+
+	return m.upgradeUsingAPI(ctx, options, nodegroup)
+}
+
+// describeUpgradableNodegroup returns the nodegroup to upgrade, and its stack if it was created by eksctl
+func (m *Manager) describeUpgradableNodegroup(ctx context.Context, options UpgradeOptions) (*ekstypes.Nodegroup, *manager.NodeGroupStack, error) {
+	stacks, err := m.stackManager.ListNodeGroupStacksWithStatuses(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if options.KubernetesVersion != "" {
 		if _, err := semver.ParseTolerant(options.KubernetesVersion); err != nil {
-			return fmt.Errorf("invalid Kubernetes version: %w", err)
+			return nil, nil, fmt.Errorf("invalid Kubernetes version: %w", err)
 		}
 	}
 
@@ -70,27 +85,22 @@ func (m *Manager) Upgrade(ctx context.Context, options UpgradeOptions) error {
 
 	if err != nil {
 		if managed.IsNotFound(err) {
-			return fmt.Errorf("upgrade is only supported for managed nodegroups; could not find one with name %q", options.NodegroupName)
+			return nil, nil, fmt.Errorf("upgrade is only supported for managed nodegroups; could not find one with name %q", options.NodegroupName)
 		}
-		return err
+		return nil, nil, err
 	}
 
 	switch nodegroupOutput.Nodegroup.Status {
 	case ekstypes.NodegroupStatusActive:
 
 	case ekstypes.NodegroupStatusUpdating:
-		return errors.New("nodegroup is currently being updated, please retry the command after the existing update is complete")
+		return nil, nil, errors.New("nodegroup is currently being updated, please retry the command after the existing update is complete")
 
 	default:
-		return fmt.Errorf("nodegroup must be in %q state when upgrading a nodegroup; got state %q", ekstypes.NodegroupStatusActive, nodegroupOutput.Nodegroup.Status)
-	}
-
-	if stack := findStack(stacks, options.NodegroupName); stack != nil {
-		options.Stack = stack
-		return m.upgradeUsingStack(ctx, options, nodegroupOutput.Nodegroup)
+		return nil, nil, fmt.Errorf("nodegroup must be in %q state when upgrading a nodegroup; got state %q", ekstypes.NodegroupStatusActive, nodegroupOutput.Nodegroup.Status)
 	}
 
-	return m.upgradeUsingAPI(ctx, options, nodegroupOutput.Nodegroup)
+	return nodegroupOutput.Nodegroup, findStack(stacks, options.NodegroupName), nil
 }
 
 func (m *Manager) upgradeUsingAPI(ctx context.Context, options UpgradeOptions, nodegroup *ekstypes.Nodegroup) error {
@@ -246,21 +256,23 @@ func (m *Manager) upgradeUsingStack(ctx context.Context, options UpgradeOptions,
 		return err
 	}
 
-	if usesCustomAMI && (options.KubernetesVersion != "" || options.ReleaseVersion != "") {
+	lt, hasEksctlLaunchTemplate := ltResources["LaunchTemplate"]
+	resolvesCustomAMI := usesCustomAMI && hasEksctlLaunchTemplate && m.cfg.AMIResolution != nil
+
+	if usesCustomAMI && (options.ReleaseVersion != "" || (options.KubernetesVersion != "" && !resolvesCustomAMI)) {
 		return errors.New("cannot specify kubernetes-version or release-version when using a custom AMI")
 	}
 
-	if options.ReleaseVersion != "" {
+	if resolvesCustomAMI {
+		if err := m.updateCustomAMI(ctx, options.KubernetesVersion, nodegroup, lt); err != nil {
+			return err
+		}
+	} else if options.ReleaseVersion != "" {
 		ngResource.ReleaseVersion = gfnt.NewString(options.ReleaseVersion)
 	} else if !usesCustomAMI {
-		kubernetesVersion := options.KubernetesVersion
-		if kubernetesVersion == "" {
-			// Use the current Kubernetes version
-			version, err := semver.ParseTolerant(*nodegroup.Version)
-			if err != nil {
-				return fmt.Errorf("unexpected error parsing Kubernetes version %q: %w", *nodegroup.Version, err)
-			}
-			kubernetesVersion = fmt.Sprintf("%v.%v", version.Major, version.Minor)
+		kubernetesVersion, err := upgradeKubernetesVersion(options.KubernetesVersion, nodegroup)
+		if err != nil {
+			return err
 		}
 
 		latestReleaseVersion, err := m.getLatestReleaseVersion(ctx, kubernetesVersion, nodegroup)
@@ -297,6 +309,72 @@ func (m *Manager) upgradeUsingStack(ctx context.Context, options UpgradeOptions,
 	return nil
 }
 
+// updateCustomAMI sets the image of the nodegroup's launch template to the AMI resolved from amiResolution
+func (m *Manager) updateCustomAMI(ctx context.Context, kubernetesVersion string, nodegroup *ekstypes.Nodegroup, lt *gfnec2.LaunchTemplate) error {
+	imageID, err := m.resolveCustomAMI(ctx, kubernetesVersion, nodegroup)
+	if err != nil {
+		return err
+	}
+
+	if lt.LaunchTemplateData.ImageId != nil && lt.LaunchTemplateData.ImageId.String() == imageID {
+		logger.Info("nodegroup %q is already using the latest custom AMI %s", *nodegroup.NodegroupName, imageID)
+		return nil
+	}
+	lt.LaunchTemplateData.ImageId = gfnt.NewString(imageID)
+	logger.Info("will upgrade nodes to custom AMI: %s", imageID)
+	return nil
+}
+
+// resolveCustomAMI resolves the AMI of the nodegroup from amiResolution
+func (m *Manager) resolveCustomAMI(ctx context.Context, kubernetesVersion string, nodegroup *ekstypes.Nodegroup) (string, error) {
+	kubernetesVersion, err := upgradeKubernetesVersion(kubernetesVersion, nodegroup)
+	if err != nil {
+		return "", err
+	}
+
+	// the AMI family is not recorded by EKS for custom AMIs, so it is read from the config file
+	var ng *api.ManagedNodeGroup
+	for _, mng := range m.cfg.ManagedNodeGroups {
+		if mng.Name == *nodegroup.NodegroupName {
+			ng = mng
+			break
+		}
+	}
+	if ng == nil {
+		return "", fmt.Errorf("nodegroup %q must be defined in managedNodeGroups to resolve its custom AMI from amiResolution", *nodegroup.NodegroupName)
+	}
+	if ng.AMIFamily == "" {
+		return "", fmt.Errorf("amiFamily of nodegroup %q must be set to resolve its custom AMI from amiResolution", ng.Name)
+	}
+	instanceType := api.SelectInstanceType(ng)
+	if instanceType == "" && len(nodegroup.InstanceTypes) > 0 {
+		instanceType = nodegroup.InstanceTypes[0]
+	}
+
+	resolver, err := ami.NewCustomResolver(m.cfg.AMIResolution, m.ctl.AWSProvider.SSM())
+	if err != nil {
+		return "", err
+	}
+	imageID, err := resolver.Resolve(ctx, m.ctl.AWSProvider.Region(), kubernetesVersion, instanceType, ng.AMIFamily)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve a custom AMI for nodegroup %q: %w", *nodegroup.NodegroupName, err)
+	}
+	return imageID, nil
+}
+
+// upgradeKubernetesVersion returns the Kubernetes version to upgrade the nodegroup to, defaulting to the
+// current Kubernetes version of the nodegroup
+func upgradeKubernetesVersion(kubernetesVersion string, nodegroup *ekstypes.Nodegroup) (string, error) {
+	if kubernetesVersion != "" {
+		return kubernetesVersion, nil
+	}
+	version, err := semver.ParseTolerant(*nodegroup.Version)
+	if err != nil {
+		return "", fmt.Errorf("unexpected error parsing Kubernetes version %q: %w", *nodegroup.Version, err)
+	}
+	return fmt.Sprintf("%v.%v", version.Major, version.Minor), nil
+}
+
 func (m *Manager) updateReleaseVersion(latestReleaseVersion, launchTemplateVersion string, nodegroup *ekstypes.Nodegroup, ngResource *gfneks.Nodegroup) error {
 	latest, err := ParseReleaseVersion(latestReleaseVersion)
 	if err != nil {
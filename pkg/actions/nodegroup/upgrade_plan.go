@@ -0,0 +1,280 @@
+package nodegroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	"github.com/weaveworks/eksctl/pkg/ami"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/goformation"
+)
+
+var (
+	kubeletVersionRegex    = regexp.MustCompile(`k8s:\s*([^,\s)]+)`)
+	containerdVersionRegex = regexp.MustCompile(`containerd:\s*([^,\s)]+)`)
+)
+
+// UpgradePlan describes the changes an upgrade would make to a nodegroup
+type UpgradePlan struct {
+	NodeGroupName string         `json:"nodeGroupName"`
+	Current       NodeGroupImage `json:"current"`
+	Target        NodeGroupImage `json:"target"`
+	// LaunchTemplateVersion is the launch template version the nodegroup will be upgraded to, if any
+	LaunchTemplateVersion string `json:"launchTemplateVersion,omitempty"`
+	// CreatesLaunchTemplateVersion is true if the upgrade creates LaunchTemplateVersion
+	CreatesLaunchTemplateVersion bool `json:"createsLaunchTemplateVersion"`
+}
+
+// NodeGroupImage describes the image of a nodegroup
+type NodeGroupImage struct {
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	ReleaseVersion    string `json:"releaseVersion,omitempty"`
+	AMI               string `json:"ami,omitempty"`
+	KubeletVersion    string `json:"kubeletVersion,omitempty"`
+	ContainerdVersion string `json:"containerdVersion,omitempty"`
+}
+
+// HasChanges returns true if the upgrade changes the nodegroup
+func (p *UpgradePlan) HasChanges() bool {
+	return p.Current != p.Target || p.LaunchTemplateVersion != ""
+}
+
+// PlanUpgrade reports the AMI, release version and launch template version changes Upgrade would make to the
+// nodegroup, without upgrading it
+func (m *Manager) PlanUpgrade(ctx context.Context, options UpgradeOptions) (*UpgradePlan, error) {
+	if options.KubernetesVersion != "" && options.ReleaseVersion != "" {
+		return nil, errors.New("only one of kubernetes-version or release-version can be specified")
+	}
+
+	nodegroup, stack, err := m.describeUpgradableNodegroup(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	usesCustomAMI, err := m.usesCustomAMIEKSNodeGroup(ctx, nodegroup)
+	if err != nil {
+		return nil, err
+	}
+	currentAMI, err := m.currentAMI(ctx, nodegroup)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &UpgradePlan{
+		NodeGroupName: options.NodegroupName,
+		Current: NodeGroupImage{
+			KubernetesVersion: aws.ToString(nodegroup.Version),
+			ReleaseVersion:    aws.ToString(nodegroup.ReleaseVersion),
+			AMI:               currentAMI,
+		},
+	}
+	plan.Target = plan.Current
+	if options.LaunchTemplateVersion != "" {
+		plan.LaunchTemplateVersion = options.LaunchTemplateVersion
+	}
+
+	if usesCustomAMI {
+		if err := m.planCustomAMIUpgrade(ctx, options, nodegroup, stack, plan); err != nil {
+			return nil, err
+		}
+	} else if err := m.planReleaseVersionUpgrade(ctx, options, nodegroup, plan); err != nil {
+		return nil, err
+	}
+
+	if err := m.describeImageVersions(ctx, &plan.Current, &plan.Target); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+func (m *Manager) planCustomAMIUpgrade(ctx context.Context, options UpgradeOptions, nodegroup *ekstypes.Nodegroup, stack *manager.NodeGroupStack, plan *UpgradePlan) error {
+	resolvesCustomAMI := false
+	if stack != nil && m.cfg.AMIResolution != nil {
+		var err error
+		if resolvesCustomAMI, err = m.hasEksctlLaunchTemplate(ctx, stack, options.NodegroupName); err != nil {
+			return err
+		}
+	}
+	if options.ReleaseVersion != "" || (options.KubernetesVersion != "" && !resolvesCustomAMI) {
+		return errors.New("cannot specify kubernetes-version or release-version when using a custom AMI")
+	}
+
+	switch {
+	case resolvesCustomAMI:
+		imageID, err := m.resolveCustomAMI(ctx, options.KubernetesVersion, nodegroup)
+		if err != nil {
+			return err
+		}
+		if imageID == plan.Current.AMI {
+			return nil
+		}
+		plan.Target.AMI = imageID
+		output, err := m.ctl.AWSProvider.EC2().DescribeLaunchTemplates(ctx, &ec2.DescribeLaunchTemplatesInput{
+			LaunchTemplateIds: []string{aws.ToString(nodegroup.LaunchTemplate.Id)},
+		})
+		if err != nil {
+			return fmt.Errorf("error describing launch template: %w", err)
+		}
+		if len(output.LaunchTemplates) != 1 {
+			return fmt.Errorf("failed to find launch template with ID %q", aws.ToString(nodegroup.LaunchTemplate.Id))
+		}
+		plan.LaunchTemplateVersion = strconv.FormatInt(aws.ToInt64(output.LaunchTemplates[0].LatestVersionNumber)+1, 10)
+		plan.CreatesLaunchTemplateVersion = true
+
+	case options.LaunchTemplateVersion != "":
+		launchTemplateData, err := m.launchTemplateFetcher.Fetch(ctx, &api.LaunchTemplate{
+			ID:      aws.ToString(nodegroup.LaunchTemplate.Id),
+			Version: aws.String(options.LaunchTemplateVersion),
+		})
+		if err != nil {
+			return fmt.Errorf("error fetching launch template data: %w", err)
+		}
+		plan.Target.AMI = aws.ToString(launchTemplateData.ImageId)
+	}
+	return nil
+}
+
+func (m *Manager) planReleaseVersionUpgrade(ctx context.Context, options UpgradeOptions, nodegroup *ekstypes.Nodegroup, plan *UpgradePlan) error {
+	kubernetesVersion, err := upgradeKubernetesVersion(options.KubernetesVersion, nodegroup)
+	if err != nil {
+		return err
+	}
+
+	latestReleaseVersion, err := m.getLatestReleaseVersion(ctx, kubernetesVersion, nodegroup)
+	if err != nil {
+		return err
+	}
+
+	targetReleaseVersion := options.ReleaseVersion
+	if targetReleaseVersion == "" && latestReleaseVersion != "" {
+		latest, err := ParseReleaseVersion(latestReleaseVersion)
+		if err != nil {
+			return err
+		}
+		current, err := ParseReleaseVersion(plan.Current.ReleaseVersion)
+		if err != nil {
+			return err
+		}
+		if !latest.LTE(current) {
+			targetReleaseVersion = latestReleaseVersion
+		}
+	}
+	if targetReleaseVersion == "" && plan.Current.KubernetesVersion == kubernetesVersion {
+		return nil
+	}
+
+	plan.Target = NodeGroupImage{
+		KubernetesVersion: kubernetesVersion,
+		ReleaseVersion:    targetReleaseVersion,
+	}
+	if targetReleaseVersion == "" || targetReleaseVersion != latestReleaseVersion {
+		// only the AMI of the latest release version can be resolved
+		return nil
+	}
+	amiFamily, err := amiFamilyForAMIType(nodegroup.AmiType)
+	if err != nil {
+		return err
+	}
+	instanceType := ""
+	if len(nodegroup.InstanceTypes) > 0 {
+		instanceType = nodegroup.InstanceTypes[0]
+	}
+	imageID, err := ami.NewSSMResolver(m.ctl.AWSProvider.SSM()).Resolve(ctx, m.ctl.AWSProvider.Region(), kubernetesVersion, instanceType, amiFamily)
+	if err != nil {
+		return fmt.Errorf("unable to resolve the AMI of release version %s: %w", targetReleaseVersion, err)
+	}
+	plan.Target.AMI = imageID
+	return nil
+}
+
+// currentAMI returns the AMI of the launch template used by the nodegroup's autoscaling group
+func (m *Manager) currentAMI(ctx context.Context, nodegroup *ekstypes.Nodegroup) (string, error) {
+	if nodegroup.Resources == nil || len(nodegroup.Resources.AutoScalingGroups) == 0 {
+		return "", nil
+	}
+	output, err := m.ctl.AWSProvider.ASG().DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{aws.ToString(nodegroup.Resources.AutoScalingGroups[0].Name)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error describing autoscaling group of nodegroup %q: %w", aws.ToString(nodegroup.NodegroupName), err)
+	}
+	if len(output.AutoScalingGroups) == 0 {
+		return "", nil
+	}
+
+	asg := output.AutoScalingGroups[0]
+	lt := asg.LaunchTemplate
+	if lt == nil && asg.MixedInstancesPolicy != nil && asg.MixedInstancesPolicy.LaunchTemplate != nil {
+		lt = asg.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification
+	}
+	if lt == nil || lt.LaunchTemplateId == nil {
+		return "", nil
+	}
+	launchTemplateData, err := m.launchTemplateFetcher.Fetch(ctx, &api.LaunchTemplate{
+		ID:      *lt.LaunchTemplateId,
+		Version: lt.Version,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error fetching launch template data: %w", err)
+	}
+	return aws.ToString(launchTemplateData.ImageId), nil
+}
+
+// describeImageVersions sets the kubelet and containerd versions of the images from the descriptions of their AMIs
+func (m *Manager) describeImageVersions(ctx context.Context, images ...*NodeGroupImage) error {
+	var imageIDs []string
+	for _, image := range images {
+		if image.AMI != "" {
+			imageIDs = append(imageIDs, image.AMI)
+		}
+	}
+	if len(imageIDs) == 0 {
+		return nil
+	}
+	output, err := m.ctl.AWSProvider.EC2().DescribeImages(ctx, &ec2.DescribeImagesInput{
+		ImageIds: imageIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("error describing images: %w", err)
+	}
+	for _, image := range images {
+		for _, ec2Image := range output.Images {
+			if aws.ToString(ec2Image.ImageId) != image.AMI {
+				continue
+			}
+			description := aws.ToString(ec2Image.Description)
+			if match := kubeletVersionRegex.FindStringSubmatch(description); match != nil {
+				image.KubeletVersion = match[1]
+			}
+			if match := containerdVersionRegex.FindStringSubmatch(description); match != nil {
+				image.ContainerdVersion = match[1]
+			}
+		}
+	}
+	return nil
+}
+
+func (m *Manager) hasEksctlLaunchTemplate(ctx context.Context, stack *manager.NodeGroupStack, nodeGroupName string) (bool, error) {
+	template, err := m.stackManager.GetManagedNodeGroupTemplate(ctx, manager.GetNodegroupOption{
+		Stack:         stack,
+		NodeGroupName: nodeGroupName,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error fetching nodegroup template: %w", err)
+	}
+	parsedTemplate, err := goformation.ParseJSON([]byte(template))
+	if err != nil {
+		return false, fmt.Errorf("unexpected error parsing nodegroup template: %w", err)
+	}
+	_, ok := parsedTemplate.GetAllEC2LaunchTemplateResources()["LaunchTemplate"]
+	return ok, nil
+}
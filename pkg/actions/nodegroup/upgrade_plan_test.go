@@ -0,0 +1,221 @@
+package nodegroup_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/goformation"
+	gfnt "github.com/weaveworks/eksctl/pkg/goformation/cloudformation/types"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("PlanUpgrade", func() {
+	const (
+		clusterName = "my-cluster"
+		ngName      = "my-nodegroup"
+	)
+
+	var (
+		p                *mockprovider.MockProvider
+		cfg              *api.ClusterConfig
+		m                *nodegroup.Manager
+		fakeStackManager *fakes.FakeStackManager
+		eksNodegroup     *ekstypes.Nodegroup
+	)
+
+	mockParameter := func(name, value string) {
+		p.MockSSM().On("GetParameter", mock.Anything, &ssm.GetParameterInput{
+			Name: aws.String(name),
+		}).Return(&ssm.GetParameterOutput{
+			Parameter: &ssmtypes.Parameter{Value: aws.String(value)},
+		}, nil)
+	}
+
+	mockImages := func(images ...ec2types.Image) {
+		p.MockEC2().On("DescribeImages", mock.Anything, mock.Anything).Return(&ec2.DescribeImagesOutput{
+			Images: images,
+		}, nil)
+	}
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+		p = mockprovider.NewMockProvider()
+		m = nodegroup.New(cfg, &eks.ClusterProvider{AWSProvider: p}, fake.NewSimpleClientset(), nil)
+		fakeStackManager = new(fakes.FakeStackManager)
+		m.SetStackManager(fakeStackManager)
+
+		eksNodegroup = &ekstypes.Nodegroup{
+			NodegroupName:  aws.String(ngName),
+			ClusterName:    aws.String(clusterName),
+			Status:         ekstypes.NodegroupStatusActive,
+			AmiType:        ekstypes.AMITypesAl2023X8664Standard,
+			Version:        aws.String(api.Version1_30),
+			ReleaseVersion: aws.String("1.30.4-20240917"),
+			InstanceTypes:  []string{"m5.large"},
+			Resources: &ekstypes.NodegroupResources{
+				AutoScalingGroups: []ekstypes.AutoScalingGroup{{Name: aws.String("eks-asg")}},
+			},
+		}
+		p.MockEKS().On("DescribeNodegroup", mock.Anything, &awseks.DescribeNodegroupInput{
+			ClusterName:   aws.String(clusterName),
+			NodegroupName: aws.String(ngName),
+		}).Return(func(context.Context, *awseks.DescribeNodegroupInput, ...func(*awseks.Options)) *awseks.DescribeNodegroupOutput {
+			return &awseks.DescribeNodegroupOutput{Nodegroup: eksNodegroup}
+		}, nil)
+
+		p.MockASG().On("DescribeAutoScalingGroups", mock.Anything, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []string{"eks-asg"},
+		}).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+			AutoScalingGroups: []autoscalingtypes.AutoScalingGroup{
+				{
+					LaunchTemplate: &autoscalingtypes.LaunchTemplateSpecification{
+						LaunchTemplateId: aws.String("lt-eks"),
+						Version:          aws.String("1"),
+					},
+				},
+			},
+		}, nil)
+		p.MockEC2().On("DescribeLaunchTemplateVersions", mock.Anything, &ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateId: aws.String("lt-eks"),
+			Versions:         []string{"1"},
+		}).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+			LaunchTemplateVersions: []ec2types.LaunchTemplateVersion{
+				{LaunchTemplateData: &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-current")}},
+			},
+		}, nil)
+	})
+
+	When("the nodegroup uses an EKS-optimized AMI", func() {
+		It("reports the latest release version and its AMI", func() {
+			mockParameter("/aws/service/eks/optimized-ami/1.31/amazon-linux-2023/x86_64/standard/recommended/release_version", "1.31.0-20241024")
+			mockParameter("/aws/service/eks/optimized-ami/1.31/amazon-linux-2023/x86_64/standard/recommended/image_id", "ami-target")
+			mockImages(ec2types.Image{
+				ImageId:     aws.String("ami-current"),
+				Description: aws.String("EKS-optimized Kubernetes node based on Amazon Linux 2023, (k8s: 1.30.4, containerd: 1.7.20-1.amzn2023.0.1)"),
+			}, ec2types.Image{
+				ImageId:     aws.String("ami-target"),
+				Description: aws.String("EKS-optimized Kubernetes node based on Amazon Linux 2023, (k8s: 1.31.0, containerd: 1.7.22-1.amzn2023.0.1)"),
+			})
+
+			plan, err := m.PlanUpgrade(context.Background(), nodegroup.UpgradeOptions{
+				NodegroupName:     ngName,
+				KubernetesVersion: api.Version1_31,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*plan).To(Equal(nodegroup.UpgradePlan{
+				NodeGroupName: ngName,
+				Current: nodegroup.NodeGroupImage{
+					KubernetesVersion: api.Version1_30,
+					ReleaseVersion:    "1.30.4-20240917",
+					AMI:               "ami-current",
+					KubeletVersion:    "1.30.4",
+					ContainerdVersion: "1.7.20-1.amzn2023.0.1",
+				},
+				Target: nodegroup.NodeGroupImage{
+					KubernetesVersion: api.Version1_31,
+					ReleaseVersion:    "1.31.0-20241024",
+					AMI:               "ami-target",
+					KubeletVersion:    "1.31.0",
+					ContainerdVersion: "1.7.22-1.amzn2023.0.1",
+				},
+			}))
+			Expect(plan.HasChanges()).To(BeTrue())
+			Expect(fakeStackManager.UpdateNodeGroupStackCallCount()).To(Equal(0))
+			p.MockEKS().AssertNotCalled(GinkgoT(), "UpdateNodegroupVersion", mock.Anything, mock.Anything)
+		})
+
+		It("reports no changes if the nodegroup is up-to-date", func() {
+			mockParameter("/aws/service/eks/optimized-ami/1.30/amazon-linux-2023/x86_64/standard/recommended/release_version", "1.30.4-20240917")
+			mockImages(ec2types.Image{
+				ImageId:     aws.String("ami-current"),
+				Description: aws.String("EKS-optimized Kubernetes node based on Amazon Linux 2023, (k8s: 1.30.4, containerd: 1.7.20-1.amzn2023.0.1)"),
+			})
+
+			plan, err := m.PlanUpgrade(context.Background(), nodegroup.UpgradeOptions{NodegroupName: ngName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Target).To(Equal(plan.Current))
+			Expect(plan.HasChanges()).To(BeFalse())
+		})
+	})
+
+	When("the nodegroup uses a custom AMI resolved from amiResolution", func() {
+		BeforeEach(func() {
+			cfg.AMIResolution = &api.AMIResolution{
+				SSMParameterPath: "/golden-amis/{{version}}/{{family}}/{{arch}}",
+			}
+			cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{{
+				NodeGroupBase: &api.NodeGroupBase{
+					Name:      ngName,
+					AMIFamily: api.NodeImageFamilyAmazonLinux2023,
+				},
+			}}
+			eksNodegroup.AmiType = ekstypes.AMITypesCustom
+			eksNodegroup.ReleaseVersion = nil
+			eksNodegroup.LaunchTemplate = &ekstypes.LaunchTemplateSpecification{
+				Id:      aws.String("lt-eksctl"),
+				Version: aws.String("3"),
+			}
+			p.MockEC2().On("DescribeLaunchTemplateVersions", mock.Anything, &ec2.DescribeLaunchTemplateVersionsInput{
+				LaunchTemplateId: aws.String("lt-eksctl"),
+				Versions:         []string{"3"},
+			}).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+				LaunchTemplateVersions: []ec2types.LaunchTemplateVersion{
+					{LaunchTemplateData: &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-current")}},
+				},
+			}, nil)
+			p.MockEC2().On("DescribeLaunchTemplates", mock.Anything, &ec2.DescribeLaunchTemplatesInput{
+				LaunchTemplateIds: []string{"lt-eksctl"},
+			}).Return(&ec2.DescribeLaunchTemplatesOutput{
+				LaunchTemplates: []ec2types.LaunchTemplate{{LatestVersionNumber: aws.Int64(4)}},
+			}, nil)
+
+			stack, err := goformation.ParseJSON([]byte(al2ForceFalseTemplate))
+			Expect(err).NotTo(HaveOccurred())
+			stack.GetAllEC2LaunchTemplateResources()["LaunchTemplate"].LaunchTemplateData.ImageId = gfnt.NewString("ami-current")
+			template, err := stack.JSON()
+			Expect(err).NotTo(HaveOccurred())
+			fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{{NodeGroupName: ngName}}, nil)
+			fakeStackManager.GetManagedNodeGroupTemplateReturns(string(template), nil)
+			mockImages()
+		})
+
+		It("reports the resolved AMI and the launch template version that will be created", func() {
+			mockParameter("/golden-amis/1.30/AmazonLinux2023/x86_64", "ami-golden")
+
+			plan, err := m.PlanUpgrade(context.Background(), nodegroup.UpgradeOptions{NodegroupName: ngName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Current.AMI).To(Equal("ami-current"))
+			Expect(plan.Target.AMI).To(Equal("ami-golden"))
+			Expect(plan.LaunchTemplateVersion).To(Equal("5"))
+			Expect(plan.CreatesLaunchTemplateVersion).To(BeTrue())
+		})
+
+		It("does not allow a release version to be specified", func() {
+			_, err := m.PlanUpgrade(context.Background(), nodegroup.UpgradeOptions{
+				NodegroupName:  ngName,
+				ReleaseVersion: "1.30.4-20240917",
+			})
+			Expect(err).To(MatchError(ContainSubstring("cannot specify kubernetes-version or release-version when using a custom AMI")))
+		})
+	})
+})
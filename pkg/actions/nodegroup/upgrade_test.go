@@ -24,6 +24,8 @@ import (
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager/fakes"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/goformation"
+	gfnt "github.com/weaveworks/eksctl/pkg/goformation/cloudformation/types"
 	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
 	"github.com/weaveworks/eksctl/pkg/version"
 )
@@ -190,6 +192,95 @@ var _ = Describe("Upgrade", func() {
 	})
 
 	Context("the nodegroup does have a stack", func() {
+		When("it uses a custom AMI and amiResolution is set", func() {
+			var customAMITemplate string
+
+			BeforeEach(func() {
+				stack, err := goformation.ParseJSON([]byte(al2ForceFalseTemplate))
+				Expect(err).NotTo(HaveOccurred())
+				stack.GetAllEC2LaunchTemplateResources()["LaunchTemplate"].LaunchTemplateData.ImageId = gfnt.NewString("ami-old")
+				templateBody, err := stack.JSON()
+				Expect(err).NotTo(HaveOccurred())
+				customAMITemplate = string(templateBody)
+
+				cfg.AMIResolution = &api.AMIResolution{
+					SSMParameterPath: "/golden-amis/{{version}}/{{family}}/{{arch}}",
+				}
+				cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{{
+					NodeGroupBase: &api.NodeGroupBase{
+						Name:      ngName,
+						AMIFamily: api.NodeImageFamilyAmazonLinux2023,
+					},
+				}}
+				options.KubernetesVersion = ""
+
+				fakeStackManager.ListNodeGroupStacksWithStatusesReturns([]manager.NodeGroupStack{{NodeGroupName: ngName}}, nil)
+				fakeStackManager.GetManagedNodeGroupTemplateReturns(customAMITemplate, nil)
+				fakeStackManager.DescribeNodeGroupStackReturns(&manager.Stack{
+					Tags: []types.Tag{
+						{
+							Key:   aws.String(api.EksctlVersionTag),
+							Value: aws.String(version.GetVersion()),
+						},
+					},
+				}, nil)
+				fakeStackManager.UpdateNodeGroupStackReturns(nil)
+
+				p.MockEKS().On("DescribeNodegroup", mock.Anything, &awseks.DescribeNodegroupInput{
+					ClusterName:   aws.String(clusterName),
+					NodegroupName: aws.String(ngName),
+				}).Return(&awseks.DescribeNodegroupOutput{
+					Nodegroup: &ekstypes.Nodegroup{
+						NodegroupName: aws.String(ngName),
+						ClusterName:   aws.String(clusterName),
+						Status:        ekstypes.NodegroupStatusActive,
+						AmiType:       ekstypes.AMITypesCustom,
+						Version:       eksVersion,
+						InstanceTypes: []string{"m5.large"},
+					},
+				}, nil)
+			})
+
+			It("updates the launch template with the resolved AMI", func() {
+				p.MockSSM().On("GetParameter", mock.Anything, &ssm.GetParameterInput{
+					Name: aws.String(fmt.Sprintf("/golden-amis/%s/AmazonLinux2023/x86_64", *eksVersion)),
+				}).Return(&ssm.GetParameterOutput{
+					Parameter: &ssmtypes.Parameter{
+						Value: aws.String("ami-new"),
+					},
+				}, nil)
+
+				Expect(m.Upgrade(context.Background(), options)).To(Succeed())
+				Expect(fakeStackManager.UpdateNodeGroupStackCallCount()).To(Equal(1))
+				_, ng, template, _ := fakeStackManager.UpdateNodeGroupStackArgsForCall(0)
+				Expect(ng).To(Equal(ngName))
+				Expect(template).To(ContainSubstring(`"ImageId": "ami-new"`))
+				Expect(template).NotTo(ContainSubstring(`"ReleaseVersion"`))
+			})
+
+			It("returns an error if no AMI is found", func() {
+				p.MockSSM().On("GetParameter", mock.Anything, mock.Anything).Return(nil, &ssmtypes.ParameterNotFound{})
+
+				err := m.Upgrade(context.Background(), options)
+				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("unable to resolve a custom AMI for nodegroup %q", ngName))))
+				Expect(fakeStackManager.UpdateNodeGroupStackCallCount()).To(Equal(0))
+			})
+
+			It("returns an error if release version is specified", func() {
+				options.ReleaseVersion = *eksReleaseVersion
+				err := m.Upgrade(context.Background(), options)
+				Expect(err).To(MatchError(ContainSubstring("cannot specify kubernetes-version or release-version when using a custom AMI")))
+			})
+
+			It("returns an error if the nodegroup is not in the config file", func() {
+				cfg.ManagedNodeGroups = nil
+				err := m.Upgrade(context.Background(), options)
+				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("nodegroup %q must be defined in managedNodeGroups", ngName))))
+				Expect(fakeStackManager.UpdateNodeGroupStackCallCount()).To(Equal(0))
+				p.MockSSM().AssertNotCalled(GinkgoT(), "GetParameter", mock.Anything, mock.Anything)
+			})
+		})
+
 		When("ForceUpdateEnabled isn't set", func() {
 			When("it uses amazonlinux2", func() {
 				BeforeEach(func() {
@@ -0,0 +1,111 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+// The offline APIs below stub the AWS calls the resource set builders make, so that templates can be rendered
+// without credentials or network access. They embed the full API interfaces so they can be passed to the builders;
+// the builders only call the methods overridden here for configurations supported offline.
+
+func offlineError(operation string) error {
+	return fmt.Errorf("%s requires access to the AWS API and is not supported when generating templates offline", operation)
+}
+
+type offlineEC2 struct {
+	awsapi.EC2
+	availabilityZones []string
+}
+
+// DescribeInstanceTypeOfferings reports every requested instance type as available in every requested zone.
+func (o *offlineEC2) DescribeInstanceTypeOfferings(_ context.Context, input *ec2.DescribeInstanceTypeOfferingsInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	var instanceTypes, locations []string
+	for _, f := range input.Filters {
+		switch aws.ToString(f.Name) {
+		case "instance-type":
+			instanceTypes = f.Values
+		case "location":
+			locations = f.Values
+		}
+	}
+	out := &ec2.DescribeInstanceTypeOfferingsOutput{}
+	for _, location := range locations {
+		for _, instanceType := range instanceTypes {
+			out.InstanceTypeOfferings = append(out.InstanceTypeOfferings, ec2types.InstanceTypeOffering{
+				InstanceType: ec2types.InstanceType(instanceType),
+				Location:     aws.String(location),
+				LocationType: input.LocationType,
+			})
+		}
+	}
+	return out, nil
+}
+
+// DescribeVpcEndpointServices reports every requested endpoint service as available in all zones.
+func (o *offlineEC2) DescribeVpcEndpointServices(_ context.Context, input *ec2.DescribeVpcEndpointServicesInput, _ ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointServicesOutput, error) {
+	out := &ec2.DescribeVpcEndpointServicesOutput{}
+	for _, serviceName := range input.ServiceNames {
+		serviceType := ec2types.ServiceTypeInterface
+		if strings.HasSuffix(serviceName, ".s3") {
+			serviceType = ec2types.ServiceTypeGateway
+		}
+		out.ServiceDetails = append(out.ServiceDetails, ec2types.ServiceDetail{
+			ServiceName:       aws.String(serviceName),
+			ServiceType:       []ec2types.ServiceTypeDetail{{ServiceType: serviceType}},
+			AvailabilityZones: o.availabilityZones,
+		})
+	}
+	return out, nil
+}
+
+func (o *offlineEC2) DescribeVpcs(context.Context, *ec2.DescribeVpcsInput, ...func(*ec2.Options)) (*ec2.DescribeVpcsOutput, error) {
+	return nil, offlineError("importing an existing VPC")
+}
+
+func (o *offlineEC2) DescribeSubnets(context.Context, *ec2.DescribeSubnetsInput, ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	return nil, offlineError("importing existing subnets")
+}
+
+func (o *offlineEC2) DescribeRouteTables(context.Context, *ec2.DescribeRouteTablesInput, ...func(*ec2.Options)) (*ec2.DescribeRouteTablesOutput, error) {
+	return nil, offlineError("importing existing route tables")
+}
+
+func (o *offlineEC2) DescribeInstanceTypes(context.Context, *ec2.DescribeInstanceTypesInput, ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	return nil, offlineError("looking up instance types for EFA")
+}
+
+func (o *offlineEC2) DescribeLaunchTemplateVersions(context.Context, *ec2.DescribeLaunchTemplateVersionsInput, ...func(*ec2.Options)) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
+	return nil, offlineError("using an existing launch template")
+}
+
+type offlineIAM struct {
+	awsapi.IAM
+}
+
+func (o *offlineIAM) GetInstanceProfile(context.Context, *iam.GetInstanceProfileInput, ...func(*iam.Options)) (*iam.GetInstanceProfileOutput, error) {
+	return nil, offlineError("importing an existing instance profile")
+}
+
+type offlineSTS struct {
+	awsapi.STS
+	accountID string
+}
+
+// GetCallerIdentity returns an identity in the configured account.
+func (o *offlineSTS) GetCallerIdentity(context.Context, *sts.GetCallerIdentityInput, ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{
+		Account: aws.String(o.accountID),
+		Arn:     aws.String(fmt.Sprintf("arn:aws:iam::%s:root", o.accountID)),
+		UserId:  aws.String(o.accountID),
+	}, nil
+}
@@ -0,0 +1,355 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	iamoidc "github.com/weaveworks/eksctl/pkg/iam/oidc"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+	"github.com/weaveworks/eksctl/pkg/utils/nodes"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+const (
+	// DefaultAccountID is the account ID used in templates when none is supplied.
+	DefaultAccountID = "000000000000"
+
+	// userDataMIMEBoundary replaces the random MIME boundary used in multipart user data.
+	userDataMIMEBoundary = "//"
+
+	placeholderOIDCID           = "EXAMPLED539D4633E53DE1B71EXAMPLE"
+	placeholderServiceIPv4CIDR  = "10.100.0.0/16"
+	placeholderServiceIPv6CIDR  = "fd00:ec2::/108"
+	placeholderCertificateData  = "placeholder-certificate-authority-data"
+	placeholderEndpointHostName = "EXAMPLE0123456789ABCDEF"
+)
+
+// Options holds the values that would otherwise be looked up from AWS.
+type Options struct {
+	// AvailabilityZones overrides the availability zones in the config file.
+	AvailabilityZones []string
+	// NodeAMI is used for nodegroups that need an AMI ID and do not set one.
+	NodeAMI string
+	// AccountID is the AWS account ID used in ARNs.
+	AccountID string
+}
+
+// Template is a rendered CloudFormation template.
+type Template struct {
+	StackName string
+	Body      []byte
+}
+
+// Generator renders the CloudFormation templates eksctl deploys for a ClusterConfig without calling AWS.
+type Generator struct {
+	cfg     *api.ClusterConfig
+	options Options
+}
+
+// NewGenerator returns a new Generator. The ClusterConfig is expected to have been defaulted and validated,
+// and is modified to hold the values that eksctl would otherwise read back from AWS.
+func NewGenerator(cfg *api.ClusterConfig, options Options) *Generator {
+	if options.AccountID == "" {
+		options.AccountID = DefaultAccountID
+	}
+	return &Generator{
+		cfg:     cfg,
+		options: options,
+	}
+}
+
+// Generate renders the templates for the cluster, its nodegroups, IAM service accounts and Karpenter.
+func (g *Generator) Generate(ctx context.Context) ([]Template, error) {
+	if err := g.prepare(); err != nil {
+		return nil, err
+	}
+
+	cfg := g.cfg
+	region := cfg.Metadata.Region
+	ec2API := &offlineEC2{availabilityZones: cfg.AvailabilityZones}
+	iamAPI := &offlineIAM{}
+	stsAPI := &offlineSTS{accountID: g.options.AccountID}
+
+	var templates []Template
+	addTemplate := func(stackName string, rs builder.ResourceSetReader) error {
+		body, err := rs.RenderJSON()
+		if err != nil {
+			return fmt.Errorf("rendering template for stack %q: %w", stackName, err)
+		}
+		templates = append(templates, Template{StackName: stackName, Body: body})
+		return nil
+	}
+
+	clusterStackName := makeClusterStackName(cfg.Metadata.Name)
+	logger.Info("building cluster stack %q", clusterStackName)
+	clusterStack := builder.NewClusterResourceSet(ec2API, stsAPI, region, cfg, nil, false)
+	if err := clusterStack.AddAllResources(ctx); err != nil {
+		return nil, fmt.Errorf("building cluster stack: %w", err)
+	}
+	if err := addTemplate(clusterStackName, clusterStack); err != nil {
+		return nil, err
+	}
+
+	g.setClusterStatus()
+	vpcImporter := vpc.NewStackConfigImporter(clusterStackName)
+	disableAccessEntryCreation := cfg.AccessConfig.AuthenticationMode == ekstypes.AuthenticationModeConfigMap
+
+	for _, ng := range cfg.NodeGroups {
+		stackName := makeNodeGroupStackName(cfg.Metadata.Name, ng.Name)
+		logger.Info("building nodegroup stack %q", stackName)
+		bootstrapper, err := nodebootstrap.NewBootstrapper(cfg, ng)
+		if err != nil {
+			return nil, fmt.Errorf("error creating bootstrapper: %w", err)
+		}
+		setMIMEBoundary(bootstrapper)
+		stack := builder.NewNodeGroupResourceSet(ec2API, iamAPI, builder.NodeGroupOptions{
+			ClusterConfig:              cfg,
+			NodeGroup:                  ng,
+			Bootstrapper:               bootstrapper,
+			VPCImporter:                vpcImporter,
+			DisableAccessEntry:         disableAccessEntryCreation,
+			DisableAccessEntryResource: ng.IAM.InstanceRoleARN != "",
+		})
+		if err := stack.AddAllResources(ctx); err != nil {
+			return nil, fmt.Errorf("building nodegroup stack %q: %w", stackName, err)
+		}
+		if err := addTemplate(stackName, stack); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ng := range cfg.ManagedNodeGroups {
+		stackName := makeNodeGroupStackName(cfg.Metadata.Name, ng.Name)
+		logger.Info("building managed nodegroup stack %q", stackName)
+		bootstrapper, err := nodebootstrap.NewManagedBootstrapper(cfg, ng)
+		if err != nil {
+			return nil, err
+		}
+		setMIMEBoundary(bootstrapper)
+		stack := builder.NewManagedNodeGroup(ec2API, cfg, ng, builder.NewLaunchTemplateFetcher(ec2API), bootstrapper, false, vpcImporter)
+		if err := stack.AddAllResources(ctx); err != nil {
+			return nil, fmt.Errorf("building managed nodegroup stack %q: %w", stackName, err)
+		}
+		if err := addTemplate(stackName, stack); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(cfg.IAM.ServiceAccounts) > 0 {
+		oidc, err := g.newOIDCManager(iamAPI)
+		if err != nil {
+			return nil, err
+		}
+		for _, sa := range cfg.IAM.ServiceAccounts {
+			stackName := makeIAMServiceAccountStackName(cfg.Metadata.Name, sa.Namespace, sa.Name)
+			logger.Info("building iamserviceaccount stack %q", stackName)
+			stack := builder.NewIAMRoleResourceSetForServiceAccount(sa, oidc)
+			if err := stack.AddAllResources(); err != nil {
+				return nil, fmt.Errorf("building iamserviceaccount stack %q: %w", stackName, err)
+			}
+			if err := addTemplate(stackName, stack); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if cfg.Karpenter != nil {
+		stackName := makeKarpenterStackName(cfg.Metadata.Name)
+		logger.Info("building karpenter stack %q", stackName)
+		instanceProfileName := fmt.Sprintf("eksctl-%s-%s", builder.KarpenterNodeInstanceProfile, cfg.Metadata.Name)
+		if cfg.Karpenter.DefaultInstanceProfile != nil {
+			instanceProfileName = *cfg.Karpenter.DefaultInstanceProfile
+		}
+		stack := builder.NewKarpenterResourceSet(cfg, instanceProfileName)
+		if err := stack.AddAllResources(); err != nil {
+			return nil, fmt.Errorf("building karpenter stack %q: %w", stackName, err)
+		}
+		if err := addTemplate(stackName, stack); err != nil {
+			return nil, err
+		}
+	}
+
+	return templates, nil
+}
+
+// prepare applies the options and the defaults that eksctl would otherwise resolve by calling AWS.
+func (g *Generator) prepare() error {
+	cfg := g.cfg
+	switch cfg.Metadata.Version {
+	case "", "auto":
+		cfg.Metadata.Version = api.DefaultVersion
+	case "latest":
+		return errors.New(`metadata.version "latest" cannot be resolved offline; set an explicit Kubernetes version`)
+	}
+
+	if cfg.IsControlPlaneOnOutposts() {
+		return errors.New("templates for clusters on Outposts cannot be generated offline")
+	}
+	if cfg.HasAnySubnets() {
+		return errors.New("templates for clusters using an existing VPC cannot be generated offline as the VPC and subnets must be looked up; remove vpc.subnets to generate templates for a dedicated VPC")
+	}
+	if len(g.options.AvailabilityZones) > 0 {
+		cfg.AvailabilityZones = g.options.AvailabilityZones
+	}
+	if len(cfg.AvailabilityZones) == 0 {
+		return errors.New("availability zones must be set using availabilityZones in the config file or --zones")
+	}
+	if err := vpc.SetSubnets(cfg.VPC, cfg.AvailabilityZones, cfg.LocalZones); err != nil {
+		return err
+	}
+	if err := vpc.SetPodSubnets(cfg.VPC, cfg.AvailabilityZones); err != nil {
+		return err
+	}
+
+	for _, np := range nodes.ToNodePools(cfg) {
+		if err := g.prepareNodeGroup(np); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Generator) prepareNodeGroup(np api.NodePool) error {
+	ng := np.BaseNodeGroup()
+	if ng.InstanceSelector != nil && !ng.InstanceSelector.IsZero() {
+		return fmt.Errorf("nodegroup %q: instanceSelector cannot be resolved offline; set instanceType or instanceTypes", ng.Name)
+	}
+	if ng.SSH != nil && ng.SSH.PublicKeyPath != nil && *ng.SSH.PublicKeyPath != "" {
+		return fmt.Errorf("nodegroup %q: ssh.publicKeyPath requires importing the key into EC2; set ssh.publicKeyName instead", ng.Name)
+	}
+
+	needsAMI := false
+	switch ng := np.(type) {
+	case *api.ManagedNodeGroup:
+		if ng.LaunchTemplate != nil {
+			return fmt.Errorf("managed nodegroup %q: launchTemplate cannot be looked up offline", ng.Name)
+		}
+		if ng.InstanceType == "" && len(ng.InstanceTypes) == 0 {
+			ng.InstanceType = api.DefaultNodeType
+		}
+		hasNativeAMIFamilySupport := ng.AMIFamily == api.NodeImageFamilyAmazonLinux2023 ||
+			ng.AMIFamily == api.NodeImageFamilyAmazonLinux2 ||
+			ng.AMIFamily == api.NodeImageFamilyBottlerocket ||
+			api.IsWindowsImage(ng.AMIFamily)
+		needsAMI = !hasNativeAMIFamilySupport
+	case *api.NodeGroup:
+		if ng.InstanceType == "" {
+			if api.HasMixedInstances(ng) {
+				ng.InstanceType = "mixed"
+			} else {
+				ng.InstanceType = api.DefaultNodeType
+			}
+		}
+		needsAMI = true
+	}
+
+	if needsAMI && !api.IsAMI(ng.AMI) {
+		if g.options.NodeAMI == "" {
+			return fmt.Errorf("nodegroup %q: an AMI ID must be set using ami in the config file or --node-ami", ng.Name)
+		}
+		ng.AMI = g.options.NodeAMI
+	}
+	return nil
+}
+
+// setClusterStatus sets placeholders for the values eksctl reads from the cluster stack and the EKS API
+// before creating nodegroups.
+func (g *Generator) setClusterStatus() {
+	cfg := g.cfg
+	networkConfig := &api.KubernetesNetworkConfig{}
+	if cfg.IPv6Enabled() {
+		networkConfig.ServiceIPv6CIDR = placeholderServiceIPv6CIDR
+	} else {
+		networkConfig.ServiceIPv4CIDR = placeholderServiceIPv4CIDR
+		if cfg.KubernetesNetworkConfig != nil && cfg.KubernetesNetworkConfig.ServiceIPv4CIDR != "" {
+			networkConfig.ServiceIPv4CIDR = cfg.KubernetesNetworkConfig.ServiceIPv4CIDR
+		}
+	}
+	cfg.Status = &api.ClusterStatus{
+		Endpoint:                 fmt.Sprintf("https://%s.gr7.%s.eks.amazonaws.com", placeholderEndpointHostName, cfg.Metadata.Region),
+		CertificateAuthorityData: []byte(placeholderCertificateData),
+		KubernetesNetworkConfig:  networkConfig,
+	}
+
+	setSubnetIDs := func(topology string, subnets api.AZSubnetMapping) {
+		for alias, subnet := range subnets {
+			subnet.ID = fmt.Sprintf("subnet-%s-%s", strings.ToLower(topology), alias)
+			subnets[alias] = subnet
+		}
+	}
+	if cfg.VPC.Subnets != nil {
+		setSubnetIDs(string(api.SubnetTopologyPrivate), cfg.VPC.Subnets.Private)
+		setSubnetIDs(string(api.SubnetTopologyPublic), cfg.VPC.Subnets.Public)
+	}
+	if cfg.VPC.LocalZoneSubnets != nil {
+		setSubnetIDs(string(api.SubnetTopologyPrivate), cfg.VPC.LocalZoneSubnets.Private)
+		setSubnetIDs(string(api.SubnetTopologyPublic), cfg.VPC.LocalZoneSubnets.Public)
+	}
+}
+
+func (g *Generator) newOIDCManager(iamAPI *offlineIAM) (*iamoidc.OpenIDConnectManager, error) {
+	region := g.cfg.Metadata.Region
+	partition := api.Partitions.ForRegion(region)
+	issuer := fmt.Sprintf("https://oidc.eks.%s.amazonaws.com/id/%s", region, placeholderOIDCID)
+	oidc, err := iamoidc.NewOpenIDConnectManager(iamAPI, g.options.AccountID, issuer, partition, nil)
+	if err != nil {
+		return nil, err
+	}
+	issuerURL, err := url.Parse(issuer)
+	if err != nil {
+		return nil, err
+	}
+	oidc.ProviderARN = fmt.Sprintf("arn:%s:iam::%s:oidc-provider/%s%s", partition, g.options.AccountID, issuerURL.Host, issuerURL.Path)
+	return oidc, nil
+}
+
+func setMIMEBoundary(bootstrapper nodebootstrap.Bootstrapper) {
+	switch b := bootstrapper.(type) {
+	case *nodebootstrap.AL2023:
+		b.UserDataMimeBoundary = userDataMIMEBoundary
+	case *nodebootstrap.ManagedAL2:
+		b.UserDataMimeBoundary = userDataMIMEBoundary
+	}
+}
+
+// WriteTemplates writes each template to <dir>/<stack name>.json, creating dir if necessary.
+func WriteTemplates(dir string, templates []Template) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	for _, t := range templates {
+		path := filepath.Join(dir, t.StackName+".json")
+		if err := os.WriteFile(path, append(slices.Clone(t.Body), '\n'), 0o644); err != nil {
+			return fmt.Errorf("writing template for stack %q: %w", t.StackName, err)
+		}
+		logger.Info("wrote template for stack %q to %q", t.StackName, path)
+	}
+	return nil
+}
+
+func makeClusterStackName(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-cluster", clusterName)
+}
+
+func makeNodeGroupStackName(clusterName, ngName string) string {
+	return fmt.Sprintf("eksctl-%s-nodegroup-%s", clusterName, ngName)
+}
+
+func makeIAMServiceAccountStackName(clusterName, namespace, name string) string {
+	return fmt.Sprintf("eksctl-%s-addon-iamserviceaccount-%s-%s", clusterName, namespace, name)
+}
+
+func makeKarpenterStackName(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-karpenter", clusterName)
+}
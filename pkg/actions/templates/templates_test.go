@@ -0,0 +1,120 @@
+package templates_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/templates"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Generator", func() {
+	newClusterConfig := func() *api.ClusterConfig {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		cfg.Metadata.Tags = map[string]string{"team": "platform", "env": "test", "cost-center": "1234"}
+		cfg.IAM.WithOIDC = api.Enabled()
+		cfg.IAM.ServiceAccounts = []*api.ClusterIAMServiceAccount{{
+			ClusterIAMMeta:   api.ClusterIAMMeta{Name: "s3-reader", Namespace: "default"},
+			AttachPolicyARNs: []string{"arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"},
+		}}
+		cfg.NodeGroups = []*api.NodeGroup{{
+			NodeGroupBase: &api.NodeGroupBase{
+				Name:              "ng-1",
+				PrivateNetworking: true,
+				Labels:            map[string]string{"role": "worker", "tier": "backend", "zone": "a"},
+			},
+		}}
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{{
+			NodeGroupBase: &api.NodeGroupBase{
+				Name:                 "mng-1",
+				PreBootstrapCommands: []string{"echo hello"},
+			},
+		}}
+		cfg.Karpenter = &api.Karpenter{Version: "1.2.1"}
+
+		api.SetClusterEndpointAccessDefaults(cfg.VPC)
+		api.SetClusterConfigDefaults(cfg)
+		Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+		for i, ng := range cfg.NodeGroups {
+			Expect(api.ValidateNodeGroup(i, ng, cfg)).To(Succeed())
+			api.SetNodeGroupDefaults(ng, cfg.Metadata, false)
+		}
+		for i, ng := range cfg.ManagedNodeGroups {
+			api.SetManagedNodeGroupDefaults(ng, cfg.Metadata, false)
+			Expect(api.ValidateManagedNodeGroup(i, ng)).To(Succeed())
+		}
+		return cfg
+	}
+
+	options := templates.Options{
+		AvailabilityZones: []string{"us-west-2a", "us-west-2b", "us-west-2c"},
+		NodeAMI:           "ami-0123456789abcdef0",
+	}
+
+	It("renders a template for each stack", func() {
+		cfg := newClusterConfig()
+		generated, err := templates.NewGenerator(cfg, options).Generate(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		var stackNames []string
+		for _, t := range generated {
+			stackNames = append(stackNames, t.StackName)
+			Expect(t.Body).NotTo(BeEmpty())
+		}
+		Expect(stackNames).To(Equal([]string{
+			"eksctl-my-cluster-cluster",
+			"eksctl-my-cluster-nodegroup-ng-1",
+			"eksctl-my-cluster-nodegroup-mng-1",
+			"eksctl-my-cluster-addon-iamserviceaccount-default-s3-reader",
+			"eksctl-my-cluster-karpenter",
+		}))
+		Expect(string(generated[1].Body)).To(ContainSubstring("ami-0123456789abcdef0"))
+		Expect(string(generated[1].Body)).To(ContainSubstring("subnet-private-us-west-2a"))
+		Expect(string(generated[3].Body)).To(ContainSubstring("arn:aws:iam::000000000000:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/"))
+	})
+
+	It("renders identical templates on every run", func() {
+		first, err := templates.NewGenerator(newClusterConfig(), options).Generate(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		for range 5 {
+			next, err := templates.NewGenerator(newClusterConfig(), options).Generate(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(HaveLen(len(first)))
+			for i := range first {
+				Expect(next[i].StackName).To(Equal(first[i].StackName))
+				Expect(string(next[i].Body)).To(Equal(string(first[i].Body)))
+			}
+		}
+	})
+
+	It("fails for configurations that require AWS lookups", func() {
+		cfg := newClusterConfig()
+		_, err := templates.NewGenerator(cfg, templates.Options{}).Generate(context.Background())
+		Expect(err).To(MatchError(ContainSubstring("availability zones must be set")))
+
+		cfg = newClusterConfig()
+		_, err = templates.NewGenerator(cfg, templates.Options{AvailabilityZones: options.AvailabilityZones}).Generate(context.Background())
+		Expect(err).To(MatchError(ContainSubstring(`nodegroup "ng-1": an AMI ID must be set`)))
+
+		cfg = newClusterConfig()
+		cfg.Metadata.Version = "latest"
+		_, err = templates.NewGenerator(cfg, options).Generate(context.Background())
+		Expect(err).To(MatchError(ContainSubstring("cannot be resolved offline")))
+	})
+
+	It("writes templates to a directory", func() {
+		dir := filepath.Join(GinkgoT().TempDir(), "templates")
+		Expect(templates.WriteTemplates(dir, []templates.Template{
+			{StackName: "eksctl-my-cluster-cluster", Body: []byte(`{}`)},
+		})).To(Succeed())
+		body, err := os.ReadFile(filepath.Join(dir, "eksctl-my-cluster-cluster.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal("{}\n"))
+	})
+})
@@ -0,0 +1,568 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	callerIdentityDataSource = "aws_caller_identity"
+	partitionDataSource      = "aws_partition"
+)
+
+var invalidNameCharsRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// cfnTemplate is a CloudFormation template rendered by the resource set builders.
+type cfnTemplate struct {
+	Resources map[string]cfnResource                  `json:"Resources"`
+	Outputs   map[string]cfnOutput                    `json:"Outputs"`
+	Mappings  map[string]map[string]map[string]string `json:"Mappings"`
+}
+
+type cfnResource struct {
+	Type       string         `json:"Type"`
+	Properties map[string]any `json:"Properties"`
+	DependsOn  any            `json:"DependsOn"`
+}
+
+type cfnOutput struct {
+	Value any `json:"Value"`
+}
+
+// stack is a CloudFormation stack whose resources are translated to Terraform resources.
+type stack struct {
+	name string
+	// prefix is prepended to the names of the Terraform resources of the stack to keep them unique.
+	prefix   string
+	template cfnTemplate
+}
+
+func newStack(name, prefix string, body []byte) (*stack, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	s := &stack{
+		name:   name,
+		prefix: prefix,
+	}
+	if err := decoder.Decode(&s.template); err != nil {
+		return nil, fmt.Errorf("parsing template of stack %q: %w", name, err)
+	}
+	return s, nil
+}
+
+// logicalIDs returns the logical IDs of the resources in the stack, in the order they are written.
+func (s *stack) logicalIDs() []string {
+	var ids []string
+	for id := range s.template.Resources {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		ri, rj := resourceOrder(s.template.Resources[ids[i]].Type), resourceOrder(s.template.Resources[ids[j]].Type)
+		if ri != rj {
+			return ri < rj
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// resourceName returns the Terraform name of the resource with the given logical ID.
+func (s *stack) resourceName(logicalID string) string {
+	return resourceName(s.prefix + toSnakeCase(logicalID))
+}
+
+// address returns the address of the Terraform resource translated from the resource with the given logical ID.
+func (s *stack) address(logicalID string) (string, error) {
+	r, ok := s.template.Resources[logicalID]
+	if !ok {
+		return "", fmt.Errorf("resource %q not found in stack %q", logicalID, s.name)
+	}
+	spec, ok := resourceSpecs[r.Type]
+	if !ok {
+		return "", fmt.Errorf("resource %q of stack %q has unsupported type %s", logicalID, s.name, r.Type)
+	}
+	return spec.resourceType + "." + s.resourceName(logicalID), nil
+}
+
+// converter translates CloudFormation templates to Terraform resources.
+type converter struct {
+	clusterConfig *api.ClusterConfig
+	partition     string
+	clusterStack  *stack
+	// clusterName references the name of the EKS cluster, used in place of its literal name to order resources.
+	clusterName expression
+	// subnetIDs maps the placeholder IDs of the subnets of the cluster to references to their Terraform resources.
+	subnetIDs map[string]expression
+	// nodeGroupDependencies are the addons that eksctl creates before nodegroups.
+	nodeGroupDependencies tuple
+	dataSources           map[string]bool
+	addresses             map[string]bool
+	blocks                []*block
+}
+
+func newConverter(clusterConfig *api.ClusterConfig) *converter {
+	return &converter{
+		clusterConfig: clusterConfig,
+		partition:     api.Partitions.ForRegion(clusterConfig.Metadata.Region),
+		subnetIDs:     map[string]expression{},
+		dataSources:   map[string]bool{},
+		addresses:     map[string]bool{},
+	}
+}
+
+// addResource adds a resource block, ensuring its address is unique.
+func (c *converter) addResource(resourceType, name string) (*block, error) {
+	address := resourceType + "." + name
+	if c.addresses[address] {
+		return nil, fmt.Errorf("more than one resource would be named %s", address)
+	}
+	c.addresses[address] = true
+	b := newBlock("resource", resourceType, name)
+	c.blocks = append(c.blocks, b)
+	return b, nil
+}
+
+// convertStack translates all resources in the stack.
+func (c *converter) convertStack(s *stack) error {
+	for _, logicalID := range s.logicalIDs() {
+		if err := c.convertResource(s, logicalID); err != nil {
+			return fmt.Errorf("translating resource %q of stack %q: %w", logicalID, s.name, err)
+		}
+	}
+	return nil
+}
+
+func (c *converter) convertResource(s *stack, logicalID string) error {
+	r := s.template.Resources[logicalID]
+	spec, ok := resourceSpecs[r.Type]
+	if !ok {
+		return fmt.Errorf("resources of type %s cannot be exported to Terraform", r.Type)
+	}
+	b, err := c.addResource(spec.resourceType, s.resourceName(logicalID))
+	if err != nil {
+		return err
+	}
+	res := &resource{
+		stack:     s,
+		logicalID: logicalID,
+		name:      s.resourceName(logicalID),
+	}
+	res.attributes, res.blocks, err = c.collectFields(s, spec.fields, r.Properties)
+	if err != nil {
+		return err
+	}
+	if spec.finish != nil {
+		if err := spec.finish(c, res, r.Properties); err != nil {
+			return err
+		}
+	}
+	writeBody(b.body, res.attributes, res.blocks)
+	return c.convertDependsOn(s, b, r.DependsOn, res.dependsOn)
+}
+
+// convertDependsOn translates DependsOn, adding the dependencies of the resource that have no CloudFormation
+// equivalent.
+func (c *converter) convertDependsOn(s *stack, b *block, dependsOn any, dependencies tuple) error {
+	var logicalIDs []string
+	switch d := dependsOn.(type) {
+	case nil:
+	case string:
+		logicalIDs = []string{d}
+	case []any:
+		for _, id := range d {
+			logicalIDs = append(logicalIDs, fmt.Sprint(id))
+		}
+	default:
+		return fmt.Errorf("unexpected DependsOn %v", dependsOn)
+	}
+	var addresses tuple
+	for _, id := range logicalIDs {
+		address, err := s.address(id)
+		if err != nil {
+			return err
+		}
+		addresses = append(addresses, traversal(address))
+	}
+	setDependsOn(b, append(addresses, dependencies...))
+	return nil
+}
+
+// setDependsOn sets depends_on after the other arguments, as is customary for meta-arguments.
+func setDependsOn(b *block, addresses tuple) {
+	if len(addresses) == 0 {
+		return
+	}
+	if !b.body.isEmpty() {
+		if _, ok := b.body.items[len(b.body.items)-1].(*block); !ok {
+			b.body.appendBlankLine()
+		}
+	}
+	b.body.setAttribute("depends_on", addresses)
+}
+
+// value translates a property value, resolving intrinsic functions. A nil expression is returned for AWS::NoValue.
+func (c *converter) value(s *stack, v any) (expression, error) {
+	switch v := v.(type) {
+	case string:
+		if ref, ok := c.subnetIDs[v]; ok {
+			return ref, nil
+		}
+		return literal{value: v}, nil
+	case json.Number, bool:
+		return literal{value: v}, nil
+	case []any:
+		var items tuple
+		for _, item := range v {
+			e, err := c.value(s, item)
+			if err != nil {
+				return nil, err
+			}
+			if e != nil {
+				items = append(items, e)
+			}
+		}
+		return items, nil
+	case map[string]any:
+		if len(v) == 1 {
+			for fn, args := range v {
+				if fn == "Ref" || strings.HasPrefix(fn, "Fn::") {
+					return c.intrinsic(s, fn, args)
+				}
+			}
+		}
+		return c.object(s, v)
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected value %v", v)
+	}
+}
+
+// object translates a JSON object, sorting its keys.
+func (c *converter) object(s *stack, v map[string]any) (object, error) {
+	var keys []string
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var o object
+	for _, key := range keys {
+		e, err := c.value(s, v[key])
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			o = append(o, objectItem{key: key, value: e})
+		}
+	}
+	return o, nil
+}
+
+func (c *converter) intrinsic(s *stack, fn string, args any) (expression, error) {
+	switch fn {
+	case "Ref":
+		name, ok := args.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected Ref %v", args)
+		}
+		return c.ref(s, name)
+
+	case "Fn::GetAtt":
+		var logicalID, attribute string
+		switch a := args.(type) {
+		case string:
+			logicalID, attribute, _ = strings.Cut(a, ".")
+		case []any:
+			if len(a) == 2 {
+				logicalID, attribute = fmt.Sprint(a[0]), fmt.Sprint(a[1])
+			}
+		}
+		if logicalID == "" || attribute == "" {
+			return nil, fmt.Errorf("unexpected Fn::GetAtt %v", args)
+		}
+		return c.getAtt(s, logicalID, attribute)
+
+	case "Fn::Sub":
+		return c.sub(s, args)
+
+	case "Fn::Join":
+		a, ok := args.([]any)
+		if !ok || len(a) != 2 {
+			return nil, fmt.Errorf("unexpected Fn::Join %v", args)
+		}
+		list, err := c.value(s, a[1])
+		if err != nil {
+			return nil, err
+		}
+		if items, ok := list.(tuple); ok {
+			if values, ok := literalStrings(items); ok {
+				return literal{value: strings.Join(values, fmt.Sprint(a[0]))}, nil
+			}
+		}
+		return functionCall{name: "join", args: []expression{literal{value: fmt.Sprint(a[0])}, list}}, nil
+
+	case "Fn::Select":
+		a, ok := args.([]any)
+		if !ok || len(a) != 2 {
+			return nil, fmt.Errorf("unexpected Fn::Select %v", args)
+		}
+		list, err := c.value(s, a[1])
+		if err != nil {
+			return nil, err
+		}
+		var index int
+		if _, err := fmt.Sscan(fmt.Sprint(a[0]), &index); err != nil {
+			return nil, fmt.Errorf("unexpected Fn::Select index %v", a[0])
+		}
+		if items, ok := list.(tuple); ok && index < len(items) {
+			return items[index], nil
+		}
+		return functionCall{name: "element", args: []expression{list, literal{value: index}}}, nil
+
+	case "Fn::Split":
+		a, ok := args.([]any)
+		if !ok || len(a) != 2 {
+			return nil, fmt.Errorf("unexpected Fn::Split %v", args)
+		}
+		source, err := c.value(s, a[1])
+		if err != nil {
+			return nil, err
+		}
+		return functionCall{name: "split", args: []expression{literal{value: fmt.Sprint(a[0])}, source}}, nil
+
+	case "Fn::Base64":
+		source, err := c.value(s, args)
+		if err != nil {
+			return nil, err
+		}
+		return functionCall{name: "base64encode", args: []expression{source}}, nil
+
+	case "Fn::FindInMap":
+		a, ok := args.([]any)
+		if !ok || len(a) != 3 {
+			return nil, fmt.Errorf("unexpected Fn::FindInMap %v", args)
+		}
+		var keys []string
+		for _, arg := range a {
+			key, err := c.staticString(s, arg)
+			if err != nil {
+				return nil, fmt.Errorf("Fn::FindInMap: %w", err)
+			}
+			keys = append(keys, key)
+		}
+		value, ok := s.template.Mappings[keys[0]][keys[1]][keys[2]]
+		if !ok {
+			return nil, fmt.Errorf("no value found in mapping %s for %s and %s", keys[0], keys[1], keys[2])
+		}
+		return literal{value: value}, nil
+
+	case "Fn::ImportValue":
+		exportName, err := c.staticString(s, args)
+		if err != nil {
+			return nil, fmt.Errorf("Fn::ImportValue: %w", err)
+		}
+		return c.importValue(exportName)
+
+	default:
+		return nil, fmt.Errorf("intrinsic function %s cannot be exported to Terraform", fn)
+	}
+}
+
+// ref resolves a Ref to a pseudo parameter or resource.
+func (c *converter) ref(s *stack, name string) (expression, error) {
+	switch name {
+	case "AWS::StackName":
+		return literal{value: s.name}, nil
+	case "AWS::Region":
+		return literal{value: c.clusterConfig.Metadata.Region}, nil
+	case "AWS::Partition":
+		return literal{value: c.partition}, nil
+	case "AWS::URLSuffix":
+		c.dataSources[partitionDataSource] = true
+		return traversal("data." + partitionDataSource + ".current.dns_suffix"), nil
+	case "AWS::AccountId":
+		c.dataSources[callerIdentityDataSource] = true
+		return traversal("data." + callerIdentityDataSource + ".current.account_id"), nil
+	case "AWS::NoValue":
+		return nil, nil
+	}
+	address, err := s.address(name)
+	if err != nil {
+		return nil, err
+	}
+	spec := resourceSpecs[s.template.Resources[name].Type]
+	return traversal(address + "." + spec.ref), nil
+}
+
+func (c *converter) getAtt(s *stack, logicalID, attribute string) (expression, error) {
+	address, err := s.address(logicalID)
+	if err != nil {
+		return nil, err
+	}
+	r := s.template.Resources[logicalID]
+	tfAttribute, ok := resourceSpecs[r.Type].attributes[attribute]
+	if !ok {
+		return nil, fmt.Errorf("attribute %s of %s cannot be exported to Terraform", attribute, r.Type)
+	}
+	return traversal(address + "." + tfAttribute), nil
+}
+
+// sub translates Fn::Sub to a string template.
+func (c *converter) sub(s *stack, args any) (expression, error) {
+	var (
+		source    string
+		variables map[string]any
+	)
+	switch a := args.(type) {
+	case string:
+		source = a
+	case []any:
+		if len(a) != 2 {
+			return nil, fmt.Errorf("unexpected Fn::Sub %v", args)
+		}
+		source = fmt.Sprint(a[0])
+		variables, _ = a[1].(map[string]any)
+	default:
+		return nil, fmt.Errorf("unexpected Fn::Sub %v", args)
+	}
+
+	var parts template
+	addLiteral := func(value string) {
+		if value == "" {
+			return
+		}
+		if n := len(parts); n > 0 {
+			if l, ok := parts[n-1].(literal); ok {
+				parts[n-1] = literal{value: l.value.(string) + value}
+				return
+			}
+		}
+		parts = append(parts, literal{value: value})
+	}
+	for source != "" {
+		start := strings.Index(source, "${")
+		if start < 0 {
+			addLiteral(source)
+			break
+		}
+		addLiteral(source[:start])
+		end := strings.Index(source[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated variable in Fn::Sub %q", source)
+		}
+		name := source[start+2 : start+end]
+		source = source[start+end+1:]
+
+		var (
+			e   expression
+			err error
+		)
+		switch {
+		case strings.HasPrefix(name, "!"):
+			addLiteral("${" + name[1:] + "}")
+			continue
+		case variables[name] != nil:
+			e, err = c.value(s, variables[name])
+		case strings.Contains(name, ".") && !strings.HasPrefix(name, "AWS::"):
+			logicalID, attribute, _ := strings.Cut(name, ".")
+			e, err = c.getAtt(s, logicalID, attribute)
+		default:
+			e, err = c.ref(s, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if l, ok := e.(literal); ok {
+			addLiteral(fmt.Sprint(l.value))
+			continue
+		}
+		parts = append(parts, e)
+	}
+
+	switch {
+	case len(parts) == 0:
+		return literal{value: ""}, nil
+	case len(parts) == 1:
+		if _, ok := parts[0].(literal); ok {
+			return parts[0], nil
+		}
+	}
+	return parts, nil
+}
+
+// importValue resolves an export of the cluster stack to the translated value of the output.
+func (c *converter) importValue(exportName string) (expression, error) {
+	stackName, outputName, ok := strings.Cut(exportName, "::")
+	if !ok || c.clusterStack == nil || stackName != c.clusterStack.name {
+		return nil, fmt.Errorf("export %q is not an output of the cluster stack", exportName)
+	}
+	output, ok := c.clusterStack.template.Outputs[outputName]
+	if !ok {
+		return nil, fmt.Errorf("output %q not found in stack %q", outputName, stackName)
+	}
+	return c.value(c.clusterStack, output.Value)
+}
+
+// staticString translates a value that must resolve to a string known without creating any resources.
+func (c *converter) staticString(s *stack, v any) (string, error) {
+	e, err := c.value(s, v)
+	if err != nil {
+		return "", err
+	}
+	if l, ok := e.(literal); ok {
+		if value, ok := l.value.(string); ok {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("value %v cannot be resolved offline", v)
+}
+
+func literalStrings(items tuple) ([]string, bool) {
+	var values []string
+	for _, item := range items {
+		l, ok := item.(literal)
+		if !ok {
+			return nil, false
+		}
+		value, ok := l.value.(string)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// toSnakeCase converts a CloudFormation logical ID or property name to snake case, keeping acronyms together,
+// e.g. SubnetPublicUSWEST2A becomes subnet_public_uswest2a.
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// resourceName returns a valid Terraform resource name, replacing dashes and other invalid characters with underscores.
+func resourceName(name string) string {
+	name = invalidNameCharsRegexp.ReplaceAllString(name, "_")
+	if name == "" || !(unicode.IsLetter(rune(name[0])) || name[0] == '_') {
+		name = "_" + name
+	}
+	return name
+}
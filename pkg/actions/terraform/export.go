@@ -0,0 +1,319 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	"github.com/weaveworks/eksctl/pkg/actions/addon"
+	"github.com/weaveworks/eksctl/pkg/actions/templates"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+const (
+	// providerSource and providerVersion set the AWS provider required by the exported configuration; 5.81 is the
+	// first version supporting EKS Auto Mode and hybrid nodes.
+	providerSource  = "hashicorp/aws"
+	providerVersion = ">= 5.81"
+)
+
+// nodeAgentAddons are the addons eksctl creates before nodegroups that become active without nodes. eksctl does not
+// wait for the other addons it creates before nodegroups, but Terraform waits for addons to become active, so they
+// are created after nodegroups instead.
+var nodeAgentAddons = map[string]bool{
+	api.VPCCNIAddon:           true,
+	api.KubeProxyAddon:        true,
+	api.PodIdentityAgentAddon: true,
+}
+
+// An Exporter translates a ClusterConfig to a Terraform configuration, using the CloudFormation templates eksctl
+// would deploy for it as the source of truth.
+type Exporter struct {
+	cfg     *api.ClusterConfig
+	options templates.Options
+}
+
+// NewExporter returns a new Exporter. Like templates.NewGenerator, it expects a defaulted and validated ClusterConfig,
+// which it modifies.
+func NewExporter(cfg *api.ClusterConfig, options templates.Options) *Exporter {
+	return &Exporter{
+		cfg:     cfg,
+		options: options,
+	}
+}
+
+// Export returns the Terraform configuration for the cluster, its managed nodegroups, addons and access entries.
+// Resources that cannot be translated are skipped with a warning.
+func (e *Exporter) Export(ctx context.Context) ([]byte, error) {
+	cfg := e.cfg
+	if cfg.IPv6Enabled() {
+		return nil, errors.New("clusters using IPv6 cannot be exported to Terraform")
+	}
+	if api.IsEnabled(cfg.VPC.AutoAllocateIPv6) {
+		return nil, errors.New("vpc.autoAllocateIPv6 cannot be exported to Terraform")
+	}
+	e.removeUnsupported()
+
+	generated, err := templates.NewGenerator(cfg, e.options).Generate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(generated) != 1+len(cfg.ManagedNodeGroups) {
+		return nil, fmt.Errorf("expected %d templates, got %d", 1+len(cfg.ManagedNodeGroups), len(generated))
+	}
+
+	c := newConverter(cfg)
+	clusterStack, err := newStack(generated[0].StackName, "", generated[0].Body)
+	if err != nil {
+		return nil, err
+	}
+	c.clusterStack = clusterStack
+	if err := c.setClusterReferences(); err != nil {
+		return nil, err
+	}
+	if err := c.convertStack(clusterStack); err != nil {
+		return nil, err
+	}
+
+	var preNodeGroupAddons, postNodeGroupAddons []*api.Addon
+	addons, _ := addon.WithDefaultAddons(cfg, cfg.Metadata.Region)
+	for _, a := range addons {
+		if addonInfo, ok := api.KnownAddons[a.Name]; ok && addonInfo.CreateBeforeNodeGroup && nodeAgentAddons[a.Name] {
+			preNodeGroupAddons = append(preNodeGroupAddons, a)
+		} else {
+			postNodeGroupAddons = append(postNodeGroupAddons, a)
+		}
+	}
+	if c.nodeGroupDependencies, err = c.convertAddons(preNodeGroupAddons, nil); err != nil {
+		return nil, err
+	}
+
+	var nodeGroups tuple
+	for i, ng := range cfg.ManagedNodeGroups {
+		t := generated[1+i]
+		if !strings.HasSuffix(t.StackName, "-"+ng.Name) {
+			return nil, fmt.Errorf("unexpected stack %q for managed nodegroup %q", t.StackName, ng.Name)
+		}
+		s, err := newStack(t.StackName, toSnakeCase(ng.Name)+"_", t.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.convertStack(s); err != nil {
+			return nil, err
+		}
+		for _, logicalID := range s.logicalIDs() {
+			if s.template.Resources[logicalID].Type == "AWS::EKS::Nodegroup" {
+				address, _ := s.address(logicalID)
+				nodeGroups = append(nodeGroups, traversal(address))
+			}
+		}
+	}
+
+	for _, accessEntry := range cfg.AccessConfig.AccessEntries {
+		if err := c.convertAccessEntry(accessEntry); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := c.convertAddons(postNodeGroupAddons, nodeGroups); err != nil {
+		return nil, err
+	}
+	return c.file().Bytes(), nil
+}
+
+// removeUnsupported removes, with a warning, the parts of the config that have no Terraform equivalent in the
+// resources the exporter writes.
+func (e *Exporter) removeUnsupported() {
+	cfg := e.cfg
+	if len(cfg.NodeGroups) > 0 {
+		logger.Warning("skipping %d unmanaged nodegroup(s); only managed nodegroups are exported to Terraform", len(cfg.NodeGroups))
+		cfg.NodeGroups = nil
+	}
+	if len(cfg.FargateProfiles) > 0 {
+		logger.Warning("skipping %d Fargate profile(s), which are not exported to Terraform", len(cfg.FargateProfiles))
+		cfg.FargateProfiles = nil
+	}
+	if cfg.Karpenter != nil {
+		logger.Warning("skipping Karpenter, which is not exported to Terraform")
+		cfg.Karpenter = nil
+	}
+	if cfg.IAM != nil {
+		if len(cfg.IAM.ServiceAccounts) > 0 {
+			logger.Warning("skipping %d IAM service account(s), which are not exported to Terraform", len(cfg.IAM.ServiceAccounts))
+			cfg.IAM.ServiceAccounts = nil
+		}
+		if api.IsEnabled(cfg.IAM.WithOIDC) {
+			// without an IAM OIDC provider, eksctl attaches the policy needed by the VPC CNI to the node roles
+			logger.Warning("skipping the IAM OIDC provider, which is not exported to Terraform; nodegroup roles are granted the permissions of the VPC CNI instead")
+			cfg.IAM.WithOIDC = api.Disabled()
+		}
+	}
+}
+
+// setClusterReferences sets the references to the cluster and its subnets used in place of the literal values
+// eksctl renders in nodegroup templates.
+func (c *converter) setClusterReferences() error {
+	s := c.clusterStack
+	for _, logicalID := range s.logicalIDs() {
+		r := s.template.Resources[logicalID]
+		switch r.Type {
+		case "AWS::EKS::Cluster":
+			address, err := s.address(logicalID)
+			if err != nil {
+				return err
+			}
+			c.clusterName = traversal(address + ".name")
+		case "AWS::EC2::Subnet":
+			availabilityZone, _ := r.Properties["AvailabilityZone"].(string)
+			cidrBlock, _ := r.Properties["CidrBlock"].(string)
+			subnetID := c.placeholderSubnetID(availabilityZone, cidrBlock)
+			if subnetID == "" {
+				continue
+			}
+			ref, err := c.ref(s, logicalID)
+			if err != nil {
+				return err
+			}
+			c.subnetIDs[subnetID] = ref
+		}
+	}
+	if c.clusterName == nil {
+		return fmt.Errorf("no EKS cluster found in stack %q", s.name)
+	}
+	return nil
+}
+
+// placeholderSubnetID returns the placeholder ID set for the subnet in the given availability zone and CIDR block.
+func (c *converter) placeholderSubnetID(availabilityZone, cidrBlock string) string {
+	subnets := c.clusterConfig.VPC.Subnets
+	if subnets == nil {
+		return ""
+	}
+	for _, mapping := range []api.AZSubnetMapping{subnets.Public, subnets.Private} {
+		for _, subnet := range mapping {
+			if subnet.AZ == availabilityZone && subnet.CIDR != nil && subnet.CIDR.String() == cidrBlock {
+				return subnet.ID
+			}
+		}
+	}
+	return ""
+}
+
+// convertAccessEntry translates the stack eksctl creates for an access entry.
+func (c *converter) convertAccessEntry(accessEntry api.AccessEntry) error {
+	rs := builder.NewAccessEntryResourceSet(c.clusterConfig.Metadata.Name, accessEntry)
+	if err := rs.AddAllResources(); err != nil {
+		return err
+	}
+	body, err := rs.RenderJSON()
+	if err != nil {
+		return err
+	}
+	principalName := accessEntry.PrincipalARN.Resource
+	if i := strings.LastIndex(principalName, "/"); i >= 0 {
+		principalName = principalName[i+1:]
+	}
+	s, err := newStack(accessentry.MakeStackName(c.clusterConfig.Metadata.Name, accessEntry), toSnakeCase(principalName)+"_", body)
+	if err != nil {
+		return err
+	}
+	return c.convertStack(s)
+}
+
+// convertAddons writes the given addons, sorted by name, and returns their addresses.
+func (c *converter) convertAddons(addons []*api.Addon, dependsOn tuple) (tuple, error) {
+	addons = slices.Clone(addons)
+	sort.Slice(addons, func(i, j int) bool {
+		return addons[i].Name < addons[j].Name
+	})
+
+	var addresses tuple
+	for _, a := range addons {
+		if a.HasIRSAPoliciesSet() || a.HasPodIDsSet() || a.UseDefaultPodIdentityAssociations {
+			logger.Warning("the IAM permissions of addon %q are not exported to Terraform; set service_account_role_arn or add an aws_eks_pod_identity_association", a.Name)
+		}
+		name := resourceName(a.Name)
+		b, err := c.addResource("aws_eks_addon", name)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, traversal("aws_eks_addon."+name))
+
+		r := &resource{}
+		r.setAttribute("addon_name", literal{value: a.Name})
+		r.setAttribute("cluster_name", c.clusterName)
+		if a.Version != "" && a.Version != "latest" {
+			r.setAttribute("addon_version", literal{value: a.Version})
+		}
+		if a.ServiceAccountRoleARN != "" {
+			r.setAttribute("service_account_role_arn", literal{value: a.ServiceAccountRoleARN})
+		}
+		if a.ConfigurationValues != "" {
+			r.setAttribute("configuration_values", literal{value: a.ConfigurationValues})
+		}
+		if a.ResolveConflicts != "" {
+			r.setAttribute("resolve_conflicts_on_create", literal{value: string(a.ResolveConflicts)})
+			r.setAttribute("resolve_conflicts_on_update", literal{value: string(a.ResolveConflicts)})
+		}
+		if len(a.Tags) > 0 {
+			var tags object
+			for key, value := range a.Tags {
+				tags = append(tags, objectItem{key: key, value: literal{value: value}})
+			}
+			sort.Slice(tags, func(i, j int) bool {
+				return tags[i].key < tags[j].key
+			})
+			r.setAttribute("tags", tags)
+		}
+		writeBody(b.body, r.attributes, nil)
+		setDependsOn(b, dependsOn)
+	}
+	return addresses, nil
+}
+
+// file returns the configuration, made of the required providers, the provider configuration setting the tags
+// eksctl sets on all stacks, the data sources used by resources, and the resources.
+func (c *converter) file() *file {
+	var f file
+
+	terraform := newBlock("terraform")
+	requiredProviders := newBlock("required_providers")
+	requiredProviders.body.setAttribute("aws", object{
+		{key: "source", value: literal{value: providerSource}},
+		{key: "version", value: literal{value: providerVersion}},
+	})
+	terraform.body.appendBlock(requiredProviders)
+	f.appendBlock(terraform)
+
+	provider := newBlock("provider", "aws")
+	provider.body.setAttribute("region", literal{value: c.clusterConfig.Metadata.Region})
+	var tags object
+	for _, tag := range manager.SharedTags(c.clusterConfig) {
+		tags = append(tags, objectItem{key: *tag.Key, value: literal{value: *tag.Value}})
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].key < tags[j].key
+	})
+	defaultTags := newBlock("default_tags")
+	defaultTags.body.setAttribute("tags", tags)
+	provider.body.appendBlock(defaultTags)
+	f.appendBlock(provider)
+
+	for _, dataSource := range []string{callerIdentityDataSource, partitionDataSource} {
+		if c.dataSources[dataSource] {
+			f.appendBlock(newBlock("data", dataSource, "current"))
+		}
+	}
+	for _, b := range c.blocks {
+		f.appendBlock(b)
+	}
+	return &f
+}
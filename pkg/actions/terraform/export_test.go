@@ -0,0 +1,178 @@
+package terraform_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/templates"
+	"github.com/weaveworks/eksctl/pkg/actions/terraform"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("Exporter", func() {
+	newClusterConfig := func(configure func(*api.ClusterConfig)) *api.ClusterConfig {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "my-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		cfg.Metadata.Tags = map[string]string{"team": "platform"}
+		cfg.AccessConfig.AccessEntries = []api.AccessEntry{{
+			PrincipalARN: api.MustParseARN("arn:aws:iam::111122223333:role/admin"),
+			AccessPolicies: []api.AccessPolicy{{
+				PolicyARN:   api.MustParseARN("arn:aws:eks::aws:cluster-access-policy/AmazonEKSClusterAdminPolicy"),
+				AccessScope: api.AccessScope{Type: "cluster"},
+			}},
+		}}
+		cfg.Addons = []*api.Addon{{Name: "aws-ebs-csi-driver", Version: "v1.38.1-eksbuild.1"}}
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{
+			{
+				NodeGroupBase: &api.NodeGroupBase{
+					Name:   "mng-1",
+					Labels: map[string]string{"role": "worker"},
+				},
+			},
+			{
+				NodeGroupBase: &api.NodeGroupBase{
+					Name:              "mng-2",
+					PrivateNetworking: true,
+				},
+				Spot:          true,
+				InstanceTypes: []string{"m5.large", "m5a.large"},
+			},
+		}
+		if configure != nil {
+			configure(cfg)
+		}
+
+		api.SetClusterEndpointAccessDefaults(cfg.VPC)
+		api.SetClusterConfigDefaults(cfg)
+		Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+		for i, ng := range cfg.NodeGroups {
+			Expect(api.ValidateNodeGroup(i, ng, cfg)).To(Succeed())
+			api.SetNodeGroupDefaults(ng, cfg.Metadata, false)
+		}
+		for i, ng := range cfg.ManagedNodeGroups {
+			api.SetManagedNodeGroupDefaults(ng, cfg.Metadata, false)
+			Expect(api.ValidateManagedNodeGroup(i, ng)).To(Succeed())
+		}
+		return cfg
+	}
+
+	options := templates.Options{
+		AvailabilityZones: []string{"us-west-2a", "us-west-2b"},
+		NodeAMI:           "ami-0123456789abcdef0",
+	}
+
+	export := func(cfg *api.ClusterConfig) (string, error) {
+		configuration, err := terraform.NewExporter(cfg, options).Export(context.Background())
+		return string(configuration), err
+	}
+
+	It("exports the VPC, cluster, managed nodegroups, addons and access entries", func() {
+		configuration, err := export(newClusterConfig(nil))
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, resource := range []string{
+			`resource "aws_vpc" "vpc"`,
+			`resource "aws_subnet" "subnet_private_uswest2a"`,
+			`resource "aws_nat_gateway" "nat_gateway"`,
+			`resource "aws_security_group" "control_plane_security_group"`,
+			`resource "aws_iam_role" "service_role"`,
+			`resource "aws_eks_cluster" "control_plane"`,
+			`resource "aws_launch_template" "mng_1_launch_template"`,
+			`resource "aws_eks_node_group" "mng_1_managed_node_group"`,
+			`resource "aws_eks_node_group" "mng_2_managed_node_group"`,
+			`resource "aws_iam_role_policy_attachment" "mng_1_node_instance_role_amazon_eks_worker_node_policy"`,
+			`resource "aws_eks_access_entry" "admin_access_entry"`,
+			`resource "aws_eks_access_policy_association" "admin_access_entry_amazon_eks_cluster_admin_policy"`,
+			`resource "aws_eks_addon" "vpc_cni"`,
+			`resource "aws_eks_addon" "aws_ebs_csi_driver"`,
+		} {
+			Expect(configuration).To(ContainSubstring(resource))
+		}
+
+		By("referencing resources in place of the values eksctl reads back from AWS")
+		Expect(configuration).To(ContainSubstring(`cluster_name   = aws_eks_cluster.control_plane.name`))
+		Expect(configuration).To(ContainSubstring(`subnet_ids      = [aws_subnet.subnet_private_uswest2a.id, aws_subnet.subnet_private_uswest2b.id]`))
+		Expect(configuration).To(ContainSubstring(`vpc_security_group_ids = [aws_eks_cluster.control_plane.vpc_config[0].cluster_security_group_id]`))
+		Expect(configuration).To(ContainSubstring(`version = aws_launch_template.mng_1_launch_template.latest_version`))
+		Expect(configuration).NotTo(ContainSubstring("subnet-private-"))
+
+		By("setting the tags eksctl sets on all stacks as default tags")
+		Expect(configuration).To(ContainSubstring(`"alpha.eksctl.io/cluster-name"                = "my-cluster"`))
+		Expect(configuration).To(ContainSubstring(`team                                          = "platform"`))
+
+		By("setting the addon version unless it is the latest")
+		Expect(configuration).To(ContainSubstring(`addon_version = "v1.38.1-eksbuild.1"`))
+	})
+
+	It("orders addons and nodegroups like eksctl creates them", func() {
+		configuration, err := export(newClusterConfig(nil))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(configuration).To(ContainSubstring(`  depends_on = [aws_eks_addon.kube_proxy, aws_eks_addon.vpc_cni]
+}`))
+		Expect(configuration).To(ContainSubstring(`resource "aws_eks_addon" "coredns" {
+  addon_name   = "coredns"
+  cluster_name = aws_eks_cluster.control_plane.name
+
+  depends_on = [
+    aws_eks_node_group.mng_1_managed_node_group,
+    aws_eks_node_group.mng_2_managed_node_group,
+  ]
+}`))
+	})
+
+	It("produces the same configuration for the same config", func() {
+		first, err := export(newClusterConfig(nil))
+		Expect(err).NotTo(HaveOccurred())
+		second, err := export(newClusterConfig(nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(Equal(second))
+	})
+
+	It("skips the resources that are not exported", func() {
+		configuration, err := export(newClusterConfig(func(cfg *api.ClusterConfig) {
+			cfg.IAM.WithOIDC = api.Enabled()
+			cfg.IAM.ServiceAccounts = []*api.ClusterIAMServiceAccount{{
+				ClusterIAMMeta:   api.ClusterIAMMeta{Name: "s3-reader", Namespace: "default"},
+				AttachPolicyARNs: []string{"arn:aws:iam::aws:policy/AmazonS3ReadOnlyAccess"},
+			}}
+			cfg.NodeGroups = []*api.NodeGroup{{
+				NodeGroupBase: &api.NodeGroupBase{Name: "ng-1"},
+			}}
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configuration).NotTo(ContainSubstring("nodegroup-ng-1"))
+		Expect(configuration).NotTo(ContainSubstring("s3-reader"))
+		Expect(configuration).NotTo(ContainSubstring("openid_connect"))
+		Expect(configuration).To(ContainSubstring("AmazonEKS_CNI_Policy"))
+	})
+
+	It("escapes template sequences in strings", func() {
+		configuration, err := export(newClusterConfig(func(cfg *api.ClusterConfig) {
+			cfg.Metadata.Tags["owner"] = "${team}"
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configuration).To(ContainSubstring(`"$${team}"`))
+	})
+
+	It("rejects clusters using IPv6", func() {
+		_, err := export(newClusterConfig(func(cfg *api.ClusterConfig) {
+			cfg.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{IPFamily: api.IPV6Family}
+			cfg.VPC.NAT = nil
+			cfg.IAM.WithOIDC = api.Enabled()
+			cfg.Addons = []*api.Addon{{Name: api.VPCCNIAddon}, {Name: api.CoreDNSAddon}, {Name: api.KubeProxyAddon}}
+		}))
+		Expect(err).To(MatchError("clusters using IPv6 cannot be exported to Terraform"))
+	})
+
+	It("rejects managed nodegroups whose user data depends on the cluster", func() {
+		_, err := export(newClusterConfig(func(cfg *api.ClusterConfig) {
+			cfg.ManagedNodeGroups[0].AMI = "ami-0123456789abcdef0"
+			cfg.ManagedNodeGroups[0].AMIFamily = api.NodeImageFamilyAmazonLinux2023
+		}))
+		Expect(err).To(MatchError(ContainSubstring("the user data depends on the endpoint and certificate authority of the cluster")))
+	})
+})
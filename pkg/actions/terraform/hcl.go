@@ -0,0 +1,276 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The types below model the subset of the HCL native syntax needed to write Terraform configurations, and render
+// it in the layout produced by terraform fmt.
+
+const indentUnit = "  "
+
+var identifierRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// An expression is an HCL expression.
+type expression interface {
+	// render returns the expression, using indent for the lines following the first one.
+	render(indent string) string
+}
+
+// literal is a string, number or bool literal.
+type literal struct {
+	value any
+}
+
+func (l literal) render(_ string) string {
+	switch v := l.value.(type) {
+	case string:
+		return quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// traversal is a reference to a resource, data source or one of their attributes, e.g. aws_vpc.vpc.id.
+type traversal string
+
+func (t traversal) render(_ string) string {
+	return string(t)
+}
+
+// tuple is a list of expressions.
+type tuple []expression
+
+func (t tuple) render(indent string) string {
+	if len(t) == 0 {
+		return "[]"
+	}
+	var items []string
+	multiLine := false
+	for _, e := range t {
+		item := e.render(indent + indentUnit)
+		multiLine = multiLine || strings.Contains(item, "\n")
+		items = append(items, item)
+	}
+	if inline := "[" + strings.Join(items, ", ") + "]"; !multiLine && len(inline) <= 80 {
+		return inline
+	}
+	var b strings.Builder
+	b.WriteString("[\n")
+	for _, item := range items {
+		fmt.Fprintf(&b, "%s%s%s,\n", indent, indentUnit, item)
+	}
+	b.WriteString(indent + "]")
+	return b.String()
+}
+
+// objectItem is a key-value pair of an object.
+type objectItem struct {
+	key   string
+	value expression
+}
+
+// object is a map or object, whose keys are kept in order.
+type object []objectItem
+
+func (o object) render(indent string) string {
+	if len(o) == 0 {
+		return "{}"
+	}
+	var lines []attributeLine
+	for _, item := range o {
+		key := item.key
+		if !identifierRegexp.MatchString(key) {
+			key = quote(key)
+		}
+		lines = append(lines, attributeLine{name: key, value: item.value})
+	}
+	return "{\n" + renderAttributes(lines, indent+indentUnit) + indent + "}"
+}
+
+// functionCall is a call to a Terraform function, e.g. jsonencode({...}).
+type functionCall struct {
+	name string
+	args []expression
+}
+
+func (f functionCall) render(indent string) string {
+	var args []string
+	for _, arg := range f.args {
+		args = append(args, arg.render(indent))
+	}
+	return f.name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// template is a string template interpolating expressions between literal strings.
+type template []expression
+
+func (t template) render(indent string) string {
+	var b strings.Builder
+	b.WriteString(`"`)
+	for _, part := range t {
+		if l, ok := part.(literal); ok {
+			if s, ok := l.value.(string); ok {
+				b.WriteString(escape(s))
+				continue
+			}
+		}
+		b.WriteString("${" + part.render(indent) + "}")
+	}
+	b.WriteString(`"`)
+	return b.String()
+}
+
+func quote(s string) string {
+	return `"` + escape(s) + `"`
+}
+
+func escape(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s)
+	// template sequences must be escaped in string literals
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+}
+
+// block is a block such as resource "aws_vpc" "vpc" { ... }.
+type block struct {
+	blockType string
+	labels    []string
+	body      *body
+}
+
+func newBlock(blockType string, labels ...string) *block {
+	return &block{
+		blockType: blockType,
+		labels:    labels,
+		body:      &body{},
+	}
+}
+
+func (b *block) render(indent string) string {
+	header := b.blockType
+	for _, label := range b.labels {
+		header += " " + quote(label)
+	}
+	if b.body.isEmpty() {
+		return indent + header + " {}\n"
+	}
+	return indent + header + " {\n" + b.body.render(indent+indentUnit) + indent + "}\n"
+}
+
+// body holds the attributes and nested blocks of a block, in order.
+type body struct {
+	items []any
+}
+
+func (b *body) setAttribute(name string, value expression) {
+	b.items = append(b.items, attributeLine{name: name, value: value})
+}
+
+func (b *body) appendBlock(nested *block) {
+	b.items = append(b.items, nested)
+}
+
+// appendBlankLine separates the attributes that follow from the preceding ones.
+func (b *body) appendBlankLine() {
+	b.items = append(b.items, blankLine{})
+}
+
+func (b *body) isEmpty() bool {
+	return len(b.items) == 0
+}
+
+func (b *body) render(indent string) string {
+	var (
+		out        strings.Builder
+		attributes []attributeLine
+	)
+	flush := func() {
+		if len(attributes) > 0 {
+			out.WriteString(renderAttributes(attributes, indent))
+			attributes = nil
+		}
+	}
+	for i, item := range b.items {
+		switch item := item.(type) {
+		case attributeLine:
+			if i > 0 {
+				if _, ok := b.items[i-1].(*block); ok {
+					out.WriteString("\n")
+				}
+			}
+			attributes = append(attributes, item)
+		case *block:
+			flush()
+			if i > 0 {
+				out.WriteString("\n")
+			}
+			out.WriteString(item.render(indent))
+		case blankLine:
+			flush()
+			out.WriteString("\n")
+		}
+	}
+	flush()
+	return out.String()
+}
+
+type blankLine struct{}
+
+type attributeLine struct {
+	name  string
+	value expression
+}
+
+// renderAttributes renders consecutive attributes, aligning their equals signs like terraform fmt. A multi-line
+// value ends the group of aligned attributes.
+func renderAttributes(lines []attributeLine, indent string) string {
+	values := make([]string, len(lines))
+	for i, line := range lines {
+		values[i] = line.value.render(indent)
+	}
+	var out strings.Builder
+	for start := 0; start < len(lines); {
+		end := start
+		width := 0
+		for end < len(lines) {
+			width = max(width, len(lines[end].name))
+			end++
+			if strings.Contains(values[end-1], "\n") {
+				break
+			}
+		}
+		for i := start; i < end; i++ {
+			fmt.Fprintf(&out, "%s%-*s = %s\n", indent, width, lines[i].name, values[i])
+		}
+		start = end
+	}
+	return out.String()
+}
+
+// file is a Terraform configuration file.
+type file struct {
+	blocks []*block
+}
+
+func (f *file) appendBlock(b *block) {
+	f.blocks = append(f.blocks, b)
+}
+
+// Bytes returns the rendered configuration.
+func (f *file) Bytes() []byte {
+	var parts []string
+	for _, b := range f.blocks {
+		parts = append(parts, b.render(""))
+	}
+	return []byte(strings.Join(parts, "\n"))
+}
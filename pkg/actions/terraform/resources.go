@@ -0,0 +1,1004 @@
+package terraform
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// resource is a CloudFormation resource being translated to a Terraform resource.
+type resource struct {
+	stack      *stack
+	logicalID  string
+	name       string
+	attributes []attributeLine
+	blocks     []*block
+	dependsOn  tuple
+}
+
+func (r *resource) setAttribute(name string, value expression) {
+	r.attributes = append(r.attributes, attributeLine{name: name, value: value})
+}
+
+func (r *resource) hasAttribute(name string) bool {
+	for _, a := range r.attributes {
+		if a.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// writeBody writes attributes sorted by name, followed by nested blocks.
+func writeBody(b *body, attributes []attributeLine, blocks []*block) {
+	sort.SliceStable(attributes, func(i, j int) bool {
+		return attributes[i].name < attributes[j].name
+	})
+	for _, a := range attributes {
+		b.setAttribute(a.name, a.value)
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].blockType < blocks[j].blockType
+	})
+	for _, nested := range blocks {
+		b.appendBlock(nested)
+	}
+}
+
+type fieldKind int
+
+const (
+	attributeField fieldKind = iota
+	// blockField translates an object, or each object in a list, to a nested block.
+	blockField
+	// inlineField translates the properties of an object to arguments of the enclosing block.
+	inlineField
+	// ignoredField is handled by the finish function of the resource.
+	ignoredField
+)
+
+// field describes how a CloudFormation property is translated.
+type field struct {
+	kind    fieldKind
+	name    string
+	fields  fields
+	convert func(c *converter, s *stack, v any) (expression, error)
+}
+
+// fields maps CloudFormation property names to their translation; properties missing from it cannot be exported.
+type fields map[string]field
+
+func attr(name string) field {
+	return field{
+		kind: attributeField,
+		name: name,
+		convert: func(c *converter, s *stack, v any) (expression, error) {
+			return c.value(s, v)
+		},
+	}
+}
+
+// listAttr translates a single value to a list attribute.
+func listAttr(name string) field {
+	return field{
+		kind: attributeField,
+		name: name,
+		convert: func(c *converter, s *stack, v any) (expression, error) {
+			e, err := c.value(s, v)
+			if err != nil || e == nil {
+				return nil, err
+			}
+			return tuple{e}, nil
+		},
+	}
+}
+
+// tagsAttr translates a list of Key and Value pairs, or a map, to a map of tags.
+func tagsAttr() field {
+	return field{
+		kind: attributeField,
+		name: "tags",
+		convert: func(c *converter, s *stack, v any) (expression, error) {
+			tags, ok := v.(map[string]any)
+			if list, isList := v.([]any); isList {
+				tags, ok = map[string]any{}, true
+				for _, item := range list {
+					tag, isTag := item.(map[string]any)
+					if !isTag {
+						return nil, fmt.Errorf("unexpected tag %v", item)
+					}
+					tags[fmt.Sprint(tag["Key"])] = tag["Value"]
+				}
+			}
+			if !ok {
+				return nil, fmt.Errorf("unexpected tags %v", v)
+			}
+			return c.object(s, tags)
+		},
+	}
+}
+
+// jsonAttr translates a JSON document, such as an IAM policy, to a call to jsonencode.
+func jsonAttr(name string) field {
+	return field{
+		kind: attributeField,
+		name: name,
+		convert: func(c *converter, s *stack, v any) (expression, error) {
+			e, err := c.value(s, v)
+			if err != nil {
+				return nil, err
+			}
+			return functionCall{name: "jsonencode", args: []expression{e}}, nil
+		},
+	}
+}
+
+// clusterNameAttr references the EKS cluster in place of its literal name, so that Terraform creates the cluster first.
+func clusterNameAttr(name string) field {
+	return field{
+		kind: attributeField,
+		name: name,
+		convert: func(c *converter, s *stack, v any) (expression, error) {
+			if v == c.clusterConfig.Metadata.Name && c.clusterName != nil {
+				return c.clusterName, nil
+			}
+			return c.value(s, v)
+		},
+	}
+}
+
+func blockOf(name string, fields fields) field {
+	return field{
+		kind:   blockField,
+		name:   name,
+		fields: fields,
+	}
+}
+
+func inline(fields fields) field {
+	return field{
+		kind:   inlineField,
+		fields: fields,
+	}
+}
+
+func ignored() field {
+	return field{kind: ignoredField}
+}
+
+// collectFields translates properties to attributes and nested blocks.
+func (c *converter) collectFields(s *stack, fields fields, properties map[string]any) ([]attributeLine, []*block, error) {
+	var keys []string
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var (
+		attributes []attributeLine
+		blocks     []*block
+	)
+	for _, key := range keys {
+		f, ok := fields[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("property %s cannot be exported to Terraform", key)
+		}
+		v := properties[key]
+		switch f.kind {
+		case ignoredField:
+			continue
+		case blockField, inlineField:
+			var objects []any
+			switch v := v.(type) {
+			case map[string]any:
+				objects = []any{v}
+			case []any:
+				objects = v
+			default:
+				return nil, nil, fmt.Errorf("property %s: expected an object, got %v", key, v)
+			}
+			for _, o := range objects {
+				nestedProperties, ok := o.(map[string]any)
+				if !ok {
+					return nil, nil, fmt.Errorf("property %s: expected an object, got %v", key, o)
+				}
+				nestedAttributes, nestedBlocks, err := c.collectFields(s, f.fields, nestedProperties)
+				if err != nil {
+					return nil, nil, fmt.Errorf("property %s: %w", key, err)
+				}
+				if f.kind == inlineField {
+					attributes = append(attributes, nestedAttributes...)
+					blocks = append(blocks, nestedBlocks...)
+					continue
+				}
+				if len(nestedAttributes) == 0 && len(nestedBlocks) == 0 {
+					continue
+				}
+				nested := newBlock(f.name)
+				writeBody(nested.body, nestedAttributes, nestedBlocks)
+				blocks = append(blocks, nested)
+			}
+		default:
+			e, err := f.convert(c, s, v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("property %s: %w", key, err)
+			}
+			if e != nil {
+				attributes = append(attributes, attributeLine{name: f.name, value: e})
+			}
+		}
+	}
+	return attributes, blocks, nil
+}
+
+// resourceSpec describes how a CloudFormation resource type is translated to a Terraform resource type.
+type resourceSpec struct {
+	resourceType string
+	// ref is the attribute a Ref to the resource resolves to.
+	ref string
+	// attributes maps the attributes supported by Fn::GetAtt to Terraform attributes.
+	attributes map[string]string
+	fields     fields
+	// finish sets the arguments and adds the resources that have no direct CloudFormation equivalent.
+	finish func(c *converter, r *resource, properties map[string]any) error
+}
+
+// resourceTypeOrder is the order resources are written in, which follows the order they are created in.
+var resourceTypeOrder = []string{
+	"AWS::EC2::VPC",
+	"AWS::EC2::VPCCidrBlock",
+	"AWS::EC2::InternetGateway",
+	"AWS::EC2::VPCGatewayAttachment",
+	"AWS::EC2::Subnet",
+	"AWS::EC2::EIP",
+	"AWS::EC2::NatGateway",
+	"AWS::EC2::RouteTable",
+	"AWS::EC2::Route",
+	"AWS::EC2::SubnetRouteTableAssociation",
+	"AWS::EC2::SecurityGroup",
+	"AWS::EC2::SecurityGroupIngress",
+	"AWS::EC2::SecurityGroupEgress",
+	"AWS::EC2::VPCEndpoint",
+	"AWS::IAM::Role",
+	"AWS::IAM::Policy",
+	"AWS::IAM::InstanceProfile",
+	"AWS::EKS::Cluster",
+	"AWS::EC2::LaunchTemplate",
+	"AWS::EKS::Nodegroup",
+	"AWS::EKS::AccessEntry",
+}
+
+func resourceOrder(resourceType string) int {
+	for i, t := range resourceTypeOrder {
+		if t == resourceType {
+			return i
+		}
+	}
+	return len(resourceTypeOrder)
+}
+
+// resourceSpecs maps the CloudFormation resource types that can be exported to their translation. It is set in init
+// as the translation functions refer back to it.
+var resourceSpecs map[string]resourceSpec
+
+func init() {
+	tagSpecificationFields := fields{
+		"ResourceType": attr("resource_type"),
+		"Tags":         tagsAttr(),
+	}
+
+	launchTemplateDataFields := fields{
+		"BlockDeviceMappings": blockOf("block_device_mappings", fields{
+			"DeviceName": attr("device_name"),
+			"Ebs": blockOf("ebs", fields{
+				"DeleteOnTermination": attr("delete_on_termination"),
+				"Encrypted":           attr("encrypted"),
+				"Iops":                attr("iops"),
+				"KmsKeyId":            attr("kms_key_id"),
+				"SnapshotId":          attr("snapshot_id"),
+				"Throughput":          attr("throughput"),
+				"VolumeSize":          attr("volume_size"),
+				"VolumeType":          attr("volume_type"),
+			}),
+		}),
+		"CapacityReservationSpecification": blockOf("capacity_reservation_specification", fields{
+			"CapacityReservationPreference": attr("capacity_reservation_preference"),
+			"CapacityReservationTarget": blockOf("capacity_reservation_target", fields{
+				"CapacityReservationId":               attr("capacity_reservation_id"),
+				"CapacityReservationResourceGroupArn": attr("capacity_reservation_resource_group_arn"),
+			}),
+		}),
+		"CpuOptions": blockOf("cpu_options", fields{
+			"CoreCount":      attr("core_count"),
+			"ThreadsPerCore": attr("threads_per_core"),
+		}),
+		"EnclaveOptions": blockOf("enclave_options", fields{
+			"Enabled": attr("enabled"),
+		}),
+		"IamInstanceProfile": blockOf("iam_instance_profile", fields{
+			"Arn":  attr("arn"),
+			"Name": attr("name"),
+		}),
+		"ImageId":      attr("image_id"),
+		"InstanceType": attr("instance_type"),
+		"KeyName":      attr("key_name"),
+		"MetadataOptions": blockOf("metadata_options", fields{
+			"HttpEndpoint":            attr("http_endpoint"),
+			"HttpProtocolIpv6":        attr("http_protocol_ipv6"),
+			"HttpPutResponseHopLimit": attr("http_put_response_hop_limit"),
+			"HttpTokens":              attr("http_tokens"),
+			"InstanceMetadataTags":    attr("instance_metadata_tags"),
+		}),
+		"Monitoring": blockOf("monitoring", fields{
+			"Enabled": attr("enabled"),
+		}),
+		"NetworkInterfaces": blockOf("network_interfaces", fields{
+			"AssociatePublicIpAddress": attr("associate_public_ip_address"),
+			"DeleteOnTermination":      attr("delete_on_termination"),
+			"DeviceIndex":              attr("device_index"),
+			"Groups":                   attr("security_groups"),
+			"InterfaceType":            attr("interface_type"),
+			"NetworkCardIndex":         attr("network_card_index"),
+			"SubnetId":                 attr("subnet_id"),
+		}),
+		"Placement": blockOf("placement", fields{
+			"AvailabilityZone": attr("availability_zone"),
+			"GroupName":        attr("group_name"),
+			"Tenancy":          attr("tenancy"),
+		}),
+		"PrivateDnsNameOptions": blockOf("private_dns_name_options", fields{
+			"EnableResourceNameDnsAAAARecord": attr("enable_resource_name_dns_aaaa_record"),
+			"EnableResourceNameDnsARecord":    attr("enable_resource_name_dns_a_record"),
+			"HostnameType":                    attr("hostname_type"),
+		}),
+		"SecurityGroupIds":  attr("vpc_security_group_ids"),
+		"TagSpecifications": blockOf("tag_specifications", tagSpecificationFields),
+		"UserData":          attr("user_data"),
+	}
+
+	resourceSpecs = map[string]resourceSpec{
+		"AWS::EC2::VPC": {
+			resourceType: "aws_vpc",
+			ref:          "id",
+			attributes: map[string]string{
+				"CidrBlock":            "cidr_block",
+				"DefaultSecurityGroup": "default_security_group_id",
+				"VpcId":                "id",
+			},
+			fields: fields{
+				"CidrBlock":          attr("cidr_block"),
+				"EnableDnsHostnames": attr("enable_dns_hostnames"),
+				"EnableDnsSupport":   attr("enable_dns_support"),
+				"Tags":               tagsAttr(),
+			},
+		},
+
+		"AWS::EC2::VPCCidrBlock": {
+			resourceType: "aws_vpc_ipv4_cidr_block_association",
+			ref:          "id",
+			fields: fields{
+				"CidrBlock": attr("cidr_block"),
+				"VpcId":     attr("vpc_id"),
+			},
+		},
+
+		"AWS::EC2::InternetGateway": {
+			resourceType: "aws_internet_gateway",
+			ref:          "id",
+			attributes: map[string]string{
+				"InternetGatewayId": "id",
+			},
+			fields: fields{
+				"Tags": tagsAttr(),
+			},
+		},
+
+		"AWS::EC2::VPCGatewayAttachment": {
+			resourceType: "aws_internet_gateway_attachment",
+			ref:          "id",
+			fields: fields{
+				"InternetGatewayId": attr("internet_gateway_id"),
+				"VpcId":             attr("vpc_id"),
+			},
+		},
+
+		"AWS::EC2::Subnet": {
+			resourceType: "aws_subnet",
+			ref:          "id",
+			attributes: map[string]string{
+				"AvailabilityZone": "availability_zone",
+				"CidrBlock":        "cidr_block",
+				"SubnetId":         "id",
+				"VpcId":            "vpc_id",
+			},
+			fields: fields{
+				"AssignIpv6AddressOnCreation": attr("assign_ipv6_address_on_creation"),
+				"AvailabilityZone":            attr("availability_zone"),
+				"AvailabilityZoneId":          attr("availability_zone_id"),
+				"CidrBlock":                   attr("cidr_block"),
+				"MapPublicIpOnLaunch":         attr("map_public_ip_on_launch"),
+				"PrivateDnsNameOptionsOnLaunch": inline(fields{
+					"EnableResourceNameDnsAAAARecord": attr("enable_resource_name_dns_aaaa_record_on_launch"),
+					"EnableResourceNameDnsARecord":    attr("enable_resource_name_dns_a_record_on_launch"),
+					"HostnameType":                    attr("private_dns_hostname_type_on_launch"),
+				}),
+				"Tags":  tagsAttr(),
+				"VpcId": attr("vpc_id"),
+			},
+		},
+
+		"AWS::EC2::EIP": {
+			resourceType: "aws_eip",
+			ref:          "public_ip",
+			attributes: map[string]string{
+				"AllocationId": "allocation_id",
+				"PublicIp":     "public_ip",
+			},
+			fields: fields{
+				"Domain": attr("domain"),
+				"Tags":   tagsAttr(),
+			},
+		},
+
+		"AWS::EC2::NatGateway": {
+			resourceType: "aws_nat_gateway",
+			ref:          "id",
+			fields: fields{
+				"AllocationId":     attr("allocation_id"),
+				"ConnectivityType": attr("connectivity_type"),
+				"SubnetId":         attr("subnet_id"),
+				"Tags":             tagsAttr(),
+			},
+		},
+
+		"AWS::EC2::RouteTable": {
+			resourceType: "aws_route_table",
+			ref:          "id",
+			attributes: map[string]string{
+				"RouteTableId": "id",
+			},
+			fields: fields{
+				"Tags":  tagsAttr(),
+				"VpcId": attr("vpc_id"),
+			},
+		},
+
+		"AWS::EC2::Route": {
+			resourceType: "aws_route",
+			ref:          "id",
+			fields: fields{
+				"DestinationCidrBlock":        attr("destination_cidr_block"),
+				"DestinationIpv6CidrBlock":    attr("destination_ipv6_cidr_block"),
+				"EgressOnlyInternetGatewayId": attr("egress_only_gateway_id"),
+				"GatewayId":                   attr("gateway_id"),
+				"NatGatewayId":                attr("nat_gateway_id"),
+				"RouteTableId":                attr("route_table_id"),
+				"TransitGatewayId":            attr("transit_gateway_id"),
+				"VpcPeeringConnectionId":      attr("vpc_peering_connection_id"),
+			},
+		},
+
+		"AWS::EC2::SubnetRouteTableAssociation": {
+			resourceType: "aws_route_table_association",
+			ref:          "id",
+			fields: fields{
+				"RouteTableId": attr("route_table_id"),
+				"SubnetId":     attr("subnet_id"),
+			},
+		},
+
+		"AWS::EC2::SecurityGroup": {
+			resourceType: "aws_security_group",
+			ref:          "id",
+			attributes: map[string]string{
+				"GroupId": "id",
+				"VpcId":   "vpc_id",
+			},
+			fields: fields{
+				"GroupDescription":     attr("description"),
+				"GroupName":            attr("name"),
+				"SecurityGroupEgress":  ignored(),
+				"SecurityGroupIngress": ignored(),
+				"Tags":                 tagsAttr(),
+				"VpcId":                attr("vpc_id"),
+			},
+			finish: finishSecurityGroup,
+		},
+
+		"AWS::EC2::SecurityGroupIngress": {
+			resourceType: "aws_security_group_rule",
+			ref:          "id",
+			fields:       securityGroupRuleFields("SourceSecurityGroupId"),
+			finish:       finishSecurityGroupRule("ingress"),
+		},
+
+		"AWS::EC2::SecurityGroupEgress": {
+			resourceType: "aws_security_group_rule",
+			ref:          "id",
+			fields:       securityGroupRuleFields("DestinationSecurityGroupId"),
+			finish:       finishSecurityGroupRule("egress"),
+		},
+
+		"AWS::EC2::VPCEndpoint": {
+			resourceType: "aws_vpc_endpoint",
+			ref:          "id",
+			fields: fields{
+				"PolicyDocument":    jsonAttr("policy"),
+				"PrivateDnsEnabled": attr("private_dns_enabled"),
+				"RouteTableIds":     attr("route_table_ids"),
+				"SecurityGroupIds":  attr("security_group_ids"),
+				"ServiceName":       attr("service_name"),
+				"SubnetIds":         attr("subnet_ids"),
+				"VpcEndpointType":   attr("vpc_endpoint_type"),
+				"VpcId":             attr("vpc_id"),
+			},
+		},
+
+		"AWS::IAM::Role": {
+			resourceType: "aws_iam_role",
+			ref:          "name",
+			attributes: map[string]string{
+				"Arn":    "arn",
+				"RoleId": "unique_id",
+			},
+			fields: fields{
+				"AssumeRolePolicyDocument": jsonAttr("assume_role_policy"),
+				"Description":              attr("description"),
+				"ManagedPolicyArns":        ignored(),
+				"MaxSessionDuration":       attr("max_session_duration"),
+				"Path":                     attr("path"),
+				"PermissionsBoundary":      attr("permissions_boundary"),
+				"Policies":                 ignored(),
+				"RoleName":                 attr("name"),
+				"Tags":                     tagsAttr(),
+			},
+			finish: finishRole,
+		},
+
+		"AWS::IAM::Policy": {
+			resourceType: "aws_iam_role_policy",
+			ref:          "id",
+			fields: fields{
+				"PolicyDocument": jsonAttr("policy"),
+				"PolicyName":     attr("name"),
+				"Roles":          ignored(),
+			},
+			finish: func(c *converter, r *resource, properties map[string]any) error {
+				roles, ok := properties["Roles"].([]any)
+				if !ok || len(roles) != 1 {
+					return errors.New("only policies attached to a single role can be exported to Terraform")
+				}
+				role, err := c.value(r.stack, roles[0])
+				if err != nil {
+					return err
+				}
+				r.setAttribute("role", role)
+				return nil
+			},
+		},
+
+		"AWS::IAM::InstanceProfile": {
+			resourceType: "aws_iam_instance_profile",
+			ref:          "name",
+			attributes: map[string]string{
+				"Arn": "arn",
+			},
+			fields: fields{
+				"InstanceProfileName": attr("name"),
+				"Path":                attr("path"),
+				"Roles":               ignored(),
+			},
+			finish: func(c *converter, r *resource, properties map[string]any) error {
+				roles, ok := properties["Roles"].([]any)
+				if !ok || len(roles) != 1 {
+					return errors.New("instance profiles must have a single role")
+				}
+				role, err := c.value(r.stack, roles[0])
+				if err != nil {
+					return err
+				}
+				r.setAttribute("role", role)
+				return nil
+			},
+		},
+
+		"AWS::EKS::Cluster": {
+			resourceType: "aws_eks_cluster",
+			ref:          "name",
+			attributes: map[string]string{
+				"Arn":                      "arn",
+				"CertificateAuthorityData": "certificate_authority[0].data",
+				"ClusterSecurityGroupId":   "vpc_config[0].cluster_security_group_id",
+				"Endpoint":                 "endpoint",
+				"KubernetesNetworkConfig.ServiceIpv4Cidr": "kubernetes_network_config[0].service_ipv4_cidr",
+				"KubernetesNetworkConfig.ServiceIpv6Cidr": "kubernetes_network_config[0].service_ipv6_cidr",
+				"OpenIdConnectIssuerUrl":                  "identity[0].oidc[0].issuer",
+			},
+			fields: fields{
+				"AccessConfig": blockOf("access_config", fields{
+					"AuthenticationMode":                      attr("authentication_mode"),
+					"BootstrapClusterCreatorAdminPermissions": attr("bootstrap_cluster_creator_admin_permissions"),
+				}),
+				"BootstrapSelfManagedAddons": attr("bootstrap_self_managed_addons"),
+				"ComputeConfig": blockOf("compute_config", fields{
+					"Enabled":     attr("enabled"),
+					"NodePools":   attr("node_pools"),
+					"NodeRoleArn": attr("node_role_arn"),
+				}),
+				"EncryptionConfig": blockOf("encryption_config", fields{
+					"Provider": blockOf("provider", fields{
+						"KeyArn": attr("key_arn"),
+					}),
+					"Resources": attr("resources"),
+				}),
+				"KubernetesNetworkConfig": blockOf("kubernetes_network_config", fields{
+					"ElasticLoadBalancing": blockOf("elastic_load_balancing", fields{
+						"Enabled": attr("enabled"),
+					}),
+					"IpFamily":        attr("ip_family"),
+					"ServiceIpv4Cidr": attr("service_ipv4_cidr"),
+				}),
+				"Logging": {
+					kind:    attributeField,
+					name:    "enabled_cluster_log_types",
+					convert: convertClusterLogging,
+				},
+				"Name": attr("name"),
+				"RemoteNetworkConfig": blockOf("remote_network_config", fields{
+					"RemoteNodeNetworks": blockOf("remote_node_networks", fields{
+						"Cidrs": attr("cidrs"),
+					}),
+					"RemotePodNetworks": blockOf("remote_pod_networks", fields{
+						"Cidrs": attr("cidrs"),
+					}),
+				}),
+				"ResourcesVpcConfig": blockOf("vpc_config", fields{
+					"EndpointPrivateAccess": attr("endpoint_private_access"),
+					"EndpointPublicAccess":  attr("endpoint_public_access"),
+					"PublicAccessCidrs":     attr("public_access_cidrs"),
+					"SecurityGroupIds":      attr("security_group_ids"),
+					"SubnetIds":             attr("subnet_ids"),
+				}),
+				"RoleArn": attr("role_arn"),
+				"StorageConfig": blockOf("storage_config", fields{
+					"BlockStorage": blockOf("block_storage", fields{
+						"Enabled": attr("enabled"),
+					}),
+				}),
+				"Tags": tagsAttr(),
+				"UpgradePolicy": blockOf("upgrade_policy", fields{
+					"SupportType": attr("support_type"),
+				}),
+				"Version": attr("version"),
+				"ZonalShiftConfig": blockOf("zonal_shift_config", fields{
+					"Enabled": attr("enabled"),
+				}),
+			},
+		},
+
+		"AWS::EC2::LaunchTemplate": {
+			resourceType: "aws_launch_template",
+			ref:          "id",
+			attributes: map[string]string{
+				"DefaultVersionNumber": "default_version",
+				"LatestVersionNumber":  "latest_version",
+				"LaunchTemplateId":     "id",
+			},
+			fields: fields{
+				"LaunchTemplateData": inline(launchTemplateDataFields),
+				"LaunchTemplateName": attr("name"),
+			},
+			finish: finishLaunchTemplate,
+		},
+
+		"AWS::EKS::Nodegroup": {
+			resourceType: "aws_eks_node_group",
+			ref:          "id",
+			attributes: map[string]string{
+				"Arn":           "arn",
+				"NodegroupName": "node_group_name",
+			},
+			fields: fields{
+				"AmiType":            attr("ami_type"),
+				"CapacityType":       attr("capacity_type"),
+				"ClusterName":        clusterNameAttr("cluster_name"),
+				"DiskSize":           attr("disk_size"),
+				"ForceUpdateEnabled": attr("force_update_version"),
+				"InstanceTypes":      attr("instance_types"),
+				"Labels":             attr("labels"),
+				"LaunchTemplate":     ignored(),
+				"NodeRepairConfig": blockOf("node_repair_config", fields{
+					"Enabled": attr("enabled"),
+				}),
+				"NodeRole":       attr("node_role_arn"),
+				"NodegroupName":  attr("node_group_name"),
+				"ReleaseVersion": attr("release_version"),
+				"RemoteAccess": blockOf("remote_access", fields{
+					"Ec2SshKey":            attr("ec2_ssh_key"),
+					"SourceSecurityGroups": attr("source_security_group_ids"),
+				}),
+				"ScalingConfig": blockOf("scaling_config", fields{
+					"DesiredSize": attr("desired_size"),
+					"MaxSize":     attr("max_size"),
+					"MinSize":     attr("min_size"),
+				}),
+				"Subnets": attr("subnet_ids"),
+				"Tags":    tagsAttr(),
+				"Taints": blockOf("taint", fields{
+					"Effect": attr("effect"),
+					"Key":    attr("key"),
+					"Value":  attr("value"),
+				}),
+				"UpdateConfig": blockOf("update_config", fields{
+					"MaxUnavailable":           attr("max_unavailable"),
+					"MaxUnavailablePercentage": attr("max_unavailable_percentage"),
+				}),
+				"Version": attr("version"),
+			},
+			finish: finishNodeGroup,
+		},
+
+		"AWS::EKS::AccessEntry": {
+			resourceType: "aws_eks_access_entry",
+			ref:          "id",
+			attributes: map[string]string{
+				"AccessEntryArn": "access_entry_arn",
+			},
+			fields: fields{
+				"AccessPolicies":   ignored(),
+				"ClusterName":      clusterNameAttr("cluster_name"),
+				"KubernetesGroups": attr("kubernetes_groups"),
+				"PrincipalArn":     attr("principal_arn"),
+				"Tags":             tagsAttr(),
+				"Type":             attr("type"),
+				"Username":         attr("user_name"),
+			},
+			finish: finishAccessEntry,
+		},
+	}
+}
+
+func securityGroupRuleFields(otherGroupProperty string) fields {
+	return fields{
+		"CidrIp":           listAttr("cidr_blocks"),
+		"CidrIpv6":         listAttr("ipv6_cidr_blocks"),
+		"Description":      attr("description"),
+		"FromPort":         attr("from_port"),
+		"GroupId":          attr("security_group_id"),
+		"IpProtocol":       attr("protocol"),
+		otherGroupProperty: attr("source_security_group_id"),
+		"ToPort":           attr("to_port"),
+	}
+}
+
+// finishSecurityGroupRule sets the type of the rule, and the ports Terraform requires for rules covering all protocols.
+func finishSecurityGroupRule(ruleType string) func(*converter, *resource, map[string]any) error {
+	return func(_ *converter, r *resource, _ map[string]any) error {
+		r.setAttribute("type", literal{value: ruleType})
+		for _, port := range []string{"from_port", "to_port"} {
+			if !r.hasAttribute(port) {
+				r.setAttribute(port, literal{value: 0})
+			}
+		}
+		return nil
+	}
+}
+
+// finishSecurityGroup names the security group after the stack like CloudFormation does, and adds its rules as separate
+// resources so that they can be combined with the rules of other resources. Unlike CloudFormation, Terraform removes
+// the default egress rule, so it is added unless the security group declares its own egress rules.
+func finishSecurityGroup(c *converter, r *resource, properties map[string]any) error {
+	if !r.hasAttribute("name") {
+		r.setAttribute("name_prefix", literal{value: fmt.Sprintf("%s-%s-", r.stack.name, r.logicalID)})
+	}
+	securityGroupID := traversal("aws_security_group." + r.name + ".id")
+
+	addRules := func(ruleType, suffix string, rules []any, otherGroupProperty string) error {
+		for i, rule := range rules {
+			ruleProperties, ok := rule.(map[string]any)
+			if !ok {
+				return fmt.Errorf("unexpected %s rule %v", ruleType, rule)
+			}
+			b, err := c.addResource("aws_security_group_rule", fmt.Sprintf("%s_%s_%d", r.name, suffix, i))
+			if err != nil {
+				return err
+			}
+			attributes, _, err := c.collectFields(r.stack, securityGroupRuleFields(otherGroupProperty), ruleProperties)
+			if err != nil {
+				return err
+			}
+			rule := &resource{attributes: attributes}
+			rule.setAttribute("security_group_id", securityGroupID)
+			if err := finishSecurityGroupRule(ruleType)(c, rule, nil); err != nil {
+				return err
+			}
+			writeBody(b.body, rule.attributes, nil)
+		}
+		return nil
+	}
+	ingress, _ := properties["SecurityGroupIngress"].([]any)
+	if err := addRules("ingress", "ingress", ingress, "SourceSecurityGroupId"); err != nil {
+		return err
+	}
+	egress, hasEgress := properties["SecurityGroupEgress"].([]any)
+	if !hasEgress {
+		egress = []any{map[string]any{
+			"CidrIp":     "0.0.0.0/0",
+			"IpProtocol": "-1",
+		}}
+	}
+	return addRules("egress", "egress", egress, "DestinationSecurityGroupId")
+}
+
+// finishRole names the role after the stack like CloudFormation does, and attaches its policies with separate
+// resources.
+func finishRole(c *converter, r *resource, properties map[string]any) error {
+	if !r.hasAttribute("name") {
+		// name_prefix is limited to 38 characters
+		prefix := fmt.Sprintf("%s-%s-", r.stack.name, r.logicalID)
+		if len(prefix) > 38 {
+			prefix = prefix[:38]
+		}
+		r.setAttribute("name_prefix", literal{value: prefix})
+	}
+	role := traversal("aws_iam_role." + r.name + ".name")
+
+	managedPolicyARNs, _ := properties["ManagedPolicyArns"].([]any)
+	for i, policyARN := range managedPolicyARNs {
+		arn, err := c.value(r.stack, policyARN)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%s_%d", r.name, i)
+		if l, ok := arn.(literal); ok {
+			name = r.name + "_" + toSnakeCase(path.Base(fmt.Sprint(l.value)))
+		}
+		b, err := c.addResource("aws_iam_role_policy_attachment", resourceName(name))
+		if err != nil {
+			return err
+		}
+		writeBody(b.body, []attributeLine{
+			{name: "policy_arn", value: arn},
+			{name: "role", value: role},
+		}, nil)
+	}
+
+	policies, _ := properties["Policies"].([]any)
+	for _, p := range policies {
+		policy, ok := p.(map[string]any)
+		if !ok {
+			return fmt.Errorf("unexpected policy %v", p)
+		}
+		policyName, err := c.staticString(r.stack, policy["PolicyName"])
+		if err != nil {
+			return err
+		}
+		document, err := jsonAttr("policy").convert(c, r.stack, policy["PolicyDocument"])
+		if err != nil {
+			return err
+		}
+		b, err := c.addResource("aws_iam_role_policy", resourceName(r.name+"_"+toSnakeCase(policyName)))
+		if err != nil {
+			return err
+		}
+		writeBody(b.body, []attributeLine{
+			{name: "name", value: literal{value: policyName}},
+			{name: "policy", value: document},
+			{name: "role", value: role},
+		}, nil)
+	}
+	return nil
+}
+
+// finishLaunchTemplate rejects user data that embeds details of the cluster which are only known once it is created,
+// as the values used to render it offline are placeholders.
+func finishLaunchTemplate(c *converter, _ *resource, properties map[string]any) error {
+	data, _ := properties["LaunchTemplateData"].(map[string]any)
+	userData, ok := data["UserData"].(string)
+	if !ok || c.clusterConfig.Status == nil {
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return nil
+	}
+	status := c.clusterConfig.Status
+	if strings.Contains(string(decoded), status.Endpoint) || strings.Contains(string(decoded), string(status.CertificateAuthorityData)) {
+		return errors.New("the user data depends on the endpoint and certificate authority of the cluster, which are not known offline")
+	}
+	return nil
+}
+
+// finishNodeGroup sets the launch template of the nodegroup, which Terraform requires a version for, and makes the
+// nodegroup depend on the addons eksctl creates before nodegroups.
+func finishNodeGroup(c *converter, r *resource, properties map[string]any) error {
+	r.dependsOn = c.nodeGroupDependencies
+	launchTemplate, ok := properties["LaunchTemplate"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	attributes, _, err := c.collectFields(r.stack, fields{
+		"Id":      attr("id"),
+		"Name":    attr("name"),
+		"Version": attr("version"),
+	}, launchTemplate)
+	if err != nil {
+		return err
+	}
+	lt := &resource{attributes: attributes}
+	if !lt.hasAttribute("version") {
+		id, ok := launchTemplate["Id"].(map[string]any)
+		logicalID, isRef := id["Ref"].(string)
+		if !ok || !isRef {
+			return errors.New("the version of the launch template must be set")
+		}
+		version, err := c.getAtt(r.stack, logicalID, "LatestVersionNumber")
+		if err != nil {
+			return err
+		}
+		lt.setAttribute("version", version)
+	}
+	b := newBlock("launch_template")
+	writeBody(b.body, lt.attributes, nil)
+	r.blocks = append(r.blocks, b)
+	return nil
+}
+
+// finishAccessEntry associates the access policies of the access entry, which are separate resources in Terraform.
+func finishAccessEntry(c *converter, r *resource, properties map[string]any) error {
+	accessPolicies, _ := properties["AccessPolicies"].([]any)
+	for _, p := range accessPolicies {
+		policy, ok := p.(map[string]any)
+		if !ok {
+			return fmt.Errorf("unexpected access policy %v", p)
+		}
+		policyARN, err := c.staticString(r.stack, policy["PolicyArn"])
+		if err != nil {
+			return err
+		}
+		b, err := c.addResource("aws_eks_access_policy_association", resourceName(r.name+"_"+toSnakeCase(path.Base(policyARN))))
+		if err != nil {
+			return err
+		}
+		_, scope, err := c.collectFields(r.stack, fields{
+			"AccessScope": blockOf("access_scope", fields{
+				"Namespaces": attr("namespaces"),
+				"Type":       attr("type"),
+			}),
+		}, map[string]any{"AccessScope": policy["AccessScope"]})
+		if err != nil {
+			return err
+		}
+		writeBody(b.body, []attributeLine{
+			{name: "cluster_name", value: traversal("aws_eks_access_entry." + r.name + ".cluster_name")},
+			{name: "policy_arn", value: literal{value: policyARN}},
+			{name: "principal_arn", value: traversal("aws_eks_access_entry." + r.name + ".principal_arn")},
+		}, scope)
+	}
+	return nil
+}
+
+func convertClusterLogging(c *converter, s *stack, v any) (expression, error) {
+	logging, _ := v.(map[string]any)
+	clusterLogging, _ := logging["ClusterLogging"].(map[string]any)
+	enabledTypes, _ := clusterLogging["EnabledTypes"].([]any)
+	var logTypes tuple
+	for _, t := range enabledTypes {
+		logType, ok := t.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected log type %v", t)
+		}
+		e, err := c.value(s, logType["Type"])
+		if err != nil {
+			return nil, err
+		}
+		logTypes = append(logTypes, e)
+	}
+	if len(logTypes) == 0 {
+		return nil, nil
+	}
+	return logTypes, nil
+}
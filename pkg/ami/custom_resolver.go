@@ -0,0 +1,147 @@
+package ami
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/kris-nova/logger"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+// Catalogue lists custom AMIs, and is read from the file specified in amiResolution.catalogueFile
+type Catalogue struct {
+	Images []CatalogueImage `json:"images"`
+}
+
+// CatalogueImage is an AMI in a Catalogue
+type CatalogueImage struct {
+	// Version is the Kubernetes version the image is built for
+	Version string `json:"version"`
+	// Family is the AMI family of the image, e.g. AmazonLinux2023
+	Family string `json:"family"`
+	// Arch is the architecture of the image, either x86_64 or arm64
+	Arch string `json:"arch"`
+	// Region is the region the image is available in; images without a region match all regions
+	Region string `json:"region,omitempty"`
+	// ImageID is the AMI ID
+	ImageID string `json:"imageID"`
+}
+
+// LoadCatalogue reads and validates an AMI catalogue file
+func LoadCatalogue(path string) (*Catalogue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading AMI catalogue: %w", err)
+	}
+	var catalogue Catalogue
+	if err := yaml.UnmarshalStrict(data, &catalogue); err != nil {
+		return nil, fmt.Errorf("loading AMI catalogue %q: %w", path, err)
+	}
+	for i, image := range catalogue.Images {
+		if image.Version == "" || image.Family == "" || image.Arch == "" {
+			return nil, fmt.Errorf("images[%d].version, images[%d].family and images[%d].arch must be set in AMI catalogue %q", i, i, i, path)
+		}
+		if !api.IsAMI(image.ImageID) {
+			return nil, fmt.Errorf("invalid AMI %q (images[%d].imageID) in AMI catalogue %q", image.ImageID, i, path)
+		}
+	}
+	return &catalogue, nil
+}
+
+// CustomResolver resolves the AMI using a user-defined SSM parameter path template or AMI catalogue.
+// It returns an UnsupportedQueryError if no AMI is found, so that a MultiResolver falls back to the
+// EKS-optimized AMIs.
+type CustomResolver struct {
+	ssmAPI        awsapi.SSM
+	parameterPath string
+	catalogue     *Catalogue
+}
+
+// NewCustomResolver creates a new CustomResolver for the specified amiResolution
+func NewCustomResolver(resolution *api.AMIResolution, ssmAPI awsapi.SSM) (Resolver, error) {
+	if resolution.CatalogueFile == "" {
+		return &CustomResolver{ssmAPI: ssmAPI, parameterPath: resolution.SSMParameterPath}, nil
+	}
+	catalogue, err := LoadCatalogue(resolution.CatalogueFile)
+	if err != nil {
+		return nil, err
+	}
+	return &CustomResolver{catalogue: catalogue}, nil
+}
+
+// Resolve will return the AMI for the nodegroup from the AMI catalogue or the SSM parameter
+func (r *CustomResolver) Resolve(ctx context.Context, region, version, instanceType, imageFamily string) (string, error) {
+	logger.Debug("resolving AMI using CustomResolver for region %s, instanceType %s and imageFamily %s", region, instanceType, imageFamily)
+
+	arch := instanceEC2ArchName(instanceType)
+	if r.catalogue != nil {
+		if imageID := r.catalogue.find(region, version, imageFamily, arch); imageID != "" {
+			return imageID, nil
+		}
+		return "", r.unresolved(fmt.Sprintf("no AMI found in AMI catalogue for version %s, family %s and arch %s in region %s", version, imageFamily, arch, region))
+	}
+
+	parameterName := MakeCustomSSMParameterName(r.parameterPath, version, imageFamily, arch)
+	output, err := r.ssmAPI.GetParameter(ctx, &ssm.GetParameterInput{
+		Name: aws.String(parameterName),
+	})
+	if err != nil {
+		var notFoundErr *ssmtypes.ParameterNotFound
+		if errors.As(err, &notFoundErr) {
+			return "", r.unresolved(fmt.Sprintf("SSM parameter %q not found", parameterName))
+		}
+		return "", fmt.Errorf("error getting AMI from SSM parameter %q: %w", parameterName, err)
+	}
+	if output.Parameter == nil || aws.ToString(output.Parameter.Value) == "" {
+		return "", r.unresolved(fmt.Sprintf("SSM parameter %q is empty", parameterName))
+	}
+	return *output.Parameter.Value, nil
+}
+
+func (r *CustomResolver) unresolved(reason string) error {
+	logger.Warning("unable to resolve a custom AMI: %s", reason)
+	return &UnsupportedQueryError{msg: reason}
+}
+
+func (c *Catalogue) find(region, version, imageFamily, arch string) string {
+	var imageID string
+	for _, image := range c.Images {
+		if image.Version != version || image.Family != imageFamily || image.Arch != arch {
+			continue
+		}
+		switch image.Region {
+		case region:
+			return image.ImageID
+		case "":
+			if imageID == "" {
+				imageID = image.ImageID
+			}
+		}
+	}
+	return imageID
+}
+
+// MakeCustomSSMParameterName expands the placeholders of an amiResolution.ssmParameterPath template
+func MakeCustomSSMParameterName(template, version, imageFamily, arch string) string {
+	return api.AMIResolutionPlaceholderRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		switch strings.TrimSpace(strings.Trim(placeholder, "{}")) {
+		case "version":
+			return version
+		case "family":
+			return imageFamily
+		case "arch":
+			return arch
+		default:
+			return placeholder
+		}
+	})
+}
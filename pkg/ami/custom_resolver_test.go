@@ -0,0 +1,133 @@
+package ami_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	. "github.com/weaveworks/eksctl/pkg/ami"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Custom AMI Resolution", func() {
+	var p *mockprovider.MockProvider
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+	})
+
+	Context("using an SSM parameter path", func() {
+		var resolver Resolver
+
+		BeforeEach(func() {
+			var err error
+			resolver, err = NewCustomResolver(&api.AMIResolution{
+				SSMParameterPath: "/golden-amis/{{version}}/{{ family }}/{{arch}}/image_id",
+			}, p.MockSSM())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("expands the placeholders of the parameter path", func() {
+			addMockGetParameter(p, "/golden-amis/1.31/AmazonLinux2023/arm64/image_id", "ami-arm64")
+
+			amiID, err := resolver.Resolve(context.Background(), "us-west-2", "1.31", "m7g.large", api.NodeImageFamilyAmazonLinux2023)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(amiID).To(Equal("ami-arm64"))
+		})
+
+		It("returns an UnsupportedQueryError if the parameter does not exist", func() {
+			p.MockSSM().On("GetParameter", mock.Anything, mock.Anything).Return(nil, &ssmtypes.ParameterNotFound{})
+
+			_, err := resolver.Resolve(context.Background(), "us-west-2", "1.31", "m5.large", api.NodeImageFamilyAmazonLinux2023)
+			var unsupportedQueryErr *UnsupportedQueryError
+			Expect(err).To(BeAssignableToTypeOf(unsupportedQueryErr))
+			Expect(err.Error()).To(ContainSubstring(`SSM parameter "/golden-amis/1.31/AmazonLinux2023/x86_64/image_id" not found`))
+		})
+
+		It("falls back to the next resolver in a MultiResolver", func() {
+			p.MockSSM().On("GetParameter", mock.Anything, mock.MatchedBy(func(input *ssm.GetParameterInput) bool {
+				return *input.Name == "/golden-amis/1.31/AmazonLinux2023/x86_64/image_id"
+			})).Return(nil, &ssmtypes.ParameterNotFound{})
+			addMockGetParameter(p, "/aws/service/eks/optimized-ami/1.31/amazon-linux-2023/x86_64/standard/recommended/image_id", "ami-eks")
+
+			multiResolver := NewMultiResolver(resolver, NewSSMResolver(p.MockSSM()))
+			amiID, err := multiResolver.Resolve(context.Background(), "us-west-2", "1.31", "m5.large", api.NodeImageFamilyAmazonLinux2023)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(amiID).To(Equal("ami-eks"))
+		})
+	})
+
+	Context("using an AMI catalogue", func() {
+		var catalogueFile string
+
+		BeforeEach(func() {
+			catalogueFile = filepath.Join(GinkgoT().TempDir(), "catalogue.yaml")
+		})
+
+		writeCatalogue := func(content string) {
+			Expect(os.WriteFile(catalogueFile, []byte(content), 0600)).To(Succeed())
+		}
+
+		It("prefers images for the region over images without a region", func() {
+			writeCatalogue(`
+images:
+- version: "1.31"
+  family: AmazonLinux2023
+  arch: x86_64
+  imageID: ami-0000000000000000a
+- version: "1.31"
+  family: AmazonLinux2023
+  arch: x86_64
+  region: eu-west-1
+  imageID: ami-0000000000000000b
+`)
+			resolver, err := NewCustomResolver(&api.AMIResolution{CatalogueFile: catalogueFile}, p.MockSSM())
+			Expect(err).NotTo(HaveOccurred())
+
+			amiID, err := resolver.Resolve(context.Background(), "eu-west-1", "1.31", "m5.large", api.NodeImageFamilyAmazonLinux2023)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(amiID).To(Equal("ami-0000000000000000b"))
+
+			amiID, err = resolver.Resolve(context.Background(), "us-west-2", "1.31", "m5.large", api.NodeImageFamilyAmazonLinux2023)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(amiID).To(Equal("ami-0000000000000000a"))
+
+			_, err = resolver.Resolve(context.Background(), "us-west-2", "1.30", "m5.large", api.NodeImageFamilyAmazonLinux2023)
+			var unsupportedQueryErr *UnsupportedQueryError
+			Expect(err).To(BeAssignableToTypeOf(unsupportedQueryErr))
+			Expect(p.MockSSM().AssertNotCalled(GinkgoT(), "GetParameter", mock.Anything, mock.Anything)).To(BeTrue())
+		})
+
+		It("rejects invalid images", func() {
+			writeCatalogue(`
+images:
+- version: "1.31"
+  family: AmazonLinux2023
+  arch: x86_64
+  imageID: not-an-ami
+`)
+			_, err := NewCustomResolver(&api.AMIResolution{CatalogueFile: catalogueFile}, p.MockSSM())
+			Expect(err).To(MatchError(ContainSubstring(`invalid AMI "not-an-ami" (images[0].imageID)`)))
+		})
+
+		It("rejects unknown fields", func() {
+			writeCatalogue(`
+images:
+- version: "1.31"
+  family: AmazonLinux2023
+  arch: x86_64
+  ami: ami-0000000000000000a
+`)
+			_, err := NewCustomResolver(&api.AMIResolution{CatalogueFile: catalogueFile}, p.MockSSM())
+			Expect(err).To(MatchError(ContainSubstring("loading AMI catalogue")))
+		})
+	})
+})
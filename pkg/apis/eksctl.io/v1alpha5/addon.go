@@ -111,6 +111,9 @@ func (a Addon) Validate() error {
 		if err := a.checkAtMostOnePolicyProviderIsSet(); err != nil {
 			return invalidAddonConfigErr(err.Error())
 		}
+		if err := a.WellKnownPolicies.Validate("wellKnownPolicies"); err != nil {
+			return invalidAddonConfigErr(err.Error())
+		}
 	}
 
 	if a.HasPodIDsSet() {
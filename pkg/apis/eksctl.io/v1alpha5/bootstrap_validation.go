@@ -0,0 +1,143 @@
+package v1alpha5
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	nodeadmapi "github.com/awslabs/amazon-eks-ami/nodeadm/api"
+	nodeadm "github.com/awslabs/amazon-eks-ami/nodeadm/api/v1alpha1"
+	kubeletconfig "k8s.io/kubelet/config/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+// bottlerocketSettings lists the known Bottlerocket settings of the Kubernetes variants, keyed by the top-level
+// setting. A nil list means the nested settings are not checked, e.g. because they are named by the user.
+// See https://bottlerocket.dev/en/os/latest/api/settings/
+var bottlerocketSettings = map[string][]string{
+	"autoscaling":          {"should-wait"},
+	"aws":                  {"config", "credentials", "profile", "region"},
+	"boot":                 {"init-parameters", "kernel-parameters", "reboot-to-reconcile"},
+	"bootstrap-commands":   nil,
+	"bootstrap-containers": nil,
+	"cloudformation":       {"logical-resource-id", "should-signal", "stack-name"},
+	"container-registry":   {"credentials", "mirrors"},
+	"container-runtime": {
+		"enable-unprivileged-icmp", "enable-unprivileged-ports", "max-concurrent-downloads",
+		"max-container-log-line-size",
+	},
+	"container-runtime-plugins": nil,
+	"dns":                       {"name-servers", "search-list"},
+	"host-containers":           nil,
+	"kernel":                    {"lockdown", "modules", "sysctl", "cpu-governor"},
+	"kubelet-device-plugins":    nil,
+	"kubernetes": {
+		"allowed-unsafe-sysctls", "api-server", "authentication-mode", "bootstrap-token", "cloud-provider",
+		"cluster-certificate", "cluster-dns-ip", "cluster-domain", "cluster-name", "container-log-max-files",
+		"container-log-max-size", "cpu-cfs-quota-enforced", "cpu-manager-policy", "cpu-manager-policy-options",
+		"cpu-manager-reconcile-period", "credential-providers", "device-ownership-from-security-context",
+		"event-burst", "event-qps", "eviction-hard", "eviction-max-pod-grace-period", "eviction-soft",
+		"eviction-soft-grace-period", "hostname-override", "hostname-override-source",
+		"image-gc-high-threshold-percent", "image-gc-low-threshold-percent", "image-maximum-gc-age",
+		"image-minimum-gc-age", "kube-api-burst", "kube-api-qps", "kube-reserved", "log-level", "max-pods",
+		"memory-manager-policy", "memory-manager-reserved-memory", "node-labels", "node-taints",
+		"pod-infra-container-image", "pod-pids-limit", "provider-id", "registry-burst", "registry-qps",
+		"reserved-cpus", "seccomp-default", "server-certificate", "server-key", "server-tls-bootstrap",
+		"shutdown-grace-period", "shutdown-grace-period-for-critical-pods", "single-process-oom-kill",
+		"standalone-mode", "static-pods", "system-reserved", "topology-manager-policy", "topology-manager-scope",
+	},
+	"metrics":                  {"metrics-url", "send-metrics", "service-checks"},
+	"motd":                     nil,
+	"network":                  {"hostname", "hosts", "http-proxy", "https-proxy", "no-proxy"},
+	"ntp":                      {"options", "time-servers"},
+	"nvidia-container-runtime": {"visible-devices-as-volume-mounts", "visible-devices-envvar-when-unprivileged"},
+	"oci-defaults":             {"capabilities", "resource-limits"},
+	"oci-hooks":                {"log4j-hotpatch-enabled"},
+	"pki":                      nil,
+	"updates":                  {"ignore-waves", "metadata-base-url", "seed", "targets-base-url", "version-lock"},
+}
+
+// kubeletConfigFields maps the JSON names of the KubeletConfiguration fields to their types.
+var kubeletConfigFields = func() map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	t := reflect.TypeOf(kubeletconfig.KubeletConfiguration{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.Anonymous || name == "" || name == "-" {
+			continue
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}()
+
+// validateKubeletConfigSchema checks that the fields of kubeletExtraConfig are KubeletConfiguration fields
+// and that their values can be decoded into them.
+func validateKubeletConfigSchema(kubeletConfig InlineDocument, path string) error {
+	for _, key := range sortedKeys(kubeletConfig) {
+		fieldPath := fmt.Sprintf("%s.kubeletExtraConfig.%s", path, key)
+		fieldType, ok := kubeletConfigFields[key]
+		if !ok {
+			return fmt.Errorf("unknown kubelet config field %q (path=%s)", key, fieldPath)
+		}
+		data, err := json.Marshal(kubeletConfig[key])
+		if err != nil {
+			return fmt.Errorf("invalid value for kubelet config field %q (path=%s): %w", key, fieldPath, err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(reflect.New(fieldType).Interface()); err != nil {
+			return fmt.Errorf("invalid value for kubelet config field %q (path=%s): %w", key, fieldPath, err)
+		}
+	}
+	return nil
+}
+
+// validateBottlerocketSettings checks the Bottlerocket settings against the known settings.
+func validateBottlerocketSettings(settings InlineDocument, path string) error {
+	settingsPath := path + ".bottlerocket.settings"
+	for _, key := range sortedKeys(settings) {
+		knownSettings, ok := bottlerocketSettings[key]
+		if !ok {
+			return fmt.Errorf("unknown Bottlerocket setting %q (path=%s.%s)", key, settingsPath, key)
+		}
+		nested, ok := settings[key].(map[string]interface{})
+		if !ok || knownSettings == nil {
+			continue
+		}
+		for _, nestedKey := range sortedKeys(nested) {
+			if !slices.Contains(knownSettings, nestedKey) {
+				return fmt.Errorf("unknown Bottlerocket setting \"%s.%s\" (path=%s.%[1]s.%[2]s)", key, nestedKey, settingsPath)
+			}
+		}
+	}
+	return nil
+}
+
+// validateNodeConfig checks that an AmazonLinux2023 overrideBootstrapCommand is a valid nodeadm NodeConfig.
+func validateNodeConfig(overrideBootstrapCommand, path string) error {
+	fieldPath := path + ".overrideBootstrapCommand"
+	var nodeConfig nodeadm.NodeConfig
+	if err := yaml.UnmarshalStrict([]byte(overrideBootstrapCommand), &nodeConfig); err != nil {
+		return fmt.Errorf("%s must be a valid nodeadm NodeConfig for %s nodegroups: %w", fieldPath, NodeImageFamilyAmazonLinux2023, err)
+	}
+	if nodeConfig.Kind != nodeadmapi.KindNodeConfig || nodeConfig.APIVersion != nodeadm.GroupVersion.String() {
+		return fmt.Errorf("%s must be a nodeadm NodeConfig with kind %q and apiVersion %q for %s nodegroups, got kind %q and apiVersion %q",
+			fieldPath, nodeadmapi.KindNodeConfig, nodeadm.GroupVersion.String(), NodeImageFamilyAmazonLinux2023, nodeConfig.Kind, nodeConfig.APIVersion)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -433,6 +433,8 @@ const (
 // supported version of Karpenter
 const (
 	supportedKarpenterVersion = "v0.20.0"
+	// minimum version of Karpenter serving the v1 NodePool and EC2NodeClass APIs
+	karpenterNodePoolsVersion = "v1.0.0"
 )
 
 // Values for Capacity Reservation Preference
@@ -970,6 +972,11 @@ type ClusterConfig struct {
 	// +optional
 	ManagedNodeGroups []*ManagedNodeGroup `json:"managedNodeGroups,omitempty"`
 
+	// AMIResolution specifies a custom source of AMIs, which is consulted before the EKS-optimized AMIs when
+	// resolving the AMI of nodegroups. See [custom AMI support](/usage/custom-ami-support/)
+	// +optional
+	AMIResolution *AMIResolution `json:"amiResolution,omitempty"`
+
 	// +optional
 	FargateProfiles []*FargateProfile `json:"fargateProfiles,omitempty"`
 
@@ -1006,6 +1013,22 @@ type ClusterConfig struct {
 	ZonalShiftConfig *ZonalShiftConfig `json:"zonalShiftConfig,omitempty"`
 }
 
+// AMIResolution holds the configuration of a custom AMI source. Exactly one of SSMParameterPath or
+// CatalogueFile must be set.
+type AMIResolution struct {
+	// SSMParameterPath is a template for the name of the SSM parameter that holds the AMI ID.
+	// The placeholders `{{version}}`, `{{family}}` and `{{arch}}` are replaced with the Kubernetes version,
+	// the AMI family (e.g. `AmazonLinux2023`) and the architecture (`x86_64` or `arm64`) of the nodegroup,
+	// e.g. `/corp/eks/{{version}}/{{family}}/{{arch}}`
+	// +optional
+	SSMParameterPath string `json:"ssmParameterPath,omitempty"`
+
+	// CatalogueFile is the path of a local YAML file listing the AMI IDs by Kubernetes version, AMI family,
+	// architecture and, optionally, region
+	// +optional
+	CatalogueFile string `json:"catalogueFile,omitempty"`
+}
+
 // Outpost holds the Outpost configuration.
 type Outpost struct {
 	// ControlPlaneOutpostARN specifies the Outpost ARN in which the control plane should be created.
@@ -1063,6 +1086,97 @@ type Karpenter struct {
 	// WithSpotInterruptionQueue if true, adds all required policies and rules
 	// for supporting Spot Interruption Queue on Karpenter deployments
 	WithSpotInterruptionQueue *bool `json:"withSpotInterruptionQueue,omitempty"`
+	// NodePools defines the Karpenter NodePools, and the EC2NodeClasses used by them,
+	// to create once Karpenter is installed. Requires Karpenter v1.0.0 or later
+	// +optional
+	NodePools []KarpenterNodePool `json:"nodePools,omitempty"`
+}
+
+// KarpenterNodePool defines a Karpenter NodePool and its EC2NodeClass, which share the same name.
+// The subnets, security groups and instance profile of the EC2NodeClass are set by eksctl
+type KarpenterNodePool struct {
+	// Name of the NodePool and EC2NodeClass
+	// +required
+	Name string `json:"name"`
+	// Requirements constrain the nodes Karpenter can launch, e.g. on `karpenter.sh/capacity-type`
+	// or `karpenter.k8s.aws/instance-category`
+	// +optional
+	Requirements []KarpenterRequirement `json:"requirements,omitempty"`
+	// Labels are applied to the nodes of the NodePool
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Taints are applied to the nodes of the NodePool
+	// +optional
+	Taints []NodeGroupTaint `json:"taints,omitempty"`
+	// Limits caps the total amount of resources of the nodes in the NodePool, e.g. `cpu: "100"`
+	// +optional
+	Limits map[string]string `json:"limits,omitempty"`
+	// Disruption configures how Karpenter disrupts the nodes of the NodePool
+	// +optional
+	Disruption *KarpenterDisruption `json:"disruption,omitempty"`
+	// AMISelectorTerms select the AMIs used by the EC2NodeClass,
+	// defaults to `alias: al2023@latest`
+	// +optional
+	AMISelectorTerms []KarpenterAMISelectorTerm `json:"amiSelectorTerms,omitempty"`
+	// Subnets are the IDs of the subnets the EC2NodeClass launches nodes in,
+	// defaults to the private subnets of the cluster
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+}
+
+// KarpenterRequirement is a node selector requirement of a Karpenter NodePool
+type KarpenterRequirement struct {
+	// +required
+	Key string `json:"key"`
+	// Operator is one of `In`, `NotIn`, `Exists`, `DoesNotExist`, `Gt` or `Lt`
+	// +required
+	Operator string `json:"operator"`
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// KarpenterDisruption configures the disruption of the nodes of a Karpenter NodePool
+type KarpenterDisruption struct {
+	// ConsolidationPolicy is either `WhenEmpty` or `WhenEmptyOrUnderutilized`
+	// +optional
+	ConsolidationPolicy string `json:"consolidationPolicy,omitempty"`
+	// ConsolidateAfter is the duration after which a node can be consolidated, e.g. `1m` or `Never`
+	// +optional
+	ConsolidateAfter string `json:"consolidateAfter,omitempty"`
+	// ExpireAfter is the duration after which nodes are replaced, e.g. `720h` or `Never`
+	// +optional
+	ExpireAfter string `json:"expireAfter,omitempty"`
+	// Budgets limit the number of nodes that can be disrupted at the same time
+	// +optional
+	Budgets []KarpenterDisruptionBudget `json:"budgets,omitempty"`
+}
+
+// KarpenterDisruptionBudget limits the number of nodes of a NodePool that can be disrupted
+type KarpenterDisruptionBudget struct {
+	// Nodes is the number or percentage of nodes that can be disrupted, e.g. `10%`
+	// +required
+	Nodes string `json:"nodes"`
+	// Schedule is a cron schedule of when the budget is active
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// Duration of the budget once it's active, required with schedule
+	// +optional
+	Duration string `json:"duration,omitempty"`
+}
+
+// KarpenterAMISelectorTerm selects AMIs for an EC2NodeClass. An alias cannot be combined with other fields
+type KarpenterAMISelectorTerm struct {
+	// Alias of an EKS optimized AMI family and version, e.g. `al2023@latest` or `bottlerocket@v1.20.0`
+	// +optional
+	Alias string `json:"alias,omitempty"`
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Owner string `json:"owner,omitempty"`
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/kris-nova/logger"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation"
 	kubeletapis "k8s.io/kubelet/pkg/apis"
 
@@ -159,6 +161,9 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		if !sa.WellKnownPolicies.HasPolicy() && len(sa.AttachPolicyARNs) == 0 && sa.AttachPolicy == nil && sa.AttachRoleARN == "" {
 			return fmt.Errorf("%[1]s.wellKnownPolicies, %[1]s.attachPolicyARNs,%[1]s.attachRoleARN  or %[1]s.attachPolicy must be set", path)
 		}
+		if err := sa.WellKnownPolicies.Validate(path + ".wellKnownPolicies"); err != nil {
+			return err
+		}
 	}
 
 	if err := cfg.validateKubernetesNetworkConfig(); err != nil {
@@ -217,6 +222,10 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		return err
 	}
 
+	if err := validateAMIResolution(cfg.AMIResolution); err != nil {
+		return err
+	}
+
 	if cfg.Outpost != nil {
 		if cfg.Outpost.ControlPlaneOutpostARN == "" {
 			return errors.New("outpost.controlPlaneOutpostARN is required for Outposts")
@@ -303,14 +312,15 @@ func ValidateClusterConfig(cfg *ClusterConfig) error {
 		}
 	}
 
-	if err := validateKarpenterConfig(cfg); err != nil {
+	if err := ValidateKarpenterConfig(cfg); err != nil {
 		return fmt.Errorf("failed to validate Karpenter config: %w", err)
 	}
 
 	return nil
 }
 
-func validateKarpenterConfig(cfg *ClusterConfig) error {
+// ValidateKarpenterConfig validates the Karpenter config.
+func ValidateKarpenterConfig(cfg *ClusterConfig) error {
 	if cfg.Karpenter == nil {
 		return nil
 	}
@@ -335,6 +345,101 @@ func validateKarpenterConfig(cfg *ClusterConfig) error {
 	if IsDisabled(cfg.IAM.WithOIDC) {
 		return errors.New("iam.withOIDC must be enabled with Karpenter")
 	}
+
+	if len(cfg.Karpenter.NodePools) == 0 {
+		return nil
+	}
+	nodePoolsVersion, err := version.NewVersion(karpenterNodePoolsVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse Karpenter version %s: %w", karpenterNodePoolsVersion, err)
+	}
+	if v.LessThan(nodePoolsVersion) {
+		return fmt.Errorf("karpenter.nodePools requires Karpenter %s or later", karpenterNodePoolsVersion)
+	}
+	names := map[string]struct{}{}
+	for i, np := range cfg.Karpenter.NodePools {
+		path := fmt.Sprintf("karpenter.nodePools[%d]", i)
+		if np.Name == "" {
+			return fmt.Errorf("%s.name must be set", path)
+		}
+		if _, ok := names[np.Name]; ok {
+			return fmt.Errorf("%s.name %q is not unique", path, np.Name)
+		}
+		names[np.Name] = struct{}{}
+		if err := validateKarpenterNodePool(np, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateKarpenterNodePool(np KarpenterNodePool, path string) error {
+	for i, r := range np.Requirements {
+		rPath := fmt.Sprintf("%s.requirements[%d]", path, i)
+		if r.Key == "" {
+			return fmt.Errorf("%s.key must be set", rPath)
+		}
+		switch r.Operator {
+		case "In", "NotIn":
+			if len(r.Values) == 0 {
+				return fmt.Errorf("%s.values must be set with operator %q", rPath, r.Operator)
+			}
+		case "Exists", "DoesNotExist":
+			if len(r.Values) > 0 {
+				return fmt.Errorf("%s.values cannot be set with operator %q", rPath, r.Operator)
+			}
+		case "Gt", "Lt":
+			if len(r.Values) != 1 {
+				return fmt.Errorf("%s.values must have a single value with operator %q", rPath, r.Operator)
+			}
+			if _, err := strconv.Atoi(r.Values[0]); err != nil {
+				return fmt.Errorf("%s.values must be an integer with operator %q", rPath, r.Operator)
+			}
+		default:
+			return fmt.Errorf("%s.operator must be one of In, NotIn, Exists, DoesNotExist, Gt or Lt", rPath)
+		}
+	}
+
+	if err := validateLabels(np.Labels); err != nil {
+		return fmt.Errorf("%s.labels: %w", path, err)
+	}
+	if err := validateTaints(np.Taints); err != nil {
+		return fmt.Errorf("%s.taints: %w", path, err)
+	}
+
+	for name, value := range np.Limits {
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("%s.limits: invalid quantity %q for %q: %w", path, value, name, err)
+		}
+	}
+
+	if d := np.Disruption; d != nil {
+		switch d.ConsolidationPolicy {
+		case "", "WhenEmpty", "WhenEmptyOrUnderutilized":
+		default:
+			return fmt.Errorf("%s.disruption.consolidationPolicy must be one of WhenEmpty or WhenEmptyOrUnderutilized", path)
+		}
+		for i, b := range d.Budgets {
+			if b.Nodes == "" {
+				return fmt.Errorf("%s.disruption.budgets[%d].nodes must be set", path, i)
+			}
+			if (b.Schedule == "") != (b.Duration == "") {
+				return fmt.Errorf("%s.disruption.budgets[%d]: schedule and duration must be set together", path, i)
+			}
+		}
+	}
+
+	for i, term := range np.AMISelectorTerms {
+		tPath := fmt.Sprintf("%s.amiSelectorTerms[%d]", path, i)
+		hasOtherFields := term.ID != "" || term.Name != "" || term.Owner != "" || len(term.Tags) > 0
+		if term.Alias != "" {
+			if hasOtherFields || len(np.AMISelectorTerms) > 1 {
+				return fmt.Errorf("%s.alias cannot be combined with other AMI selector terms or fields", tPath)
+			}
+		} else if !hasOtherFields {
+			return fmt.Errorf("%s must set at least one of alias, id, name, owner or tags", tPath)
+		}
+	}
 	return nil
 }
 
@@ -464,6 +569,60 @@ func (c *ClusterConfig) ValidateVPCConfig() error {
 		}
 	}
 
+	return c.validatePodSubnets()
+}
+
+func (c *ClusterConfig) validatePodSubnets() error {
+	podSubnets := c.VPC.PodSubnets
+	if podSubnets == nil {
+		return nil
+	}
+	if c.IPv6Enabled() {
+		return errors.New("vpc.podSubnets is not supported with IPv6")
+	}
+	if c.IsAutoModeEnabled() {
+		return errors.New("vpc.podSubnets is not supported with Auto Mode")
+	}
+	if c.AddonsConfig.DisableDefaultAddons && !slices.ContainsFunc(c.Addons, func(a *Addon) bool {
+		return a.CanonicalName() == VPCCNIAddon
+	}) {
+		return fmt.Errorf("the %s addon must be specified in addons when using vpc.podSubnets with addonsConfig.disableDefaultAddons", VPCCNIAddon)
+	}
+
+	if c.VPC.ID != "" || c.HasAnySubnets() {
+		if podSubnets.CIDR != nil {
+			return errors.New("vpc.podSubnets.cidr cannot be set with a pre-existing VPC; specify the pod subnets by ID instead")
+		}
+		if len(podSubnets.Subnets) == 0 {
+			return errors.New("vpc.podSubnets.subnets must be set when using a pre-existing VPC")
+		}
+		for name, subnet := range podSubnets.Subnets {
+			if subnet.ID == "" {
+				return fmt.Errorf("vpc.podSubnets.subnets[%q].id must be set when using a pre-existing VPC", name)
+			}
+		}
+		return nil
+	}
+
+	if podSubnets.CIDR != nil {
+		if prefix, _ := podSubnets.CIDR.Mask.Size(); prefix < 16 || prefix > 24 {
+			return errors.New("vpc.podSubnets.cidr prefix must be between /16 and /24")
+		}
+		if c.VPC.CIDR != nil && (podSubnets.CIDR.Contains(c.VPC.CIDR.IP) || c.VPC.CIDR.Contains(podSubnets.CIDR.IP)) {
+			return fmt.Errorf("vpc.podSubnets.cidr %s overlaps with the VPC CIDR %s", podSubnets.CIDR, c.VPC.CIDR)
+		}
+	}
+	for name, subnet := range podSubnets.Subnets {
+		if subnet.ID != "" {
+			return fmt.Errorf("vpc.podSubnets.subnets[%q].id cannot be set when eksctl creates the VPC", name)
+		}
+		if subnet.CIDR == nil {
+			return fmt.Errorf("vpc.podSubnets.subnets[%q].cidr must be set", name)
+		}
+		if podSubnets.CIDR == nil || !podSubnets.CIDR.Contains(subnet.CIDR.IP) {
+			return fmt.Errorf("vpc.podSubnets.subnets[%q].cidr %s must be within vpc.podSubnets.cidr", name, subnet.CIDR)
+		}
+	}
 	return nil
 }
 
@@ -737,6 +896,18 @@ func validateNodeGroupBase(np NodePool, path string, controlPlaneOnOutposts bool
 		}
 	}
 
+	if ng.AMIFamily == NodeImageFamilyAmazonLinux2023 && ng.OverrideBootstrapCommand != nil {
+		if err := validateNodeConfig(*ng.OverrideBootstrapCommand, path); err != nil {
+			return err
+		}
+	}
+
+	if ng.Bottlerocket != nil && ng.Bottlerocket.Settings != nil {
+		if err := validateBottlerocketSettings(*ng.Bottlerocket.Settings, path); err != nil {
+			return err
+		}
+	}
+
 	if ng.SSH != nil {
 		if enableSSM := ng.SSH.EnableSSM; enableSSM != nil {
 			if !*enableSSM {
@@ -996,7 +1167,7 @@ func ValidateNodeGroup(i int, ng *NodeGroup, cfg *ClusterConfig) error {
 				return err
 			}
 		}
-	} else if err := validateNodeGroupKubeletExtraConfig(ng.KubeletExtraConfig); err != nil {
+	} else if err := validateNodeGroupKubeletExtraConfig(ng.KubeletExtraConfig, path); err != nil {
 		return err
 	}
 
@@ -1565,7 +1736,7 @@ func countEnabledFields(fields ...*string) int {
 	return count
 }
 
-func validateNodeGroupKubeletExtraConfig(kubeletConfig *InlineDocument) error {
+func validateNodeGroupKubeletExtraConfig(kubeletConfig *InlineDocument, path string) error {
 	if kubeletConfig == nil {
 		return nil
 	}
@@ -1585,7 +1756,7 @@ func validateNodeGroupKubeletExtraConfig(kubeletConfig *InlineDocument) error {
 			return fmt.Errorf("cannot override %q in kubelet config, as it's critical to eksctl functionality", k)
 		}
 	}
-	return nil
+	return validateKubeletConfigSchema(*kubeletConfig, path)
 }
 
 func isSupportedAMIFamily(imageFamily string) bool {
@@ -1741,6 +1912,33 @@ func checkBottlerocketSettings(ng *NodeGroup, path string) error {
 	return nil
 }
 
+// AMIResolutionPlaceholderRegex matches the placeholders of amiResolution.ssmParameterPath, capturing their name.
+var AMIResolutionPlaceholderRegex = regexp.MustCompile(`{{\s*([^}]*?)\s*}}`)
+
+func validateAMIResolution(r *AMIResolution) error {
+	if r == nil {
+		return nil
+	}
+	switch {
+	case r.SSMParameterPath == "" && r.CatalogueFile == "":
+		return errors.New("one of amiResolution.ssmParameterPath or amiResolution.catalogueFile must be set")
+	case r.SSMParameterPath != "" && r.CatalogueFile != "":
+		return errors.New("only one of amiResolution.ssmParameterPath or amiResolution.catalogueFile can be set")
+	case r.SSMParameterPath != "":
+		if !strings.HasPrefix(r.SSMParameterPath, "/") {
+			return fmt.Errorf("amiResolution.ssmParameterPath must be a fully qualified SSM parameter name starting with \"/\"; got %q", r.SSMParameterPath)
+		}
+		for _, match := range AMIResolutionPlaceholderRegex.FindAllStringSubmatch(r.SSMParameterPath, -1) {
+			switch match[1] {
+			case "version", "family", "arch":
+			default:
+				return fmt.Errorf("unknown placeholder %q in amiResolution.ssmParameterPath; supported placeholders are {{version}}, {{family}} and {{arch}}", match[0])
+			}
+		}
+	}
+	return nil
+}
+
 func validateAvailabilityZones(azList []string) error {
 	count := len(azList)
 	switch {
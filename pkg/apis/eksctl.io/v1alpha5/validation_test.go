@@ -12,6 +12,7 @@ import (
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	cft "github.com/weaveworks/eksctl/pkg/cfn/template"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
 
 var _ = Describe("ClusterConfig validation", func() {
@@ -206,6 +207,46 @@ var _ = Describe("ClusterConfig validation", func() {
 			ng0.OverrideBootstrapCommand = aws.String("echo 'yo'")
 			Expect(api.ValidateNodeGroup(0, ng0, cfg)).To(MatchError(ContainSubstring(fmt.Sprintf("overrideBootstrapCommand is not supported for %s nodegroups", api.NodeImageFamilyBottlerocket))))
 		})
+		It("should accept a nodeadm NodeConfig in overrideBootstrapCommand for AmazonLinux2023", func() {
+			cfg := api.NewClusterConfig()
+			ng0 := cfg.NewNodeGroup()
+			ng0.Name = "node-group"
+			ng0.AMI = "ami-1234"
+			ng0.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+			ng0.OverrideBootstrapCommand = aws.String(`apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  instance:
+    localStorage:
+      strategy: RAID0
+`)
+			Expect(api.ValidateNodeGroup(0, ng0, cfg)).To(Succeed())
+		})
+		It("should reject an invalid nodeadm NodeConfig in overrideBootstrapCommand for AmazonLinux2023", func() {
+			cfg := api.NewClusterConfig()
+			ng0 := cfg.NewNodeGroup()
+			ng0.Name = "node-group"
+			ng0.AMI = "ami-1234"
+			ng0.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+			ng0.OverrideBootstrapCommand = aws.String(`apiVersion: node.eks.aws/v1alpha1
+kind: NodeConfig
+spec:
+  instance:
+    localStorge:
+      strategy: RAID0
+`)
+			Expect(api.ValidateNodeGroup(0, ng0, cfg)).To(MatchError(ContainSubstring("nodeGroups[0].overrideBootstrapCommand must be a valid nodeadm NodeConfig for AmazonLinux2023 nodegroups")))
+
+			ng0.OverrideBootstrapCommand = aws.String("/etc/eks/bootstrap.sh my-cluster")
+			Expect(api.ValidateNodeGroup(0, ng0, cfg)).To(MatchError(ContainSubstring("nodeGroups[0].overrideBootstrapCommand must be")))
+
+			mng := api.NewManagedNodeGroup()
+			mng.Name = "mng"
+			mng.AMI = "ami-1234"
+			mng.AMIFamily = api.NodeImageFamilyAmazonLinux2023
+			mng.OverrideBootstrapCommand = aws.String("kind: Pod")
+			Expect(api.ValidateManagedNodeGroup(0, mng)).To(MatchError(ContainSubstring(`managedNodeGroups[0].overrideBootstrapCommand must be a nodeadm NodeConfig with kind "NodeConfig" and apiVersion "node.eks.aws/v1alpha1"`)))
+		})
 		It("should accept ami with a overrideBootstrapCommand set", func() {
 			cfg := api.NewClusterConfig()
 			ng0 := cfg.NewNodeGroup()
@@ -601,6 +642,28 @@ var _ = Describe("ClusterConfig validation", func() {
 			Expect(err.Error()).To(HavePrefix("iam.serviceAccounts[1].name must be set"))
 		})
 
+		It("should validate the resource ARNs of iam.serviceAccounts[].wellKnownPolicies", func() {
+			cfg.IAM.WithOIDC = api.Enabled()
+
+			cfg.IAM.ServiceAccounts = []*api.ClusterIAMServiceAccount{{}}
+			cfg.IAM.ServiceAccounts[0].Name = "sa-1"
+			cfg.IAM.ServiceAccounts[0].WellKnownPolicies = api.WellKnownPolicies{
+				EBSCSIController: true,
+				S3BucketARNs:     []string{"arn:aws:s3:::backups"},
+			}
+			Expect(api.ValidateClusterConfig(cfg)).To(MatchError("iam.serviceAccounts[0].wellKnownPolicies.s3BucketARNs can only be set with iam.serviceAccounts[0].wellKnownPolicies.velero or iam.serviceAccounts[0].wellKnownPolicies.mountpointS3CSIDriver"))
+
+			cfg.IAM.ServiceAccounts[0].WellKnownPolicies.Velero = true
+			Expect(api.ValidateClusterConfig(cfg)).To(Succeed())
+
+			cfg.IAM.ServiceAccounts[0].WellKnownPolicies.S3BucketARNs = []string{"backups"}
+			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(`iam.serviceAccounts[0].wellKnownPolicies.s3BucketARNs: invalid S3 bucket ARN "backups"`))
+
+			cfg.IAM.ServiceAccounts[0].WellKnownPolicies.S3BucketARNs = nil
+			cfg.IAM.ServiceAccounts[0].WellKnownPolicies.FSxFileSystemARNs = []string{"arn:aws:fsx:us-west-2:111122223333:file-system/fs-1234"}
+			Expect(api.ValidateClusterConfig(cfg)).To(MatchError("iam.serviceAccounts[0].wellKnownPolicies.fsxFileSystemARNs can only be set with iam.serviceAccounts[0].wellKnownPolicies.fsxCSIController"))
+		})
+
 		It("should fail when iam.serviceAccounts[1] has no policy", func() {
 			cfg.IAM.WithOIDC = api.Enabled()
 
@@ -1470,6 +1533,68 @@ var _ = Describe("ClusterConfig validation", func() {
 			})
 		})
 
+		Context("podSubnets", func() {
+			DescribeTable("validates pod subnets", func(updateConfig func(*api.ClusterConfig), expectedErr string) {
+				cfg.VPC.PodSubnets = &api.PodSubnets{}
+				updateConfig(cfg)
+				err := cfg.ValidateVPCConfig()
+				if expectedErr == "" {
+					Expect(err).NotTo(HaveOccurred())
+					return
+				}
+				Expect(err).To(MatchError(expectedErr))
+			},
+				Entry("new VPC with the default CIDR", func(*api.ClusterConfig) {}, ""),
+				Entry("new VPC with subnets", func(c *api.ClusterConfig) {
+					c.VPC.PodSubnets.CIDR = ipnet.MustParseCIDR("100.64.0.0/16")
+					c.VPC.PodSubnets.Subnets = api.AZSubnetMapping{
+						"us-west-2a": {AZ: "us-west-2a", CIDR: ipnet.MustParseCIDR("100.64.0.0/18")},
+					}
+				}, ""),
+				Entry("existing VPC", func(c *api.ClusterConfig) {
+					c.VPC.ID = "vpc-1"
+					c.VPC.PodSubnets.Subnets = api.AZSubnetMapping{"us-west-2a": {ID: "subnet-1"}}
+				}, ""),
+				Entry("IPv6", func(c *api.ClusterConfig) {
+					c.KubernetesNetworkConfig.IPFamily = api.IPV6Family
+					c.VPC.NAT = nil
+				}, "vpc.podSubnets is not supported with IPv6"),
+				Entry("Auto Mode", func(c *api.ClusterConfig) {
+					c.AutoModeConfig = &api.AutoModeConfig{Enabled: api.Enabled()}
+				}, "vpc.podSubnets is not supported with Auto Mode"),
+				Entry("default addons disabled without vpc-cni", func(c *api.ClusterConfig) {
+					c.AddonsConfig.DisableDefaultAddons = true
+				}, "the vpc-cni addon must be specified in addons when using vpc.podSubnets with addonsConfig.disableDefaultAddons"),
+				Entry("default addons disabled with vpc-cni", func(c *api.ClusterConfig) {
+					c.AddonsConfig.DisableDefaultAddons = true
+					c.Addons = []*api.Addon{{Name: api.VPCCNIAddon}}
+				}, ""),
+				Entry("CIDR overlapping with the VPC CIDR", func(c *api.ClusterConfig) {
+					c.VPC.PodSubnets.CIDR = ipnet.MustParseCIDR("192.168.0.0/16")
+				}, "vpc.podSubnets.cidr 192.168.0.0/16 overlaps with the VPC CIDR 192.168.0.0/16"),
+				Entry("invalid CIDR prefix", func(c *api.ClusterConfig) {
+					c.VPC.PodSubnets.CIDR = ipnet.MustParseCIDR("100.64.0.0/28")
+				}, "vpc.podSubnets.cidr prefix must be between /16 and /24"),
+				Entry("subnet outside of the CIDR", func(c *api.ClusterConfig) {
+					c.VPC.PodSubnets.CIDR = ipnet.MustParseCIDR("100.64.0.0/16")
+					c.VPC.PodSubnets.Subnets = api.AZSubnetMapping{
+						"us-west-2a": {AZ: "us-west-2a", CIDR: ipnet.MustParseCIDR("100.65.0.0/18")},
+					}
+				}, `vpc.podSubnets.subnets["us-west-2a"].cidr 100.65.0.0/18 must be within vpc.podSubnets.cidr`),
+				Entry("subnet ID with a new VPC", func(c *api.ClusterConfig) {
+					c.VPC.PodSubnets.Subnets = api.AZSubnetMapping{"us-west-2a": {ID: "subnet-1"}}
+				}, `vpc.podSubnets.subnets["us-west-2a"].id cannot be set when eksctl creates the VPC`),
+				Entry("CIDR with an existing VPC", func(c *api.ClusterConfig) {
+					c.VPC.ID = "vpc-1"
+					c.VPC.PodSubnets.CIDR = ipnet.MustParseCIDR("100.64.0.0/16")
+				}, "vpc.podSubnets.cidr cannot be set with a pre-existing VPC; specify the pod subnets by ID instead"),
+				Entry("subnet without ID with an existing VPC", func(c *api.ClusterConfig) {
+					c.VPC.ID = "vpc-1"
+					c.VPC.PodSubnets.Subnets = api.AZSubnetMapping{"us-west-2a": {CIDR: ipnet.MustParseCIDR("100.64.0.0/18")}}
+				}, `vpc.podSubnets.subnets["us-west-2a"].id must be set when using a pre-existing VPC`),
+			)
+		})
+
 		Context("ipv6 CIDRs", func() {
 			When("IPv6Cidr or IPv6CidrPool is provided and ipv6 is not set", func() {
 				It("returns an error", func() {
@@ -1928,6 +2053,33 @@ var _ = Describe("ClusterConfig validation", func() {
 				err := api.ValidateNodeGroup(0, ng, cfg)
 				Expect(err).NotTo(HaveOccurred())
 			})
+
+			It("Forbids unknown kubelet options", func() {
+				ng.KubeletExtraConfig = &api.InlineDocument{
+					"cgroupDriver": "systemd",
+					"kubeReseved": map[string]string{
+						"cpu": "300m",
+					},
+				}
+				err := api.ValidateNodeGroup(0, ng, cfg)
+				Expect(err).To(MatchError(`unknown kubelet config field "kubeReseved" (path=nodeGroups[0].kubeletExtraConfig.kubeReseved)`))
+			})
+
+			It("Forbids invalid values for kubelet options", func() {
+				ng.KubeletExtraConfig = &api.InlineDocument{
+					"maxPods": "many",
+				}
+				err := api.ValidateNodeGroup(0, ng, cfg)
+				Expect(err).To(MatchError(ContainSubstring(`invalid value for kubelet config field "maxPods" (path=nodeGroups[0].kubeletExtraConfig.maxPods)`)))
+
+				ng.KubeletExtraConfig = &api.InlineDocument{
+					"logging": map[string]interface{}{
+						"formt": "json",
+					},
+				}
+				err = api.ValidateNodeGroup(0, ng, cfg)
+				Expect(err).To(MatchError(ContainSubstring(`invalid value for kubelet config field "logging" (path=nodeGroups[0].kubeletExtraConfig.logging): json: unknown field "formt"`)))
+			})
 		})
 	})
 
@@ -2160,6 +2312,66 @@ var _ = Describe("ClusterConfig validation", func() {
 				expectedErr: "only one of nodeGroups[0].bottlerocket.settings.kubernetes.cluster-dns-ip or nodeGroups[0].clusterDNS can be set",
 			}),
 
+			Entry("unknown setting", bottlerocketEntry{
+				ng: &api.NodeGroup{
+					NodeGroupBase: &api.NodeGroupBase{
+						Bottlerocket: &api.NodeGroupBottlerocket{
+							Settings: &api.InlineDocument{
+								"kubernets": map[string]interface{}{
+									"max-pods": 32,
+								},
+							},
+						},
+					},
+				},
+
+				expectedErr: `unknown Bottlerocket setting "kubernets" (path=nodeGroups[0].bottlerocket.settings.kubernets)`,
+			}),
+
+			Entry("unknown kubernetes setting", bottlerocketEntry{
+				ng: &api.NodeGroup{
+					NodeGroupBase: &api.NodeGroupBase{
+						Bottlerocket: &api.NodeGroupBottlerocket{
+							Settings: &api.InlineDocument{
+								"motd": "Hello, eksctl!",
+								"kubernetes": map[string]interface{}{
+									"eviction-hard": map[string]interface{}{
+										"memory.available": "15%",
+									},
+									"evictoin-soft": map[string]interface{}{
+										"memory.available": "20%",
+									},
+								},
+							},
+						},
+					},
+				},
+
+				expectedErr: `unknown Bottlerocket setting "kubernetes.evictoin-soft" (path=nodeGroups[0].bottlerocket.settings.kubernetes.evictoin-soft)`,
+			}),
+
+			Entry("known settings", bottlerocketEntry{
+				ng: &api.NodeGroup{
+					NodeGroupBase: &api.NodeGroupBase{
+						Bottlerocket: &api.NodeGroupBottlerocket{
+							Settings: &api.InlineDocument{
+								"motd": "Hello, eksctl!",
+								"host-containers": map[string]interface{}{
+									"my-container": map[string]interface{}{
+										"enabled": true,
+									},
+								},
+								"kernel": map[string]interface{}{
+									"sysctl": map[string]interface{}{
+										"vm.max_map_count": "262144",
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+
 			Entry("labels", bottlerocketEntry{
 				ng: &api.NodeGroup{
 					NodeGroupBase: &api.NodeGroupBase{Labels: map[string]string{"label": "label-value"}},
@@ -2370,6 +2582,111 @@ var _ = Describe("ClusterConfig validation", func() {
 			}
 			Expect(api.ValidateClusterConfig(cfg)).To(MatchError(ContainSubstring("failed to validate Karpenter config: minimum supported version is v0.20.0")))
 		})
+
+		type nodePoolsEntry struct {
+			version     string
+			updateFn    func(*api.KarpenterNodePool)
+			expectedErr string
+		}
+
+		DescribeTable("nodePools", func(e nodePoolsEntry) {
+			cfg := api.NewClusterConfig()
+			cfg.IAM.WithOIDC = aws.Bool(true)
+			np := api.KarpenterNodePool{
+				Name: "default",
+				Requirements: []api.KarpenterRequirement{
+					{Key: "karpenter.sh/capacity-type", Operator: "In", Values: []string{"spot", "on-demand"}},
+				},
+				Limits: map[string]string{"cpu": "100", "memory": "400Gi"},
+			}
+			if e.updateFn != nil {
+				e.updateFn(&np)
+			}
+			cfg.Karpenter = &api.Karpenter{
+				Version:   "1.2.1",
+				NodePools: []api.KarpenterNodePool{np},
+			}
+			if e.version != "" {
+				cfg.Karpenter.Version = e.version
+			}
+			err := api.ValidateClusterConfig(cfg)
+			if e.expectedErr == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+		},
+			Entry("valid nodePool", nodePoolsEntry{}),
+			Entry("Karpenter version without the v1 API", nodePoolsEntry{
+				version:     "0.37.0",
+				expectedErr: "karpenter.nodePools requires Karpenter v1.0.0 or later",
+			}),
+			Entry("missing name", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.Name = ""
+				},
+				expectedErr: "karpenter.nodePools[0].name must be set",
+			}),
+			Entry("unknown requirement operator", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.Requirements[0].Operator = "Equals"
+				},
+				expectedErr: "karpenter.nodePools[0].requirements[0].operator must be one of In, NotIn, Exists, DoesNotExist, Gt or Lt",
+			}),
+			Entry("Gt requirement with a non-integer value", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.Requirements[0] = api.KarpenterRequirement{Key: "karpenter.k8s.aws/instance-generation", Operator: "Gt", Values: []string{"two"}}
+				},
+				expectedErr: `karpenter.nodePools[0].requirements[0].values must be an integer with operator "Gt"`,
+			}),
+			Entry("invalid limit", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.Limits["cpu"] = "lots"
+				},
+				expectedErr: `karpenter.nodePools[0].limits: invalid quantity "lots" for "cpu"`,
+			}),
+			Entry("unknown consolidation policy", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.Disruption = &api.KarpenterDisruption{ConsolidationPolicy: "Always"}
+				},
+				expectedErr: "karpenter.nodePools[0].disruption.consolidationPolicy must be one of WhenEmpty or WhenEmptyOrUnderutilized",
+			}),
+			Entry("budget schedule without a duration", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.Disruption = &api.KarpenterDisruption{
+						Budgets: []api.KarpenterDisruptionBudget{{Nodes: "0", Schedule: "@daily"}},
+					}
+				},
+				expectedErr: "karpenter.nodePools[0].disruption.budgets[0]: schedule and duration must be set together",
+			}),
+			Entry("AMI alias combined with other terms", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.AMISelectorTerms = []api.KarpenterAMISelectorTerm{
+						{Alias: "al2023@latest"},
+						{ID: "ami-123"},
+					}
+				},
+				expectedErr: "karpenter.nodePools[0].amiSelectorTerms[0].alias cannot be combined with other AMI selector terms or fields",
+			}),
+			Entry("empty AMI selector term", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.AMISelectorTerms = []api.KarpenterAMISelectorTerm{{}}
+				},
+				expectedErr: "karpenter.nodePools[0].amiSelectorTerms[0] must set at least one of alias, id, name, owner or tags",
+			}),
+			Entry("valid labels and taints", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.Labels = map[string]string{"role": "workers"}
+					np.Taints = []api.NodeGroupTaint{{Key: "dedicated", Value: "workers", Effect: "NoSchedule"}}
+				},
+			}),
+			Entry("invalid taint effect", nodePoolsEntry{
+				updateFn: func(np *api.KarpenterNodePool) {
+					np.Taints = []api.NodeGroupTaint{{Key: "dedicated", Effect: "Sometimes"}}
+				},
+				expectedErr: "karpenter.nodePools[0].taints: invalid taint effect: Sometimes, unsupported taint effect",
+			}),
+		)
 	})
 
 	type labelsTaintsEntry struct {
@@ -2493,6 +2810,35 @@ var _ = Describe("ClusterConfig validation", func() {
 		})
 	})
 
+	DescribeTable("amiResolution", func(amiResolution *api.AMIResolution, expectedErr string) {
+		cfg := api.NewClusterConfig()
+		cfg.AMIResolution = amiResolution
+		err := api.ValidateClusterConfig(cfg)
+		if expectedErr == "" {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		}
+	},
+		Entry("valid SSM parameter path", &api.AMIResolution{
+			SSMParameterPath: "/golden-amis/{{version}}/{{family}}/{{ arch }}/image_id",
+		}, ""),
+		Entry("valid catalogue file", &api.AMIResolution{
+			CatalogueFile: "amis.yaml",
+		}, ""),
+		Entry("no source", &api.AMIResolution{}, "one of amiResolution.ssmParameterPath or amiResolution.catalogueFile must be set"),
+		Entry("both sources", &api.AMIResolution{
+			SSMParameterPath: "/golden-amis/{{version}}",
+			CatalogueFile:    "amis.yaml",
+		}, "only one of amiResolution.ssmParameterPath or amiResolution.catalogueFile can be set"),
+		Entry("relative SSM parameter path", &api.AMIResolution{
+			SSMParameterPath: "golden-amis/{{version}}",
+		}, "amiResolution.ssmParameterPath must be a fully qualified SSM parameter name"),
+		Entry("unknown placeholder", &api.AMIResolution{
+			SSMParameterPath: "/golden-amis/{{region}}/{{version}}",
+		}, `unknown placeholder "{{region}}" in amiResolution.ssmParameterPath`),
+	)
+
 	Describe("Validate SecretsEncryption", func() {
 		var cfg *api.ClusterConfig
 
@@ -181,6 +181,27 @@ type (
 		// ControlPlaneSecurityGroupIDs configures the security groups for the control plane.
 		// +optional
 		ControlPlaneSecurityGroupIDs []string `json:"controlPlaneSecurityGroupIDs,omitempty"`
+		// PodSubnets enables VPC CNI custom networking, with pods being
+		// assigned IPs from subnets other than the ones of their nodes.
+		// See [custom networking](/usage/vpc-custom-networking/)
+		// +optional
+		PodSubnets *PodSubnets `json:"podSubnets,omitempty"`
+	}
+	// PodSubnets holds the subnets used for pod networking, one per AZ
+	PodSubnets struct {
+		// CIDR is associated with the VPC as a secondary CIDR block, and
+		// pod subnets are carved out of it. Only used when eksctl creates the VPC.
+		// Defaults to `100.64.0.0/16`
+		// +optional
+		CIDR *ipnet.IPNet `json:"cidr,omitempty"`
+		// Subnets are keyed by AZ; when using an existing VPC, they must
+		// be specified by ID
+		// +optional
+		Subnets AZSubnetMapping `json:"subnets,omitempty"`
+		// SecurityGroups are attached to the pod network interfaces.
+		// Defaults to the security groups of the primary network interface of the node
+		// +optional
+		SecurityGroups []string `json:"securityGroups,omitempty"`
 	}
 	// ClusterSubnets holds private and public subnets
 	ClusterSubnets struct {
@@ -264,6 +285,16 @@ func DefaultCIDR() ipnet.IPNet {
 	}
 }
 
+// DefaultPodSubnetsCIDR returns the default CIDR for pod subnets
+func DefaultPodSubnetsCIDR() ipnet.IPNet {
+	return ipnet.IPNet{
+		IPNet: net.IPNet{
+			IP:   []byte{100, 64, 0, 0},
+			Mask: []byte{255, 255, 0, 0},
+		},
+	}
+}
+
 // ImportSubnet loads a given subnet into ClusterConfig.
 // Note that the user must use
 // either AZs as keys
@@ -410,6 +441,11 @@ func (c *ClusterConfig) HasAnySubnets() bool {
 	return c.VPC.Subnets != nil && (len(c.VPC.Subnets.Private) > 0 || len(c.VPC.Subnets.Public) > 0)
 }
 
+// HasPodSubnets returns true if VPC CNI custom networking is configured
+func (c *ClusterConfig) HasPodSubnets() bool {
+	return c.VPC != nil && c.VPC.PodSubnets != nil
+}
+
 // HasSufficientPrivateSubnets validates if there is a sufficient
 // number of private subnets available to create a cluster
 func (c *ClusterConfig) HasSufficientPrivateSubnets() bool {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 )
 
 // WellKnownPolicies for attaching common IAM policies
@@ -32,10 +34,66 @@ type WellKnownPolicies struct {
 	// efs-csi-controller. See [aws-efs-csi-driver
 	// docs](https://aws.amazon.com/blogs/containers/introducing-efs-csi-dynamic-provisioning).
 	EFSCSIController bool `json:"efsCSIController,inline"`
+	// Velero adds policies for backing up volumes and cluster resources
+	// to Amazon S3 with Velero. See [velero-plugin-for-aws
+	// docs](https://github.com/vmware-tanzu/velero-plugin-for-aws#set-permissions-for-velero).
+	Velero bool `json:"velero,inline"`
+	// SecretsStoreCSIDriver adds policies for mounting AWS Secrets Manager
+	// secrets and Systems Manager parameters with the Secrets Store CSI
+	// driver. See [secrets-store-csi-driver-provider-aws
+	// docs](https://github.com/aws/secrets-store-csi-driver-provider-aws).
+	SecretsStoreCSIDriver bool `json:"secretsStoreCSIDriver,inline"`
+	// FSxCSIController adds policies for using the fsx-csi-controller.
+	// See [aws-fsx-csi-driver
+	// docs](https://github.com/kubernetes-sigs/aws-fsx-csi-driver/blob/master/docs/install.md).
+	FSxCSIController bool `json:"fsxCSIController,inline"`
+	// MountpointS3CSIDriver adds policies for mounting Amazon S3 buckets
+	// with the Mountpoint for Amazon S3 CSI driver. See [mountpoint-s3-csi-driver
+	// docs](https://github.com/awslabs/mountpoint-s3-csi-driver/blob/main/docs/install.md).
+	MountpointS3CSIDriver bool `json:"mountpointS3CSIDriver,inline"`
+	// CloudWatchAgent adds policies for using the CloudWatch agent and
+	// Container Insights. See [Container Insights
+	// docs](https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/install-CloudWatch-Observability-EKS-addon.html).
+	CloudWatchAgent bool `json:"cloudWatchAgent,inline"`
+	// AWSDistroForOpenTelemetry adds policies for exporting metrics and
+	// traces with the AWS Distro for OpenTelemetry collector. See [ADOT
+	// docs](https://aws-otel.github.io/docs/getting-started/adot-eks-add-on).
+	AWSDistroForOpenTelemetry bool `json:"awsDistroForOpenTelemetry,inline"`
+	// S3BucketARNs restricts the Velero and MountpointS3CSIDriver policies
+	// to the specified S3 buckets. Defaults to all buckets
+	// +optional
+	S3BucketARNs []string `json:"s3BucketARNs,omitempty"`
+	// FSxFileSystemARNs restricts the FSxCSIController policy to the
+	// specified FSx file systems. The driver can then only mount these file
+	// systems and cannot provision new ones
+	// +optional
+	FSxFileSystemARNs []string `json:"fsxFileSystemARNs,omitempty"`
 }
 
 func (p *WellKnownPolicies) HasPolicy() bool {
-	return p.ImageBuilder || p.AutoScaler || p.AWSLoadBalancerController || p.ExternalDNS || p.CertManager || p.EBSCSIController || p.EFSCSIController
+	return p.ImageBuilder || p.AutoScaler || p.AWSLoadBalancerController || p.ExternalDNS || p.CertManager || p.EBSCSIController || p.EFSCSIController ||
+		p.Velero || p.SecretsStoreCSIDriver || p.FSxCSIController || p.MountpointS3CSIDriver || p.CloudWatchAgent || p.AWSDistroForOpenTelemetry
+}
+
+// Validate checks that resource ARNs are valid and only set for the policies that use them.
+func (p *WellKnownPolicies) Validate(path string) error {
+	if len(p.S3BucketARNs) > 0 && !p.Velero && !p.MountpointS3CSIDriver {
+		return fmt.Errorf("%s.s3BucketARNs can only be set with %[1]s.velero or %[1]s.mountpointS3CSIDriver", path)
+	}
+	for _, bucketARN := range p.S3BucketARNs {
+		if parsed, err := arn.Parse(bucketARN); err != nil || parsed.Service != "s3" {
+			return fmt.Errorf("%s.s3BucketARNs: invalid S3 bucket ARN %q", path, bucketARN)
+		}
+	}
+	if len(p.FSxFileSystemARNs) > 0 && !p.FSxCSIController {
+		return fmt.Errorf("%s.fsxFileSystemARNs can only be set with %[1]s.fsxCSIController", path)
+	}
+	for _, fileSystemARN := range p.FSxFileSystemARNs {
+		if parsed, err := arn.Parse(fileSystemARN); err != nil || parsed.Service != "fsx" {
+			return fmt.Errorf("%s.fsxFileSystemARNs: invalid FSx file system ARN %q", path, fileSystemARN)
+		}
+	}
+	return nil
 }
 
 func (p *WellKnownPolicies) String() string { return "" }
@@ -49,7 +107,7 @@ func (p *WellKnownPolicies) Set(policiesStr string) error {
 		isValidPolicyName := false
 		for i := 0; i < val.NumField(); i++ {
 			fieldName := val.Type().Field(i).Name
-			if strings.EqualFold(fieldName, pName) {
+			if val.Field(i).Kind() == reflect.Bool && strings.EqualFold(fieldName, pName) {
 				val.FieldByName(fieldName).SetBool(true)
 				isValidPolicyName = true
 			}
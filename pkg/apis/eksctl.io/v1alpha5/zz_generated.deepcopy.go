@@ -29,6 +29,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AMIResolution) DeepCopyInto(out *AMIResolution) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMIResolution.
+func (in *AMIResolution) DeepCopy() *AMIResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(AMIResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ARN) DeepCopyInto(out *ARN) {
 	*out = *in
@@ -192,7 +208,7 @@ func (in *Addon) DeepCopyInto(out *Addon) {
 		copy(*out, *in)
 	}
 	in.AttachPolicy.DeepCopyInto(&out.AttachPolicy)
-	out.WellKnownPolicies = in.WellKnownPolicies
+	in.WellKnownPolicies.DeepCopyInto(&out.WellKnownPolicies)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -476,6 +492,11 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 			}
 		}
 	}
+	if in.AMIResolution != nil {
+		in, out := &in.AMIResolution, &out.AMIResolution
+		*out = new(AMIResolution)
+		**out = **in
+	}
 	if in.FargateProfiles != nil {
 		in, out := &in.FargateProfiles, &out.FargateProfiles
 		*out = make([]*FargateProfile, len(*in))
@@ -715,7 +736,7 @@ func (in *ClusterIAMServiceAccount) DeepCopyInto(out *ClusterIAMServiceAccount)
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	out.WellKnownPolicies = in.WellKnownPolicies
+	in.WellKnownPolicies.DeepCopyInto(&out.WellKnownPolicies)
 	in.AttachPolicy.DeepCopyInto(&out.AttachPolicy)
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
@@ -951,6 +972,11 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodSubnets != nil {
+		in, out := &in.PodSubnets, &out.PodSubnets
+		*out = new(PodSubnets)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1343,6 +1369,13 @@ func (in *Karpenter) DeepCopyInto(out *Karpenter) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]KarpenterNodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1356,6 +1389,146 @@ func (in *Karpenter) DeepCopy() *Karpenter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterAMISelectorTerm) DeepCopyInto(out *KarpenterAMISelectorTerm) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterAMISelectorTerm.
+func (in *KarpenterAMISelectorTerm) DeepCopy() *KarpenterAMISelectorTerm {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterAMISelectorTerm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterDisruption) DeepCopyInto(out *KarpenterDisruption) {
+	*out = *in
+	if in.Budgets != nil {
+		in, out := &in.Budgets, &out.Budgets
+		*out = make([]KarpenterDisruptionBudget, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterDisruption.
+func (in *KarpenterDisruption) DeepCopy() *KarpenterDisruption {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterDisruption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterDisruptionBudget) DeepCopyInto(out *KarpenterDisruptionBudget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterDisruptionBudget.
+func (in *KarpenterDisruptionBudget) DeepCopy() *KarpenterDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterNodePool) DeepCopyInto(out *KarpenterNodePool) {
+	*out = *in
+	if in.Requirements != nil {
+		in, out := &in.Requirements, &out.Requirements
+		*out = make([]KarpenterRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]NodeGroupTaint, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Disruption != nil {
+		in, out := &in.Disruption, &out.Disruption
+		*out = new(KarpenterDisruption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMISelectorTerms != nil {
+		in, out := &in.AMISelectorTerms, &out.AMISelectorTerms
+		*out = make([]KarpenterAMISelectorTerm, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterNodePool.
+func (in *KarpenterNodePool) DeepCopy() *KarpenterNodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterNodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterRequirement) DeepCopyInto(out *KarpenterRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KarpenterRequirement.
+func (in *KarpenterRequirement) DeepCopy() *KarpenterRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(KarpenterRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesNetworkConfig) DeepCopyInto(out *KubernetesNetworkConfig) {
 	*out = *in
@@ -2124,7 +2297,7 @@ func (in *PodIdentityAssociation) DeepCopyInto(out *PodIdentityAssociation) {
 		copy(*out, *in)
 	}
 	in.PermissionPolicy.DeepCopyInto(&out.PermissionPolicy)
-	out.WellKnownPolicies = in.WellKnownPolicies
+	in.WellKnownPolicies.DeepCopyInto(&out.WellKnownPolicies)
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -2145,6 +2318,38 @@ func (in *PodIdentityAssociation) DeepCopy() *PodIdentityAssociation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSubnets) DeepCopyInto(out *PodSubnets) {
+	*out = *in
+	if in.CIDR != nil {
+		in, out := &in.CIDR, &out.CIDR
+		*out = (*in).DeepCopy()
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(AZSubnetMapping, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSubnets.
+func (in *PodSubnets) DeepCopy() *PodSubnets {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSubnets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrivateCluster) DeepCopyInto(out *PrivateCluster) {
 	*out = *in
@@ -2405,6 +2610,16 @@ func (in *VolumeMapping) DeepCopy() *VolumeMapping {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WellKnownPolicies) DeepCopyInto(out *WellKnownPolicies) {
 	*out = *in
+	if in.S3BucketARNs != nil {
+		in, out := &in.S3BucketARNs, &out.S3BucketARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FSxFileSystemARNs != nil {
+		in, out := &in.FSxFileSystemARNs, &out.FSxFileSystemARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
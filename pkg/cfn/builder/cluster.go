@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
@@ -207,7 +209,8 @@ func (c *ClusterResourceSet) addResourcesForSecurityGroups(vpcID *gfnt.Value) *c
 		})
 		if c.spec.IsControlPlaneOnOutposts() && c.spec.IsFullyPrivate() {
 			if subnets := c.spec.VPC.Subnets; subnets != nil && subnets.Private != nil {
-				for az, subnet := range subnets.Private {
+				for _, az := range slices.Sorted(maps.Keys(subnets.Private)) {
+					subnet := subnets.Private[az]
 					c.newResource(fmt.Sprintf("IngressPrivateSubnet%s", formatAZ(az)), &gfnec2.SecurityGroupIngress{
 						GroupId:     refClusterSharedNodeSG,
 						CidrIp:      gfnt.NewString(subnet.CIDR.String()),
@@ -474,10 +477,10 @@ func (c *ClusterResourceSet) addAccessEntryForRemoteNodes() {
 
 func makeCFNTags(clusterConfig *api.ClusterConfig) []gfncfn.Tag {
 	var tags []gfncfn.Tag
-	for k, v := range clusterConfig.Metadata.Tags {
+	for _, k := range slices.Sorted(maps.Keys(clusterConfig.Metadata.Tags)) {
 		tags = append(tags, gfncfn.Tag{
 			Key:   gfnt.NewString(k),
-			Value: gfnt.NewString(v),
+			Value: gfnt.NewString(clusterConfig.Metadata.Tags[k]),
 		})
 	}
 	return tags
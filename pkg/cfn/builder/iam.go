@@ -30,6 +30,8 @@ const (
 	iamPolicyAmazonEC2ContainerRegistryReadOnly  = "AmazonEC2ContainerRegistryReadOnly"
 	iamPolicyCloudWatchAgentServerPolicy         = "CloudWatchAgentServerPolicy"
 	iamPolicyAmazonSSMManagedInstanceCore        = "AmazonSSMManagedInstanceCore"
+	iamPolicyAmazonPrometheusRemoteWriteAccess   = "AmazonPrometheusRemoteWriteAccess"
+	iamPolicyAWSXrayWriteOnlyAccess              = "AWSXrayWriteOnlyAccess"
 
 	iamPolicyAmazonEKSFargatePodExecutionRolePolicy = "AmazonEKSFargatePodExecutionRolePolicy"
 )
@@ -74,6 +74,37 @@ func createWellKnownPolicies(wellKnownPolicies api.WellKnownPolicies) ([]managed
 			customPolicyForRole{Name: "PolicyEFSCSIController", Statements: efsCSIControllerStatements()},
 		)
 	}
+	if wellKnownPolicies.Velero {
+		customPolicies = append(customPolicies,
+			customPolicyForRole{Name: "PolicyVelero", Statements: veleroStatements(wellKnownPolicies.S3BucketARNs)},
+		)
+	}
+	if wellKnownPolicies.SecretsStoreCSIDriver {
+		customPolicies = append(customPolicies,
+			customPolicyForRole{Name: "PolicySecretsStoreCSIDriver", Statements: secretsStoreCSIDriverStatements()},
+		)
+	}
+	if wellKnownPolicies.FSxCSIController {
+		customPolicies = append(customPolicies,
+			customPolicyForRole{Name: "PolicyFSxCSIController", Statements: fsxCSIControllerStatements(wellKnownPolicies.FSxFileSystemARNs)},
+		)
+	}
+	if wellKnownPolicies.MountpointS3CSIDriver {
+		customPolicies = append(customPolicies,
+			customPolicyForRole{Name: "PolicyMountpointS3CSIDriver", Statements: mountpointS3CSIDriverStatements(wellKnownPolicies.S3BucketARNs)},
+		)
+	}
+	if wellKnownPolicies.CloudWatchAgent || wellKnownPolicies.AWSDistroForOpenTelemetry {
+		managedPolicies = append(managedPolicies,
+			managedPolicyForRole{name: iamPolicyCloudWatchAgentServerPolicy},
+		)
+	}
+	if wellKnownPolicies.AWSDistroForOpenTelemetry {
+		managedPolicies = append(managedPolicies,
+			managedPolicyForRole{name: iamPolicyAmazonPrometheusRemoteWriteAccess},
+			managedPolicyForRole{name: iamPolicyAWSXrayWriteOnlyAccess},
+		)
+	}
 	return managedPolicies, customPolicies
 }
 
@@ -299,6 +299,77 @@ var _ = Describe("template builder for IAM", func() {
 			Expect(t).To(HaveResourceWithPropertyValue("PolicyEBSCSIController", "PolicyDocument", expectedEbsPolicyDocument))
 		})
 
+		It("can construct an iamserviceaccount addon template with wellKnownPolicies scoped to S3 buckets and FSx file systems", func() {
+			serviceAccount := &api.ClusterIAMServiceAccount{}
+
+			serviceAccount.Name = "sa-1"
+
+			serviceAccount.WellKnownPolicies = api.WellKnownPolicies{
+				Velero:                    true,
+				SecretsStoreCSIDriver:     true,
+				FSxCSIController:          true,
+				MountpointS3CSIDriver:     true,
+				AWSDistroForOpenTelemetry: true,
+				S3BucketARNs:              []string{"arn:aws:s3:::backups"},
+				FSxFileSystemARNs:         []string{"arn:aws:fsx:us-west-2:111122223333:file-system/fs-1234"},
+			}
+
+			appendServiceAccountToClusterConfig(cfg, serviceAccount)
+
+			rs := builder.NewIAMRoleResourceSetForServiceAccount(serviceAccount, oidc)
+
+			templateBody := []byte{}
+
+			Expect(rs).To(RenderWithoutErrors(&templateBody))
+
+			t := cft.NewTemplate()
+
+			Expect(t).To(LoadBytesWithoutErrors(templateBody))
+
+			Expect(t.Resources).To(HaveLen(5))
+			Expect(t).To(HaveResourceWithPropertyValue(outputs.IAMServiceAccountRoleName, "ManagedPolicyArns", `[
+              {
+                "Fn::Sub": "arn:${AWS::Partition}:iam::aws:policy/CloudWatchAgentServerPolicy"
+              },
+              {
+                "Fn::Sub": "arn:${AWS::Partition}:iam::aws:policy/AmazonPrometheusRemoteWriteAccess"
+              },
+              {
+                "Fn::Sub": "arn:${AWS::Partition}:iam::aws:policy/AWSXrayWriteOnlyAccess"
+              }
+            ]`))
+			Expect(t).To(HaveResourceWithPropertyValue("PolicyMountpointS3CSIDriver", "PolicyDocument", `{
+              "Version": "2012-10-17",
+              "Statement": [
+                {
+                  "Action": ["s3:ListBucket"],
+                  "Effect": "Allow",
+                  "Resource": ["arn:aws:s3:::backups"]
+                },
+                {
+                  "Action": ["s3:GetObject", "s3:PutObject", "s3:AbortMultipartUpload", "s3:DeleteObject"],
+                  "Effect": "Allow",
+                  "Resource": ["arn:aws:s3:::backups/*"]
+                }
+              ]
+            }`))
+			Expect(t).To(HaveResourceWithPropertyValue("PolicyFSxCSIController", "PolicyDocument", `{
+              "Version": "2012-10-17",
+              "Statement": [
+                {
+                  "Action": ["fsx:DescribeFileSystems"],
+                  "Effect": "Allow",
+                  "Resource": "*"
+                },
+                {
+                  "Action": ["fsx:UpdateFileSystem", "fsx:TagResource"],
+                  "Effect": "Allow",
+                  "Resource": ["arn:aws:fsx:us-west-2:111122223333:file-system/fs-1234"]
+                }
+              ]
+            }`))
+		})
+
 		It("can parse an iamserviceaccount addon template", func() {
 			t := cft.NewTemplate()
 
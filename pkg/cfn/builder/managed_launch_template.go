@@ -3,12 +3,15 @@ package builder
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/weaveworks/eksctl/pkg/goformation/cloudformation/cloudformation"
 	gfnec2 "github.com/weaveworks/eksctl/pkg/goformation/cloudformation/ec2"
 	gfnt "github.com/weaveworks/eksctl/pkg/goformation/cloudformation/types"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
 )
 
 func (m *ManagedNodeGroupResourceSet) makeLaunchTemplateData(ctx context.Context) (*gfnec2.LaunchTemplate_LaunchTemplateData, error) {
@@ -45,6 +48,9 @@ func (m *ManagedNodeGroupResourceSet) makeLaunchTemplateData(ctx context.Context
 		return nil, err
 	}
 	if userData != "" {
+		if err := nodebootstrap.ValidateUserDataSize(userData, mng.NodeGroupBase); err != nil {
+			return nil, err
+		}
 		launchTemplateData.UserData = gfnt.NewString(userData)
 	}
 
@@ -167,10 +173,10 @@ func makeTags(ng *api.NodeGroupBase, meta *api.ClusterMeta) []gfnec2.LaunchTempl
 			Value: gfnt.NewString(generateNodeName(ng, meta)),
 		},
 	}
-	for k, v := range ng.Tags {
+	for _, k := range slices.Sorted(maps.Keys(ng.Tags)) {
 		cfnTags = append(cfnTags, cloudformation.Tag{
 			Key:   gfnt.NewString(k),
-			Value: gfnt.NewString(v),
+			Value: gfnt.NewString(ng.Tags[k]),
 		})
 	}
 
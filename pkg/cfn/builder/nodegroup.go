@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
-	"k8s.io/utils/strings/slices"
-
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 
 	gfn "github.com/weaveworks/eksctl/pkg/goformation/cloudformation"
@@ -362,8 +362,9 @@ func (n *NodeGroupResourceSet) addResourcesForNodeGroup(ctx context.Context) err
 // GenerateClusterAutoscalerTags generates Cluster Autoscaler tags for labels and taints.
 func GenerateClusterAutoscalerTags(np api.NodePool, addTag func(key, value string)) {
 	// labels
-	for k, v := range np.BaseNodeGroup().Labels {
-		addTag(labelsPrefix+k, v)
+	labels := np.BaseNodeGroup().Labels
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		addTag(labelsPrefix+k, labels[k])
 	}
 
 	var taints []api.NodeGroupTaint
@@ -418,7 +419,8 @@ func AssignSubnets(ctx context.Context, np api.NodePool, clusterConfig *api.Clus
 
 	subnetIDs := []string{}
 	// only assign a subnet if the AZ to which it belongs supports all required instance types
-	for key, subnetSpec := range subnetMapping {
+	for _, key := range slices.Sorted(maps.Keys(subnetMapping)) {
+		subnetSpec := subnetMapping[key]
 		az := subnetSpec.AZ
 		if az == "" {
 			az = key
@@ -451,6 +453,9 @@ func newLaunchTemplateData(ctx context.Context, n *NodeGroupResourceSet) (*gfnec
 	}
 
 	ng := n.options.NodeGroup
+	if err := nodebootstrap.ValidateUserDataSize(userData, ng.NodeGroupBase); err != nil {
+		return nil, err
+	}
 	launchTemplateData := &gfnec2.LaunchTemplate_LaunchTemplateData{
 		IamInstanceProfile: &gfnec2.LaunchTemplate_IamInstanceProfile{
 			Arn: n.instanceProfileARN,
@@ -617,3 +617,155 @@ func efsCSIControllerStatements() []cft.MapOfInterfaces {
 		},
 	}
 }
+
+// s3BucketResources returns the specified bucket ARNs, or all buckets, and the objects in them.
+func s3BucketResources(bucketARNs []string) (buckets, objects []*gfnt.Value) {
+	if len(bucketARNs) == 0 {
+		return []*gfnt.Value{addARNPartitionPrefix("s3:::*")}, []*gfnt.Value{addARNPartitionPrefix("s3:::*/*")}
+	}
+	for _, bucketARN := range bucketARNs {
+		buckets = append(buckets, gfnt.NewString(bucketARN))
+		objects = append(objects, gfnt.NewString(bucketARN+"/*"))
+	}
+	return buckets, objects
+}
+
+func veleroStatements(bucketARNs []string) []cft.MapOfInterfaces {
+	buckets, objects := s3BucketResources(bucketARNs)
+	return []cft.MapOfInterfaces{
+		{
+			"Effect":   effectAllow,
+			"Resource": resourceAll,
+			"Action": []string{
+				"ec2:DescribeVolumes",
+				"ec2:DescribeSnapshots",
+				"ec2:CreateTags",
+				"ec2:CreateVolume",
+				"ec2:CreateSnapshot",
+				"ec2:DeleteSnapshot",
+			},
+		},
+		{
+			"Effect":   effectAllow,
+			"Resource": objects,
+			"Action": []string{
+				"s3:GetObject",
+				"s3:DeleteObject",
+				"s3:PutObject",
+				"s3:AbortMultipartUpload",
+				"s3:ListMultipartUploadParts",
+			},
+		},
+		{
+			"Effect":   effectAllow,
+			"Resource": buckets,
+			"Action": []string{
+				"s3:ListBucket",
+			},
+		},
+	}
+}
+
+func secretsStoreCSIDriverStatements() []cft.MapOfInterfaces {
+	return []cft.MapOfInterfaces{
+		{
+			"Effect":   effectAllow,
+			"Resource": addARNPartitionPrefix("secretsmanager:*:*:secret:*"),
+			"Action": []string{
+				"secretsmanager:GetSecretValue",
+				"secretsmanager:DescribeSecret",
+			},
+		},
+		{
+			"Effect":   effectAllow,
+			"Resource": addARNPartitionPrefix("ssm:*:*:parameter/*"),
+			"Action": []string{
+				"ssm:GetParameters",
+			},
+		},
+	}
+}
+
+func fsxCSIControllerStatements(fileSystemARNs []string) []cft.MapOfInterfaces {
+	if len(fileSystemARNs) > 0 {
+		var fileSystems []*gfnt.Value
+		for _, fileSystemARN := range fileSystemARNs {
+			fileSystems = append(fileSystems, gfnt.NewString(fileSystemARN))
+		}
+		return []cft.MapOfInterfaces{
+			{
+				"Effect":   effectAllow,
+				"Resource": resourceAll,
+				"Action": []string{
+					"fsx:DescribeFileSystems",
+				},
+			},
+			{
+				"Effect":   effectAllow,
+				"Resource": fileSystems,
+				"Action": []string{
+					"fsx:UpdateFileSystem",
+					"fsx:TagResource",
+				},
+			},
+		}
+	}
+	return []cft.MapOfInterfaces{
+		{
+			"Effect":   effectAllow,
+			"Resource": addARNPartitionPrefix("iam::*:role/aws-service-role/s3.data-source.lustre.fsx.amazonaws.com/*"),
+			"Action": []string{
+				"iam:CreateServiceLinkedRole",
+				"iam:AttachRolePolicy",
+				"iam:PutRolePolicy",
+			},
+		},
+		{
+			"Effect":   effectAllow,
+			"Resource": resourceAll,
+			"Action": []string{
+				"iam:CreateServiceLinkedRole",
+			},
+			"Condition": map[string]interface{}{
+				"StringLike": map[string]string{
+					"iam:AWSServiceName": "fsx.amazonaws.com",
+				},
+			},
+		},
+		{
+			"Effect":   effectAllow,
+			"Resource": resourceAll,
+			"Action": []string{
+				"s3:ListBucket",
+				"fsx:CreateFileSystem",
+				"fsx:DeleteFileSystem",
+				"fsx:DescribeFileSystems",
+				"fsx:UpdateFileSystem",
+				"fsx:TagResource",
+			},
+		},
+	}
+}
+
+func mountpointS3CSIDriverStatements(bucketARNs []string) []cft.MapOfInterfaces {
+	buckets, objects := s3BucketResources(bucketARNs)
+	return []cft.MapOfInterfaces{
+		{
+			"Effect":   effectAllow,
+			"Resource": buckets,
+			"Action": []string{
+				"s3:ListBucket",
+			},
+		},
+		{
+			"Effect":   effectAllow,
+			"Resource": objects,
+			"Action": []string{
+				"s3:GetObject",
+				"s3:PutObject",
+				"s3:AbortMultipartUpload",
+				"s3:DeleteObject",
+			},
+		},
+	}
+}
@@ -91,6 +91,10 @@ func (v *ExistingVPCResourceSet) addOutputs(ctx context.Context) {
 		addSubnetOutput(subnetAZs, v.clusterConfig.VPC.Subnets.Public, outputs.ClusterSubnetsPublic)
 	}
 
+	if subnetAZs := v.subnetDetails.PodSubnetRefs(); len(subnetAZs) > 0 {
+		addSubnetOutput(subnetAZs, v.clusterConfig.VPC.PodSubnets.Subnets, outputs.ClusterSubnetsPod)
+	}
+
 	if v.clusterConfig.IsFullyPrivate() {
 		v.rs.defineOutputWithoutCollector(outputs.ClusterFullyPrivate, true, true)
 	}
@@ -131,6 +135,14 @@ func (v *ExistingVPCResourceSet) importExistingResources(ctx context.Context) er
 		v.subnetDetails.Public = subnetResources
 	}
 
+	if v.clusterConfig.HasPodSubnets() {
+		subnetResources, err := makeSubnetResources(v.clusterConfig.VPC.PodSubnets.Subnets, nil)
+		if err != nil {
+			return err
+		}
+		v.subnetDetails.Pod = subnetResources
+	}
+
 	return nil
 }
 
@@ -3,6 +3,8 @@ package builder
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -23,6 +25,7 @@ const (
 	cfnSharedNodeSGResource           = "ClusterSharedNodeSecurityGroup"
 	cfnIngressClusterToNodeSGResource = "IngressDefaultClusterToNodeSG"
 	cfnVPCResource                    = "VPC"
+	cfnPodCIDRResource                = "PodCIDR"
 )
 
 // A IPv4VPCResourceSet builds the resources required for the specified VPC
@@ -48,6 +51,7 @@ type SubnetDetails struct {
 	Public           []SubnetResource
 	PrivateLocalZone []SubnetResource
 	PublicLocalZone  []SubnetResource
+	Pod              []SubnetResource
 
 	controlPlaneOnOutposts bool
 	autoMode               bool
@@ -91,6 +95,9 @@ func (v *IPv4VPCResourceSet) addResources() error {
 	// - Private Route Tables
 	v.addPrivateRouteTables()
 	v.subnetDetails.Private = v.addSubnets(nil, api.SubnetTopologyPrivate, vpc.Subnets.Private)
+	if vpc.PodSubnets != nil {
+		v.addPodSubnets()
+	}
 
 	if v.clusterConfig.IsFullyPrivate() {
 		// if the cluster if fully private, we have already added all required resources
@@ -171,6 +178,10 @@ func (s *SubnetDetails) PrivateSubnetRefs() []*gfnt.Value {
 	return collectSubnetRefs(s.Private)
 }
 
+func (s *SubnetDetails) PodSubnetRefs() []*gfnt.Value {
+	return collectSubnetRefs(s.Pod)
+}
+
 func (s *SubnetDetails) PublicLocalZoneSubnetRefs() []*gfnt.Value {
 	return collectSubnetRefs(s.PublicLocalZone)
 }
@@ -243,6 +254,10 @@ func (v *IPv4VPCResourceSet) addOutputs(ctx context.Context) {
 		addSubnetOutput(subnetAZs, clusterVPC.Subnets.Public, outputs.ClusterSubnetsPublic)
 	}
 
+	if subnetAZs := v.subnetDetails.PodSubnetRefs(); len(subnetAZs) > 0 {
+		addSubnetOutput(subnetAZs, clusterVPC.PodSubnets.Subnets, outputs.ClusterSubnetsPod)
+	}
+
 	if subnetAZs := v.subnetDetails.PrivateLocalZoneSubnetRefs(); len(subnetAZs) > 0 {
 		addSubnetOutput(subnetAZs, clusterVPC.LocalZoneSubnets.Private, outputs.ClusterSubnetsPrivateLocal)
 	}
@@ -275,7 +290,8 @@ func (v *IPv4VPCResourceSet) addSubnets(refRT *gfnt.Value, topology api.SubnetTo
 
 	var subnetResources []SubnetResource
 
-	for name, s := range subnets {
+	for _, name := range slices.Sorted(maps.Keys(subnets)) {
+		s := subnets[name]
 		az := s.AZ
 		nameAlias := makeAZResourceName(name)
 		subnet := &gfnec2.Subnet{
@@ -332,6 +348,52 @@ func (v *IPv4VPCResourceSet) addSubnets(refRT *gfnt.Value, topology api.SubnetTo
 	return subnetResources
 }
 
+// addPodSubnets adds the pod subnets used for custom networking, along with the secondary
+// CIDR block they are carved out of; each pod subnet uses the private route table of its AZ
+func (v *IPv4VPCResourceSet) addPodSubnets() {
+	podSubnets := v.clusterConfig.VPC.PodSubnets
+	v.rs.newResource(cfnPodCIDRResource, &gfnec2.VPCCidrBlock{
+		VpcId:     v.vpcID,
+		CidrBlock: gfnt.NewString(podSubnets.CIDR.String()),
+	})
+
+	privateSubnetAliases := map[string]string{}
+	for _, alias := range slices.Sorted(maps.Keys(v.clusterConfig.VPC.Subnets.Private)) {
+		az := v.clusterConfig.VPC.Subnets.Private[alias].AZ
+		if _, ok := privateSubnetAliases[az]; !ok {
+			privateSubnetAliases[az] = alias
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(podSubnets.Subnets)) {
+		s := podSubnets.Subnets[name]
+		nameAlias := makeAZResourceName(name)
+		subnet := &gfnec2.Subnet{
+			AvailabilityZone:           gfnt.NewString(s.AZ),
+			CidrBlock:                  gfnt.NewString(s.CIDR.String()),
+			VpcId:                      v.vpcID,
+			AWSCloudFormationDependsOn: []string{cfnPodCIDRResource},
+		}
+		maybeSetHostnameType(v.clusterConfig.VPC, subnet)
+		refSubnet := v.rs.newResource("SubnetPod"+nameAlias, subnet)
+
+		privateSubnetAlias, ok := privateSubnetAliases[s.AZ]
+		if !ok {
+			privateSubnetAlias = s.AZ
+		}
+		refRT := gfnt.MakeRef("PrivateRouteTable" + makeAZResourceName(privateSubnetAlias))
+		v.rs.newResource("RouteTableAssociationPod"+nameAlias, &gfnec2.SubnetRouteTableAssociation{
+			SubnetId:     refSubnet,
+			RouteTableId: refRT,
+		})
+
+		v.subnetDetails.Pod = append(v.subnetDetails.Pod, SubnetResource{
+			AvailabilityZone: s.AZ,
+			RouteTable:       refRT,
+			Subnet:           refSubnet,
+		})
+	}
+}
+
 func (v *IPv4VPCResourceSet) getPrivateRouteTableRefForAZ(az string) *gfnt.Value {
 	return v.azToRTMap[az]
 }
@@ -403,11 +465,11 @@ func (v *IPv4VPCResourceSet) addHybridNodesNetworking() {
 }
 
 func forEachPrivateSubnet(clusterVPC *api.ClusterVPC, fn func(subnetAlias string)) {
-	for subnetAlias := range clusterVPC.Subnets.Private {
+	for _, subnetAlias := range slices.Sorted(maps.Keys(clusterVPC.Subnets.Private)) {
 		fn(subnetAlias)
 	}
 	if clusterVPC.LocalZoneSubnets != nil {
-		for subnetAlias := range clusterVPC.LocalZoneSubnets.Private {
+		for _, subnetAlias := range slices.Sorted(maps.Keys(clusterVPC.LocalZoneSubnets.Private)) {
 			fn(subnetAlias)
 		}
 	}
@@ -446,7 +508,7 @@ type clusterSecurityGroup struct {
 }
 
 func (v *IPv4VPCResourceSet) haNAT() {
-	for subnetAlias := range v.clusterConfig.VPC.Subnets.Public {
+	for _, subnetAlias := range slices.Sorted(maps.Keys(v.clusterConfig.VPC.Subnets.Public)) {
 		subnetAZResourceName := makeAZResourceName(subnetAlias)
 
 		// Allocate an EIP
@@ -90,8 +90,8 @@ func newTag(key, value string) types.Tag {
 	return types.Tag{Key: &key, Value: &value}
 }
 
-// NewStackCollection creates a stack manager for a single cluster
-func NewStackCollection(provider api.ClusterProvider, spec *api.ClusterConfig) StackManager {
+// SharedTags returns the tags set on all stacks of the cluster, which CloudFormation propagates to their resources
+func SharedTags(spec *api.ClusterConfig) []types.Tag {
 	tags := []types.Tag{
 		newTag(api.ClusterNameTag, spec.Metadata.Name),
 		newTag(api.OldClusterNameTag, spec.Metadata.Name),
@@ -100,9 +100,14 @@ func NewStackCollection(provider api.ClusterProvider, spec *api.ClusterConfig) S
 	for key, value := range spec.Metadata.Tags {
 		tags = append(tags, newTag(key, value))
 	}
+	return tags
+}
+
+// NewStackCollection creates a stack manager for a single cluster
+func NewStackCollection(provider api.ClusterProvider, spec *api.ClusterConfig) StackManager {
 	return &StackCollection{
 		spec:              spec,
-		sharedTags:        tags,
+		sharedTags:        SharedTags(spec),
 		cloudformationAPI: provider.CloudFormation(),
 		ec2API:            provider.EC2(),
 		eksAPI:            provider.EKS(),
@@ -482,6 +487,12 @@ func (c *StackCollection) ListStacksMatching(ctx context.Context, nameRegex stri
 	return stacks, nil
 }
 
+// MakeStackNamePrefix returns the prefix shared by the names of all stacks eksctl creates for a cluster,
+// and by the names of the IAM roles CloudFormation creates in those stacks.
+func MakeStackNamePrefix(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-", clusterName)
+}
+
 // ListStackNames lists all stack names matching regExp.
 func (c *StackCollection) ListStackNames(ctx context.Context, regExp string) ([]string, error) {
 	re, err := regexp.Compile(regExp)
@@ -634,6 +645,60 @@ func (c *StackCollection) DeleteStackSync(ctx context.Context, s *Stack) error {
 	return c.doWaitUntilStackIsDeleted(ctx, s)
 }
 
+// PrepareStackForRetry makes way for a stack that a previous, failed or interrupted, run may have left behind to be
+// created again. It waits for a stack that is still in progress, and deletes a stack whose creation failed.
+// It returns the stack if it exists once settled, in which case it was created successfully, or nil.
+func PrepareStackForRetry(ctx context.Context, stackManager StackManager, stackName string) (*Stack, error) {
+	describeStack := func() (*Stack, error) {
+		stack, err := stackManager.DescribeStack(ctx, &Stack{StackName: aws.String(stackName)})
+		if err != nil {
+			if IsStackDoesNotExistError(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return stack, nil
+	}
+
+	stack, err := describeStack()
+	if err != nil || stack == nil {
+		return nil, err
+	}
+	if strings.HasSuffix(string(stack.StackStatus), "_IN_PROGRESS") {
+		logger.Info("waiting for stack %q, left in status %s by a previous run", stackName, stack.StackStatus)
+		w := &waiter.Waiter{
+			NextDelay: waiter.ClusterCreationNextDelay,
+			Operation: func() (bool, error) {
+				if stack, err = describeStack(); err != nil || stack == nil {
+					return true, err
+				}
+				return !strings.HasSuffix(string(stack.StackStatus), "_IN_PROGRESS"), nil
+			},
+		}
+		if err := w.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("waiting for stack %q: %w", stackName, err)
+		}
+		if stack == nil {
+			return nil, nil
+		}
+	}
+
+	switch stack.StackStatus {
+	case types.StackStatusCreateComplete, types.StackStatusUpdateComplete, types.StackStatusUpdateRollbackComplete:
+		return stack, nil
+	case types.StackStatusDeleteComplete:
+		return nil, nil
+	case types.StackStatusRollbackComplete, types.StackStatusCreateFailed:
+		logger.Info("deleting stack %q, left in status %s by a previous run, to create it again", stackName, stack.StackStatus)
+		if err := stackManager.DeleteStackSync(ctx, stack); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("stack %q is in status %s and cannot be created again; delete it and retry", stackName, stack.StackStatus)
+	}
+}
+
 func fmtStacksRegexForCluster(name string) string {
 	return fmt.Sprintf(ourStackRegexFmt, name)
 }
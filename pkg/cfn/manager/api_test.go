@@ -10,6 +10,7 @@ import (
 	asTypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	cfn "github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
 
 	. "github.com/onsi/ginkgo/v2"
 
@@ -436,4 +437,67 @@ var _ = Describe("StackCollection", func() {
 			})
 		})
 	})
+
+	Context("PrepareStackForRetry", func() {
+		const stackName = "eksctl-test-cluster-nodegroup-ng-1"
+		var (
+			p  *mockprovider.MockProvider
+			sm StackManager
+		)
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			cfg := api.NewClusterConfig()
+			cfg.Metadata.Name = "test-cluster"
+			sm = NewStackCollection(p, cfg)
+		})
+
+		mockStackStatus := func(status types.StackStatus) *mock.Call {
+			return p.MockCloudFormation().On("DescribeStacks", mock.Anything, &cfn.DescribeStacksInput{StackName: aws.String(stackName)}, mock.Anything).Return(&cfn.DescribeStacksOutput{
+				Stacks: []types.Stack{{
+					StackName:   aws.String(stackName),
+					StackId:     aws.String("stack-id"),
+					StackStatus: status,
+					Tags:        []types.Tag{{Key: aws.String(api.ClusterNameTag), Value: aws.String("test-cluster")}},
+				}},
+			}, nil)
+		}
+		mockStackDoesNotExist := func() {
+			p.MockCloudFormation().On("DescribeStacks", mock.Anything, &cfn.DescribeStacksInput{StackName: aws.String(stackName)}, mock.Anything).Return(nil, &smithy.OperationError{
+				OperationName: "DescribeStacks",
+				Err:           &smithy.GenericAPIError{Code: "ValidationError", Message: "Stack with id eksctl-test-cluster-nodegroup-ng-1 does not exist"},
+			})
+		}
+
+		It("returns no stack if it does not exist", func() {
+			mockStackDoesNotExist()
+			stack, err := PrepareStackForRetry(context.Background(), sm, stackName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stack).To(BeNil())
+		})
+
+		It("returns a stack that was created", func() {
+			mockStackStatus(types.StackStatusCreateComplete)
+			stack, err := PrepareStackForRetry(context.Background(), sm, stackName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stack.StackStatus).To(Equal(types.StackStatusCreateComplete))
+			p.MockCloudFormation().AssertNotCalled(GinkgoT(), "DeleteStack", mock.Anything, mock.Anything)
+		})
+
+		It("deletes a stack whose creation failed", func() {
+			mockStackStatus(types.StackStatusRollbackComplete).Once()
+			mockStackDoesNotExist()
+			p.MockCloudFormation().On("DeleteStack", mock.Anything, &cfn.DeleteStackInput{StackName: aws.String("stack-id")}).Return(&cfn.DeleteStackOutput{}, nil)
+
+			stack, err := PrepareStackForRetry(context.Background(), sm, stackName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stack).To(BeNil())
+			p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DeleteStack", 1)
+		})
+
+		It("returns an error for a stack that cannot be created again", func() {
+			mockStackStatus(types.StackStatusRollbackFailed)
+			_, err := PrepareStackForRetry(context.Background(), sm, stackName)
+			Expect(err).To(MatchError(ContainSubstring(`stack "eksctl-test-cluster-nodegroup-ng-1" is in status ROLLBACK_FAILED`)))
+		})
+	})
 })
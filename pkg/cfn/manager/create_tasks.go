@@ -81,6 +81,9 @@ func (c *StackCollection) NewUnmanagedNodeGroupTask(ctx context.Context, nodeGro
 			return builder.NewNodeGroupResourceSet(c.ec2API, c.iamAPI, options)
 		},
 		NewBootstrapper: func(clusterConfig *api.ClusterConfig, ng *api.NodeGroup) (nodebootstrap.Bootstrapper, error) {
+			if err := nodebootstrap.SetCustomNetworkingMaxPods(ctx, c.ec2API, clusterConfig, ng); err != nil {
+				return nil, err
+			}
 			return nodebootstrap.NewBootstrapper(clusterConfig, ng)
 		},
 		EKSAPI:       c.eksAPI,
@@ -80,6 +80,14 @@ type UnmanagedNodeGroupTask struct {
 	StackManager               NodeGroupStackManager
 }
 
+// nodeGroupStackTask creates the stack of a self-managed nodegroup.
+type nodeGroupStackTask struct {
+	tasks.GenericTask
+	stackName string
+}
+
+func (t *nodeGroupStackTask) StackName() string { return t.stackName }
+
 // Create creates a TaskTree for creating nodegroups.
 func (t *UnmanagedNodeGroupTask) Create(ctx context.Context, options CreateNodeGroupOptions) *tasks.TaskTree {
 	taskTree := &tasks.TaskTree{Parallel: true, Limit: options.Parallelism}
@@ -87,11 +95,14 @@ func (t *UnmanagedNodeGroupTask) Create(ctx context.Context, options CreateNodeG
 	for _, ng := range t.NodeGroups {
 		ng := ng
 		createAccessEntryInStack := ng.IAM.InstanceRoleARN == ""
-		createNodeGroupTask := &tasks.GenericTask{
-			Description: fmt.Sprintf("create nodegroup %q", ng.NameString()),
-			Doer: func() error {
-				return t.createNodeGroup(ctx, ng, options, createAccessEntryInStack)
+		createNodeGroupTask := &nodeGroupStackTask{
+			GenericTask: tasks.GenericTask{
+				Description: fmt.Sprintf("create nodegroup %q", ng.NameString()),
+				Doer: func() error {
+					return t.createNodeGroup(ctx, ng, options, createAccessEntryInStack)
+				},
 			},
+			stackName: makeNodeGroupStackName(t.ClusterConfig.Metadata.Name, ng.Name),
 		}
 
 		if options.DisableAccessEntryCreation || createAccessEntryInStack {
@@ -186,6 +197,9 @@ func (c *StackCollection) createManagedNodeGroupTask(ctx context.Context, errorC
 		return errors.New("managed nodegroups cannot be created on IPv6 unowned clusters")
 	}
 	logger.Info("building managed nodegroup stack %q", name)
+	if err := nodebootstrap.SetCustomNetworkingMaxPods(ctx, c.ec2API, c.spec, ng); err != nil {
+		return err
+	}
 	bootstrapper, err := nodebootstrap.NewManagedBootstrapper(c.spec, ng)
 	if err != nil {
 		return err
@@ -22,6 +22,8 @@ type createClusterTask struct {
 
 func (t *createClusterTask) Describe() string { return t.info }
 
+func (t *createClusterTask) StackName() string { return t.stackCollection.MakeClusterStackName() }
+
 func (t *createClusterTask) Do(errorCh chan error) error {
 	return t.stackCollection.createClusterTask(t.ctx, errorCh, t.supportsManagedNodes)
 }
@@ -37,6 +39,10 @@ type managedNodeGroupTask struct {
 
 func (t *managedNodeGroupTask) Describe() string { return t.info }
 
+func (t *managedNodeGroupTask) StackName() string {
+	return t.stackCollection.makeNodeGroupStackName(t.nodeGroup.Name)
+}
+
 func (t *managedNodeGroupTask) Do(errorCh chan error) error {
 	return t.stackCollection.createManagedNodeGroupTask(t.ctx, errorCh, t.nodeGroup, t.forceAddCNIPolicy, t.vpcImporter)
 }
@@ -62,6 +68,9 @@ type taskWithClusterIAMServiceAccountSpec struct {
 }
 
 func (t *taskWithClusterIAMServiceAccountSpec) Describe() string { return t.info }
+func (t *taskWithClusterIAMServiceAccountSpec) StackName() string {
+	return t.stackCollection.makeIAMServiceAccountStackName(t.serviceAccount.Namespace, t.serviceAccount.Name)
+}
 func (t *taskWithClusterIAMServiceAccountSpec) Do(errs chan error) error {
 	return t.stackCollection.createIAMServiceAccountTask(context.TODO(), errs, t.serviceAccount, t.oidc)
 }
@@ -72,7 +81,8 @@ type taskWithStackSpec struct {
 	call  func(context.Context, *Stack, chan error) error
 }
 
-func (t *taskWithStackSpec) Describe() string { return t.info }
+func (t *taskWithStackSpec) Describe() string  { return t.info }
+func (t *taskWithStackSpec) StackName() string { return *t.stack.StackName }
 func (t *taskWithStackSpec) Do(errs chan error) error {
 	return t.call(context.TODO(), t.stack, errs)
 }
@@ -83,7 +93,8 @@ type asyncTaskWithStackSpec struct {
 	call  func(context.Context, *Stack) (*Stack, error)
 }
 
-func (t *asyncTaskWithStackSpec) Describe() string { return t.info + " [async]" }
+func (t *asyncTaskWithStackSpec) Describe() string  { return t.info + " [async]" }
+func (t *asyncTaskWithStackSpec) StackName() string { return *t.stack.StackName }
 func (t *asyncTaskWithStackSpec) Do(errs chan error) error {
 	_, err := t.call(context.TODO(), t.stack)
 	close(errs)
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	cfntypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 
 	"github.com/kris-nova/logger"
 
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/waiter"
 )
 
 // TroubleshootStackFailureCause identifies the cause of the stack's failure and prints the stack events
@@ -61,6 +63,10 @@ func (c *StackCollection) TroubleshootStackFailureCause(ctx context.Context, s *
 	}
 }
 
+func (c *StackCollection) newStackEventReporter(i *Stack) *waiter.StackEventReporter {
+	return waiter.NewStackEventReporter(c.cloudformationAPI, aws.ToString(i.StackId), aws.ToString(i.StackName))
+}
+
 // NoChangeError represents an error for when a CloudFormation changeset contains no changes.
 type NoChangeError struct {
 	Msg string
@@ -73,10 +79,12 @@ func (e *NoChangeError) Error() string {
 // DoWaitUntilStackIsCreated blocks until the given stack's
 // creation has completed.
 func (c *StackCollection) DoWaitUntilStackIsCreated(ctx context.Context, i *Stack) error {
+	reporter := c.newStackEventReporter(i)
 	setCustomRetryer := func(o *cloudformation.StackCreateCompleteWaiterOptions) {
 		defaultRetryer := o.Retryable
 		o.Retryable = func(ctx context.Context, in *cloudformation.DescribeStacksInput, out *cloudformation.DescribeStacksOutput, err error) (bool, error) {
 			logger.Info("waiting for CloudFormation stack %q", *i.StackName)
+			reporter.Report(ctx)
 			return defaultRetryer(ctx, in, out, err)
 		}
 	}
@@ -107,10 +115,12 @@ func (c *StackCollection) waitUntilStackIsCreated(ctx context.Context, i *Stack,
 }
 
 func (c *StackCollection) doWaitUntilStackIsDeleted(ctx context.Context, i *Stack) error {
+	reporter := c.newStackEventReporter(i)
 	setCustomRetryer := func(o *cloudformation.StackDeleteCompleteWaiterOptions) {
 		defaultRetryer := o.Retryable
 		o.Retryable = func(ctx context.Context, in *cloudformation.DescribeStacksInput, out *cloudformation.DescribeStacksOutput, err error) (bool, error) {
 			logger.Info("waiting for CloudFormation stack %q", *i.StackName)
+			reporter.Report(ctx)
 			return defaultRetryer(ctx, in, out, err)
 		}
 	}
@@ -132,10 +142,12 @@ func (c *StackCollection) waitUntilStackIsDeleted(ctx context.Context, i *Stack,
 }
 
 func (c *StackCollection) doWaitUntilStackIsUpdated(ctx context.Context, i *Stack) error {
+	reporter := c.newStackEventReporter(i)
 	setCustomRetryer := func(o *cloudformation.StackUpdateCompleteWaiterOptions) {
 		defaultRetryer := o.Retryable
 		o.Retryable = func(ctx context.Context, in *cloudformation.DescribeStacksInput, out *cloudformation.DescribeStacksOutput, err error) (bool, error) {
 			logger.Info("waiting for CloudFormation stack %q", *i.StackName)
+			reporter.Report(ctx)
 			return defaultRetryer(ctx, in, out, err)
 		}
 	}
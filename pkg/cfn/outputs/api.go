@@ -23,6 +23,7 @@ const (
 	ClusterSubnetsPublicLocal     = string("SubnetsLocalZone" + api.SubnetTopologyPublic)
 	ClusterSubnetsPrivateExtended = ClusterSubnetsPrivate + "Extended"
 	ClusterSubnetsPublicExtended  = ClusterSubnetsPublic + "Extended"
+	ClusterSubnetsPod             = "SubnetsPod"
 	ClusterFullyPrivate           = "ClusterFullyPrivate"
 
 	ClusterSubnetsPublicLegacy = "Subnets"
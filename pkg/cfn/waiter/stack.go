@@ -28,6 +28,7 @@ type NextDelay func(attempts int) time.Duration
 // WaitForStack waits for the cluster stack to reach a success or failure state, and returns the stack.
 func WaitForStack(ctx context.Context, cfnAPI awsapi.CloudFormation, stackID, stackName string, nextDelay NextDelay) (*types.Stack, error) {
 	var lastStack *types.Stack
+	reporter := NewStackEventReporter(cfnAPI, stackID, stackName)
 	waiter := &Waiter{
 		NextDelay: nextDelay,
 		Operation: func() (bool, error) {
@@ -36,6 +37,7 @@ func WaitForStack(ctx context.Context, cfnAPI awsapi.CloudFormation, stackID, st
 				success bool
 			)
 			lastStack, success, err = describeStackStatus(context.Background(), cfnAPI, stackID, stackName)
+			reporter.Report(context.Background())
 			return success, err
 		},
 	}
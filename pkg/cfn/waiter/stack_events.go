@@ -0,0 +1,87 @@
+package waiter
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
+)
+
+// stackEventsClockSkew allows for the difference between the local clock and the timestamps recorded by CloudFormation.
+const stackEventsClockSkew = time.Minute
+
+// StackEventReporter emits an event for each new CloudFormation event of a stack, so that the progress of the
+// stack's resources can be followed while waiting for the stack. It does nothing unless events are enabled.
+type StackEventReporter struct {
+	cfnAPI    awsapi.CloudFormation
+	stackName string
+	stackID   string
+	since     time.Time
+	seen      map[string]bool
+}
+
+// NewStackEventReporter returns a StackEventReporter for the stack with the given ID or name, ignoring events
+// that occurred before it was created. stackID may be empty.
+func NewStackEventReporter(cfnAPI awsapi.CloudFormation, stackID, stackName string) *StackEventReporter {
+	return &StackEventReporter{
+		cfnAPI:    cfnAPI,
+		stackName: stackName,
+		stackID:   stackID,
+		since:     time.Now().Add(-stackEventsClockSkew),
+		seen:      map[string]bool{},
+	}
+}
+
+// Report emits the stack events that occurred since the previous call, oldest first.
+func (r *StackEventReporter) Report(ctx context.Context) {
+	if !events.Enabled() {
+		return
+	}
+	stackName := r.stackID
+	if stackName == "" {
+		stackName = r.stackName
+	}
+	output, err := r.cfnAPI.DescribeStackEvents(ctx, &cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(stackName),
+	})
+	if err != nil {
+		logger.Debug("describing events for stack %q: %v", r.stackName, err)
+		return
+	}
+
+	// events are returned in reverse chronological order
+	for i := len(output.StackEvents) - 1; i >= 0; i-- {
+		e := output.StackEvents[i]
+		eventID := aws.ToString(e.EventId)
+		if r.seen[eventID] || (e.Timestamp != nil && e.Timestamp.Before(r.since)) {
+			continue
+		}
+		r.seen[eventID] = true
+		emitStackEvent(e)
+	}
+}
+
+// emitStackEvent emits an event for a CloudFormation stack event.
+func emitStackEvent(e types.StackEvent) {
+	event := events.Event{
+		Type:               events.StackResource,
+		StackName:          aws.ToString(e.StackName),
+		EventID:            aws.ToString(e.EventId),
+		LogicalResourceID:  aws.ToString(e.LogicalResourceId),
+		PhysicalResourceID: aws.ToString(e.PhysicalResourceId),
+		ResourceType:       aws.ToString(e.ResourceType),
+		Status:             string(e.ResourceStatus),
+		Reason:             aws.ToString(e.ResourceStatusReason),
+	}
+	if e.Timestamp != nil {
+		timestamp := e.Timestamp.UTC()
+		event.Timestamp = &timestamp
+	}
+	events.Emit(event)
+}
@@ -0,0 +1,100 @@
+package waiter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/eks/mocksv2"
+	"github.com/weaveworks/eksctl/pkg/utils/events"
+)
+
+var _ = Describe("WaitForStack events", func() {
+	var (
+		out    *bytes.Buffer
+		cfnAPI *mocksv2.CloudFormation
+	)
+
+	stackEvent := func(id, logicalID string, status types.ResourceStatus, timestamp time.Time) types.StackEvent {
+		return types.StackEvent{
+			EventId:           aws.String(id),
+			StackName:         aws.String("eksctl-test-cluster"),
+			LogicalResourceId: aws.String(logicalID),
+			ResourceType:      aws.String("AWS::EC2::VPC"),
+			ResourceStatus:    status,
+			Timestamp:         aws.Time(timestamp),
+		}
+	}
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cfnAPI = &mocksv2.CloudFormation{}
+		now := time.Now()
+		cfnAPI.On("DescribeStacks", mock.Anything, mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []types.Stack{{StackName: aws.String("eksctl-test-cluster"), StackStatus: types.StackStatusCreateInProgress}},
+		}, nil).Once()
+		cfnAPI.On("DescribeStacks", mock.Anything, mock.Anything).Return(&cloudformation.DescribeStacksOutput{
+			Stacks: []types.Stack{{StackName: aws.String("eksctl-test-cluster"), StackStatus: types.StackStatusCreateComplete}},
+		}, nil)
+		cfnAPI.On("DescribeStackEvents", mock.Anything, &cloudformation.DescribeStackEventsInput{
+			StackName: aws.String("stack-id"),
+		}).Return(&cloudformation.DescribeStackEventsOutput{
+			StackEvents: []types.StackEvent{
+				stackEvent("2", "VPC", types.ResourceStatusCreateInProgress, now),
+				stackEvent("1", "VPC", types.ResourceStatusCreateInProgress, now),
+				stackEvent("0", "VPC", types.ResourceStatusCreateComplete, now.Add(-time.Hour)),
+			},
+		}, nil).Once()
+		cfnAPI.On("DescribeStackEvents", mock.Anything, mock.Anything).Return(&cloudformation.DescribeStackEventsOutput{
+			StackEvents: []types.StackEvent{
+				stackEvent("3", "VPC", types.ResourceStatusCreateComplete, now),
+				stackEvent("2", "VPC", types.ResourceStatusCreateInProgress, now),
+				stackEvent("1", "VPC", types.ResourceStatusCreateInProgress, now),
+			},
+		}, nil)
+	})
+
+	AfterEach(func() {
+		Expect(events.SetOutput("", nil)).To(Succeed())
+	})
+
+	waitForStack := func() {
+		_, err := WaitForStack(context.Background(), cfnAPI, "stack-id", "eksctl-test-cluster", func(int) time.Duration {
+			return time.Nanosecond
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("emits each new stack event once, oldest first", func() {
+		Expect(events.SetOutput(events.OutputNDJSON, out)).To(Succeed())
+		waitForStack()
+
+		var ids, statuses []string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var e events.Event
+			Expect(json.Unmarshal([]byte(line), &e)).To(Succeed())
+			Expect(e.Type).To(Equal(events.StackResource))
+			Expect(e.StackName).To(Equal("eksctl-test-cluster"))
+			Expect(e.LogicalResourceID).To(Equal("VPC"))
+			Expect(e.Timestamp).NotTo(BeNil())
+			ids = append(ids, e.EventID)
+			statuses = append(statuses, e.Status)
+		}
+		Expect(ids).To(Equal([]string{"1", "2", "3"}))
+		Expect(statuses).To(Equal([]string{"CREATE_IN_PROGRESS", "CREATE_IN_PROGRESS", "CREATE_COMPLETE"}))
+	})
+
+	It("does not describe stack events when events are disabled", func() {
+		waitForStack()
+		cfnAPI.AssertNotCalled(GinkgoT(), "DescribeStackEvents", mock.Anything, mock.Anything)
+	})
+})
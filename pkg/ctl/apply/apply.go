@@ -0,0 +1,105 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/apply"
+	"github.com/weaveworks/eksctl/pkg/actions/diff"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+// Command creates the `apply` command
+func Command(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+
+	cmd.SetDescription(
+		"apply",
+		"Reconcile a cluster with a ClusterConfig file",
+		"Creates, updates and, with --prune, deletes nodegroups, managed nodegroups, addons, IAM service accounts, pod identity associations, access entries and Fargate profiles so that the cluster matches the config file.",
+	)
+
+	var prune bool
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.BoolVar(&prune, "prune", false, "delete resources that exist in the cluster but are not declared in the config file")
+		cmdutils.AddPlanFlag(fs, cmd, "print the changes that would be applied without applying them")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doApply(cmd, prune)
+	}
+}
+
+func doApply(cmd *cmdutils.Cmd, prune bool) error {
+	if err := cmdutils.NewApplyLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	cfg := cmd.ClusterConfig
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	items, err := diff.New(cfg, ctl.AWSProvider, ctl.NewStackManager(cfg)).Diff(ctx)
+	if err != nil {
+		return fmt.Errorf("computing diff for cluster %q: %w", cfg.Metadata.Name, err)
+	}
+	if len(items) == 0 {
+		logger.Success("cluster %q already matches %q", cfg.Metadata.Name, cmd.ClusterConfigFile)
+		return nil
+	}
+
+	reconciler := &apply.Reconciler{
+		ClusterConfig: cfg,
+		Prune:         prune,
+	}
+	// the applier connects to the cluster, which is not needed to print the plan
+	if !cmd.Plan {
+		instanceSelector, err := selector.New(ctx, ctl.AWSProvider.AWSConfig())
+		if err != nil {
+			return err
+		}
+		if reconciler.Applier, err = apply.NewClusterApplier(ctx, cfg, ctl, instanceSelector, cmd.ProviderConfig.WaitTimeout); err != nil {
+			return err
+		}
+	}
+
+	taskTree := reconciler.Tasks(ctx, items)
+	if taskTree.Len() == 0 {
+		logger.Info("no changes to apply to cluster %q", cfg.Metadata.Name)
+		return nil
+	}
+
+	if cmd.Plan {
+		taskTree.PlanMode = true
+		fmt.Fprintln(cmd.CobraCommand.OutOrStdout(), taskTree.Describe())
+		logger.Warning("no changes were applied, run again without '--plan' to apply the changes")
+		return nil
+	}
+
+	logger.Info(taskTree.Describe())
+	if errs := taskTree.DoAllSync(); len(errs) > 0 {
+		logger.Warning("%d error(s) occurred while applying %q to cluster %q", len(errs), cmd.ClusterConfigFile, cfg.Metadata.Name)
+		for _, err := range errs {
+			logger.Critical("%s\n", err.Error())
+		}
+		return fmt.Errorf("failed to apply %q to cluster %q", cmd.ClusterConfigFile, cfg.Metadata.Name)
+	}
+	logger.Success("applied %q to cluster %q", cmd.ClusterConfigFile, cfg.Metadata.Name)
+	return nil
+}
@@ -0,0 +1,11 @@
+package apply
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlApply(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
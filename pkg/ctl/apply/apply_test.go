@@ -0,0 +1,43 @@
+package apply
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("apply", func() {
+	type applyTest struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("invalid arguments", func(e applyTest) {
+		rootCmd := &cobra.Command{}
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), rootCmd, Command)
+		rootCmd.SetArgs(append([]string{"apply"}, e.args...))
+		errBuf := new(bytes.Buffer)
+		rootCmd.SetOut(new(bytes.Buffer))
+		rootCmd.SetErr(errBuf)
+		err := rootCmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(errors.New(errBuf.String())).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing config file", applyTest{
+			expectedErr: "Error: --config-file must be set",
+		}),
+		Entry("name argument", applyTest{
+			args:        []string{"test-cluster", "--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: `Error: unknown command "test-cluster"`,
+		}),
+		Entry("cluster flag with config file", applyTest{
+			args:        []string{"--cluster", "test-cluster", "--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: "Error: unknown flag: --cluster",
+		}),
+	)
+})
@@ -107,6 +107,45 @@ func NewDeleteAccessEntryLoader(cmd *Cmd, accessEntry api.AccessEntry) ClusterCo
 	return l
 }
 
+// NewUpdateAccessEntryLoader loads config file and validates command for `eksctl update accessentry`.
+func NewUpdateAccessEntryLoader(cmd *Cmd, accessEntry api.AccessEntry) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.flagsIncompatibleWithConfigFile.Insert(principalARNFlag, "kubernetes-groups")
+
+	l.validateWithConfigFile = func() error {
+		if len(cmd.ClusterConfig.AccessConfig.AccessEntries) == 0 {
+			return fmt.Errorf("no access entries specified")
+		}
+		for _, ae := range cmd.ClusterConfig.AccessConfig.AccessEntries {
+			if ae.PrincipalARN.IsZero() {
+				return fmt.Errorf("must specify access entry principalArn")
+			}
+			for _, policy := range ae.AccessPolicies {
+				if policy.PolicyARN.IsZero() {
+					return fmt.Errorf("must specify access policy arn")
+				}
+			}
+		}
+		return nil
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		if cmd.ClusterConfig.Metadata.Name == "" {
+			return ErrMustBeSet(ClusterNameFlag(cmd))
+		}
+		if accessEntry.PrincipalARN.IsZero() {
+			return ErrMustBeSet(fmt.Sprintf("--%s", principalARNFlag))
+		}
+		if !cmd.CobraCommand.Flag("kubernetes-groups").Changed {
+			return ErrMustBeSet("--kubernetes-groups")
+		}
+		l.ClusterConfig.AccessConfig.AccessEntries = []api.AccessEntry{accessEntry}
+		return nil
+	}
+
+	return l
+}
+
 // NewUtilsUpdateAuthenticationModeLoader loads config or uses flags for `eksctl utils update-autentication-mode`
 func NewUtilsUpdateAuthenticationModeLoader(cmd *Cmd) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
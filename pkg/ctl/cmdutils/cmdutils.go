@@ -14,6 +14,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/printers"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 	"github.com/weaveworks/eksctl/pkg/version"
 )
 
@@ -74,6 +75,13 @@ func LogPlanModeWarning(plan bool) {
 	}
 }
 
+// LogResumeHint will log a message to inform user that a failed operation can be resumed from its checkpoint
+func LogResumeHint(checkpoint *tasks.Checkpoint) {
+	if checkpoint != nil {
+		logger.Info("to retry only the tasks that did not complete, rerun the command with '--resume'; progress was saved to %s", checkpoint.Path())
+	}
+}
+
 // LogRegionAndVersionInfo will log the selected region and build version
 func LogRegionAndVersionInfo(meta *api.ClusterMeta) {
 	if meta != nil {
@@ -92,6 +100,12 @@ func AddApproveFlag(fs *pflag.FlagSet, cmd *Cmd) {
 	})
 }
 
+// AddPlanFlag adds common `--plan` flag, for commands that apply changes by default
+func AddPlanFlag(fs *pflag.FlagSet, cmd *Cmd, description string) {
+	cmd.Plan = false
+	fs.BoolVar(&cmd.Plan, "plan", false, description)
+}
+
 // GetNameArg tests to ensure there is only 1 name argument
 func GetNameArg(args []string) string {
 	if len(args) > 1 {
@@ -184,6 +198,11 @@ func AddWaitFlagWithFullDescription(fs *pflag.FlagSet, wait *bool, description s
 	fs.BoolVarP(wait, "wait", "w", *wait, description)
 }
 
+// AddResumeFlag adds common --resume flag
+func AddResumeFlag(fs *pflag.FlagSet, resume *bool, operation string) {
+	fs.BoolVar(resume, "resume", false, fmt.Sprintf("resume a previously failed %s, skipping the tasks that already completed", operation))
+}
+
 // AddUpdateAuthConfigMap adds common --update-auth-configmap flag
 func AddUpdateAuthConfigMap(fs *pflag.FlagSet, description string) *bool {
 	return fs.Bool("update-auth-configmap", true, description)
@@ -205,7 +224,19 @@ func AddCommonFlagsForKubeconfig(fs *pflag.FlagSet, outputPath, authenticatorRol
 // AddCommonFlagsForGetCmd adds common flags for get commands.
 func AddCommonFlagsForGetCmd(fs *pflag.FlagSet, chunkSize *int, outputMode *printers.Type) {
 	fs.IntVar(chunkSize, "chunk-size", 100, "return large lists in chunks rather than all at once, pass 0 to disable")
-	fs.StringVarP(outputMode, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	AddOutputFlag(fs, outputMode, "specifies the output format")
+}
+
+// AddOutputFlag adds the --output flag, listing the formats supported by printers.NewPrinter in its usage
+func AddOutputFlag(fs *pflag.FlagSet, outputMode *printers.Type, usage string) {
+	AddOutputFlagWithFormats(fs, outputMode, usage, printers.TableType, printers.CSVType, printers.MarkdownType, printers.JSONType, printers.YAMLType,
+		printers.JSONPathType+"=<expression>", printers.GoTemplateType+"=<template>")
+}
+
+// AddOutputFlagWithFormats adds the --output flag for commands supporting only some of the formats of
+// printers.NewPrinter, the first of which is the default
+func AddOutputFlagWithFormats(fs *pflag.FlagSet, outputMode *printers.Type, usage string, formats ...printers.Type) {
+	fs.StringVarP(outputMode, "output", "o", formats[0], fmt.Sprintf("%s (valid options: %s)", usage, strings.Join(formats, ", ")))
 }
 
 // AddStringToStringVarPFlag is a wrapper that prefixes the description of the flag for consistency
@@ -731,6 +731,68 @@ func NewDeleteAndDrainNodeGroupLoader(cmd *Cmd, ng *api.NodeGroup, ngFilter *fil
 	return l
 }
 
+// NewReplaceNodeGroupLoader will load config or use flags for 'eksctl replace nodegroup'. When a config file is used,
+// only the nodegroup being replaced is kept in the ClusterConfig
+func NewReplaceNodeGroupLoader(cmd *Cmd, ngName *string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.flagsIncompatibleWithConfigFile.Delete("name", "version")
+
+	validateName := func() error {
+		if *ngName != "" && l.NameArg != "" {
+			return ErrFlagAndArg("--name", *ngName, l.NameArg)
+		}
+		if l.NameArg != "" {
+			*ngName = l.NameArg
+		}
+		if *ngName == "" {
+			return ErrMustBeSet("--name")
+		}
+		return nil
+	}
+
+	l.validateWithConfigFile = func() error {
+		if err := validateUnsetNodeGroups(l.ClusterConfig); err != nil {
+			return err
+		}
+		if err := validateName(); err != nil {
+			return err
+		}
+		if flag := l.CobraCommand.Flag("version"); flag != nil && flag.Changed {
+			l.ClusterConfig.Metadata.Version = flag.Value.String()
+		}
+
+		var (
+			nodeGroups        []*api.NodeGroup
+			managedNodeGroups []*api.ManagedNodeGroup
+		)
+		for _, ng := range l.ClusterConfig.NodeGroups {
+			if ng.Name == *ngName {
+				nodeGroups = append(nodeGroups, ng)
+			}
+		}
+		for _, ng := range l.ClusterConfig.ManagedNodeGroups {
+			if ng.Name == *ngName {
+				managedNodeGroups = append(managedNodeGroups, ng)
+			}
+		}
+		if len(nodeGroups)+len(managedNodeGroups) == 0 {
+			return fmt.Errorf("nodegroup %q not found in config file %q", *ngName, l.ClusterConfigFile)
+		}
+		l.ClusterConfig.NodeGroups, l.ClusterConfig.ManagedNodeGroups = nodeGroups, managedNodeGroups
+		return nil
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		if l.ClusterConfig.Metadata.Name == "" {
+			return ErrMustBeSet(ClusterNameFlag(cmd))
+		}
+		return validateName()
+	}
+
+	return l
+}
+
 // NewUtilsEnableLoggingLoader will load config or use flags for 'eksctl utils update-cluster-logging'
 func NewUtilsEnableLoggingLoader(cmd *Cmd) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
@@ -1069,6 +1131,79 @@ func NewGetClusterLoader(cmd *Cmd) ClusterConfigLoader {
 	return l
 }
 
+// NewDiffClusterLoader will load config for 'eksctl diff cluster'; a config file is required
+// as it declares the desired state to compare against.
+func NewDiffClusterLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+
+	return l
+}
+
+// NewApplyLoader will load config for 'eksctl apply'; a config file is required
+// as it declares the desired state of the cluster.
+func NewApplyLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithConfigFile = func() error {
+		return validateUnsetNodeGroups(l.ClusterConfig)
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+
+	return l
+}
+
+// NewGenerateTemplatesLoader will load config for `eksctl generate templates`
+func NewGenerateTemplatesLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithConfigFile = func() error {
+		clusterConfig := l.ClusterConfig
+		ipv6Enabled := clusterConfig.IPv6Enabled()
+
+		if clusterConfig.VPC == nil {
+			clusterConfig.VPC = api.NewClusterVPC(ipv6Enabled)
+		}
+
+		if clusterConfig.VPC.NAT == nil && !ipv6Enabled {
+			clusterConfig.VPC.NAT = api.DefaultClusterNAT()
+		}
+
+		if clusterConfig.VPC.NAT != nil && api.IsEmpty(clusterConfig.VPC.NAT.Gateway) {
+			*clusterConfig.VPC.NAT.Gateway = api.ClusterSingleNAT
+		}
+
+		if err := validateUnsetNodeGroups(clusterConfig); err != nil {
+			return err
+		}
+
+		if clusterConfig.IsFullyPrivate() {
+			clusterEndpoints := clusterConfig.VPC.ClusterEndpoints
+			if clusterEndpoints != nil && (clusterEndpoints.PublicAccess != nil || clusterEndpoints.PrivateAccess != nil) {
+				return errors.New("vpc.clusterEndpoints cannot be set for a fully-private cluster (privateCluster.enabled) as the endpoint access defaults to private-only")
+			}
+		}
+		api.SetClusterEndpointAccessDefaults(clusterConfig.VPC)
+
+		if clusterConfig.HasAnySubnets() && len(clusterConfig.AvailabilityZones) != 0 {
+			return errors.New("vpc.subnets and availabilityZones cannot be set at the same time")
+		}
+		return nil
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+
+	return l
+}
+
 // NewGetAddonsLoader loads config file and validates command for `eksctl get addon`.
 func NewGetAddonsLoader(cmd *Cmd) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(cmd)
@@ -1179,3 +1314,46 @@ func contains(supportedFields []string, fieldName string) bool {
 	}
 	return false
 }
+
+// NewGenerateIAMPolicyLoader will load config for `eksctl utils generate-iam-policy`; a config file is required
+// as it declares the features the generated policy must allow.
+func NewGenerateIAMPolicyLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+
+	return l
+}
+
+// NewRenderUserDataLoader will load config for `eksctl utils render-userdata`; a config file is required
+// as it declares the nodegroups whose userdata is rendered.
+func NewRenderUserDataLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	// --name selects the nodegroup in the config file
+	l.flagsIncompatibleWithConfigFile.Delete("name")
+
+	l.validateWithoutConfigFile = func() error {
+		return ErrMustBeSet("--config-file")
+	}
+
+	return l
+}
+
+// NewUpgradeNodeGroupLoader will load config or use flags for 'eksctl upgrade nodegroup'; the config file
+// provides the amiResolution used to upgrade nodegroups that use a custom AMI.
+func NewUpgradeNodeGroupLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	// --name selects the nodegroup to upgrade
+	l.flagsIncompatibleWithConfigFile.Delete("name")
+
+	l.validateWithoutConfigFile = func() error {
+		if cmd.ClusterConfig.Metadata.Name == "" {
+			return ErrMustBeSet(ClusterNameFlag(cmd))
+		}
+		return nil
+	}
+
+	return l
+}
@@ -21,6 +21,7 @@ type CreateClusterCmdParams struct {
 	WithoutNodeGroup      bool
 	Fargate               bool
 	DryRun                bool
+	Resume                bool
 	EnableAutoMode        bool
 	CreateNGOptions
 	CreateManagedNGOptions
@@ -35,6 +36,7 @@ type NodeGroupOptions struct {
 	UpdateAuthConfigMap     *bool
 	SkipOutdatedAddonsCheck bool
 	SubnetIDs               []string
+	Resume                  bool
 }
 
 // CreateManagedNGOptions holds options for creating a managed nodegroup
@@ -1,6 +1,7 @@
 package cmdutils
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/pflag"
@@ -14,6 +15,7 @@ const (
 	fargateProfileSelectorNamespace = "namespace" // Fargate profile selector's namespace.
 	fargateProfileSelectorLabels    = "labels"    // Fargate profile selector's labels.
 	fargateProfileTags              = "tags"      // Fargate profile tags.
+	fargateProfileSubnets           = "subnets"   // Fargate profile subnets.
 )
 
 // AddFlagsForFargate configures the flags required to interact with Fargate.
@@ -36,6 +38,29 @@ func AddFlagsForFargateProfileCreation(fs *pflag.FlagSet, options *fargate.Creat
 		"Used to tag the AWS resources")
 }
 
+// AddFlagsForFargateProfileUpdate configures the flags required to
+// update a Fargate profile.
+func AddFlagsForFargateProfileUpdate(fs *pflag.FlagSet, options *fargate.UpdateOptions) {
+	addFargateProfileName(fs, &options.ProfileName)
+
+	fs.StringVar(&options.ProfileSelectorNamespace, fargateProfileSelectorNamespace, "",
+		"Kubernetes namespace of the workloads to schedule on Fargate, replacing the selectors of the profile")
+
+	AddStringToStringVarPFlag(fs, &options.ProfileSelectorLabels, fargateProfileSelectorLabels, "l", nil,
+		"Kubernetes selector labels of the workloads to schedule on Fargate")
+
+	fs.StringSliceVar(&options.Subnets, fargateProfileSubnets, nil,
+		"Subnets to schedule the workloads in, replacing the subnets of the profile")
+
+	AddStringToStringVarPFlag(fs, &options.Tags, fargateProfileTags, "t", nil,
+		"Used to tag the AWS resources, replacing the tags of the profile")
+
+	fs.BoolVar(&options.RestartPods, "restart-pods", false,
+		"once the profile is updated, restart the pods it matches which are not running on Fargate; the pods matched only by removed selectors are not restarted, as EKS deletes them with the existing profile")
+	fs.BoolVar(&options.Plan, "plan", false,
+		"show the profiles which would be replaced and the namespaces and labels gaining or losing Fargate coverage, without updating them")
+}
+
 func addFargateProfileName(fs *pflag.FlagSet, profileName *string) {
 	fs.StringVar(profileName, fargateProfileName, "",
 		"Fargate profile's name")
@@ -167,3 +192,67 @@ func NewDeleteFargateProfileLoader(cmd *Cmd, options *fargate.Options) ClusterCo
 	}
 	return l
 }
+
+// NewUpdateFargateProfileLoader will load config or use flags for
+// 'eksctl update fargateprofile'
+func NewUpdateFargateProfileLoader(cmd *Cmd, options *fargate.UpdateOptions) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	// We optionally want to be able to only update the profile with the
+	// provided name from a ClusterConfig file:
+	l.flagsIncompatibleWithConfigFile = flagsIncompatibleWithConfigFileExcept(fargateProfileName)
+	l.flagsIncompatibleWithConfigFile.Insert(fargateProfileTags, fargateProfileSubnets)
+	l.validateWithConfigFile = func() error {
+		if err := validateUpdateNameFlagAndArg(cmd, options); err != nil {
+			return err
+		}
+		if options.ProfileName != "" {
+			profile := findFargateProfile(l.ClusterConfig.FargateProfiles, options.ProfileName)
+			if profile == nil {
+				return fmt.Errorf("Fargate profile %q not found in config file", options.ProfileName)
+			}
+			l.ClusterConfig.FargateProfiles = []*api.FargateProfile{profile}
+		}
+		if len(l.ClusterConfig.FargateProfiles) == 0 {
+			return errors.New("no Fargate profiles specified in config file")
+		}
+		return validateFargateProfiles(l)
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateCluster(cmd); err != nil {
+			return err
+		}
+		if err := validateUpdateNameFlagAndArg(cmd, options); err != nil {
+			return err
+		}
+		if err := options.Validate(); err != nil {
+			return err
+		}
+		if options.ProfileSelectorNamespace == "" && options.Subnets == nil && options.Tags == nil {
+			return fmt.Errorf("at least one of --%s, --%s or --%s must be set", fargateProfileSelectorNamespace, fargateProfileSubnets, fargateProfileTags)
+		}
+		cmd.ClusterConfig.FargateProfiles = []*api.FargateProfile{
+			options.ToFargateProfile(),
+		}
+		return nil
+	}
+	return l
+}
+
+func validateUpdateNameFlagAndArg(cmd *Cmd, options *fargate.UpdateOptions) error {
+	if options.ProfileName != "" && cmd.NameArg != "" {
+		return ErrFlagAndArg(fmt.Sprintf("--%s", fargateProfileName), options.ProfileName, cmd.NameArg)
+	}
+	if options.ProfileName == "" && cmd.NameArg != "" {
+		options.ProfileName = cmd.NameArg
+	}
+	return nil
+}
+
+func findFargateProfile(profiles []*api.FargateProfile, name string) *api.FargateProfile {
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile
+		}
+	}
+	return nil
+}
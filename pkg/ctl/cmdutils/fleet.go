@@ -0,0 +1,119 @@
+package cmdutils
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/fleet"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var defaultFlagsIncompatibleWithFleet = []string{
+	"config-file",
+	"name",
+	"cluster",
+	"region",
+}
+
+// FleetOptions holds the options of commands that can run on a fleet of clusters
+type FleetOptions struct {
+	// ConfigFile is the path of the ClusterConfigList file listing the clusters of the fleet
+	ConfigFile string
+	// MaxConcurrent is the maximum number of clusters to run on concurrently
+	MaxConcurrent int
+}
+
+// Enabled returns true if the command runs on a fleet
+func (o FleetOptions) Enabled() bool {
+	return o.ConfigFile != ""
+}
+
+// AddFleetFlags adds the --fleet and --max-concurrent flags
+func AddFleetFlags(fs *pflag.FlagSet, options *FleetOptions) {
+	fs.StringVar(&options.ConfigFile, "fleet", "", "Path to a ClusterConfigList file; runs the command on each of its clusters")
+	fs.IntVar(&options.MaxConcurrent, "max-concurrent", 1, "Maximum number of clusters of the fleet to run on concurrently")
+}
+
+// RunFleet runs runFunc on each cluster of the fleet, with a copy of cmd whose ClusterConfig is the cluster's config,
+// and whose region and profile are those of the cluster. Clusters that fail do not stop the rest.
+func RunFleet(cmd *Cmd, options FleetOptions, incompatibleFlags []string, runFunc func(cmd *Cmd) error) ([]fleet.Result, error) {
+	for _, flagName := range append(defaultFlagsIncompatibleWithFleet, incompatibleFlags...) {
+		if flag := cmd.CobraCommand.Flag(flagName); flag != nil && flag.Changed {
+			return nil, fmt.Errorf("cannot use --%s with --fleet", flagName)
+		}
+	}
+	if cmd.NameArg != "" {
+		return nil, fmt.Errorf("cannot use name argument with --fleet")
+	}
+	if options.MaxConcurrent < 1 {
+		return nil, fmt.Errorf("--max-concurrent must be at least 1")
+	}
+
+	clusters, err := fleet.Load(options.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return fleet.Run(context.Background(), clusters, options.MaxConcurrent, func(_ context.Context, cfg *api.ClusterConfig) error {
+		return runFunc(cmd.forFleetCluster(cfg))
+	}), nil
+}
+
+// forFleetCluster returns a copy of the command that runs on a cluster of a fleet
+func (c *Cmd) forFleetCluster(cfg *api.ClusterConfig) *Cmd {
+	clusterCmd := *c
+	clusterCmd.ClusterConfig = cfg
+	clusterCmd.ProviderConfig.Region = cfg.Metadata.Region
+	if profile := cfg.Metadata.Annotations[fleet.ProfileAnnotation]; profile != "" {
+		clusterCmd.ProviderConfig.Profile = api.Profile{Name: profile}
+	}
+	return &clusterCmd
+}
+
+// PrintFleetSummary prints the result of each cluster of the fleet, and returns an error if any failed
+func PrintFleetSummary(results []fleet.Result, w io.Writer) error {
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	printer.AddColumn("CLUSTER", func(r fleet.Result) string {
+		return r.Cluster
+	})
+	printer.AddColumn("REGION", func(r fleet.Result) string {
+		return r.Region
+	})
+	printer.AddColumn("PROFILE", func(r fleet.Result) string {
+		if r.Profile == "" {
+			return "-"
+		}
+		return r.Profile
+	})
+	printer.AddColumn("STATUS", func(r fleet.Result) string {
+		if r.Succeeded() {
+			return "succeeded"
+		}
+		return "failed"
+	})
+	printer.AddColumn("DURATION", func(r fleet.Result) string {
+		return r.Duration.String()
+	})
+	printer.AddColumn("ERROR", func(r fleet.Result) string {
+		if r.Succeeded() {
+			return "-"
+		}
+		return r.Error.Error()
+	})
+	if err := printer.PrintObjWithKind("clusters", results, w); err != nil {
+		return err
+	}
+	return FleetError(results)
+}
+
+// FleetError returns an error if any cluster of the fleet failed
+func FleetError(results []fleet.Result) error {
+	if failed := fleet.CountFailed(results); failed > 0 {
+		return fmt.Errorf("%d of %d clusters failed", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package cmdutils
+
+// NewCreateHybridNodeActivationLoader will load config or use flags for 'eksctl create hybrid-node-activation'.
+// The config file is only needed to override the remote nodes IAM provider or role through remoteNetworkConfig.iam.
+func NewCreateHybridNodeActivationLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithoutConfigFile = l.validateMetadataWithoutConfigFile
+	return l
+}
+
+// NewGetHybridNodesLoader will load config or use flags for 'eksctl get hybrid-nodes'.
+func NewGetHybridNodesLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.validateWithoutConfigFile = l.validateMetadataWithoutConfigFile
+	return l
+}
@@ -0,0 +1,86 @@
+package cmdutils
+
+import (
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// NewUpgradeKarpenterLoader will load config or use flags for 'eksctl upgrade karpenter'.
+// When set, version overrides karpenter.version from the config file.
+func NewUpgradeKarpenterLoader(cmd *Cmd, version string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+	l.flagsIncompatibleWithConfigFile.Delete("version")
+
+	l.validateWithConfigFile = func() error {
+		if cmd.ClusterConfig.Karpenter == nil {
+			return ErrMustBeSet("karpenter")
+		}
+		if version != "" {
+			cmd.ClusterConfig.Karpenter.Version = version
+		}
+		return api.ValidateKarpenterConfig(cmd.ClusterConfig)
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateMetadataWithoutConfigFile(cmd); err != nil {
+			return err
+		}
+		if version == "" {
+			return ErrMustBeSet("--version")
+		}
+		cmd.ClusterConfig.Karpenter = &api.Karpenter{
+			Version: version,
+		}
+		// Karpenter can only have been installed by eksctl on a cluster with an IAM OIDC provider
+		cmd.ClusterConfig.IAM.WithOIDC = api.Enabled()
+		return api.ValidateKarpenterConfig(cmd.ClusterConfig)
+	}
+	return l
+}
+
+// NewDeleteKarpenterLoader will load config or use flags for 'eksctl delete karpenter'.
+func NewDeleteKarpenterLoader(cmd *Cmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithoutConfigFile = func() error {
+		if err := validateMetadataWithoutConfigFile(cmd); err != nil {
+			return err
+		}
+		cmd.ClusterConfig.Karpenter = &api.Karpenter{}
+		return nil
+	}
+	l.validateWithConfigFile = func() error {
+		if cmd.ClusterConfig.Karpenter == nil {
+			cmd.ClusterConfig.Karpenter = &api.Karpenter{}
+		}
+		return nil
+	}
+	return l
+}
+
+// NewMigrateToKarpenterLoader will load config or use flags for 'eksctl utils migrate-to-karpenter'.
+// The Karpenter version is only needed when Karpenter is not installed yet; when set, it overrides
+// karpenter.version from the config file.
+func NewMigrateToKarpenterLoader(cmd *Cmd, version string) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(cmd)
+
+	l.validateWithConfigFile = func() error {
+		if version != "" {
+			if cmd.ClusterConfig.Karpenter == nil {
+				cmd.ClusterConfig.Karpenter = &api.Karpenter{}
+			}
+			cmd.ClusterConfig.Karpenter.Version = version
+		}
+		return nil
+	}
+	l.validateWithoutConfigFile = func() error {
+		if err := validateMetadataWithoutConfigFile(cmd); err != nil {
+			return err
+		}
+		cmd.ClusterConfig.Karpenter = &api.Karpenter{
+			Version: version,
+		}
+		// Karpenter can only be installed by eksctl on a cluster with an IAM OIDC provider
+		cmd.ClusterConfig.IAM.WithOIDC = api.Enabled()
+		return nil
+	}
+	return l
+}
@@ -135,6 +135,9 @@ func validatePodIdentityAssociations(podIdentityAssociations []api.PodIdentityAs
 			!pia.WellKnownPolicies.HasPolicy() {
 			return fmt.Errorf("at least one of the following must be specified: %[1]s.roleARN, %[1]s.permissionPolicy, %[1]s.permissionPolicyARNs, %[1]s.wellKnownPolicies", path)
 		}
+		if err := pia.WellKnownPolicies.Validate(path + ".wellKnownPolicies"); err != nil {
+			return err
+		}
 		if pia.RoleARN != "" {
 			makeIncompatibleFieldErr := func(fieldName string) error {
 				return fmt.Errorf("%[1]s.%s cannot be specified when %[1]s.roleARN is set", path, fieldName)
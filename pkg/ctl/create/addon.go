@@ -3,6 +3,7 @@ package create
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"k8s.io/client-go/kubernetes"
 
@@ -77,6 +78,14 @@ func createAddonCmd(cmd *cmdutils.Cmd) {
 
 		stackManager := clusterProvider.NewStackManager(cmd.ClusterConfig)
 
+		if slices.ContainsFunc(cmd.ClusterConfig.Addons, func(a *api.Addon) bool {
+			return a.CanonicalName() == api.VPCCNIAddon
+		}) {
+			if err := clusterProvider.LoadPodSubnets(ctx, cmd.ClusterConfig, stackManager); err != nil {
+				return err
+			}
+		}
+
 		output, err := clusterProvider.AWSProvider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
 			Name: &cmd.ClusterConfig.Metadata.Name,
 		})
@@ -104,6 +104,7 @@ func createClusterCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.C
 		fs.BoolVarP(&params.InstallWindowsVPCController, "install-vpc-controllers", "", false, "Install VPC controller that's required for Windows workloads")
 		fs.BoolVarP(&params.Fargate, "fargate", "", false, "Create a Fargate profile scheduling pods in the default and kube-system namespaces onto Fargate")
 		fs.BoolVarP(&params.DryRun, "dry-run", "", false, "Dry-run mode that skips cluster creation and outputs a ClusterConfig")
+		cmdutils.AddResumeFlag(fs, &params.Resume, "cluster creation")
 
 		_ = fs.MarkDeprecated("install-vpc-controllers", vpcControllerInfoMessage)
 	})
@@ -154,6 +155,10 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 	}
 	printer := printers.NewJSONPrinter()
 
+	if params.DryRun && params.Resume {
+		return fmt.Errorf("--dry-run and --resume %s", cmdutils.IncompatibleFlags)
+	}
+
 	if params.DryRun {
 		originalWriter := logger.Writer
 		logger.Writer = io.Discard
@@ -283,7 +288,21 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 			"either create the nodegroups after cluster creation or consider creating the control plane on Outposts")
 	}
 
-	if err := createOrImportVPC(ctx, cmd, cfg, params, ctl); err != nil {
+	var existingClusterStack *manager.Stack
+	if params.Resume {
+		// a cluster stack whose creation failed is deleted, so that the VPC is created again along with it
+		stackManager := ctl.NewStackManager(cfg)
+		if existingClusterStack, err = manager.PrepareStackForRetry(ctx, stackManager, stackManager.MakeClusterStackName()); err != nil {
+			return fmt.Errorf("checking for an existing cluster stack: %w", err)
+		}
+	}
+	if existingClusterStack != nil {
+		// the VPC was created along with the cluster stack in a previous run, and must be reused
+		logger.Info("using VPC configuration from existing cluster stack %q", aws.ToString(existingClusterStack.StackName))
+		if err := ctl.LoadClusterIntoSpecFromStack(ctx, cfg, existingClusterStack); err != nil {
+			return fmt.Errorf("loading configuration from existing cluster stack: %w", err)
+		}
+	} else if err := createOrImportVPC(ctx, cmd, cfg, params, ctl); err != nil {
 		return err
 	}
 
@@ -371,12 +390,24 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 	postNodeGroupAddons = postAddons
 	postClusterCreationTasks = ctl.CreateExtraClusterConfigTasks(ctx, cfg, preNodegroupAddons, updateVPCCNITask)
 
+	checkpoint, err := tasks.NewCheckpoint(meta.Name, meta.Region, "create cluster", params.Resume)
+	if err != nil {
+		return err
+	}
+
+	checkpoint.SetPrepareStack(func(stackName string) (bool, error) {
+		stack, err := manager.PrepareStackForRetry(ctx, stackManager, stackName)
+		return stack != nil, err
+	})
+
 	taskTree := stackManager.NewTasksToCreateCluster(ctx, cfg.NodeGroups, cfg.ManagedNodeGroups, cfg.AccessConfig, makeAccessEntryCreator(cfg.Metadata.Name, stackManager), params.NodeGroupParallelism, postClusterCreationTasks)
+	taskTree.Checkpoint = checkpoint
 
 	logger.Info(taskTree.Describe())
 	if errs := taskTree.DoAllSync(); len(errs) > 0 {
 		logger.Warning("%d error(s) occurred and cluster hasn't been created properly, you may wish to check CloudFormation console", len(errs))
 		logger.Info("to cleanup resources, run 'eksctl delete cluster --region=%s --name=%s'", meta.Region, meta.Name)
+		cmdutils.LogResumeHint(checkpoint)
 		for _, err := range errs {
 			ufe := &api.UnsupportedFeatureError{}
 			if errors.As(err, &ufe) {
@@ -409,11 +440,13 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 		}
 
 		ngTasks := ctl.ClusterTasksForNodeGroups(cfg, params.InstallNeuronDevicePlugin, params.InstallNvidiaDevicePlugin)
+		ngTasks.Checkpoint = checkpoint
 
 		logger.Info(ngTasks.Describe())
 		if errs := ngTasks.DoAllSync(); len(errs) > 0 {
 			logger.Warning("%d error(s) occurred and post actions have failed, you may wish to check CloudFormation console", len(errs))
 			logger.Info("to cleanup resources, run 'eksctl delete cluster --region=%s --name=%s'", meta.Region, meta.Name)
+			cmdutils.LogResumeHint(checkpoint)
 			for _, err := range errs {
 				logger.Critical("%s\n", err.Error())
 			}
@@ -466,14 +499,19 @@ func doCreateCluster(cmd *cmdutils.Cmd, ngFilter *filter.NodeGroupFilter, params
 			}
 		}
 		if postNodeGroupAddons != nil && postNodeGroupAddons.Len() > 0 {
+			postNodeGroupAddons.Checkpoint = checkpoint
 			if errs := postNodeGroupAddons.DoAllSync(); len(errs) > 0 {
 				logger.Warning("%d error(s) occurred while creating addons", len(errs))
+				cmdutils.LogResumeHint(checkpoint)
 				for _, err := range errs {
 					logger.Critical("%s\n", err.Error())
 				}
 				return errors.New("failed to create addons")
 			}
 		}
+		if err := checkpoint.Clear(); err != nil {
+			logger.Warning("unable to remove state file %s: %v", checkpoint.Path(), err)
+		}
 
 		if len(cfg.IAM.PodIdentityAssociations) > 0 {
 			clientSet, err := makeClientSet()
@@ -599,7 +637,10 @@ func createOrImportVPC(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.ClusterC
 				return nil
 			}
 		}
-		return vpc.SetSubnets(cfg.VPC, cfg.AvailabilityZones, cfg.LocalZones)
+		if err := vpc.SetSubnets(cfg.VPC, cfg.AvailabilityZones, cfg.LocalZones); err != nil {
+			return err
+		}
+		return vpc.SetPodSubnets(cfg.VPC, cfg.AvailabilityZones)
 	}
 
 	if params.KopsClusterNameForVPC != "" {
@@ -91,6 +91,7 @@ var _ = Describe("create cluster", func() {
 			Entry("with cluster name with hyphen as flag", "--name", "my-cluster-name-is-fine10"),
 			Entry("with cluster name with hyphen as argument", "my-Cluster-name-is-fine10"),
 			// vpc networking flags
+			Entry("with resume flag", "--resume"),
 			Entry("with vpc-cidr flag", "--vpc-cidr", "10.0.0.0/20"),
 			Entry("with vpc-private-subnets flag", "--vpc-private-subnets", "10.0.0.0/24"),
 			Entry("with vpc-public-subnets flag", "--vpc-public-subnets", "10.0.0.0/24"),
@@ -3,9 +3,17 @@ package create_test
 import (
 	"testing"
 
+	. "github.com/onsi/ginkgo/v2"
+
 	"github.com/weaveworks/eksctl/pkg/testutils"
+	"github.com/weaveworks/eksctl/pkg/utils/tasks"
 )
 
 func TestCtlCreate(t *testing.T) {
 	testutils.RegisterAndRun(t)
 }
+
+var _ = BeforeSuite(func() {
+	// keep the state files of task checkpoints out of the home directory
+	GinkgoT().Setenv(tasks.EksctlStateDirEnvName, GinkgoT().TempDir())
+})
@@ -0,0 +1,98 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	"github.com/weaveworks/eksctl/pkg/actions/hybridnodes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+type hybridNodeActivationOptions struct {
+	hybridnodes.ActivationOptions
+	outputFile string
+}
+
+func createHybridNodeActivationCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("hybrid-node-activation", "Create an activation for hybrid nodes and write their nodeadm config",
+		"Creates an SSM hybrid activation for the remote nodes role of the cluster, or uses its IAM Roles Anywhere trust anchor and profile, "+
+			"and writes the nodeadm config on-premises hosts use to join the cluster as hybrid nodes.")
+
+	var options hybridNodeActivationOptions
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVar(&options.outputFile, "output-file", "nodeConfig.yaml", "Path to write the nodeadm config to")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("SSM", func(fs *pflag.FlagSet) {
+		fs.Int32Var(&options.RegistrationLimit, "registration-limit", 1, "Maximum number of hosts the SSM activation can register")
+		fs.DurationVar(&options.Expiration, "expiration", 24*time.Hour, "Duration the SSM activation can be used for, up to 720h")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("IAM Roles Anywhere", func(fs *pflag.FlagSet) {
+		fs.StringVar(&options.NodeName, "node-name", "", "Name of the node, which must be unique to each host")
+		fs.StringVar(&options.CertificatePath, "certificate-path", "/etc/iam/pki/server.pem", "Path of the certificate of the host")
+		fs.StringVar(&options.PrivateKeyPath, "private-key-path", "/etc/iam/pki/server.key", "Path of the private key of the host")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doCreateHybridNodeActivation(cmd, options)
+	}
+}
+
+func doCreateHybridNodeActivation(cmd *cmdutils.Cmd, options hybridNodeActivationOptions) error {
+	if err := cmdutils.NewCreateHybridNodeActivationLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if rnc := ctl.Status.ClusterInfo.Cluster.RemoteNetworkConfig; rnc == nil || len(rnc.RemoteNodeNetworks) == 0 {
+		return fmt.Errorf("cluster %q has no remote node networks; set remoteNetworkConfig when creating the cluster to use hybrid nodes", cfg.Metadata.Name)
+	}
+
+	activationCreator := &hybridnodes.ActivationCreator{
+		ClusterConfig: cfg,
+		StackManager:  ctl.NewStackManager(cfg),
+		SSM:           ctl.AWSProvider.SSM(),
+	}
+	nodeConfig, err := activationCreator.Create(ctx, options.ActivationOptions)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(nodeConfig)
+	if err != nil {
+		return fmt.Errorf("marshalling nodeadm config: %w", err)
+	}
+	// the config contains the SSM activation code, which grants access to the remote nodes role
+	if err := os.WriteFile(options.outputFile, data, 0600); err != nil {
+		return fmt.Errorf("writing nodeadm config: %w", err)
+	}
+	logger.Success("wrote nodeadm config to %q", options.outputFile)
+	logger.Info("copy it to each host and run \"nodeadm init -c file://%s\" to join the cluster", options.outputFile)
+	return nil
+}
@@ -0,0 +1,31 @@
+package create
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("create hybrid-node-activation", func() {
+	type createHybridNodeActivationEntry struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("unsupported arguments", func(e createHybridNodeActivationEntry) {
+		cmd := newDefaultCmd(append([]string{"hybrid-node-activation"}, e.args...)...)
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing required flag --cluster", createHybridNodeActivationEntry{
+			expectedErr: "--cluster must be set",
+		}),
+		Entry("setting --cluster and a name argument at the same time", createHybridNodeActivationEntry{
+			args:        []string{"--cluster", "test-cluster", "test-cluster-2"},
+			expectedErr: "cannot be used at the same time",
+		}),
+		Entry("setting --cluster and --config-file at the same time", createHybridNodeActivationEntry{
+			args:        []string{"--cluster", "test-cluster", "--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: "cannot use --cluster when --config-file/-f is set",
+		}),
+	)
+})
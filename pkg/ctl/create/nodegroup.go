@@ -26,6 +26,10 @@ func createNodeGroupCmd(cmd *cmdutils.Cmd) {
 			return api.ErrInvalidName(ng.Name)
 		}
 
+		if options.DryRun && options.Resume {
+			return fmt.Errorf("--dry-run and --resume %s", cmdutils.IncompatibleFlags)
+		}
+
 		if options.SubnetIDs != nil {
 			ng.Subnets = append(ng.Subnets, options.SubnetIDs...)
 		}
@@ -81,6 +85,7 @@ func createNodeGroupCmd(cmd *cmdutils.Cmd) {
 			SkipOutdatedAddonsCheck: options.SkipOutdatedAddonsCheck,
 			ConfigFileProvided:      cmd.ClusterConfigFile != "",
 			Parallelism:             options.NodeGroupParallelism,
+			Resume:                  options.Resume,
 		}, ngFilter)
 	})
 }
@@ -114,6 +119,7 @@ func createNodeGroupCmdWithRunFunc(cmd *cmdutils.Cmd, runFunc runFn) {
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 		cmdutils.AddSubnetIDs(fs, &options.SubnetIDs, "Define an optional list of subnet IDs to create the nodegroup in")
 		fs.BoolVarP(&options.DryRun, "dry-run", "", false, "Dry-run mode that skips nodegroup creation and outputs a ClusterConfig")
+		cmdutils.AddResumeFlag(fs, &options.Resume, "nodegroup creation")
 		fs.BoolVarP(&options.SkipOutdatedAddonsCheck, "skip-outdated-addons-check", "", false, "whether the creation of ARM nodegroups should proceed when the cluster addons are outdated")
 	})
 
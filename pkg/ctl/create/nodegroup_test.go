@@ -57,6 +57,7 @@ var _ = Describe("create nodegroup", func() {
 			Entry("with appmesh-access flag", "--appmesh-access", "true"),
 			Entry("with alb-ingress-access flag", "--alb-ingress-access", "true"),
 			Entry("with subnet-ids flag", "--subnet-ids", "id1,id2,id3"),
+			Entry("with resume flag", "--resume"),
 		)
 
 		DescribeTable("invalid flags or arguments",
@@ -91,6 +92,10 @@ var _ = Describe("create nodegroup", func() {
 				args:  []string{"--cluster", "foo", "--instance-types", "some-type"},
 				error: "--instance-types is only valid with managed nodegroups (--managed)",
 			}),
+			Entry("with dry-run and resume flags", invalidParamsCase{
+				args:  []string{"--cluster", "clusterName", "--dry-run", "--resume"},
+				error: "--dry-run and --resume cannot be used at the same time",
+			}),
 			Entry("with nodegroup name as flag with invalid characters", invalidParamsCase{
 				args:  []string{"--cluster", "clusterName", "--name", "eksctl-ng_k8s_nodegroup1"},
 				error: "validation for eksctl-ng_k8s_nodegroup1 failed, name must satisfy regular expression pattern: [a-zA-Z][-a-zA-Z0-9]*",
@@ -0,0 +1,54 @@
+package delete
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func deleteKarpenterCmd(cmd *cmdutils.Cmd) {
+	deleteKarpenterWithRunFunc(cmd, doDeleteKarpenter)
+}
+
+func deleteKarpenterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription("karpenter", "Delete Karpenter",
+		"Delete Karpenter installed by eksctl. NodePools are deleted and their nodes drained before the chart is uninstalled and the IAM resources and interruption queue are deleted.")
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewDeleteKarpenterLoader(cmd).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd)
+	}
+}
+
+func doDeleteKarpenter(cmd *cmdutils.Cmd) error {
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cmd.ClusterConfig); !ok {
+		return err
+	}
+	installer, err := karpenter.NewInstallerForExistingCluster(ctx, cmd.ClusterConfig, ctl)
+	if err != nil {
+		return err
+	}
+	return installer.Delete(ctx)
+}
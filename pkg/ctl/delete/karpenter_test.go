@@ -0,0 +1,37 @@
+package delete
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/ctltest"
+)
+
+var _ = Describe("delete karpenter", func() {
+
+	newMockDeleteKarpenterCmd := func(args ...string) *ctltest.MockCmd {
+		return ctltest.NewMockCmd(deleteKarpenterWithRunFunc, "delete", args...)
+	}
+
+	It("requires the cluster name", func() {
+		cmd := newMockDeleteKarpenterCmd("karpenter")
+		_, err := cmd.Execute()
+		Expect(err).To(MatchError(ContainSubstring("--cluster must be set")))
+	})
+
+	It("accepts the --cluster flag", func() {
+		cmd := newMockDeleteKarpenterCmd("karpenter", "--cluster", "clus-1")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.ClusterConfig.Metadata.Name).To(Equal("clus-1"))
+		Expect(cmd.Cmd.ClusterConfig.Karpenter).NotTo(BeNil())
+	})
+
+	It("accepts a config file without the karpenter field", func() {
+		cmd := newMockDeleteKarpenterCmd("karpenter", "-f", "../../../examples/01-simple-cluster.yaml")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.ClusterConfig.Metadata.Name).To(Equal("cluster-1"))
+		Expect(cmd.Cmd.ClusterConfig.Karpenter).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,100 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/diff"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func diffClusterCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+
+	cmd.SetDescription(
+		"cluster",
+		"Show differences between a ClusterConfig file and the live cluster",
+		"Compares cluster settings, nodegroups, addons, IAM service accounts, access entries, pod identity associations and Fargate profiles declared in the config file with the live cluster. Exits with a non-zero status if any drift is found.",
+	)
+
+	var output printers.Type
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddOutputFlag(fs, &output, "specifies the output format")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doDiffCluster(cmd, output)
+	}
+}
+
+func doDiffCluster(cmd *cmdutils.Cmd, output printers.Type) error {
+	if err := cmdutils.NewDiffClusterLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	if output != printers.TableType {
+		//log warnings and errors to stderr
+		logger.Writer = os.Stderr
+	}
+
+	ctx := context.Background()
+	clusterProvider, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	items, err := diff.New(cfg, clusterProvider.AWSProvider, clusterProvider.NewStackManager(cfg)).Diff(ctx)
+	if err != nil {
+		return fmt.Errorf("computing diff for cluster %q: %w", cfg.Metadata.Name, err)
+	}
+
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addDiffTableColumns(columnPrinter)
+	}
+	if err := printer.PrintObjWithKind("differences", items, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+
+	if len(items) > 0 {
+		return fmt.Errorf("found %d difference(s) between %q and cluster %q", len(items), cmd.ClusterConfigFile, cfg.Metadata.Name)
+	}
+	logger.Success("cluster %q matches %q", cfg.Metadata.Name, cmd.ClusterConfigFile)
+	return nil
+}
+
+func addDiffTableColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("RESOURCE", func(i diff.Item) string {
+		return i.ResourceType
+	})
+	printer.AddColumn("NAME", func(i diff.Item) string {
+		return i.Name
+	})
+	printer.AddColumn("CHANGE", func(i diff.Item) string {
+		return string(i.Change)
+	})
+	printer.AddColumn("FIELD", func(i diff.Item) string {
+		return i.Field
+	})
+	printer.AddColumn("DESIRED", func(i diff.Item) string {
+		return i.Desired
+	})
+	printer.AddColumn("LIVE", func(i diff.Item) string {
+		return i.Live
+	})
+}
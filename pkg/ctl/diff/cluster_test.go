@@ -0,0 +1,41 @@
+package diff
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("diff cluster", func() {
+	type diffClusterTest struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("invalid arguments", func(e diffClusterTest) {
+		cmd := Command(cmdutils.NewGrouping())
+		cmd.SetArgs(append([]string{"cluster"}, e.args...))
+		errBuf := new(bytes.Buffer)
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(errBuf)
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(errors.New(errBuf.String())).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing config file", diffClusterTest{
+			expectedErr: "Error: --config-file must be set",
+		}),
+		Entry("name argument with config file", diffClusterTest{
+			args:        []string{"test-cluster", "--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: "Error: cannot use name argument when --config-file/-f is set",
+		}),
+		Entry("invalid output format", diffClusterTest{
+			args:        []string{"--config-file", "../../../examples/01-simple-cluster.yaml", "--output", "xml"},
+			expectedErr: "unknown output printer type",
+		}),
+	)
+})
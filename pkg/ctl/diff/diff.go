@@ -0,0 +1,16 @@
+package diff
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+// Command will create the `diff` commands
+func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	verbCmd := cmdutils.NewVerbCmd("diff", "Show differences between a config file and live resource(s)", "")
+
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, diffClusterCmd)
+
+	return verbCmd
+}
@@ -0,0 +1,11 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlDiff(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
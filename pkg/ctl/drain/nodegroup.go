@@ -2,6 +2,11 @@ package drain
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"time"
 
 	"github.com/kris-nova/logger"
@@ -12,34 +17,41 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils/filter"
+	"github.com/weaveworks/eksctl/pkg/drain"
+	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
+type drainNodeGroupOptions struct {
+	undo                  bool
+	onlyMissing           bool
+	disableEviction       bool
+	parallel              int
+	maxGracePeriod        time.Duration
+	nodeDrainWaitPeriod   time.Duration
+	podEvictionWaitPeriod time.Duration
+	planReport            bool
+	output                printers.Type
+	batch                 drain.BatchOptions
+}
+
 func drainNodeGroupCmd(cmd *cmdutils.Cmd) {
-	drainNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ng *api.NodeGroup, undo, onlyMissing bool, maxGracePeriod, nodeDrainWaitPeriod, podEvictionWaitPeriod time.Duration, disableEviction bool, parallel int) error {
-		return doDrainNodeGroup(cmd, ng, undo, onlyMissing, maxGracePeriod, nodeDrainWaitPeriod, podEvictionWaitPeriod, disableEviction, parallel)
+	drainNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ng *api.NodeGroup, options drainNodeGroupOptions) error {
+		return doDrainNodeGroup(cmd, ng, options)
 	})
 }
 
-func drainNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, ng *api.NodeGroup, undo, onlyMissing bool, maxGracePeriod, nodeDrainWaitPeriod, podEvictionWaitPeriod time.Duration, disableEviction bool, parallel int) error) {
+func drainNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, ng *api.NodeGroup, options drainNodeGroupOptions) error) {
 	cfg := api.NewClusterConfig()
 	ng := api.NewNodeGroup()
 	cmd.ClusterConfig = cfg
 
-	var (
-		undo                  bool
-		onlyMissing           bool
-		disableEviction       bool
-		parallel              int
-		maxGracePeriod        time.Duration
-		nodeDrainWaitPeriod   time.Duration
-		podEvictionWaitPeriod time.Duration
-	)
+	var options drainNodeGroupOptions
 
 	cmd.SetDescription("nodegroup", "Cordon and drain a nodegroup", "", "ng")
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return runFunc(cmd, ng, undo, onlyMissing, maxGracePeriod, nodeDrainWaitPeriod, podEvictionWaitPeriod, disableEviction, parallel)
+		return runFunc(cmd, ng, options)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -49,29 +61,48 @@ func drainNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddApproveFlag(fs, cmd)
 		cmdutils.AddNodeGroupFilterFlags(fs, &cmd.Include, &cmd.Exclude)
-		fs.BoolVar(&onlyMissing, "only-missing", false, "Only drain nodegroups that are not defined in the given config file")
-		fs.BoolVar(&undo, "undo", false, "Uncordon the nodegroup")
+		fs.BoolVar(&options.onlyMissing, "only-missing", false, "Only drain nodegroups that are not defined in the given config file")
+		fs.BoolVar(&options.undo, "undo", false, "Uncordon the nodegroup")
 		defaultMaxGracePeriod, _ := time.ParseDuration("10m")
-		fs.DurationVar(&maxGracePeriod, "max-grace-period", defaultMaxGracePeriod, "Maximum pods termination grace period")
+		fs.DurationVar(&options.maxGracePeriod, "max-grace-period", defaultMaxGracePeriod, "Maximum pods termination grace period")
 		defaultPodEvictionWaitPeriod, _ := time.ParseDuration("10s")
-		fs.DurationVar(&podEvictionWaitPeriod, "pod-eviction-wait-period", defaultPodEvictionWaitPeriod, "Duration to wait after failing to evict a pod")
+		fs.DurationVar(&options.podEvictionWaitPeriod, "pod-eviction-wait-period", defaultPodEvictionWaitPeriod, "Duration to wait after failing to evict a pod")
 		defaultDisableEviction := false
-		fs.BoolVar(&disableEviction, "disable-eviction", defaultDisableEviction, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
+		fs.BoolVar(&options.disableEviction, "disable-eviction", defaultDisableEviction, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
-		fs.DurationVar(&nodeDrainWaitPeriod, "node-drain-wait-period", 0, "Amount of time to wait between draining nodes in a nodegroup")
-		fs.IntVar(&parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		fs.DurationVar(&options.nodeDrainWaitPeriod, "node-drain-wait-period", 0, "Amount of time to wait between draining nodes in a nodegroup")
+		fs.IntVar(&options.parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		fs.IntVar(&options.batch.BatchSize, "batch-size", 0, "Maximum number of nodes of each nodegroup to drain before checking that evicted workloads have recovered")
+		fs.IntVar(&options.batch.MaxUnavailablePercent, "max-unavailable-percent", 0, "Maximum percentage of the nodes of each nodegroup to drain before checking that evicted workloads have recovered")
+		fs.DurationVar(&options.batch.HealthCheckTimeout, "health-check-timeout", 10*time.Minute, "Maximum time to wait for the Deployments and StatefulSets of evicted pods to become ready after each batch")
+		fs.BoolVar(&options.planReport, "plan", false, "Report the pods that would be evicted, their PodDisruptionBudgets and the expected outcome, without cordoning nodes or evicting pods")
+		cmdutils.AddOutputFlag(fs, &options.output, "Output format of the --plan report")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
 }
 
-func doDrainNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, undo, onlyMissing bool, maxGracePeriod, nodeDrainWaitPeriod time.Duration, podEvictionWaitPeriod time.Duration, disableEviction bool, parallel int) error {
+func doDrainNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, options drainNodeGroupOptions) error {
 	ngFilter := filter.NewNodeGroupFilter()
 
 	if err := cmdutils.NewDeleteAndDrainNodeGroupLoader(cmd, ng, ngFilter).Load(); err != nil {
 		return err
 	}
 
+	if options.planReport && options.undo {
+		return fmt.Errorf("--plan and --undo %s", cmdutils.IncompatibleFlags)
+	}
+
+	if options.batch.BatchSize < 0 {
+		return errors.New("--batch-size cannot be negative")
+	}
+	if options.batch.MaxUnavailablePercent < 0 || options.batch.MaxUnavailablePercent > 100 {
+		return errors.New("--max-unavailable-percent value must be of range 0-100")
+	}
+	if options.batch.HealthCheckTimeout <= 0 {
+		return errors.New("--health-check-timeout must be positive")
+	}
+
 	cfg := cmd.ClusterConfig
 
 	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
@@ -94,7 +125,7 @@ func doDrainNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, undo, onlyMissing bo
 	stackManager := ctl.NewStackManager(cfg)
 	if cmd.ClusterConfigFile != "" {
 		logger.Info("comparing %d nodegroups defined in the given config (%q) against remote state", len(cfg.NodeGroups), cmd.ClusterConfigFile)
-		if onlyMissing {
+		if options.onlyMissing {
 			err = ngFilter.SetOnlyRemote(ctx, ctl.AWSProvider.EKS(), stackManager, cfg)
 			if err != nil {
 				return err
@@ -109,8 +140,32 @@ func doDrainNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, undo, onlyMissing bo
 
 	logFiltered := cmdutils.ApplyFilter(cfg, ngFilter)
 
+	drainer := &nodegroup.Drainer{
+		ClientSet: clientSet,
+	}
+	drainInput := &nodegroup.DrainInput{
+		NodeGroups:            cmdutils.ToKubeNodeGroups(cfg.NodeGroups, cfg.ManagedNodeGroups),
+		Plan:                  cmd.Plan,
+		MaxGracePeriod:        options.maxGracePeriod,
+		NodeDrainWaitPeriod:   options.nodeDrainWaitPeriod,
+		PodEvictionWaitPeriod: options.podEvictionWaitPeriod,
+		Undo:                  options.undo,
+		DisableEviction:       options.disableEviction,
+		Parallel:              options.parallel,
+		Batch:                 options.batch,
+	}
+
+	if options.planReport {
+		logFiltered()
+		plans, err := drainer.Plan(ctx, drainInput)
+		if err != nil {
+			return err
+		}
+		return printDrainPlan(plans, options.output, os.Stdout)
+	}
+
 	verb := "drain"
-	if undo {
+	if options.undo {
 		verb = "uncordon"
 	}
 
@@ -127,20 +182,114 @@ func doDrainNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, undo, onlyMissing bo
 	if cmd.Plan {
 		return nil
 	}
-	allNodeGroups := cmdutils.ToKubeNodeGroups(cfg.NodeGroups, cfg.ManagedNodeGroups)
 
-	drainInput := &nodegroup.DrainInput{
-		NodeGroups:            allNodeGroups,
-		Plan:                  cmd.Plan,
-		MaxGracePeriod:        maxGracePeriod,
-		NodeDrainWaitPeriod:   nodeDrainWaitPeriod,
-		PodEvictionWaitPeriod: podEvictionWaitPeriod,
-		Undo:                  undo,
-		DisableEviction:       disableEviction,
-		Parallel:              parallel,
+	return drainer.Drain(ctx, drainInput)
+}
+
+// podPlanRow is a row of the --plan report when it is printed as a table.
+type podPlanRow struct {
+	drain.PodPlan
+	NodeGroup string
+	Node      string
+}
+
+func printDrainPlan(plans []drain.NodePlan, output printers.Type, w io.Writer) error {
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
 	}
 
-	return (&nodegroup.Drainer{
-		ClientSet: clientSet,
-	}).Drain(ctx, drainInput)
+	columnPrinter, ok := printer.(printers.ColumnPrinter)
+	if !ok {
+		if plans == nil {
+			plans = []drain.NodePlan{}
+		}
+		return printer.PrintObjWithKind("drain plan", plans, w)
+	}
+
+	var rows []podPlanRow
+	for _, nodePlan := range plans {
+		for _, podPlan := range nodePlan.Pods {
+			rows = append(rows, podPlanRow{
+				PodPlan:   podPlan,
+				NodeGroup: nodePlan.NodeGroup,
+				Node:      nodePlan.Node,
+			})
+		}
+	}
+	addDrainPlanColumns(columnPrinter)
+	if err := columnPrinter.PrintObjWithKind("pods", rows, w); err != nil {
+		return err
+	}
+	logDrainPlanSummary(rows)
+	return nil
+}
+
+func addDrainPlanColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("NODEGROUP", func(r podPlanRow) string {
+		return r.NodeGroup
+	})
+	printer.AddColumn("NODE", func(r podPlanRow) string {
+		return r.Node
+	})
+	printer.AddColumn("POD", func(r podPlanRow) string {
+		return r.Namespace + "/" + r.Name
+	})
+	printer.AddColumn("OWNER", func(r podPlanRow) string {
+		if r.Unreplicated {
+			return "<none>"
+		}
+		return r.Owner
+	})
+	printer.AddColumn("PDB", func(r podPlanRow) string {
+		return r.PodDisruptionBudget
+	})
+	printer.AddColumn("PDB HEADROOM", func(r podPlanRow) string {
+		if r.DisruptionsAllowed == nil {
+			return ""
+		}
+		return strconv.Itoa(int(*r.DisruptionsAllowed))
+	})
+	printer.AddColumn("LOCAL STORAGE", func(r podPlanRow) bool {
+		return r.LocalStorage
+	})
+	printer.AddColumn("OUTCOME", func(r podPlanRow) drain.Outcome {
+		return r.Outcome
+	})
+	printer.AddColumn("REASON", func(r podPlanRow) string {
+		return r.Reason
+	})
+}
+
+func logDrainPlanSummary(rows []podPlanRow) {
+	var blocked, failed, localStorage, unreplicated int
+	for _, r := range rows {
+		switch r.Outcome {
+		case drain.OutcomeBlockedByPDB:
+			blocked++
+		case drain.OutcomeFail:
+			failed++
+		}
+		if r.Outcome == drain.OutcomeSkip {
+			continue
+		}
+		if r.LocalStorage {
+			localStorage++
+		}
+		if r.Unreplicated {
+			unreplicated++
+		}
+	}
+	if blocked > 0 {
+		logger.Warning("%d pod(s) are blocked by PodDisruptionBudgets; the drain will wait until they allow more disruptions", blocked)
+	}
+	if failed > 0 {
+		logger.Warning("%d pod(s) cannot be drained; the drain will fail", failed)
+	}
+	if localStorage > 0 {
+		logger.Warning("%d pod(s) use local storage, which will be lost", localStorage)
+	}
+	if unreplicated > 0 {
+		logger.Warning("%d pod(s) are not managed by a controller and will not be recreated", unreplicated)
+	}
 }
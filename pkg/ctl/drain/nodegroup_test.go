@@ -22,7 +22,7 @@ var _ = Describe("drain node group", func() {
 			cmd := newMockEmptyCmd(args...)
 			count := 0
 			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
-				drainNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ng *v1alpha5.NodeGroup, undo, onlyMissing bool, maxGracePeriod, nodeDrainWaitPeriod, podEvictionWaitPeriod time.Duration, disableEviction bool, parallel int) error {
+				drainNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ng *v1alpha5.NodeGroup, options drainNodeGroupOptions) error {
 					Expect(cmd.ClusterConfig.Metadata.Name).To(Equal("clusterName"))
 					Expect(ng.Name).To(Equal("ng"))
 					count++
@@ -37,6 +37,26 @@ var _ = Describe("drain node group", func() {
 		Entry("with deprecated flag --only", "nodegroup", "--cluster", "clusterName", "--name", "ng", "--only", "ng"),
 	)
 
+	It("parses the --plan and batch flags", func() {
+		cmd := newMockEmptyCmd("nodegroup", "--cluster", "clusterName", "--name", "ng", "--plan", "--output", "json", "--batch-size", "2", "--max-unavailable-percent", "25")
+		count := 0
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+			drainNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, ng *v1alpha5.NodeGroup, options drainNodeGroupOptions) error {
+				Expect(options.planReport).To(BeTrue())
+				Expect(options.output).To(Equal("json"))
+				Expect(options.maxGracePeriod).To(Equal(10 * time.Minute))
+				Expect(options.batch.BatchSize).To(Equal(2))
+				Expect(options.batch.MaxUnavailablePercent).To(Equal(25))
+				Expect(options.batch.HealthCheckTimeout).To(Equal(10 * time.Minute))
+				count++
+				return nil
+			})
+		})
+		_, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+
 	DescribeTable("invalid flags or arguments",
 		func(c invalidParamsCase) {
 			cmd := newDefaultCmd(c.args...)
@@ -52,6 +72,22 @@ var _ = Describe("drain node group", func() {
 			args:  []string{"nodegroup", "ng", "--cluster", "dummy", "--name", "ng"},
 			error: fmt.Errorf("Error: --name=ng and argument ng cannot be used at the same time"),
 		}),
+		Entry("setting --plan and --undo at the same time", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--plan", "--undo"},
+			error: fmt.Errorf("Error: --plan and --undo %s", cmdutils.IncompatibleFlags),
+		}),
+		Entry("setting a negative --batch-size", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--batch-size", "-1"},
+			error: fmt.Errorf("Error: --batch-size cannot be negative"),
+		}),
+		Entry("setting --max-unavailable-percent above 100", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--max-unavailable-percent", "101"},
+			error: fmt.Errorf("Error: --max-unavailable-percent value must be of range 0-100"),
+		}),
+		Entry("setting --health-check-timeout to zero", invalidParamsCase{
+			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--health-check-timeout", "0s"},
+			error: fmt.Errorf("Error: --health-check-timeout must be positive"),
+		}),
 		Entry("setting --parallel below 1", invalidParamsCase{
 			args:  []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--parallel", "-1"},
 			error: fmt.Errorf("Error: --parallel value must be of range 1-25"),
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/export"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func exportClusterCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription(
+		"cluster",
+		"Export a ClusterConfig reconstructed from a live cluster",
+		"Reconstructs a ClusterConfig from the live cluster, its nodegroups, addons, IAM service accounts, access entries, pod identity associations and Fargate profiles, for clusters whose config file is not available. Creating a cluster from the exported config produces an equivalent cluster.",
+	)
+
+	var output printers.Type
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVarP(&output, "output", "o", printers.YAMLType, "specifies the output format (valid option: yaml, json)")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doExportCluster(cmd, output)
+	}
+}
+
+func doExportCluster(cmd *cmdutils.Cmd, output printers.Type) error {
+	if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if output != printers.YAMLType && output != printers.JSONType {
+		return fmt.Errorf("unsupported output format %q (valid option: yaml, json)", output)
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	// the config is written to stdout, so log to stderr to allow redirecting it to a file
+	logger.Writer = os.Stderr
+
+	ctx := context.Background()
+	clusterProvider, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	exported, err := export.New(cfg.Metadata, clusterProvider.AWSProvider, clusterProvider.NewStackManager(cfg)).Export(ctx)
+	if err != nil {
+		return fmt.Errorf("exporting cluster %q: %w", cfg.Metadata.Name, err)
+	}
+	return printer.PrintObj(exported, cmd.CobraCommand.OutOrStdout())
+}
@@ -0,0 +1,30 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("export cluster", func() {
+	DescribeTable("invalid arguments", func(args []string, expectedErr string) {
+		cmd := Command(cmdutils.NewGrouping())
+		cmd.SetArgs(append([]string{"cluster"}, args...))
+		errBuf := new(bytes.Buffer)
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(errBuf)
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(errors.New(errBuf.String())).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		Entry("missing name", nil, "Error: --name must be set"),
+		Entry("name flag and argument", []string{"test-cluster", "--name", "test-cluster"},
+			"Error: --name=test-cluster and argument test-cluster cannot be used at the same time"),
+		Entry("unsupported output format", []string{"--name", "test-cluster", "--output", "table"},
+			`Error: unsupported output format "table" (valid option: yaml, json)`),
+	)
+})
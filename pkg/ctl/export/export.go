@@ -0,0 +1,16 @@
+package export
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+// Command will create the `export` commands
+func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	verbCmd := cmdutils.NewVerbCmd("export", "Export the config of live resource(s)", "")
+
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, exportClusterCmd)
+
+	return verbCmd
+}
@@ -0,0 +1,11 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlExport(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
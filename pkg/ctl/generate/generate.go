@@ -0,0 +1,16 @@
+package generate
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+// Command will create the `generate` commands
+func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	verbCmd := cmdutils.NewVerbCmd("generate", "Generate resource(s) from a config file without calling AWS", "")
+
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, generateTemplatesCmd)
+
+	return verbCmd
+}
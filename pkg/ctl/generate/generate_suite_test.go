@@ -0,0 +1,11 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestCtlGenerate(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
@@ -0,0 +1,61 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/templates"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func generateTemplatesCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+
+	cmd.SetDescription(
+		"templates",
+		"Generate the CloudFormation templates for a ClusterConfig file",
+		"Renders the CloudFormation templates of the cluster, nodegroup, managed nodegroup, IAM service account and Karpenter stacks that eksctl would deploy for the config file, and writes each template to <output-dir>/<stack name>.json. AWS is not called; availability zones, AMI IDs and the account ID are taken from the config file or flags, so the output is deterministic.",
+	)
+
+	var (
+		outputDir string
+		options   templates.Options
+	)
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVar(&outputDir, "output-dir", "templates", "directory to write the templates to")
+		fs.StringSliceVar(&options.AvailabilityZones, "zones", nil, "availability zones to use instead of availabilityZones in the config file")
+		fs.StringVar(&options.NodeAMI, "node-ami", "", "AMI ID to use for nodegroups that require one and do not set ami")
+		fs.StringVar(&options.AccountID, "account-id", templates.DefaultAccountID, "AWS account ID to use in ARNs")
+	})
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doGenerateTemplates(cmd, outputDir, options)
+	}
+}
+
+func doGenerateTemplates(cmd *cmdutils.Cmd, outputDir string, options templates.Options) error {
+	if err := cmdutils.NewGenerateTemplatesLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if err := cmd.InitializeClusterConfig(); err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	generated, err := templates.NewGenerator(cfg, options).Generate(context.Background())
+	if err != nil {
+		return fmt.Errorf("generating templates for cluster %q: %w", cfg.Metadata.Name, err)
+	}
+	if err := templates.WriteTemplates(outputDir, generated); err != nil {
+		return err
+	}
+	logger.Success("wrote %d template(s) for cluster %q to %q", len(generated), cfg.Metadata.Name, outputDir)
+	return nil
+}
@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("generate templates", func() {
+	type generateTemplatesTest struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("invalid arguments", func(e generateTemplatesTest) {
+		cmd := Command(cmdutils.NewGrouping())
+		cmd.SetArgs(append([]string{"templates"}, e.args...))
+		errBuf := new(bytes.Buffer)
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(errBuf)
+		err := cmd.Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(errors.New(errBuf.String())).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing config file", generateTemplatesTest{
+			expectedErr: "Error: --config-file must be set",
+		}),
+		Entry("name argument", generateTemplatesTest{
+			args:        []string{"test-cluster", "--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: `Error: unknown command "test-cluster"`,
+		}),
+		Entry("missing AMI ID", generateTemplatesTest{
+			args:        []string{"--config-file", "../../../examples/01-simple-cluster.yaml", "--zones", "eu-north-1a,eu-north-1b"},
+			expectedErr: "an AMI ID must be set",
+		}),
+	)
+
+	It("writes a template for each stack", func() {
+		outputDir := GinkgoT().TempDir()
+		cmd := Command(cmdutils.NewGrouping())
+		cmd.SetArgs([]string{"templates",
+			"--config-file", "../../../examples/01-simple-cluster.yaml",
+			"--zones", "eu-north-1a,eu-north-1b",
+			"--node-ami", "ami-0123456789abcdef0",
+			"--output-dir", outputDir,
+		})
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		Expect(cmd.Execute()).To(Succeed())
+
+		files, err := filepath.Glob(filepath.Join(outputDir, "*.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(ConsistOf(
+			filepath.Join(outputDir, "eksctl-cluster-1-cluster.json"),
+			filepath.Join(outputDir, "eksctl-cluster-1-nodegroup-ng-1.json"),
+		))
+		body, err := os.ReadFile(filepath.Join(outputDir, "eksctl-cluster-1-nodegroup-ng-1.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring("ami-0123456789abcdef0"))
+	})
+})
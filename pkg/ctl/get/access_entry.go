@@ -81,15 +81,15 @@ func doGetAccessEntry(cmd *cmdutils.Cmd, principalARN api.ARN, params *getCmdPar
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addAccessEntrySummaryTableColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addAccessEntrySummaryTableColumns(columnPrinter)
 		logger.Info("to get a detailed view of Kubernetes groups or policies associated with each access entry, use --output yaml or json")
 	}
 
 	return printer.PrintObjWithKind("accessentries", summaries, os.Stdout)
 }
 
-func addAccessEntrySummaryTableColumns(printer *printers.TablePrinter) {
+func addAccessEntrySummaryTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("PRINCIPAL ARN", func(s accessentryactions.Summary) string {
 		return s.PrincipalARN
 	})
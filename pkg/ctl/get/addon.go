@@ -107,13 +107,13 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 		return err
 	}
 
-	if tablePrinter, ok := printer.(*printers.TablePrinter); ok {
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
 		if slices.ContainsFunc(summaries, func(summary addon.Summary) bool {
 			return len(summary.PodIdentityAssociations) > 0
 		}) {
 			logger.Info("to view pod identity associations for an addon, rerun the command with --output=json or --output=yaml")
 		}
-		addAddonSummaryTableColumns(tablePrinter)
+		addAddonSummaryTableColumns(columnPrinter)
 	}
 
 	if err := printer.PrintObjWithKind("addons", summaries, cmd.CobraCommand.OutOrStdout()); err != nil {
@@ -130,7 +130,7 @@ func getAddon(cmd *cmdutils.Cmd, a *api.Addon, params *getCmdParams) error {
 	return nil
 }
 
-func addAddonSummaryTableColumns(printer *printers.TablePrinter) {
+func addAddonSummaryTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("NAME", func(s addon.Summary) string {
 		return s.Name
 	})
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -26,7 +28,10 @@ func getClusterCmd(cmd *cmdutils.Cmd) {
 	cfg := api.NewClusterConfig()
 	cmd.ClusterConfig = cfg
 
-	var listAllRegions bool
+	var (
+		listAllRegions bool
+		fleetOptions   cmdutils.FleetOptions
+	)
 
 	params := &getCmdParams{}
 
@@ -34,6 +39,9 @@ func getClusterCmd(cmd *cmdutils.Cmd) {
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
+		if fleetOptions.Enabled() {
+			return doGetFleetClusters(cmd, params, fleetOptions)
+		}
 		return doGetCluster(cmd, params, listAllRegions)
 	}
 
@@ -46,6 +54,10 @@ func getClusterCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 	})
 
+	cmd.FlagSetGroup.InFlagSet("Fleet", func(fs *pflag.FlagSet) {
+		cmdutils.AddFleetFlags(fs, &fleetOptions)
+	})
+
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 }
 
@@ -100,8 +112,8 @@ func getAndPrinterClusters(ctx context.Context, cmd *cmdutils.Cmd, ctl *eks.Clus
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addGetClustersSummaryTableColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addGetClustersSummaryTableColumns(columnPrinter)
 	}
 
 	clusters, err := cluster.GetClusters(ctx, ctl.AWSProvider, listAllRegions, params.chunkSize)
@@ -112,7 +124,7 @@ func getAndPrinterClusters(ctx context.Context, cmd *cmdutils.Cmd, ctl *eks.Clus
 	return printer.PrintObjWithKind("clusters", clusters, cmd.CobraCommand.OutOrStdout())
 }
 
-func addGetClustersSummaryTableColumns(printer *printers.TablePrinter) {
+func addGetClustersSummaryTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("NAME", func(c cluster.Description) string {
 		return c.Name
 	})
@@ -130,8 +142,8 @@ func getAndPrintCluster(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.Cluster
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addGetClusterSummaryTableColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addGetClusterSummaryTableColumns(columnPrinter)
 	}
 
 	cluster, err := ctl.GetCluster(ctx, cfg.Metadata.Name)
@@ -142,7 +154,7 @@ func getAndPrintCluster(ctx context.Context, cmd *cmdutils.Cmd, cfg *api.Cluster
 	return printer.PrintObjWithKind("clusters", []*ekstypes.Cluster{cluster}, cmd.CobraCommand.OutOrStdout())
 }
 
-func addGetClusterSummaryTableColumns(printer *printers.TablePrinter) {
+func addGetClusterSummaryTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("NAME", func(c *ekstypes.Cluster) string {
 		if c.Name == nil {
 			return "-"
@@ -201,3 +213,98 @@ func addGetClusterSummaryTableColumns(printer *printers.TablePrinter) {
 		return "EKS"
 	})
 }
+
+// fleetCluster is a cluster of a fleet, as printed by `eksctl get cluster --fleet`
+type fleetCluster struct {
+	Name    string `json:"name"`
+	Region  string `json:"region"`
+	Profile string `json:"profile,omitempty"`
+	Version string `json:"version,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func doGetFleetClusters(cmd *cmdutils.Cmd, params *getCmdParams, fleetOptions cmdutils.FleetOptions) error {
+	if params.output != printers.TableType {
+		logger.Writer = os.Stderr
+	}
+
+	var (
+		mu       sync.Mutex
+		clusters = map[string]*ekstypes.Cluster{}
+	)
+	results, err := cmdutils.RunFleet(cmd, fleetOptions, []string{"all-regions"}, func(clusterCmd *cmdutils.Cmd) error {
+		ctx := context.Background()
+		ctl, err := eks.New(ctx, &clusterCmd.ProviderConfig, clusterCmd.ClusterConfig)
+		if err != nil {
+			return err
+		}
+		cluster, err := ctl.GetCluster(ctx, clusterCmd.ClusterConfig.Metadata.Name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		clusters[clusterCmd.ClusterConfig.Metadata.Region+"/"+clusterCmd.ClusterConfig.Metadata.Name] = cluster
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var fleetClusters []fleetCluster
+	for _, r := range results {
+		c := fleetCluster{
+			Name:    r.Cluster,
+			Region:  r.Region,
+			Profile: r.Profile,
+		}
+		if cluster, ok := clusters[r.Region+"/"+r.Cluster]; ok {
+			c.Version = aws.ToString(cluster.Version)
+			c.Status = string(cluster.Status)
+		}
+		if !r.Succeeded() {
+			c.Error = r.Error.Error()
+		}
+		fleetClusters = append(fleetClusters, c)
+	}
+
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addGetFleetClustersTableColumns(columnPrinter)
+	}
+	if err := printer.PrintObjWithKind("clusters", fleetClusters, cmd.CobraCommand.OutOrStdout()); err != nil {
+		return err
+	}
+	return cmdutils.FleetError(results)
+}
+
+func addGetFleetClustersTableColumns(printer printers.ColumnPrinter) {
+	orNone := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+	printer.AddColumn("NAME", func(c fleetCluster) string {
+		return c.Name
+	})
+	printer.AddColumn("REGION", func(c fleetCluster) string {
+		return c.Region
+	})
+	printer.AddColumn("PROFILE", func(c fleetCluster) string {
+		return orNone(c.Profile)
+	})
+	printer.AddColumn("VERSION", func(c fleetCluster) string {
+		return orNone(c.Version)
+	})
+	printer.AddColumn("STATUS", func(c fleetCluster) string {
+		return orNone(c.Status)
+	})
+	printer.AddColumn("ERROR", func(c fleetCluster) string {
+		return orNone(c.Error)
+	})
+}
@@ -26,6 +26,7 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getAddonCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getPodIdentityAssociationCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getAccessEntryCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getHybridNodesCmd)
 
 	return verbCmd
 }
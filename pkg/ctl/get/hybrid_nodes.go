@@ -0,0 +1,105 @@
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/hybridnodes"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func getHybridNodesCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	params := &getCmdParams{}
+
+	cmd.SetDescription(
+		"hybrid-nodes",
+		"Get hybrid node(s)",
+		"Lists the hybrid nodes registered with the cluster, along with the status of the SSM activations they were registered with",
+		"hybrid-node",
+	)
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doGetHybridNodes(cmd, params)
+	}
+}
+
+func doGetHybridNodes(cmd *cmdutils.Cmd, params *getCmdParams) error {
+	if err := cmdutils.NewGetHybridNodesLoader(cmd).Load(); err != nil {
+		return err
+	}
+
+	if params.output != printers.TableType {
+		//log warnings and errors to stdout
+		logger.Writer = os.Stderr
+	}
+
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	getter := &hybridnodes.Getter{
+		ClientSet: clientSet,
+		SSM:       ctl.AWSProvider.SSM(),
+	}
+	nodes, err := getter.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve hybrid nodes for cluster %s: %w", cfg.Metadata.Name, err)
+	}
+
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return err
+	}
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addHybridNodeTableColumns(columnPrinter)
+	}
+	return printer.PrintObjWithKind("hybridnodes", nodes, os.Stdout)
+}
+
+func addHybridNodeTableColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("NAME", func(n hybridnodes.Node) string {
+		return n.Name
+	})
+	printer.AddColumn("STATUS", func(n hybridnodes.Node) string {
+		return n.Status
+	})
+	printer.AddColumn("VERSION", func(n hybridnodes.Node) string {
+		return n.KubeletVersion
+	})
+	printer.AddColumn("INTERNAL-IP", func(n hybridnodes.Node) string {
+		return n.InternalIP
+	})
+	printer.AddColumn("SSM PING STATUS", func(n hybridnodes.Node) string {
+		return n.SSMPingStatus
+	})
+	printer.AddColumn("ACTIVATION ID", func(n hybridnodes.Node) string {
+		return n.ActivationID
+	})
+	printer.AddColumn("ACTIVATION STATUS", func(n hybridnodes.Node) string {
+		return n.ActivationStatus
+	})
+}
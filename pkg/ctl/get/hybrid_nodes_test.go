@@ -0,0 +1,31 @@
+package get
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("get hybrid nodes", func() {
+	type getHybridNodesTest struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("unsupported arguments", func(e getHybridNodesTest) {
+		cmd := newMockCmd(append([]string{"hybrid-nodes"}, e.args...)...)
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing required flag --cluster", getHybridNodesTest{
+			expectedErr: "Error: --cluster must be set",
+		}),
+		Entry("setting --cluster and a name argument at the same time", getHybridNodesTest{
+			args:        []string{"--cluster", "test", "test-2"},
+			expectedErr: "cannot be used at the same time",
+		}),
+		Entry("setting --cluster and --config-file at the same time", getHybridNodesTest{
+			args:        []string{"--cluster", "test", "--config-file", "../../../examples/01-simple-cluster.yaml"},
+			expectedErr: "Error: cannot use --cluster when --config-file/-f is set",
+		}),
+	)
+})
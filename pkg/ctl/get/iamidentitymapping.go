@@ -100,14 +100,14 @@ func doGetIAMIdentityMapping(cmd *cmdutils.Cmd, params *getCmdParams, arn string
 	if err != nil {
 		return err
 	}
-	if params.output == printers.TableType {
-		addIAMIdentityMappingTableColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addIAMIdentityMappingTableColumns(columnPrinter)
 	}
 
 	return printer.PrintObjWithKind("iamidentitymappings", identities, cmd.CobraCommand.OutOrStdout())
 }
 
-func addIAMIdentityMappingTableColumns(printer *printers.TablePrinter) {
+func addIAMIdentityMappingTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("ARN", func(r iam.Identity) string {
 		return r.ARN()
 	})
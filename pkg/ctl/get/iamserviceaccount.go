@@ -89,14 +89,14 @@ func doGetIAMServiceAccount(cmd *cmdutils.Cmd, options IAMServiceAccountOptions)
 		return err
 	}
 
-	if options.output == printers.TableType {
-		addIAMServiceAccountSummaryTableColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addIAMServiceAccountSummaryTableColumns(columnPrinter)
 	}
 
 	return printer.PrintObjWithKind("iamserviceaccounts", serviceAccounts, cmd.CobraCommand.OutOrStdout())
 }
 
-func addIAMServiceAccountSummaryTableColumns(printer *printers.TablePrinter) {
+func addIAMServiceAccountSummaryTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("NAMESPACE", func(sa *api.ClusterIAMServiceAccount) string {
 		return sa.Namespace
 	})
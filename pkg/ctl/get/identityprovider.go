@@ -77,14 +77,14 @@ func doGetIdentityProvider(cmd *cmdutils.Cmd, params getCmdParams, name string)
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addIdentityProviderTableColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addIdentityProviderTableColumns(columnPrinter)
 	}
 
 	return printer.PrintObjWithKind("identity provider summary", summaries, cmd.CobraCommand.OutOrStdout())
 }
 
-func addIdentityProviderTableColumns(printer *printers.TablePrinter) {
+func addIdentityProviderTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("NAME", func(s identityproviders.Summary) string {
 		return s.Name
 	})
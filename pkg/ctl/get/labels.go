@@ -66,7 +66,7 @@ func getLabels(cmd *cmdutils.Cmd, nodeGroupName string) error {
 	return printer.PrintObjWithKind("labels", labels, cmd.CobraCommand.OutOrStdout())
 }
 
-func addColumns(printer *printers.TablePrinter) {
+func addColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("CLUSTER", func(s label.Summary) string {
 		return s.Cluster
 	})
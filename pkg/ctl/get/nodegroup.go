@@ -106,13 +106,15 @@ func doGetNodeGroup(cmd *cmdutils.Cmd, ng *api.NodeGroup, params *getCmdParams)
 			}
 			return fmt.Errorf("nodegroup with name %v not found", ng.Name)
 		}
-		addSummaryTableColumns(printer.(*printers.TablePrinter))
+	}
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addSummaryTableColumns(columnPrinter)
 	}
 
 	return printer.PrintObjWithKind("nodegroups", summaries, cmd.CobraCommand.OutOrStdout())
 }
 
-func addSummaryTableColumns(printer *printers.TablePrinter) {
+func addSummaryTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("CLUSTER", func(s *nodegroup.Summary) string {
 		return s.Cluster
 	})
@@ -57,8 +57,8 @@ func doGetPodIdentityAssociation(cmd *cmdutils.Cmd, namespace, serviceAccountNam
 		return err
 	}
 
-	if params.output == printers.TableType {
-		addPodIdentityAssociationSummaryTableColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addPodIdentityAssociationSummaryTableColumns(columnPrinter)
 	}
 
 	return printer.PrintObjWithKind("podidentityassociations", summaries, cmd.CobraCommand.OutOrStdout())
@@ -79,7 +79,7 @@ func configureGetPodIdentityAssociationCmd(cmd *cmdutils.Cmd, params *getCmdPara
 	})
 }
 
-func addPodIdentityAssociationSummaryTableColumns(printer *printers.TablePrinter) {
+func addPodIdentityAssociationSummaryTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("ASSOCIATION ARN", func(s podidentityassociation.Summary) string {
 		return s.AssociationARN
 	})
@@ -0,0 +1,203 @@
+package replace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+type replaceNodeGroupOptions struct {
+	nodegroup.ReplaceOptions
+	name                string
+	updateAuthConfigMap *bool
+}
+
+func replaceNodeGroupCmd(cmd *cmdutils.Cmd) {
+	replaceNodeGroupWithRunFunc(cmd, doReplaceNodeGroup)
+}
+
+func replaceNodeGroupWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, options replaceNodeGroupOptions) error) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	var options replaceNodeGroupOptions
+
+	cmd.SetDescription("nodegroup", "Replace a nodegroup with a new one (blue/green)",
+		"Creates a copy of the nodegroup under a new name, waits for its nodes to become ready, then drains and deletes the existing nodegroup. "+
+			"If the nodes of the new nodegroup do not become ready, it is deleted and the existing nodegroup is left untouched.", "ng")
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return runFunc(cmd, options)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		fs.StringVarP(&options.name, "name", "n", "", "Name of the nodegroup to replace")
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddApproveFlag(fs, cmd)
+		cmdutils.AddWaitFlag(fs, &options.Wait, "deletion of the existing nodegroup")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Replacement nodegroup", func(fs *pflag.FlagSet) {
+		fs.StringVar(&options.NewName, "new-name", "", "Name of the replacement nodegroup (generated if not specified)")
+		fs.StringVar(&options.AMI, "ami", "", "AMI of the replacement nodegroup")
+		fs.StringVar(&options.InstanceType, "instance-type", "", "Instance type of the replacement nodegroup")
+		cmdutils.AddVersionFlag(fs, cfg.Metadata, `"auto" or "latest" can be used to automatically inherit version from the control plane or force latest`)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Drain", func(fs *pflag.FlagSet) {
+		fs.DurationVar(&options.Drain.MaxGracePeriod, "max-grace-period", 10*time.Minute, "Maximum pods termination grace period")
+		fs.DurationVar(&options.Drain.PodEvictionWaitPeriod, "pod-eviction-wait-period", 10*time.Second, "Duration to wait after failing to evict a pod")
+		fs.DurationVar(&options.Drain.NodeDrainWaitPeriod, "node-drain-wait-period", 0, "Amount of time to wait between draining nodes in a nodegroup")
+		fs.BoolVar(&options.Drain.DisableEviction, "disable-eviction", false, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
+		fs.IntVar(&options.Drain.Parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+		options.updateAuthConfigMap = cmdutils.AddUpdateAuthConfigMap(fs, "Remove the IAM role of the existing nodegroup from aws-auth configmap")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, true)
+}
+
+type authConfigMapUpdater struct {
+	clientSet kubernetes.Interface
+}
+
+func (a *authConfigMapUpdater) RemoveNodeGroup(ng *api.NodeGroup) error {
+	return authconfigmap.RemoveNodeGroup(a.clientSet, ng)
+}
+
+// timeoutDrainer bounds the time spent draining the existing nodegroup, as drain does not time out by itself.
+type timeoutDrainer struct {
+	drainer nodegroup.NodeGroupDrainer
+	timeout time.Duration
+}
+
+func (t *timeoutDrainer) Drain(ctx context.Context, input *nodegroup.DrainInput) error {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.drainer.Drain(ctx, input)
+}
+
+func doReplaceNodeGroup(cmd *cmdutils.Cmd, options replaceNodeGroupOptions) error {
+	if err := cmdutils.NewReplaceNodeGroupLoader(cmd, &options.name).Load(); err != nil {
+		return err
+	}
+	if options.Drain.Parallel < 1 || options.Drain.Parallel > 25 {
+		return fmt.Errorf("--parallel value must be of range 1-25")
+	}
+	if options.NewName != "" && api.IsInvalidNameArg(options.NewName) {
+		return api.ErrInvalidName(options.NewName)
+	}
+
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingClusterHelper(ctx, standardizeNodeGroupVersion)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	instanceSelector, err := selector.New(ctx, ctl.AWSProvider.AWSConfig())
+	if err != nil {
+		return err
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+	m := nodegroup.New(cfg, ctl, clientSet, instanceSelector)
+
+	existing, err := existingNodeGroup(ctx, cmd, m, stackManager, options.name)
+	if err != nil {
+		return err
+	}
+
+	options.UpdateAuthConfigMap = !api.IsDisabled(options.updateAuthConfigMap)
+	if ng, ok := existing.(*api.NodeGroup); ok && options.UpdateAuthConfigMap && (ng.IAM == nil || ng.IAM.InstanceRoleARN == "") {
+		if err := ctl.GetNodeGroupIAM(ctx, stackManager, ng); err != nil {
+			logger.Warning("error getting instance role ARN for nodegroup %q, it will not be removed from the aws-auth ConfigMap: %v", ng.Name, err)
+		}
+	}
+	options.Plan = cmd.Plan
+
+	replacer := &nodegroup.Replacer{
+		ClusterConfig: cfg,
+		Creator:       m,
+		Drainer: &timeoutDrainer{
+			drainer: &nodegroup.Drainer{
+				ClientSet: clientSet,
+			},
+			timeout: cmd.ProviderConfig.WaitTimeout,
+		},
+		Remover: &nodegroup.Deleter{
+			StackHelper:      stackManager,
+			NodeGroupDeleter: ctl.AWSProvider.EKS(),
+			ClusterName:      cfg.Metadata.Name,
+			AuthConfigMapUpdater: &authConfigMapUpdater{
+				clientSet: clientSet,
+			},
+		},
+	}
+	_, err = replacer.Replace(ctx, existing, options.ReplaceOptions)
+	return err
+}
+
+// existingNodeGroup returns the spec of the nodegroup to replace, from the config file if one was given, or else from
+// the EKS API. The spec of a self-managed nodegroup cannot be recovered, so its config file is required.
+func existingNodeGroup(ctx context.Context, cmd *cmdutils.Cmd, m *nodegroup.Manager, stackManager manager.StackManager, name string) (api.NodePool, error) {
+	cfg := cmd.ClusterConfig
+	if cmd.ClusterConfigFile != "" {
+		if len(cfg.NodeGroups) > 0 {
+			return cfg.NodeGroups[0], nil
+		}
+		return cfg.ManagedNodeGroups[0], nil
+	}
+
+	nodeGroupType, err := stackManager.GetNodeGroupStackType(ctx, manager.GetNodegroupOption{
+		NodeGroupName: name,
+	})
+	if err != nil {
+		logger.Debug("failed to fetch nodegroup %q stack: %v", name, err)
+	} else if nodeGroupType == api.NodeGroupTypeUnmanaged {
+		return nil, fmt.Errorf("nodegroup %q is a self-managed nodegroup; pass the config file it was created from using --config-file", name)
+	}
+	return m.GetManagedNodeGroup(ctx, name)
+}
+
+func standardizeNodeGroupVersion(cvm eks.ClusterVersionsManagerInterface, controlPlaneVersion string, meta *api.ClusterMeta) error {
+	switch meta.Version {
+	case "", "auto":
+		meta.Version = controlPlaneVersion
+	case "latest":
+		meta.Version = cvm.LatestVersion()
+	default:
+		if err := cvm.ValidateVersion(meta.Version); err != nil {
+			return err
+		}
+	}
+	if meta.Version != controlPlaneVersion {
+		logger.Warning("will use version %s for the replacement nodegroup, while control plane version is %s", meta.Version, controlPlaneVersion)
+	}
+	return nil
+}
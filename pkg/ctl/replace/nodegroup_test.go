@@ -0,0 +1,145 @@
+package replace
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("replace nodegroup", func() {
+	It("parses the flags", func() {
+		cmd := newMockEmptyCmd("nodegroup", "--cluster", "clusterName", "--name", "ng-1", "--new-name", "ng-2",
+			"--ami", "ami-123", "--instance-type", "m6i.large", "--version", "1.32", "--parallel", "3", "--max-grace-period", "1m")
+		count := 0
+		cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+			replaceNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, options replaceNodeGroupOptions) error {
+				Expect(cmd.ClusterConfig.Metadata.Name).To(Equal("clusterName"))
+				Expect(cmd.ClusterConfig.Metadata.Version).To(Equal("1.32"))
+				Expect(options.name).To(Equal("ng-1"))
+				Expect(options.NewName).To(Equal("ng-2"))
+				Expect(options.AMI).To(Equal("ami-123"))
+				Expect(options.InstanceType).To(Equal("m6i.large"))
+				Expect(options.Drain.Parallel).To(Equal(3))
+				Expect(options.Drain.MaxGracePeriod).To(Equal(time.Minute))
+				Expect(options.Drain.PodEvictionWaitPeriod).To(Equal(10 * time.Second))
+				Expect(*options.updateAuthConfigMap).To(BeTrue())
+				count++
+				return nil
+			})
+		})
+		_, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(1))
+	})
+
+	Describe("loading the config file", func() {
+		var configFile string
+
+		BeforeEach(func() {
+			configFile = filepath.Join(GinkgoT().TempDir(), "cluster.yaml")
+			Expect(os.WriteFile(configFile, []byte(`
+apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfig
+metadata:
+  name: test-cluster
+  region: us-west-2
+nodeGroups:
+  - name: ng-1
+managedNodeGroups:
+  - name: mng-1
+  - name: mng-2
+`), 0644)).To(Succeed())
+		})
+
+		loadConfig := func(args ...string) (*cmdutils.Cmd, error) {
+			cmd := newMockEmptyCmd(append([]string{"nodegroup"}, args...)...)
+			var loaded *cmdutils.Cmd
+			cmdutils.AddResourceCmd(cmdutils.NewGrouping(), cmd.parentCmd, func(cmd *cmdutils.Cmd) {
+				replaceNodeGroupWithRunFunc(cmd, func(cmd *cmdutils.Cmd, options replaceNodeGroupOptions) error {
+					loaded = cmd
+					return cmdutils.NewReplaceNodeGroupLoader(cmd, &options.name).Load()
+				})
+			})
+			_, err := cmd.execute()
+			return loaded, err
+		}
+
+		It("keeps only the nodegroup being replaced", func() {
+			cmd, err := loadConfig("-f", configFile, "--name", "mng-2", "--version", "1.32")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.ClusterConfig.NodeGroups).To(BeEmpty())
+			Expect(cmd.ClusterConfig.ManagedNodeGroups).To(HaveLen(1))
+			Expect(cmd.ClusterConfig.ManagedNodeGroups[0].Name).To(Equal("mng-2"))
+			Expect(cmd.ClusterConfig.Metadata.Version).To(Equal("1.32"))
+		})
+
+		It("returns an error if the nodegroup is not in the config file", func() {
+			_, err := loadConfig("-f", configFile, "--name", "ng-2")
+			Expect(err).To(MatchError(ContainSubstring(`Error: nodegroup "ng-2" not found in config file`)))
+		})
+
+		It("returns an error if the name is not set", func() {
+			_, err := loadConfig("-f", configFile)
+			Expect(err).To(MatchError(ContainSubstring("Error: --name must be set")))
+		})
+	})
+
+	DescribeTable("invalid flags or arguments",
+		func(args []string, expectedErr string) {
+			cmd := newDefaultCmd(args...)
+			_, err := cmd.execute()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(expectedErr))
+		},
+		Entry("missing required flag --cluster", []string{"nodegroup"}, "Error: --cluster must be set"),
+		Entry("missing required flag --name", []string{"nodegroup", "--cluster", "dummy"}, "Error: --name must be set"),
+		Entry("setting --name and argument at the same time", []string{"nodegroup", "ng", "--cluster", "dummy", "--name", "ng"},
+			"Error: --name=ng and argument ng cannot be used at the same time"),
+		Entry("setting --parallel above 25", []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--parallel", "26"},
+			"Error: --parallel value must be of range 1-25"),
+		Entry("invalid --new-name", []string{"nodegroup", "--cluster", "dummy", "--name", "ng", "--new-name", "ng_2"},
+			"Error: validation for ng_2 failed"),
+		Entry("invalid resource", []string{"invalid-resource"}, `Error: unknown command "invalid-resource" for "replace"`),
+	)
+})
+
+func newDefaultCmd(args ...string) *mockVerbCmd {
+	flagGrouping := cmdutils.NewGrouping()
+	cmd := Command(flagGrouping)
+	cmd.SetArgs(args)
+	return &mockVerbCmd{
+		parentCmd: cmd,
+	}
+}
+
+func newMockEmptyCmd(args ...string) *mockVerbCmd {
+	cmd := cmdutils.NewVerbCmd("replace", "Replace resource(s)", "")
+	cmd.SetArgs(args)
+	return &mockVerbCmd{
+		parentCmd: cmd,
+	}
+}
+
+type mockVerbCmd struct {
+	parentCmd *cobra.Command
+}
+
+func (c mockVerbCmd) execute() (string, error) {
+	outBuf := new(bytes.Buffer)
+	errBuf := new(bytes.Buffer)
+	c.parentCmd.SetOut(outBuf)
+	c.parentCmd.SetErr(errBuf)
+	err := c.parentCmd.Execute()
+	if err != nil {
+		err = errors.New(errBuf.String())
+	}
+	return outBuf.String(), err
+}
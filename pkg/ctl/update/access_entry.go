@@ -0,0 +1,90 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/accessentry"
+	accessentryactions "github.com/weaveworks/eksctl/pkg/actions/accessentry"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func updateAccessEntryCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"accessentry",
+		"Update access entries",
+		"Updates the Kubernetes groups and access policies of existing access entries in place",
+	)
+
+	var accessEntry api.AccessEntry
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewUpdateAccessEntryLoader(cmd, accessEntry).Load(); err != nil {
+			return err
+		}
+		return doUpdateAccessEntry(cmd)
+	}
+
+	cmd.FlagSetGroup.InFlagSet("Access Entry", func(fs *pflag.FlagSet) {
+		fs.VarP(&accessEntry.PrincipalARN, "principal-arn", "", "Principal ARN")
+		fs.StringSliceVar(&accessEntry.KubernetesGroups, "kubernetes-groups", nil, "A set of Kubernetes groups to map to the principal ARN, replacing the existing groups")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+		cmdutils.AddPlanFlag(fs, cmd, "print the changes that would be applied without applying them")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+}
+
+func doUpdateAccessEntry(cmd *cmdutils.Cmd) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cmd.ProviderConfig.WaitTimeout)
+	defer cancel()
+
+	cfg := cmd.ClusterConfig
+	clusterProvider, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if !(&accessentry.Service{ClusterStateGetter: clusterProvider}).IsEnabled() {
+		return accessentry.ErrDisabledAccessEntryAPI
+	}
+
+	accessEntries := cfg.AccessConfig.AccessEntries
+	if cmd.ClusterConfigFile == "" {
+		// only the Kubernetes groups can be set with flags, so keep the associated access policies as they are
+		summaries, err := accessentryactions.NewGetter(cfg.Metadata.Name, clusterProvider.AWSProvider.EKS()).Get(ctx, accessEntries[0].PrincipalARN)
+		if err != nil {
+			return err
+		}
+		accessEntries[0].AccessPolicies = summaries[0].AccessPolicies
+	}
+
+	updater := accessentryactions.NewUpdater(cfg.Metadata.Name, clusterProvider.AWSProvider.EKS())
+	if !cmd.Plan {
+		return updater.Update(ctx, accessEntries)
+	}
+
+	taskTree, err := updater.UpdateTasks(ctx, accessEntries)
+	if err != nil {
+		return err
+	}
+	if taskTree.Len() == 0 {
+		logger.Info("access entries already up-to-date")
+		return nil
+	}
+	taskTree.PlanMode = true
+	fmt.Fprintln(cmd.CobraCommand.OutOrStdout(), taskTree.Describe())
+	logger.Warning("no changes were applied, run again without '--plan' to apply the changes")
+	return nil
+}
@@ -0,0 +1,24 @@
+package update
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("update accessentry", func() {
+	DescribeTable("invalid arguments",
+		func(expectedErr string, args ...string) {
+			cmd := newMockCmd(append([]string{"accessentry"}, args...)...)
+			_, err := cmd.execute()
+			Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+		},
+		Entry("missing cluster name", "--cluster must be set",
+			"--principal-arn", "arn:aws:iam::111122223333:user/admin", "--kubernetes-groups", "viewers"),
+		Entry("missing principal ARN", "--principal-arn must be set",
+			"--cluster", "test-cluster", "--kubernetes-groups", "viewers"),
+		Entry("missing Kubernetes groups", "--kubernetes-groups must be set",
+			"--cluster", "test-cluster", "--principal-arn", "arn:aws:iam::111122223333:user/admin"),
+		Entry("invalid principal ARN", "invalid argument",
+			"--cluster", "test-cluster", "--principal-arn", "not-an-arn"),
+	)
+})
@@ -2,7 +2,9 @@ package update
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	awseks "github.com/aws/aws-sdk-go-v2/service/eks"
 
@@ -25,7 +27,10 @@ func updateAddonCmd(cmd *cmdutils.Cmd) {
 		"",
 	)
 
-	var force, wait bool
+	var (
+		force, wait  bool
+		fleetOptions cmdutils.FleetOptions
+	)
 	cmd.ClusterConfig.Addons = []*api.Addon{{}}
 	cmd.FlagSetGroup.InFlagSet("Addon", func(fs *pflag.FlagSet) {
 		fs.StringVar(&cmd.ClusterConfig.Addons[0].Name, "name", "", "Addon name")
@@ -41,10 +46,16 @@ func updateAddonCmd(cmd *cmdutils.Cmd) {
 		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
 	})
+	cmd.FlagSetGroup.InFlagSet("Fleet", func(fs *pflag.FlagSet) {
+		cmdutils.AddFleetFlags(fs, &fleetOptions)
+	})
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
+		if fleetOptions.Enabled() {
+			return updateFleetAddons(cmd, fleetOptions, force, wait)
+		}
 		return updateAddon(cmd, force, wait)
 	}
 }
@@ -53,7 +64,29 @@ func updateAddon(cmd *cmdutils.Cmd, force, wait bool) error {
 	if err := cmdutils.NewCreateOrUpgradeAddonLoader(cmd).Load(); err != nil {
 		return err
 	}
+	return doUpdateAddon(cmd, force, wait)
+}
+
+// updateFleetAddons updates the addons specified for each cluster of the fleet
+func updateFleetAddons(cmd *cmdutils.Cmd, fleetOptions cmdutils.FleetOptions, force, wait bool) error {
+	results, err := cmdutils.RunFleet(cmd, fleetOptions, []string{"version", "service-account-role-arn"}, func(clusterCmd *cmdutils.Cmd) error {
+		if len(clusterCmd.ClusterConfig.Addons) == 0 {
+			return errors.New("no addons specified")
+		}
+		for _, a := range clusterCmd.ClusterConfig.Addons {
+			if err := a.Validate(); err != nil {
+				return err
+			}
+		}
+		return doUpdateAddon(clusterCmd, force, wait)
+	})
+	if err != nil {
+		return err
+	}
+	return cmdutils.PrintFleetSummary(results, cmd.CobraCommand.OutOrStdout())
+}
 
+func doUpdateAddon(cmd *cmdutils.Cmd, force, wait bool) error {
 	ctx := context.Background()
 	clusterProvider, err := cmd.NewProviderForExistingCluster(ctx)
 	if err != nil {
@@ -80,6 +113,14 @@ func updateAddon(cmd *cmdutils.Cmd, force, wait bool) error {
 
 	stackManager := clusterProvider.NewStackManager(cmd.ClusterConfig)
 
+	if slices.ContainsFunc(cmd.ClusterConfig.Addons, func(a *api.Addon) bool {
+		return a.CanonicalName() == api.VPCCNIAddon
+	}) {
+		if err := clusterProvider.LoadPodSubnets(ctx, cmd.ClusterConfig, stackManager); err != nil {
+			return err
+		}
+	}
+
 	output, err := clusterProvider.AWSProvider.EKS().DescribeCluster(ctx, &awseks.DescribeClusterInput{
 		Name: &cmd.ClusterConfig.Metadata.Name,
 	})
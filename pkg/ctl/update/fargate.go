@@ -0,0 +1,129 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kris-nova/logger"
+	"github.com/lithammer/dedent"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
+
+	actionsfargate "github.com/weaveworks/eksctl/pkg/actions/fargate"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func updateFargateProfileWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd, options *fargate.UpdateOptions) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"fargateprofile",
+		"Update a Fargate profile",
+		dedent.Dedent(`Update a Fargate profile by replacing it.
+
+		As Fargate profiles are immutable, a temporary profile with the desired selectors, subnets and tags is created,
+		the existing profile is deleted and recreated with the desired configuration, and the temporary profile is deleted.
+	`),
+	)
+
+	var options fargate.UpdateOptions
+	cmd.FlagSetGroup.InFlagSet("Fargate", func(fs *pflag.FlagSet) {
+		cmdutils.AddFlagsForFargateProfileUpdate(fs, &options)
+	})
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewUpdateFargateProfileLoader(cmd, &options).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd, &options)
+	}
+}
+
+func updateFargateProfileCmd(cmd *cmdutils.Cmd) {
+	updateFargateProfileWithRunFunc(cmd, doUpdateFargateProfile)
+}
+
+func doUpdateFargateProfile(cmd *cmdutils.Cmd, options *fargate.UpdateOptions) error {
+	ctx := context.TODO()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("couldn't create cluster provider from command line options: %w", err)
+	}
+
+	manager := actionsfargate.New(cmd.ClusterConfig, ctl, ctl.NewStackManager(cmd.ClusterConfig))
+	plan, err := manager.PlanUpdate(ctx, options.RestartPods)
+	if err != nil {
+		return err
+	}
+	if !options.Plan {
+		return manager.Update(plan)
+	}
+
+	if len(plan.Replacements) == 0 {
+		logger.Info("all Fargate profiles are up-to-date")
+		return nil
+	}
+	out := cmd.CobraCommand.OutOrStdout()
+	if err := printCoverageChanges(plan.Replacements, out); err != nil {
+		return err
+	}
+	plan.Tasks.PlanMode = true
+	fmt.Fprintln(out, plan.Tasks.Describe())
+	logger.Warning("no changes were applied, run again without '--plan' to apply the changes")
+	return nil
+}
+
+// coverageChangeRow is a row of the --plan report.
+type coverageChangeRow struct {
+	Profile  string
+	Change   string
+	Selector api.FargateProfileSelector
+}
+
+func printCoverageChanges(replacements []*actionsfargate.ProfileReplacement, w io.Writer) error {
+	var rows []coverageChangeRow
+	for _, r := range replacements {
+		for _, selector := range r.Gained {
+			rows = append(rows, coverageChangeRow{Profile: r.Replacement.Name, Change: "gained", Selector: selector})
+		}
+		for _, selector := range r.Lost {
+			rows = append(rows, coverageChangeRow{Profile: r.Replacement.Name, Change: "lost", Selector: selector})
+		}
+		if len(r.Gained) == 0 && len(r.Lost) == 0 {
+			rows = append(rows, coverageChangeRow{Profile: r.Replacement.Name, Change: "unchanged"})
+		}
+	}
+
+	printer := printers.NewTablePrinter().(*printers.TablePrinter)
+	printer.AddColumn("PROFILE", func(r coverageChangeRow) string {
+		return r.Profile
+	})
+	printer.AddColumn("COVERAGE", func(r coverageChangeRow) string {
+		return r.Change
+	})
+	printer.AddColumn("NAMESPACE", func(r coverageChangeRow) string {
+		if r.Selector.Namespace == "" {
+			return "-"
+		}
+		return r.Selector.Namespace
+	})
+	printer.AddColumn("LABELS", func(r coverageChangeRow) string {
+		if r.Selector.Namespace == "" {
+			return "-"
+		}
+		return labels.FormatLabels(r.Selector.Labels)
+	})
+	return printer.PrintObjWithKind("Fargate profile coverage changes", rows, w)
+}
@@ -0,0 +1,115 @@
+package update
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	actionsfargate "github.com/weaveworks/eksctl/pkg/actions/fargate"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/ctltest"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+var _ = Describe("update fargateprofile", func() {
+	var (
+		cmd     *cmdutils.Cmd
+		options *fargate.UpdateOptions
+	)
+
+	execute := func(args ...string) error {
+		grouping := cmdutils.NewGrouping()
+		parentCmd := cmdutils.NewVerbCmd("update", "", "")
+		cmdutils.AddResourceCmd(grouping, parentCmd, func(c *cmdutils.Cmd) {
+			updateFargateProfileWithRunFunc(c, func(c *cmdutils.Cmd, o *fargate.UpdateOptions) error {
+				cmd, options = c, o
+				return nil
+			})
+		})
+		parentCmd.SetArgs(append([]string{"fargateprofile"}, args...))
+		parentCmd.SetOut(new(bytes.Buffer))
+		parentCmd.SetErr(new(bytes.Buffer))
+		return parentCmd.Execute()
+	}
+
+	It("sets the profile to update from flags", func() {
+		Expect(execute("fp-dev", "--cluster", "my-cluster", "--namespace", "dev", "--labels", "env=dev", "--restart-pods")).To(Succeed())
+		Expect(options.RestartPods).To(BeTrue())
+		Expect(cmd.ClusterConfig.FargateProfiles).To(Equal([]*api.FargateProfile{
+			{
+				Name: "fp-dev",
+				Selectors: []api.FargateProfileSelector{
+					{Namespace: "dev", Labels: map[string]string{"env": "dev"}},
+				},
+			},
+		}))
+	})
+
+	It("requires something to update", func() {
+		err := execute("--cluster", "my-cluster", "--name", "fp-dev")
+		Expect(err).To(MatchError("at least one of --namespace, --subnets or --tags must be set"))
+	})
+
+	It("requires a namespace for selector labels", func() {
+		err := execute("--cluster", "my-cluster", "--name", "fp-dev", "--labels", "env=dev", "--tags", "team=a")
+		Expect(err).To(MatchError(`invalid Fargate profile "fp-dev": selector labels require a selector namespace`))
+	})
+
+	Context("with a config file", func() {
+		var configFile string
+
+		BeforeEach(func() {
+			configFile = ctltest.CreateConfigFile(&api.ClusterConfig{
+				TypeMeta: api.ClusterConfigTypeMeta(),
+				Metadata: &api.ClusterMeta{
+					Name:   "my-cluster",
+					Region: "us-west-2",
+				},
+				FargateProfiles: []*api.FargateProfile{
+					{Name: "fp-dev", Selectors: []api.FargateProfileSelector{{Namespace: "dev"}}},
+					{Name: "fp-prod", Selectors: []api.FargateProfileSelector{{Namespace: "prod"}}},
+				},
+			})
+		})
+
+		It("updates all profiles", func() {
+			Expect(execute("--config-file", configFile, "--plan")).To(Succeed())
+			Expect(options.Plan).To(BeTrue())
+			Expect(cmd.ClusterConfig.FargateProfiles).To(HaveLen(2))
+		})
+
+		It("only updates the profile with the provided name", func() {
+			Expect(execute("--config-file", configFile, "--name", "fp-prod")).To(Succeed())
+			Expect(cmd.ClusterConfig.FargateProfiles).To(HaveLen(1))
+			Expect(cmd.ClusterConfig.FargateProfiles[0].Name).To(Equal("fp-prod"))
+		})
+
+		It("returns an error if the profile is not in the config file", func() {
+			err := execute("--config-file", configFile, "fp-test")
+			Expect(err).To(MatchError(`Fargate profile "fp-test" not found in config file`))
+		})
+
+		It("does not allow selector flags", func() {
+			err := execute("--config-file", configFile, "--namespace", "dev")
+			Expect(err).To(MatchError(ContainSubstring("cannot use --namespace when --config-file/-f is set")))
+		})
+	})
+
+	It("prints the coverage changes", func() {
+		out := new(bytes.Buffer)
+		Expect(printCoverageChanges([]*actionsfargate.ProfileReplacement{
+			{
+				Current:     &api.FargateProfile{Name: "fp-dev"},
+				Replacement: &api.FargateProfile{Name: "fp-dev"},
+				Gained:      []api.FargateProfileSelector{{Namespace: "qa", Labels: map[string]string{"env": "qa"}}},
+				Lost:        []api.FargateProfileSelector{{Namespace: "staging"}},
+			},
+		}, out)).To(Succeed())
+		Expect(out.String()).To(Equal(`PROFILE	COVERAGE	NAMESPACE	LABELS
+fp-dev	gained		qa		env=qa
+fp-dev	lost		staging		<none>
+`))
+	})
+})
@@ -16,6 +16,8 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateNodeGroupCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updatePodIdentityAssociation)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateAutoModeConfigCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateAccessEntryCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateFargateProfileCmd)
 
 	return verbCmd
 }
@@ -33,7 +33,10 @@ func upgradeClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 
 	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
 
-	var force bool
+	var (
+		force        bool
+		fleetOptions cmdutils.FleetOptions
+	)
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
@@ -48,9 +51,26 @@ func upgradeClusterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd
 		cmdutils.AddTimeoutFlagWithValue(fs, &cmd.ProviderConfig.WaitTimeout, upgradeClusterTimeout)
 	})
 
+	cmd.FlagSetGroup.InFlagSet("Fleet", func(fs *pflag.FlagSet) {
+		cmdutils.AddFleetFlags(fs, &fleetOptions)
+	})
+
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
 
+		if fleetOptions.Enabled() {
+			results, err := cmdutils.RunFleet(cmd, fleetOptions, []string{"version"}, func(clusterCmd *cmdutils.Cmd) error {
+				if force {
+					clusterCmd.ClusterConfig.Metadata.ForceUpdateVersion = &force
+				}
+				return runFunc(clusterCmd)
+			})
+			if err != nil {
+				return err
+			}
+			return cmdutils.PrintFleetSummary(results, cmd.CobraCommand.OutOrStdout())
+		}
+
 		if err := cmdutils.NewMetadataLoader(cmd).Load(); err != nil {
 			return err
 		}
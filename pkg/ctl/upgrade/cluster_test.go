@@ -2,6 +2,7 @@ package upgrade
 
 import (
 	"os"
+	"path/filepath"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -177,4 +178,53 @@ var _ = Describe("upgrade cluster", func() {
 			Expect(loadedCfg.Version).To(Equal(""))
 		})
 	})
+
+	Describe("with a fleet file", func() {
+		var fleetFile string
+
+		BeforeEach(func() {
+			fleetFile = filepath.Join(GinkgoT().TempDir(), "fleet.yaml")
+			Expect(os.WriteFile(fleetFile, []byte(`apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfigList
+items:
+- metadata:
+    name: cluster-1
+    region: us-west-2
+    version: "1.31"
+- metadata:
+    name: cluster-2
+    region: eu-west-1
+    version: "1.31"
+    annotations:
+      eksctl.io/aws-profile: production
+`), 0600)).To(Succeed())
+		})
+
+		It("runs for each cluster with its region and profile", func() {
+			cmd := newMockUpgradeClusterCmd("cluster", "--fleet", fleetFile, "--force")
+			out, err := cmd.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchRegexp(`cluster-1\s+us-west-2\s+-\s+succeeded`))
+			Expect(out).To(MatchRegexp(`cluster-2\s+eu-west-1\s+production\s+succeeded`))
+
+			// the clusters are run in order when --max-concurrent is not set
+			Expect(cmd.Cmd.ClusterConfig.Metadata.Name).To(Equal("cluster-2"))
+			Expect(cmd.Cmd.ClusterConfig.Metadata.Version).To(Equal("1.31"))
+			Expect(*cmd.Cmd.ClusterConfig.Metadata.ForceUpdateVersion).To(BeTrue())
+			Expect(cmd.Cmd.ProviderConfig.Region).To(Equal("eu-west-1"))
+			Expect(cmd.Cmd.ProviderConfig.Profile.Name).To(Equal("production"))
+		})
+
+		It("fails if --fleet is used with --name", func() {
+			cmd := newMockUpgradeClusterCmd("cluster", "--fleet", fleetFile, "--name", "cluster-1")
+			_, err := cmd.Execute()
+			Expect(err).To(MatchError("cannot use --name with --fleet"))
+		})
+
+		It("fails if --max-concurrent is less than 1", func() {
+			cmd := newMockUpgradeClusterCmd("cluster", "--fleet", fleetFile, "--max-concurrent", "0")
+			_, err := cmd.Execute()
+			Expect(err).To(MatchError("--max-concurrent must be at least 1"))
+		})
+	})
 })
@@ -0,0 +1,56 @@
+package upgrade
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func upgradeKarpenterCmd(cmd *cmdutils.Cmd) {
+	upgradeKarpenterWithRunFunc(cmd, doUpgradeKarpenter)
+}
+
+func upgradeKarpenterWithRunFunc(cmd *cmdutils.Cmd, runFunc func(cmd *cmdutils.Cmd) error) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription("karpenter", "Upgrade Karpenter",
+		"Upgrade Karpenter installed by eksctl to a new version, updating its IAM resources, chart and CRDs.")
+
+	var version string
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVar(&version, "version", "", "Karpenter version to upgrade to")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		if err := cmdutils.NewUpgradeKarpenterLoader(cmd, version).Load(); err != nil {
+			return err
+		}
+		return runFunc(cmd)
+	}
+}
+
+func doUpgradeKarpenter(cmd *cmdutils.Cmd) error {
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cmd.ClusterConfig); !ok {
+		return err
+	}
+	installer, err := karpenter.NewInstallerForExistingCluster(ctx, cmd.ClusterConfig, ctl)
+	if err != nil {
+		return err
+	}
+	return installer.Upgrade(ctx)
+}
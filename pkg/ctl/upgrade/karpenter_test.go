@@ -0,0 +1,62 @@
+package upgrade
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/ctltest"
+)
+
+var _ = Describe("upgrade karpenter", func() {
+
+	newMockUpgradeKarpenterCmd := func(args ...string) *ctltest.MockCmd {
+		return ctltest.NewMockCmd(upgradeKarpenterWithRunFunc, "upgrade", args...)
+	}
+
+	It("sets the Karpenter version from the --version flag", func() {
+		cmd := newMockUpgradeKarpenterCmd("karpenter", "--cluster", "clus-1", "--version", "1.2.1")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.ClusterConfig.Metadata.Name).To(Equal("clus-1"))
+		Expect(cmd.Cmd.ClusterConfig.Karpenter.Version).To(Equal("1.2.1"))
+	})
+
+	It("requires the --version flag without a config file", func() {
+		cmd := newMockUpgradeKarpenterCmd("karpenter", "--cluster", "clus-1")
+		_, err := cmd.Execute()
+		Expect(err).To(MatchError(ContainSubstring("--version must be set")))
+	})
+
+	It("requires the cluster name", func() {
+		cmd := newMockUpgradeKarpenterCmd("karpenter", "--version", "1.2.1")
+		_, err := cmd.Execute()
+		Expect(err).To(MatchError(ContainSubstring("--cluster must be set")))
+	})
+
+	It("validates the version", func() {
+		cmd := newMockUpgradeKarpenterCmd("karpenter", "--cluster", "clus-1", "--version", "0.19.0")
+		_, err := cmd.Execute()
+		Expect(err).To(MatchError(ContainSubstring("minimum supported version is")))
+	})
+
+	It("loads the Karpenter config from a config file", func() {
+		cmd := newMockUpgradeKarpenterCmd("karpenter", "-f", "../../../examples/46-karpenter-nodepools.yaml")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.ClusterConfig.Karpenter.Version).To(Equal("1.2.1"))
+		Expect(cmd.Cmd.ClusterConfig.Karpenter.NodePools).NotTo(BeEmpty())
+	})
+
+	It("overrides the version of the config file with the --version flag", func() {
+		cmd := newMockUpgradeKarpenterCmd("karpenter", "-f", "../../../examples/46-karpenter-nodepools.yaml", "--version", "1.3.0")
+		_, err := cmd.Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Cmd.ClusterConfig.Karpenter.Version).To(Equal("1.3.0"))
+	})
+
+	It("requires the karpenter field in a config file", func() {
+		cmd := newMockUpgradeKarpenterCmd("karpenter", "-f", "../../../examples/01-simple-cluster.yaml")
+		_, err := cmd.Execute()
+		Expect(err).To(MatchError(ContainSubstring("karpenter must be set")))
+	})
+})
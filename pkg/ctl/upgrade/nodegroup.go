@@ -2,9 +2,12 @@ package upgrade
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/aws/amazon-ec2-instance-selector/v3/pkg/selector"
+	"github.com/kris-nova/logger"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -12,6 +15,7 @@ import (
 	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
 const upgradeNodegroupTimeout = 45 * time.Minute
@@ -22,10 +26,14 @@ func upgradeNodeGroupCmd(cmd *cmdutils.Cmd) {
 
 	cmd.SetDescription("nodegroup", "Upgrade nodegroup", "")
 
-	var options nodegroup.UpgradeOptions
+	var (
+		options nodegroup.UpgradeOptions
+		plan    bool
+		output  printers.Type
+	)
 	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
 		cmd.NameArg = cmdutils.GetNameArg(args)
-		return upgradeNodeGroup(cmd, options)
+		return upgradeNodeGroup(cmd, options, plan, output)
 	}
 
 	cmd.FlagSetGroup.InFlagSet("Nodegroup", func(fs *pflag.FlagSet) {
@@ -35,11 +43,14 @@ func upgradeNodeGroupCmd(cmd *cmdutils.Cmd) {
 		fs.BoolVar(&options.ForceUpgrade, "force-upgrade", false, "Force the update if the existing node group's pods are unable to be drained due to a pod disruption budget issue")
 		fs.StringVar(&options.ReleaseVersion, "release-version", "", "AMI version of the EKS optimized AMI to use")
 		fs.BoolVar(&options.Wait, "wait", true, "nodegroup upgrade to complete")
+		fs.BoolVar(&plan, "plan", false, "Report the current and target AMI, release version, kubelet and containerd versions and launch template version, without upgrading the nodegroup")
+		cmdutils.AddOutputFlag(fs, &output, "Output format of the --plan report")
 	})
 
 	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddClusterFlag(fs, cmd.ClusterConfig.Metadata)
 		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
 		// found with experimentation
 		cmdutils.AddTimeoutFlagWithValue(fs, &cmd.ProviderConfig.WaitTimeout, upgradeNodegroupTimeout)
 	})
@@ -48,11 +59,11 @@ func upgradeNodeGroupCmd(cmd *cmdutils.Cmd) {
 
 }
 
-func upgradeNodeGroup(cmd *cmdutils.Cmd, options nodegroup.UpgradeOptions) error {
-	cfg := cmd.ClusterConfig
-	if cfg.Metadata.Name == "" {
-		return cmdutils.ErrMustBeSet(cmdutils.ClusterNameFlag(cmd))
+func upgradeNodeGroup(cmd *cmdutils.Cmd, options nodegroup.UpgradeOptions, plan bool, output printers.Type) error {
+	if err := cmdutils.NewUpgradeNodeGroupLoader(cmd).Load(); err != nil {
+		return err
 	}
+	cfg := cmd.ClusterConfig
 
 	if options.NodegroupName != "" && cmd.NameArg != "" {
 		return cmdutils.ErrFlagAndArg("--name", options.NodegroupName, cmd.NameArg)
@@ -85,5 +96,75 @@ func upgradeNodeGroup(cmd *cmdutils.Cmd, options nodegroup.UpgradeOptions) error
 	if err != nil {
 		return err
 	}
-	return nodegroup.New(cfg, ctl, clientSet, instanceSelector).Upgrade(ctx, options)
+	m := nodegroup.New(cfg, ctl, clientSet, instanceSelector)
+	if plan {
+		upgradePlan, err := m.PlanUpgrade(ctx, options)
+		if err != nil {
+			return err
+		}
+		if err := printUpgradePlan(upgradePlan, output, cmd.CobraCommand.OutOrStdout()); err != nil {
+			return err
+		}
+		if upgradePlan.HasChanges() {
+			logger.Warning("no changes were applied, run again without '--plan' to apply the changes")
+		} else {
+			logger.Info("nodegroup %q is already up-to-date", options.NodegroupName)
+		}
+		return nil
+	}
+	return m.Upgrade(ctx, options)
+}
+
+// upgradePlanRow is a row of the --plan report when it is printed as a table.
+type upgradePlanRow struct {
+	Field   string
+	Current string
+	Target  string
+}
+
+func printUpgradePlan(plan *nodegroup.UpgradePlan, output printers.Type, w io.Writer) error {
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	columnPrinter, ok := printer.(printers.ColumnPrinter)
+	if !ok {
+		return printer.PrintObjWithKind("upgrade plan", plan, w)
+	}
+
+	launchTemplateVersion := plan.LaunchTemplateVersion
+	if plan.CreatesLaunchTemplateVersion {
+		launchTemplateVersion = fmt.Sprintf("%s (new)", launchTemplateVersion)
+	}
+	rows := []upgradePlanRow{
+		{Field: "Kubernetes version", Current: plan.Current.KubernetesVersion, Target: plan.Target.KubernetesVersion},
+		{Field: "Release version", Current: plan.Current.ReleaseVersion, Target: plan.Target.ReleaseVersion},
+		{Field: "AMI", Current: plan.Current.AMI, Target: plan.Target.AMI},
+		{Field: "kubelet version", Current: plan.Current.KubeletVersion, Target: plan.Target.KubeletVersion},
+		{Field: "containerd version", Current: plan.Current.ContainerdVersion, Target: plan.Target.ContainerdVersion},
+		{Field: "Launch template version", Target: launchTemplateVersion},
+	}
+	orNone := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+	columnPrinter.AddColumn("NODEGROUP", func(upgradePlanRow) string {
+		return plan.NodeGroupName
+	})
+	columnPrinter.AddColumn("FIELD", func(r upgradePlanRow) string {
+		return r.Field
+	})
+	columnPrinter.AddColumn("CURRENT", func(r upgradePlanRow) string {
+		return orNone(r.Current)
+	})
+	columnPrinter.AddColumn("TARGET", func(r upgradePlanRow) string {
+		return orNone(r.Target)
+	})
+	columnPrinter.AddColumn("CHANGED", func(r upgradePlanRow) bool {
+		return r.Current != r.Target
+	})
+	return columnPrinter.PrintObjWithKind("upgrade plan", rows, w)
 }
@@ -0,0 +1,47 @@
+package upgrade
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var _ = Describe("upgrade nodegroup --plan", func() {
+	plan := &nodegroup.UpgradePlan{
+		NodeGroupName: "ng-1",
+		Current: nodegroup.NodeGroupImage{
+			KubernetesVersion: "1.30",
+			AMI:               "ami-current",
+			KubeletVersion:    "1.30.4",
+		},
+		Target: nodegroup.NodeGroupImage{
+			KubernetesVersion: "1.30",
+			AMI:               "ami-target",
+			KubeletVersion:    "1.30.6",
+		},
+		LaunchTemplateVersion:        "5",
+		CreatesLaunchTemplateVersion: true,
+	}
+
+	It("prints the plan as a table", func() {
+		var out bytes.Buffer
+		Expect(printUpgradePlan(plan, printers.TableType, &out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("NODEGROUP"))
+		Expect(out.String()).To(MatchRegexp(`ng-1\s+AMI\s+ami-current\s+ami-target\s+true`))
+		Expect(out.String()).To(MatchRegexp(`ng-1\s+Kubernetes version\s+1.30\s+1.30\s+false`))
+		Expect(out.String()).To(MatchRegexp(`ng-1\s+Launch template version\s+-\s+5 \(new\)\s+true`))
+	})
+
+	It("prints the plan as JSON", func() {
+		var out bytes.Buffer
+		Expect(printUpgradePlan(plan, printers.JSONType, &out)).To(Succeed())
+		var printed nodegroup.UpgradePlan
+		Expect(json.Unmarshal(out.Bytes(), &printed)).To(Succeed())
+		Expect(printed).To(Equal(*plan))
+	})
+})
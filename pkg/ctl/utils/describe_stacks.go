@@ -43,7 +43,7 @@ func describeStacksCmd(cmd *cmdutils.Cmd) {
 			}
 		}
 		switch output {
-		case printers.TableType:
+		case printers.TableType, printers.CSVType, printers.MarkdownType:
 			return fmt.Errorf("output type %q is not supported", output)
 		case "":
 		default:
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/templates"
+	"github.com/weaveworks/eksctl/pkg/actions/terraform"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func exportTerraformCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"export-terraform",
+		"Export a ClusterConfig file as a Terraform configuration",
+		"Translates the CloudFormation templates eksctl would deploy for the config file into a Terraform configuration for the VPC, cluster, managed nodegroups, addons and access entries. AWS is not called; availability zones, AMI IDs and the account ID are taken from the config file or flags. Unmanaged nodegroups, Fargate profiles, IAM service accounts, the IAM OIDC provider and Karpenter are skipped with a warning.",
+	)
+
+	var (
+		outputFile string
+		options    templates.Options
+	)
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVar(&outputFile, "output-file", "", "file to write the Terraform configuration to (default: standard output)")
+		fs.StringSliceVar(&options.AvailabilityZones, "zones", nil, "availability zones to use instead of availabilityZones in the config file")
+		fs.StringVar(&options.NodeAMI, "node-ami", "", "AMI ID to use for nodegroups that require one and do not set ami")
+		fs.StringVar(&options.AccountID, "account-id", templates.DefaultAccountID, "AWS account ID to use in ARNs")
+	})
+
+	cmd.CobraCommand.Args = cobra.NoArgs
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, _ []string) error {
+		return doExportTerraform(cmd, outputFile, options)
+	}
+}
+
+func doExportTerraform(cmd *cmdutils.Cmd, outputFile string, options templates.Options) error {
+	if outputFile == "" {
+		// keep the configuration written to stdout free of log messages
+		logger.Writer = os.Stderr
+	}
+	if err := cmdutils.NewGenerateTemplatesLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if err := cmd.InitializeClusterConfig(); err != nil {
+		return err
+	}
+
+	cfg := cmd.ClusterConfig
+	configuration, err := terraform.NewExporter(cfg, options).Export(context.Background())
+	if err != nil {
+		return fmt.Errorf("exporting cluster %q to Terraform: %w", cfg.Metadata.Name, err)
+	}
+	if outputFile == "" {
+		_, err := cmd.CobraCommand.OutOrStdout().Write(configuration)
+		return err
+	}
+	if err := os.WriteFile(outputFile, configuration, 0o644); err != nil {
+		return fmt.Errorf("writing Terraform configuration: %w", err)
+	}
+	logger.Success("wrote Terraform configuration for cluster %q to %q", cfg.Metadata.Name, outputFile)
+	return nil
+}
@@ -0,0 +1,45 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("export terraform", func() {
+	It("requires a config file", func() {
+		cmd := newMockCmd("export-terraform")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring("Error: --config-file must be set")))
+	})
+
+	It("requires availability zones", func() {
+		cmd := newMockCmd("export-terraform", "--config-file", "../../../examples/26-managed-nodegroup-spot-instances.yaml")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring("availability zones must be set")))
+	})
+
+	It("writes the Terraform configuration to stdout", func() {
+		cmd := newMockCmd("export-terraform", "--config-file", "../../../examples/26-managed-nodegroup-spot-instances.yaml",
+			"--zones", "us-west-2a,us-west-2b")
+		out, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(HavePrefix("terraform {"))
+		Expect(out).To(ContainSubstring(`resource "aws_eks_cluster" "control_plane"`))
+		Expect(out).To(ContainSubstring(`capacity_type  = "SPOT"`))
+	})
+
+	It("writes the Terraform configuration to a file", func() {
+		outputFile := filepath.Join(GinkgoT().TempDir(), "main.tf")
+		cmd := newMockCmd("export-terraform", "--config-file", "../../../examples/26-managed-nodegroup-spot-instances.yaml",
+			"--zones", "us-west-2a,us-west-2b", "--output-file", outputFile)
+		out, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(BeEmpty())
+		configuration, err := os.ReadFile(outputFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(configuration)).To(ContainSubstring(`resource "aws_eks_node_group"`))
+	})
+})
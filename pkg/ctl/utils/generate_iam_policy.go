@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/iam/policy"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// iamPolicyOutputFormats are the output formats of generate-iam-policy, which only prints documents
+var iamPolicyOutputFormats = []printers.Type{printers.JSONType, printers.YAMLType}
+
+func generateIAMPolicyCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"generate-iam-policy",
+		"Generate the IAM policy required to run eksctl for a config file",
+		"Generates a least-privilege IAM policy for the principal that runs eksctl, allowing the operations needed to create, update and delete the cluster described by the config file",
+	)
+
+	var (
+		accountID string
+		output    printers.Type
+	)
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVar(&accountID, "account-id", "*", "ID of the AWS account the cluster is created in, used to scope resource ARNs")
+		cmdutils.AddOutputFlagWithFormats(fs, &output, "specifies the output format", iamPolicyOutputFormats...)
+	})
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doGenerateIAMPolicy(cmd, accountID, output)
+	}
+}
+
+func doGenerateIAMPolicy(cmd *cmdutils.Cmd, accountID string, output printers.Type) error {
+	if err := cmdutils.NewGenerateIAMPolicyLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if !slices.Contains(iamPolicyOutputFormats, output) {
+		return fmt.Errorf("unsupported output format %q (valid options: %s)", output, strings.Join(iamPolicyOutputFormats, ", "))
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+	return printer.PrintObj(policy.Generate(cmd.ClusterConfig, accountID), cmd.CobraCommand.OutOrStdout())
+}
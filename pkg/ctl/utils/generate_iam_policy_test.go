@@ -0,0 +1,35 @@
+package utils_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("generate IAM policy", func() {
+	It("requires a config file", func() {
+		cmd := newMockCmd("generate-iam-policy")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring("Error: --config-file must be set")))
+	})
+
+	It("rejects unsupported output formats", func() {
+		cmd := newMockCmd("generate-iam-policy", "--config-file", "../../../examples/01-simple-cluster.yaml", "--output", "table")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(`Error: unsupported output format "table"`)))
+	})
+
+	It("prints a policy document scoped to the cluster", func() {
+		cmd := newMockCmd("generate-iam-policy", "--config-file", "../../../examples/01-simple-cluster.yaml", "--account-id", "111122223333")
+		out, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+
+		var doc api.IAMPolicyDocument
+		Expect(json.Unmarshal([]byte(out), &doc)).To(Succeed())
+		Expect(doc.Statements).NotTo(BeEmpty())
+		Expect(doc.Statements[0].Resource).To(ConsistOf("arn:aws:cloudformation:eu-north-1:111122223333:stack/eksctl-cluster-1-*/*"))
+	})
+})
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions/karpenter"
+	"github.com/weaveworks/eksctl/pkg/actions/nodegroup"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+type migrateToKarpenterOptions struct {
+	karpenter.MigrateOptions
+	karpenterVersion string
+}
+
+func migrateToKarpenterCmd(cmd *cmdutils.Cmd) {
+	cfg := api.NewClusterConfig()
+	cmd.ClusterConfig = cfg
+
+	cmd.SetDescription("migrate-to-karpenter", "Migrates unmanaged nodegroups scaled by Cluster Autoscaler to Karpenter",
+		"Creates a Karpenter NodePool and EC2NodeClass matching each unmanaged nodegroup scaled by Cluster Autoscaler, installing Karpenter if needed, "+
+			"then scales down Cluster Autoscaler and drains the nodegroups and scales them to 0 nodes one at a time.")
+
+	var options migrateToKarpenterOptions
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddClusterFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, &cmd.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringSliceVar(&options.NodeGroups, "nodegroups", nil, "Names of the nodegroups to migrate (all nodegroups scaled by Cluster Autoscaler if not specified)")
+		fs.StringVar(&options.karpenterVersion, "karpenter-version", "", "Karpenter version to install if Karpenter is not installed yet")
+		fs.BoolVar(&options.Approve, "approve", false, "Apply the changes")
+		cmdutils.AddTimeoutFlag(fs, &cmd.ProviderConfig.WaitTimeout)
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Drain", func(fs *pflag.FlagSet) {
+		fs.DurationVar(&options.Drain.MaxGracePeriod, "max-grace-period", 10*time.Minute, "Maximum pods termination grace period")
+		fs.DurationVar(&options.Drain.PodEvictionWaitPeriod, "pod-eviction-wait-period", 10*time.Second, "Duration to wait after failing to evict a pod")
+		fs.DurationVar(&options.Drain.NodeDrainWaitPeriod, "node-drain-wait-period", 0, "Amount of time to wait between draining nodes in a nodegroup")
+		fs.BoolVar(&options.Drain.DisableEviction, "disable-eviction", false, "Force drain to use delete, even if eviction is supported. This will bypass checking PodDisruptionBudgets, use with caution.")
+		fs.IntVar(&options.Drain.Parallel, "parallel", 1, "Number of nodes to drain in parallel. Max 25")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(cmd, &cmd.ProviderConfig, false)
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		cmd.NameArg = cmdutils.GetNameArg(args)
+		return doMigrateToKarpenter(cmd, options)
+	}
+}
+
+func doMigrateToKarpenter(cmd *cmdutils.Cmd, options migrateToKarpenterOptions) error {
+	if err := cmdutils.NewMigrateToKarpenterLoader(cmd, options.karpenterVersion).Load(); err != nil {
+		return err
+	}
+	if options.Drain.Parallel < 1 || options.Drain.Parallel > 25 {
+		return fmt.Errorf("--parallel value must be of range 1-25")
+	}
+
+	cfg := cmd.ClusterConfig
+	ctx := context.Background()
+	ctl, err := cmd.NewProviderForExistingCluster(ctx)
+	if err != nil {
+		return err
+	}
+	if ok, err := ctl.CanOperate(cfg); !ok {
+		return err
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+	clusterStack, err := stackManager.DescribeClusterStackIfExists(ctx)
+	if err != nil {
+		return err
+	}
+	if clusterStack != nil {
+		// the subnets of the cluster are needed to install Karpenter and to select the subnets of NodePools
+		if err := ctl.LoadClusterIntoSpecFromStack(ctx, cfg, clusterStack); err != nil {
+			return err
+		}
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	installer, err := karpenter.NewInstallerForExistingCluster(ctx, cfg, ctl)
+	if err != nil {
+		return err
+	}
+
+	migrator := &karpenter.Migrator{
+		ClusterConfig: cfg,
+		StackManager:  stackManager,
+		ClientSet:     clientSet,
+		Installer:     installer,
+		Drainer: &nodegroup.Drainer{
+			ClientSet: clientSet,
+		},
+		Scaler: nodegroup.New(cfg, ctl, clientSet, nil),
+		Out:    os.Stdout,
+	}
+	return migrator.Migrate(ctx, options.MigrateOptions)
+}
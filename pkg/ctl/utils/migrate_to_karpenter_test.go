@@ -0,0 +1,36 @@
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("migrate to karpenter", func() {
+
+	type migrateToKarpenterEntry struct {
+		args        []string
+		expectedErr string
+	}
+
+	DescribeTable("unsupported arguments", func(e migrateToKarpenterEntry) {
+		cmd := newMockCmd(append([]string{"migrate-to-karpenter"}, e.args...)...)
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(e.expectedErr)))
+	},
+		Entry("missing required flag --cluster", migrateToKarpenterEntry{
+			expectedErr: "Error: --cluster must be set",
+		}),
+		Entry("setting --cluster and a name argument at the same time", migrateToKarpenterEntry{
+			expectedErr: "cannot be used at the same time",
+			args:        []string{"--cluster", "test", "test"},
+		}),
+		Entry("setting --cluster and --config-file at the same time", migrateToKarpenterEntry{
+			expectedErr: "Error: cannot use --cluster when --config-file/-f is set",
+			args:        []string{"--cluster", "test", "--config-file", "../../../examples/01-simple-cluster.yaml"},
+		}),
+		Entry("--parallel out of range", migrateToKarpenterEntry{
+			expectedErr: "Error: --parallel value must be of range 1-25",
+			args:        []string{"--cluster", "test", "--parallel", "30"},
+		}),
+	)
+})
@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+)
+
+const (
+	userDataOutputRaw    = "raw"
+	userDataOutputBase64 = "base64"
+)
+
+type renderUserDataOptions struct {
+	nodeGroupName            string
+	endpoint                 string
+	certificateAuthorityData string
+	clusterDNS               string
+	serviceCIDR              string
+	output                   string
+}
+
+func renderUserDataCmd(cmd *cmdutils.Cmd) {
+	cmd.ClusterConfig = api.NewClusterConfig()
+	cmd.SetDescription(
+		"render-userdata",
+		"Render the userdata of a nodegroup",
+		"Renders the userdata that eksctl generates for a nodegroup in a config file, without requiring access to AWS",
+	)
+
+	var options renderUserDataOptions
+	cmd.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, &cmd.ClusterConfigFile)
+		fs.StringVarP(&options.nodeGroupName, "name", "n", "", "name of the nodegroup; may be omitted if the config file has a single nodegroup")
+		fs.StringVarP(&options.output, "output", "o", userDataOutputRaw, "specifies the output format (valid option: raw, base64); base64 prints the userdata as it would appear in the launch template")
+	})
+
+	cmd.FlagSetGroup.InFlagSet("Cluster", func(fs *pflag.FlagSet) {
+		fs.StringVar(&options.endpoint, "endpoint", "", "API server endpoint of the cluster")
+		fs.StringVar(&options.certificateAuthorityData, "certificate-authority-data", "", "base64-encoded certificate authority data of the cluster")
+		fs.StringVar(&options.serviceCIDR, "service-cidr", "", "service CIDR of the cluster (default: kubernetesNetworkConfig.serviceIPv4CIDR, or 10.100.0.0/16)")
+		fs.StringVar(&options.clusterDNS, "cluster-dns", "", "IP address of the cluster DNS server, for self-managed nodegroups (default: derived from the service CIDR)")
+	})
+
+	cmd.CobraCommand.RunE = func(_ *cobra.Command, args []string) error {
+		if options.nodeGroupName == "" {
+			options.nodeGroupName = cmdutils.GetNameArg(args)
+		}
+		return doRenderUserData(cmd, options)
+	}
+}
+
+func doRenderUserData(cmd *cmdutils.Cmd, options renderUserDataOptions) error {
+	if err := cmdutils.NewRenderUserDataLoader(cmd).Load(); err != nil {
+		return err
+	}
+	if options.output != userDataOutputRaw && options.output != userDataOutputBase64 {
+		return fmt.Errorf("unsupported output format %q (valid option: raw, base64)", options.output)
+	}
+
+	cfg := cmd.ClusterConfig
+	if cfg.Metadata.Version == "" {
+		cfg.Metadata.Version = api.DefaultVersion
+	}
+	status, err := makeRenderClusterStatus(cfg, options)
+	if err != nil {
+		return err
+	}
+	cfg.Status = status
+
+	bootstrapper, ng, err := newRenderBootstrapper(cfg, options)
+	if err != nil {
+		return err
+	}
+	userData, err := bootstrapper.UserData()
+	if err != nil {
+		return fmt.Errorf("rendering userdata: %w", err)
+	}
+	if userData == "" {
+		logger.Info("no userdata is generated by eksctl for this nodegroup; EKS supplies the userdata for its AMI")
+		return nil
+	}
+
+	if err := nodebootstrap.ValidateUserDataSize(userData, ng); err != nil {
+		logger.Warning(err.Error())
+	}
+
+	if options.output == userDataOutputRaw {
+		if userData, err = nodebootstrap.DecodeUserData(userData); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintln(cmd.CobraCommand.OutOrStdout(), userData)
+	return nil
+}
+
+func makeRenderClusterStatus(cfg *api.ClusterConfig, options renderUserDataOptions) (*api.ClusterStatus, error) {
+	caData, err := base64.StdEncoding.DecodeString(options.certificateAuthorityData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --certificate-authority-data: %w", err)
+	}
+	if options.endpoint == "" {
+		logger.Warning("--endpoint was not set; the rendered userdata will not contain the API server endpoint")
+	}
+	if len(caData) == 0 {
+		logger.Warning("--certificate-authority-data was not set; the rendered userdata will not contain the cluster CA")
+	}
+
+	serviceCIDR := options.serviceCIDR
+	if serviceCIDR == "" {
+		serviceCIDR = "10.100.0.0/16"
+		if cfg.KubernetesNetworkConfig != nil && cfg.KubernetesNetworkConfig.ServiceIPv4CIDR != "" {
+			serviceCIDR = cfg.KubernetesNetworkConfig.ServiceIPv4CIDR
+		}
+	}
+	ip, _, err := net.ParseCIDR(serviceCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --service-cidr: %w", err)
+	}
+	networkConfig := &api.KubernetesNetworkConfig{}
+	if ip.To4() != nil {
+		networkConfig.ServiceIPv4CIDR = serviceCIDR
+	} else {
+		networkConfig.ServiceIPv6CIDR = serviceCIDR
+	}
+
+	return &api.ClusterStatus{
+		Endpoint:                 options.endpoint,
+		CertificateAuthorityData: caData,
+		KubernetesNetworkConfig:  networkConfig,
+	}, nil
+}
+
+func newRenderBootstrapper(cfg *api.ClusterConfig, options renderUserDataOptions) (nodebootstrap.Bootstrapper, *api.NodeGroupBase, error) {
+	nodeGroupNames := cfg.GetAllNodeGroupNames()
+	name := options.nodeGroupName
+	if name == "" {
+		if len(nodeGroupNames) != 1 {
+			return nil, nil, fmt.Errorf("--name must be set as the config file has %d nodegroups", len(nodeGroupNames))
+		}
+		name = nodeGroupNames[0]
+	}
+
+	controlPlaneOnOutposts := cfg.IsControlPlaneOnOutposts()
+	for _, ng := range cfg.NodeGroups {
+		if ng.Name != name {
+			continue
+		}
+		api.SetNodeGroupDefaults(ng, cfg.Metadata, controlPlaneOnOutposts)
+		if options.clusterDNS != "" {
+			ng.ClusterDNS = options.clusterDNS
+		}
+		bootstrapper, err := nodebootstrap.NewBootstrapper(cfg, ng)
+		return bootstrapper, ng.NodeGroupBase, err
+	}
+	for _, ng := range cfg.ManagedNodeGroups {
+		if ng.Name != name {
+			continue
+		}
+		if options.clusterDNS != "" {
+			return nil, nil, fmt.Errorf("--cluster-dns is not supported for managed nodegroups")
+		}
+		api.SetManagedNodeGroupDefaults(ng, cfg.Metadata, controlPlaneOnOutposts)
+		bootstrapper, err := nodebootstrap.NewManagedBootstrapper(cfg, ng)
+		if err != nil {
+			return nil, nil, err
+		}
+		if bootstrapper == nil {
+			return nil, nil, fmt.Errorf("rendering userdata is not supported for AMI family %q", ng.AMIFamily)
+		}
+		return bootstrapper, ng.NodeGroupBase, nil
+	}
+	return nil, nil, fmt.Errorf("nodegroup %q not found in config file", name)
+}
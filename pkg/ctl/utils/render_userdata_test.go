@@ -0,0 +1,107 @@
+package utils_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const al2023Config = `apiVersion: eksctl.io/v1alpha5
+kind: ClusterConfig
+metadata:
+  name: cluster-1
+  region: us-west-2
+managedNodeGroups:
+  - name: mng-1
+    amiFamily: AmazonLinux2023
+    ami: ami-123
+    preBootstrapCommands:
+      - echo pre-bootstrap
+`
+
+var _ = Describe("render userdata", func() {
+	var caData = base64.StdEncoding.EncodeToString([]byte("test-ca"))
+
+	It("requires a config file", func() {
+		cmd := newMockCmd("render-userdata")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring("Error: --config-file must be set")))
+	})
+
+	It("rejects unsupported output formats", func() {
+		cmd := newMockCmd("render-userdata", "--config-file", "../../../examples/01-simple-cluster.yaml", "--output", "json")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(`Error: unsupported output format "json"`)))
+	})
+
+	It("requires the nodegroup name if the config file has several nodegroups", func() {
+		cmd := newMockCmd("render-userdata", "--config-file", "../../../examples/03-two-nodegroups.yaml")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring("Error: --name must be set as the config file has 2 nodegroups")))
+	})
+
+	It("returns an error if the nodegroup does not exist", func() {
+		cmd := newMockCmd("render-userdata", "--config-file", "../../../examples/03-two-nodegroups.yaml", "--name", "ng3")
+		_, err := cmd.execute()
+		Expect(err).To(MatchError(ContainSubstring(`Error: nodegroup "ng3" not found in config file`)))
+	})
+
+	It("renders the cloud-config files and scripts of an AmazonLinux2 nodegroup", func() {
+		cmd := newMockCmd("render-userdata", "--config-file", "../../../examples/01-simple-cluster.yaml",
+			"--endpoint", "https://test.eks.amazonaws.com", "--certificate-authority-data", caData)
+		out, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(HavePrefix("#cloud-config"))
+		Expect(out).To(ContainSubstring("/var/lib/cloud/scripts/eksctl/bootstrap.al2.sh"))
+		Expect(out).To(ContainSubstring("API_SERVER_URL=https://test.eks.amazonaws.com"))
+		Expect(out).To(ContainSubstring("B64_CLUSTER_CA=" + caData))
+		Expect(out).To(ContainSubstring("CLUSTER_DNS=10.100.0.10"))
+	})
+
+	It("renders the nodeadm NodeConfig of an AmazonLinux2023 nodegroup", func() {
+		configFile := filepath.Join(GinkgoT().TempDir(), "al2023.yaml")
+		Expect(os.WriteFile(configFile, []byte(al2023Config), 0644)).To(Succeed())
+
+		cmd := newMockCmd("render-userdata", "--config-file", configFile,
+			"--endpoint", "https://test.eks.amazonaws.com", "--certificate-authority-data", caData)
+		out, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Content-Type: text/x-shellscript"))
+		Expect(out).To(ContainSubstring("echo pre-bootstrap"))
+		Expect(out).To(ContainSubstring("Content-Type: application/node.eks.aws"))
+		Expect(out).To(ContainSubstring("apiServerEndpoint: https://test.eks.amazonaws.com"))
+		Expect(out).To(ContainSubstring("certificateAuthority: " + caData))
+		Expect(out).To(ContainSubstring("cidr: 10.100.0.0/16"))
+		Expect(out).To(ContainSubstring("- 10.100.0.10"))
+	})
+
+	It("renders the settings of a Bottlerocket nodegroup", func() {
+		cmd := newMockCmd("render-userdata", "--config-file", "../../../examples/20-bottlerocket.yaml", "ng1-public",
+			"--endpoint", "https://test.eks.amazonaws.com", "--certificate-authority-data", caData)
+		out, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("[settings.kubernetes]"))
+		Expect(out).To(ContainSubstring(`api-server = "https://test.eks.amazonaws.com"`))
+	})
+
+	It("renders the PowerShell script of a Windows nodegroup with a custom cluster DNS", func() {
+		cmd := newMockCmd("render-userdata", "--config-file", "../../../examples/14-windows-nodes.yaml", "--name", "windows-ng",
+			"--endpoint", "https://test.eks.amazonaws.com", "--cluster-dns", "172.20.0.10")
+		out, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(HavePrefix("<powershell>"))
+		Expect(out).To(ContainSubstring(`-DNSClusterIP "172.20.0.10"`))
+	})
+
+	It("prints base64-encoded userdata", func() {
+		cmd := newMockCmd("render-userdata", "--config-file", "../../../examples/20-bottlerocket.yaml", "--name", "ng1-public", "--output", "base64")
+		out, err := cmd.execute()
+		Expect(err).NotTo(HaveOccurred())
+		decoded, err := base64.StdEncoding.DecodeString(out[:len(out)-1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(decoded)).To(ContainSubstring("[settings.kubernetes]"))
+	})
+})
@@ -29,8 +29,12 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeAddonVersionsCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeAddonConfigurationCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateToPodIdentityCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateToKarpenterCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, migrateAccessEntryCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateZonalShiftConfigCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, generateIAMPolicyCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, renderUserDataCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, exportTerraformCmd)
 
 	return verbCmd
 }
@@ -0,0 +1,189 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kris-nova/logger"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// healthCheckInterval is how often the workloads of evicted pods are checked between batches
+var healthCheckInterval = 5 * time.Second
+
+// BatchOptions controls draining the nodes of a nodegroup in batches. Once a batch is drained, the Deployments and
+// StatefulSets whose pods were evicted must have all their replicas ready before the next batch is drained.
+type BatchOptions struct {
+	// BatchSize is the maximum number of nodes drained in each batch.
+	BatchSize int
+	// MaxUnavailablePercent is the maximum percentage of the nodegroup's nodes drained in each batch.
+	MaxUnavailablePercent int
+	// HealthCheckTimeout is how long to wait for workloads to recover after each batch.
+	HealthCheckTimeout time.Duration
+}
+
+// Enabled reports whether nodes are drained in batches.
+func (b BatchOptions) Enabled() bool {
+	return b.BatchSize > 0 || b.MaxUnavailablePercent > 0
+}
+
+// size returns the number of nodes to drain in the next batch, out of a nodegroup of nodeCount nodes.
+func (b BatchOptions) size(nodeCount int) int {
+	size := math.MaxInt
+	if b.BatchSize > 0 {
+		size = b.BatchSize
+	}
+	if b.MaxUnavailablePercent > 0 {
+		size = min(size, max(1, nodeCount*b.MaxUnavailablePercent/100))
+	}
+	return size
+}
+
+// workload is a Deployment or StatefulSet whose pods were evicted.
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (w workload) String() string {
+	return fmt.Sprintf("%s %s/%s", strings.ToLower(w.kind), w.namespace, w.name)
+}
+
+// evictedWorkloads records the controllers of the pods evicted during a batch.
+type evictedWorkloads struct {
+	mu          sync.Mutex
+	controllers map[workload]struct{}
+}
+
+func (e *evictedWorkloads) add(pod corev1.Pod) {
+	controllerRef := metav1.GetControllerOf(&pod)
+	if controllerRef == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.controllers == nil {
+		e.controllers = map[workload]struct{}{}
+	}
+	e.controllers[workload{kind: controllerRef.Kind, namespace: pod.Namespace, name: controllerRef.Name}] = struct{}{}
+}
+
+// reset returns the controllers recorded since the last call.
+func (e *evictedWorkloads) reset() []workload {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var controllers []workload
+	for c := range e.controllers {
+		controllers = append(controllers, c)
+	}
+	e.controllers = nil
+	return controllers
+}
+
+// workloadsToCheck resolves the controllers of evicted pods to the Deployments and StatefulSets that own them.
+func (n *NodeGroupDrainer) workloadsToCheck(ctx context.Context, controllers []workload) ([]workload, error) {
+	workloads := map[workload]struct{}{}
+	for _, c := range controllers {
+		switch c.kind {
+		case "StatefulSet", "Deployment":
+			workloads[c] = struct{}{}
+		case "ReplicaSet":
+			rs, err := n.clientSet.AppsV1().ReplicaSets(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("getting ReplicaSet %s/%s: %w", c.namespace, c.name, err)
+			}
+			if owner := metav1.GetControllerOf(rs); owner != nil && owner.Kind == "Deployment" {
+				workloads[workload{kind: owner.Kind, namespace: c.namespace, name: owner.Name}] = struct{}{}
+			}
+		}
+	}
+	var list []workload
+	for w := range workloads {
+		list = append(list, w)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].String() < list[j].String()
+	})
+	return list, nil
+}
+
+// readyReplicas returns the number of ready and desired replicas of the workload.
+func (n *NodeGroupDrainer) readyReplicas(ctx context.Context, w workload) (ready, desired int32, err error) {
+	switch w.kind {
+	case "Deployment":
+		d, err := n.clientSet.AppsV1().Deployments(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return 0, 0, err
+		}
+		return d.Status.ReadyReplicas, replicas(d.Spec.Replicas), nil
+	default:
+		s, err := n.clientSet.AppsV1().StatefulSets(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return 0, 0, err
+		}
+		return s.Status.ReadyReplicas, replicas(s.Spec.Replicas), nil
+	}
+}
+
+func replicas(r *int32) int32 {
+	if r == nil {
+		return 1
+	}
+	return *r
+}
+
+// waitForWorkloads waits until the workloads whose pods were evicted from the drained nodes have all their replicas
+// ready, and returns an error listing those that did not recover within the health check timeout.
+func (n *NodeGroupDrainer) waitForWorkloads(ctx context.Context, drainedNodes []string) error {
+	workloads, err := n.workloadsToCheck(ctx, n.evicted.reset())
+	if err != nil {
+		return err
+	}
+	if len(workloads) == 0 {
+		return nil
+	}
+	logger.Info("waiting for %d workload(s) evicted from node(s) %v to become ready", len(workloads), drainedNodes)
+
+	notReady := map[workload]string{}
+	pollErr := wait.PollUntilContextTimeout(ctx, healthCheckInterval, n.batch.HealthCheckTimeout, true, func(ctx context.Context) (bool, error) {
+		for _, w := range workloads {
+			ready, desired, err := n.readyReplicas(ctx, w)
+			switch {
+			case apierrors.IsNotFound(err):
+				delete(notReady, w)
+			case err != nil:
+				notReady[w] = err.Error()
+			case ready < desired:
+				notReady[w] = fmt.Sprintf("%d/%d replicas ready", ready, desired)
+			default:
+				delete(notReady, w)
+			}
+		}
+		return len(notReady) == 0, nil
+	})
+	if pollErr == nil {
+		logger.Success("all workloads evicted from node(s) %v are ready", drainedNodes)
+		return nil
+	}
+
+	var report []string
+	for _, w := range workloads {
+		if reason, ok := notReady[w]; ok {
+			report = append(report, fmt.Sprintf("%s: %s", w, reason))
+		}
+	}
+	return fmt.Errorf("stopped draining nodegroup %q: workloads evicted from node(s) %v did not become ready within %s: %s",
+		n.ng.NameString(), drainedNodes, n.batch.HealthCheckTimeout, strings.Join(report, "; "))
+}
@@ -0,0 +1,140 @@
+package drain_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/sync/semaphore"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/weaveworks/eksctl/pkg/drain"
+	"github.com/weaveworks/eksctl/pkg/drain/evictor"
+	"github.com/weaveworks/eksctl/pkg/drain/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks/mocks"
+)
+
+var _ = Describe("Drain in batches", func() {
+	var (
+		mockNG        mocks.KubeNodeGroup
+		fakeClientSet *fake.Clientset
+		fakeEvictor   *fakes.FakeEvictor
+		deployment    *appsv1.Deployment
+		healthChecks  int
+	)
+
+	const nodeCount = 4
+
+	controller := func(kind, name string) []metav1.OwnerReference {
+		isController := true
+		return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+	}
+
+	BeforeEach(func() {
+		DeferCleanup(drain.SetHealthCheckInterval(10 * time.Millisecond))
+
+		mockNG = mocks.KubeNodeGroup{}
+		mockNG.Mock.On("NameString").Return("ng-1")
+		mockNG.Mock.On("ListOptions").Return(metav1.ListOptions{})
+
+		deployment = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec:       appsv1.DeploymentSpec{Replicas: int32Ptr(3)},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 3},
+		}
+		objects := []runtime.Object{
+			deployment,
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-abc", OwnerReferences: controller("Deployment", "web")}},
+		}
+		for i := 1; i <= nodeCount; i++ {
+			objects = append(objects, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("node-%d", i)}})
+		}
+		fakeClientSet = fake.NewSimpleClientset(objects...)
+		healthChecks = 0
+		fakeClientSet.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			healthChecks++
+			return false, nil, nil
+		})
+
+		var mu sync.Mutex
+		drained := map[string]bool{}
+		fakeEvictor = new(fakes.FakeEvictor)
+		fakeEvictor.GetPodsForEvictionStub = func(node string) (*evictor.PodDeleteList, []error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if drained[node] {
+				return &evictor.PodDeleteList{}, nil
+			}
+			drained[node] = true
+			return &evictor.PodDeleteList{Items: []evictor.PodDelete{{
+				Pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "default",
+					Name:            "web-abc-" + node,
+					OwnerReferences: controller("ReplicaSet", "web-abc"),
+				}},
+				Status: evictor.PodDeleteStatus{Delete: true},
+			}}}, nil
+		}
+	})
+
+	drainNodeGroup := func(batch drain.BatchOptions) error {
+		nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Minute, 0, 0, false, false, nodeCount)
+		nodeGroupDrainer.SetDrainer(fakeEvictor)
+		nodeGroupDrainer.SetBatchOptions(batch)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return nodeGroupDrainer.Drain(ctx, semaphore.NewWeighted(nodeCount))
+	}
+
+	cordonedNodes := func() []string {
+		nodes, err := fakeClientSet.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		var cordoned []string
+		for _, node := range nodes.Items {
+			if node.Spec.Unschedulable {
+				cordoned = append(cordoned, node.Name)
+			}
+		}
+		return cordoned
+	}
+
+	It("checks that evicted workloads are ready after each batch", func() {
+		Expect(drainNodeGroup(drain.BatchOptions{BatchSize: 1, HealthCheckTimeout: time.Second})).To(Succeed())
+		Expect(fakeEvictor.EvictOrDeletePodCallCount()).To(Equal(nodeCount))
+		Expect(healthChecks).To(Equal(nodeCount))
+	})
+
+	It("limits each batch to a percentage of the nodes", func() {
+		Expect(drainNodeGroup(drain.BatchOptions{MaxUnavailablePercent: 50, HealthCheckTimeout: time.Second})).To(Succeed())
+		Expect(fakeEvictor.EvictOrDeletePodCallCount()).To(Equal(nodeCount))
+		Expect(healthChecks).To(Equal(2))
+	})
+
+	It("does not check workloads when batches are disabled", func() {
+		Expect(drainNodeGroup(drain.BatchOptions{})).To(Succeed())
+		Expect(healthChecks).To(BeZero())
+	})
+
+	It("stops draining if evicted workloads do not recover", func() {
+		deployment.Status.ReadyReplicas = 2
+		_, err := fakeClientSet.AppsV1().Deployments("default").UpdateStatus(context.Background(), deployment, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = drainNodeGroup(drain.BatchOptions{BatchSize: 2, HealthCheckTimeout: 100 * time.Millisecond})
+		Expect(err).To(MatchError(`stopped draining nodegroup "ng-1": workloads evicted from node(s) [node-1 node-2] did not become ready within 100ms: deployment default/web: 2/3 replicas ready`))
+		Expect(fakeEvictor.EvictOrDeletePodCallCount()).To(Equal(2))
+		Expect(cordonedNodes()).To(HaveLen(nodeCount))
+	})
+})
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -98,6 +98,11 @@ type PodDeleteStatus struct {
 	Message string
 }
 
+// IsError reports whether the Pod cannot be drained
+func (s PodDeleteStatus) IsError() bool {
+	return s.Reason == podDeleteStatusTypeError
+}
+
 // Takes a Pod and returns a PodDeleteStatus
 type podFilter func(corev1.Pod) PodDeleteStatus
 
@@ -148,7 +153,9 @@ func (d *Evictor) makeFilters() []podFilter {
 	}
 }
 
-func hasLocalStorage(pod corev1.Pod) bool {
+// HasLocalStorage reports whether the Pod uses an emptyDir volume that is not backed by memory, whose data is lost
+// when the Pod is evicted
+func HasLocalStorage(pod corev1.Pod) bool {
 	for _, volume := range pod.Spec.Volumes {
 		if volume.EmptyDir != nil && volume.EmptyDir.Medium != "Memory" {
 			return true
@@ -223,7 +230,7 @@ func (d *Evictor) mirrorPodFilter(pod corev1.Pod) PodDeleteStatus {
 }
 
 func (d *Evictor) localStorageFilter(pod corev1.Pod) PodDeleteStatus {
-	if !hasLocalStorage(pod) {
+	if !HasLocalStorage(pod) {
 		return makePodDeleteStatusOkay()
 	}
 	// Any finished Pod can be removed.
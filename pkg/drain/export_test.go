@@ -1,5 +1,15 @@
 package drain
 
+import "time"
+
 func (n *NodeGroupDrainer) SetDrainer(drainer Evictor) {
 	n.evictor = drainer
 }
+
+func SetHealthCheckInterval(interval time.Duration) func() {
+	previous := healthCheckInterval
+	healthCheckInterval = interval
+	return func() {
+		healthCheckInterval = previous
+	}
+}
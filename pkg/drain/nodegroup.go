@@ -41,7 +41,10 @@ type NodeGroupDrainer struct {
 	nodeDrainWaitPeriod   time.Duration
 	podEvictionWaitPeriod time.Duration
 	undo                  bool
+	disableEviction       bool
 	parallel              int
+	batch                 BatchOptions
+	evicted               *evictedWorkloads
 }
 
 func NewNodeGroupDrainer(clientSet kubernetes.Interface, ng eks.KubeNodeGroup, maxGracePeriod, nodeDrainWaitPeriod time.Duration, podEvictionWaitPeriod time.Duration, undo, disableEviction bool, parallel int) NodeGroupDrainer {
@@ -78,10 +81,18 @@ func NewNodeGroupDrainer(clientSet kubernetes.Interface, ng eks.KubeNodeGroup, m
 		nodeDrainWaitPeriod:   nodeDrainWaitPeriod,
 		podEvictionWaitPeriod: podEvictionWaitPeriod,
 		undo:                  undo,
+		disableEviction:       disableEviction,
 		parallel:              parallel,
+		evicted:               &evictedWorkloads{},
 	}
 }
 
+// SetBatchOptions configures the drainer to drain nodes in batches, checking that evicted workloads recover
+// between batches.
+func (n *NodeGroupDrainer) SetBatchOptions(batch BatchOptions) {
+	n.batch = batch
+}
+
 // Drain drains a nodegroup
 func (n *NodeGroupDrainer) Drain(ctx context.Context, sem *semaphore.Weighted) error {
 	if err := n.evictor.CanUseEvictions(); err != nil {
@@ -136,21 +147,28 @@ func (n *NodeGroupDrainer) Drain(ctx context.Context, sem *semaphore.Weighted) e
 				return nil // no new nodes were seen
 			}
 
+			pendingNodes := sets.List(newPendingNodes)
+			if n.batch.Enabled() {
+				if size := n.batch.size(len(nodes.Items)); size < len(pendingNodes) {
+					pendingNodes = pendingNodes[:size]
+				}
+			}
+
 			logger.Debug("already drained: %v", mapToList(drainedNodes.Items()))
-			logger.Debug("will drain: %v", sets.List(newPendingNodes))
+			logger.Debug("will drain: %v", pendingNodes)
 
-			g, ctx := errgroup.WithContext(ctx)
-			for _, node := range sets.List(newPendingNodes) {
+			g, gctx := errgroup.WithContext(ctx)
+			for _, node := range pendingNodes {
 				node := node
 				g.Go(func() error {
-					if err := sem.Acquire(ctx, 1); err != nil {
+					if err := sem.Acquire(gctx, 1); err != nil {
 						return fmt.Errorf("failed to acquire semaphore: %w", err)
 					}
 					defer sem.Release(1)
 
 					drainedNodes.Set(node, nil)
 					logger.Debug("starting drain of node %s", node)
-					if err := n.evictPods(ctx, node); err != nil {
+					if err := n.evictPods(gctx, node); err != nil {
 						logger.Warning("pod eviction error (%q) on node %s", err, node)
 						time.Sleep(retryDelay)
 						return err
@@ -168,6 +186,11 @@ func (n *NodeGroupDrainer) Drain(ctx context.Context, sem *semaphore.Weighted) e
 			// We need to loop even if this is an error to check whether the error was a
 			// context timeout or something else.  This lets us log timout errors consistently
 			evictErr = g.Wait()
+			if evictErr == nil && n.batch.Enabled() {
+				if err := n.waitForWorkloads(ctx, pendingNodes); err != nil {
+					return err
+				}
+			}
 		}
 	}
 }
@@ -233,6 +256,8 @@ func (n *NodeGroupDrainer) evictPods(ctx context.Context, node string) error {
 					}
 					logger.Debug("recoverable pod eviction failure: %q", err)
 					failedEvictions = true
+				} else if n.batch.Enabled() {
+					n.evicted.add(pod)
 				}
 			}
 			if failedEvictions {
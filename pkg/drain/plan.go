@@ -0,0 +1,187 @@
+package drain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/drain/evictor"
+)
+
+// Outcome is the expected outcome of draining a pod.
+type Outcome string
+
+const (
+	// OutcomeEvict means the pod will be evicted.
+	OutcomeEvict Outcome = "evict"
+	// OutcomeDelete means the pod will be deleted without checking PodDisruptionBudgets, as eviction is disabled.
+	OutcomeDelete Outcome = "delete"
+	// OutcomeBlockedByPDB means the pod's PodDisruptionBudget does not allow any more disruptions, so its eviction
+	// will be retried until the PodDisruptionBudget allows it or the drain times out.
+	OutcomeBlockedByPDB Outcome = "blocked-by-pdb"
+	// OutcomeSkip means the pod will be left running, e.g. because it is managed by a DaemonSet.
+	OutcomeSkip Outcome = "skip"
+	// OutcomeFail means the pod cannot be drained, and draining its node will fail.
+	OutcomeFail Outcome = "fail"
+)
+
+// PodPlan describes what draining will do to a pod.
+type PodPlan struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Owner is the kind and name of the pod's controller, empty if the pod is unreplicated.
+	Owner string `json:"owner,omitempty"`
+	// PodDisruptionBudget is the name of the PodDisruptionBudget that selects the pod.
+	PodDisruptionBudget string `json:"podDisruptionBudget,omitempty"`
+	// DisruptionsAllowed is the number of disruptions the PodDisruptionBudget allows before this pod is evicted,
+	// taking into account the pods of the nodegroup that are evicted before it.
+	DisruptionsAllowed *int32 `json:"disruptionsAllowed,omitempty"`
+	// LocalStorage is true if the pod uses local storage that is lost on eviction.
+	LocalStorage bool `json:"localStorage"`
+	// Unreplicated is true if the pod is not managed by a controller, so it will not be recreated.
+	Unreplicated bool    `json:"unreplicated"`
+	Outcome      Outcome `json:"outcome"`
+	Reason       string  `json:"reason,omitempty"`
+}
+
+// NodePlan describes what draining will do to the pods of a node.
+type NodePlan struct {
+	NodeGroup string    `json:"nodeGroup"`
+	Node      string    `json:"node"`
+	Pods      []PodPlan `json:"pods"`
+}
+
+// Plan reports what draining the nodegroup would do to each pod, without cordoning nodes or evicting pods.
+// Nodes are reported in the order they are drained, and pods covered by the same PodDisruptionBudget consume its
+// allowed disruptions in that order.
+func (n *NodeGroupDrainer) Plan(ctx context.Context) ([]NodePlan, error) {
+	nodes, err := n.clientSet.CoreV1().Nodes().List(ctx, n.ng.ListOptions())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool {
+		return nodes.Items[i].Name < nodes.Items[j].Name
+	})
+
+	pdbs := &pdbCache{
+		clientSet:          n.clientSet,
+		byNamespace:        map[string][]policyv1.PodDisruptionBudget{},
+		disruptionsAllowed: map[string]int32{},
+	}
+	var plans []NodePlan
+	for _, node := range nodes.Items {
+		list, errs := n.evictor.GetPodsForEviction(node.Name)
+		if list == nil && len(errs) > 0 {
+			return nil, fmt.Errorf("listing pods on node %q: %v", node.Name, errs)
+		}
+		nodePlan := NodePlan{
+			NodeGroup: n.ng.NameString(),
+			Node:      node.Name,
+		}
+		for _, item := range list.Items {
+			podPlan, err := n.planPod(ctx, item, pdbs)
+			if err != nil {
+				return nil, err
+			}
+			nodePlan.Pods = append(nodePlan.Pods, podPlan)
+		}
+		plans = append(plans, nodePlan)
+	}
+	return plans, nil
+}
+
+func (n *NodeGroupDrainer) planPod(ctx context.Context, item evictor.PodDelete, pdbs *pdbCache) (PodPlan, error) {
+	pod := item.Pod
+	plan := PodPlan{
+		Namespace:    pod.Namespace,
+		Name:         pod.Name,
+		LocalStorage: evictor.HasLocalStorage(pod),
+	}
+	if controllerRef := metav1.GetControllerOf(&pod); controllerRef != nil {
+		plan.Owner = fmt.Sprintf("%s/%s", controllerRef.Kind, controllerRef.Name)
+	} else {
+		plan.Unreplicated = true
+	}
+
+	if !item.Status.Delete {
+		plan.Outcome = OutcomeSkip
+		if item.Status.IsError() {
+			plan.Outcome = OutcomeFail
+		}
+		plan.Reason = item.Status.Message
+		return plan, nil
+	}
+
+	pdb, err := pdbs.find(ctx, pod)
+	if err != nil {
+		return PodPlan{}, err
+	}
+	if pdb != nil {
+		plan.PodDisruptionBudget = pdb.Name
+	}
+
+	switch {
+	case n.disableEviction:
+		plan.Outcome = OutcomeDelete
+		plan.Reason = "eviction is disabled"
+	case pdb == nil:
+		plan.Outcome = OutcomeEvict
+	default:
+		allowed := pdbs.disruptionsAllowed[pdbKey(pdb)]
+		plan.DisruptionsAllowed = &allowed
+		if allowed > 0 {
+			plan.Outcome = OutcomeEvict
+			pdbs.disruptionsAllowed[pdbKey(pdb)] = allowed - 1
+		} else {
+			plan.Outcome = OutcomeBlockedByPDB
+			plan.Reason = fmt.Sprintf("PodDisruptionBudget %s/%s allows no more disruptions", pdb.Namespace, pdb.Name)
+		}
+	}
+	if plan.Reason == "" && item.Status.Message != "" {
+		plan.Reason = item.Status.Message
+	}
+	return plan, nil
+}
+
+// pdbCache lists the PodDisruptionBudgets of each namespace once, and tracks the disruptions they still allow.
+type pdbCache struct {
+	clientSet          kubernetes.Interface
+	byNamespace        map[string][]policyv1.PodDisruptionBudget
+	disruptionsAllowed map[string]int32
+}
+
+func pdbKey(pdb *policyv1.PodDisruptionBudget) string {
+	return pdb.Namespace + "/" + pdb.Name
+}
+
+// find returns the first PodDisruptionBudget that selects the pod, or nil.
+func (c *pdbCache) find(ctx context.Context, pod corev1.Pod) (*policyv1.PodDisruptionBudget, error) {
+	pdbs, ok := c.byNamespace[pod.Namespace]
+	if !ok {
+		list, err := c.clientSet.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("listing PodDisruptionBudgets in namespace %q: %w", pod.Namespace, err)
+		}
+		pdbs = list.Items
+		c.byNamespace[pod.Namespace] = pdbs
+		for i := range pdbs {
+			c.disruptionsAllowed[pdbKey(&pdbs[i])] = pdbs[i].Status.DisruptionsAllowed
+		}
+	}
+	for i := range pdbs {
+		selector, err := metav1.LabelSelectorAsSelector(pdbs[i].Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return &pdbs[i], nil
+		}
+	}
+	return nil, nil
+}
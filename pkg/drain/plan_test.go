@@ -0,0 +1,152 @@
+package drain_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/weaveworks/eksctl/pkg/drain"
+	"github.com/weaveworks/eksctl/pkg/drain/evictor"
+	"github.com/weaveworks/eksctl/pkg/drain/fakes"
+	"github.com/weaveworks/eksctl/pkg/eks/mocks"
+)
+
+var _ = Describe("Plan", func() {
+	var (
+		mockNG        mocks.KubeNodeGroup
+		fakeClientSet *fake.Clientset
+		fakeEvictor   *fakes.FakeEvictor
+	)
+
+	newPod := func(name string, labels map[string]string, owner *metav1.OwnerReference) corev1.Pod {
+		pod := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    labels,
+			},
+		}
+		if owner != nil {
+			pod.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return pod
+	}
+
+	controller := func(kind, name string) *metav1.OwnerReference {
+		isController := true
+		return &metav1.OwnerReference{Kind: kind, Name: name, Controller: &isController}
+	}
+
+	BeforeEach(func() {
+		mockNG = mocks.KubeNodeGroup{}
+		mockNG.Mock.On("NameString").Return("ng-1")
+		mockNG.Mock.On("ListOptions").Return(metav1.ListOptions{})
+		fakeClientSet = fake.NewSimpleClientset(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				},
+				Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+			},
+		)
+		fakeEvictor = new(fakes.FakeEvictor)
+
+		web1 := newPod("web-1", map[string]string{"app": "web"}, controller("ReplicaSet", "web-abc"))
+		web2 := newPod("web-2", map[string]string{"app": "web"}, controller("ReplicaSet", "web-abc"))
+		scratch := newPod("scratch", nil, nil)
+		scratch.Spec.Volumes = []corev1.Volume{{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}}
+		agent := newPod("agent", nil, controller("DaemonSet", "agent"))
+
+		fakeEvictor.GetPodsForEvictionStub = func(node string) (*evictor.PodDeleteList, []error) {
+			switch node {
+			case "node-1":
+				return &evictor.PodDeleteList{Items: []evictor.PodDelete{
+					{Pod: web1, Status: evictor.PodDeleteStatus{Delete: true}},
+					{Pod: agent, Status: evictor.PodDeleteStatus{Delete: false, Reason: "Skip"}},
+				}}, nil
+			default:
+				return &evictor.PodDeleteList{Items: []evictor.PodDelete{
+					{Pod: web2, Status: evictor.PodDeleteStatus{Delete: true}},
+					{Pod: scratch, Status: evictor.PodDeleteStatus{Delete: true, Reason: "Warning", Message: "deleting Pods not managed by ReplicationController, ReplicaSet, Job, DaemonSet or StatefulSet"}},
+				}}, nil
+			}
+		}
+	})
+
+	It("reports the expected outcome for each pod without cordoning nodes", func() {
+		nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Minute, 0, 0, false, false, 1)
+		nodeGroupDrainer.SetDrainer(fakeEvictor)
+
+		plans, err := nodeGroupDrainer.Plan(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plans).To(HaveLen(2))
+
+		Expect(plans[0].NodeGroup).To(Equal("ng-1"))
+		Expect(plans[0].Node).To(Equal("node-1"))
+		Expect(plans[0].Pods).To(HaveLen(2))
+		web1 := plans[0].Pods[0]
+		Expect(web1.Owner).To(Equal("ReplicaSet/web-abc"))
+		Expect(web1.PodDisruptionBudget).To(Equal("web"))
+		Expect(*web1.DisruptionsAllowed).To(BeEquivalentTo(1))
+		Expect(web1.Outcome).To(Equal(drain.OutcomeEvict))
+		Expect(plans[0].Pods[1].Outcome).To(Equal(drain.OutcomeSkip))
+
+		Expect(plans[1].Node).To(Equal("node-2"))
+		web2 := plans[1].Pods[0]
+		Expect(*web2.DisruptionsAllowed).To(BeEquivalentTo(0))
+		Expect(web2.Outcome).To(Equal(drain.OutcomeBlockedByPDB))
+		Expect(web2.Reason).To(Equal("PodDisruptionBudget default/web allows no more disruptions"))
+
+		scratch := plans[1].Pods[1]
+		Expect(scratch.LocalStorage).To(BeTrue())
+		Expect(scratch.Unreplicated).To(BeTrue())
+		Expect(scratch.Owner).To(BeEmpty())
+		Expect(scratch.PodDisruptionBudget).To(BeEmpty())
+		Expect(scratch.Outcome).To(Equal(drain.OutcomeEvict))
+
+		nodes, err := fakeClientSet.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		for _, node := range nodes.Items {
+			Expect(node.Spec.Unschedulable).To(BeFalse())
+		}
+		Expect(fakeEvictor.EvictOrDeletePodCallCount()).To(BeZero())
+		Expect(fakeEvictor.CanUseEvictionsCallCount()).To(BeZero())
+	})
+
+	It("reports pods as deleted when eviction is disabled", func() {
+		nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Minute, 0, 0, false, true, 1)
+		nodeGroupDrainer.SetDrainer(fakeEvictor)
+
+		plans, err := nodeGroupDrainer.Plan(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plans[1].Pods[0].Outcome).To(Equal(drain.OutcomeDelete))
+		Expect(plans[1].Pods[0].DisruptionsAllowed).To(BeNil())
+	})
+
+	It("reports pods that cannot be drained", func() {
+		fakeEvictor.GetPodsForEvictionStub = nil
+		fakeEvictor.GetPodsForEvictionReturns(&evictor.PodDeleteList{Items: []evictor.PodDelete{{
+			Pod:    newPod("never", nil, nil),
+			Status: evictor.PodDeleteStatus{Delete: false, Reason: "Error", Message: "cannot be drained due to annotation pod.alpha.kubernetes.io/drain=never"},
+		}}}, nil)
+		nodeGroupDrainer := drain.NewNodeGroupDrainer(fakeClientSet, &mockNG, time.Minute, 0, 0, false, false, 1)
+		nodeGroupDrainer.SetDrainer(fakeEvictor)
+
+		plans, err := nodeGroupDrainer.Plan(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plans[0].Pods[0].Outcome).To(Equal(drain.OutcomeFail))
+		Expect(plans[0].Pods[0].Reason).To(ContainSubstring("drain=never"))
+	})
+})
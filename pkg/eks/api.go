@@ -2,6 +2,7 @@ package eks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -298,8 +299,9 @@ func (c *ClusterProvider) checkAuth(ctx context.Context) (*sts.GetCallerIdentity
 	return output, nil
 }
 
-// ResolveAMI ensures that the node AMI is set and is available
-func ResolveAMI(ctx context.Context, provider api.ClusterProvider, version string, np api.NodePool) error {
+// ResolveAMI ensures that the node AMI is set and is available.
+// If amiResolution is set, the custom AMI source is consulted before the EKS-optimized AMIs.
+func ResolveAMI(ctx context.Context, provider api.ClusterProvider, version string, np api.NodePool, amiResolution *api.AMIResolution) error {
 	var resolver ami.Resolver
 	ng := np.BaseNodeGroup()
 	switch ng.AMI {
@@ -308,10 +310,18 @@ func ResolveAMI(ctx context.Context, provider api.ClusterProvider, version strin
 	case api.NodeImageResolverAutoSSM:
 		resolver = ami.NewSSMResolver(provider.SSM())
 	case "":
-		resolver = ami.NewMultiResolver(
+		var delegates []ami.Resolver
+		if amiResolution != nil {
+			customResolver, err := ami.NewCustomResolver(amiResolution, provider.SSM())
+			if err != nil {
+				return err
+			}
+			delegates = append(delegates, customResolver)
+		}
+		resolver = ami.NewMultiResolver(append(delegates,
 			ami.NewSSMResolver(provider.SSM()),
 			ami.NewAutoResolver(provider.EC2()),
-		)
+		)...)
 	default:
 		return fmt.Errorf("invalid AMI value: %q", ng.AMI)
 	}
@@ -328,6 +338,26 @@ func ResolveAMI(ctx context.Context, provider api.ClusterProvider, version strin
 	return nil
 }
 
+// ResolveCustomAMI sets the AMI of a nodegroup that would otherwise use the EKS-optimized AMI supplied by EKS,
+// if one is found in the custom AMI source
+func ResolveCustomAMI(ctx context.Context, provider api.ClusterProvider, version string, np api.NodePool, amiResolution *api.AMIResolution) error {
+	resolver, err := ami.NewCustomResolver(amiResolution, provider.SSM())
+	if err != nil {
+		return err
+	}
+	ng := np.BaseNodeGroup()
+	id, err := resolver.Resolve(ctx, provider.Region(), version, api.SelectInstanceType(np), ng.AMIFamily)
+	if err != nil {
+		var queryErr *ami.UnsupportedQueryError
+		if errors.As(err, &queryErr) {
+			return nil
+		}
+		return fmt.Errorf("unable to determine AMI to use: %w", err)
+	}
+	ng.AMI = id
+	return nil
+}
+
 // SetAvailabilityZones sets the given (or chooses) the availability zones
 // Returns whether azs were set randomly or provided by a user.
 // CheckInstanceAvailability is only run if azs were provided by the user. Random
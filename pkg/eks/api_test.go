@@ -262,7 +262,7 @@ var _ = Describe("eksctl API", func() {
 		})
 
 		testEnsureAMI := func(matcher gomegatypes.GomegaMatcher, version string) {
-			err := ResolveAMI(context.Background(), provider, version, ng)
+			err := ResolveAMI(context.Background(), provider, version, ng, nil)
 			ExpectWithOffset(1, err).NotTo(HaveOccurred())
 			ExpectWithOffset(1, ng.AMI).To(matcher)
 		}
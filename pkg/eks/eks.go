@@ -190,6 +190,25 @@ func (c *ClusterProvider) LoadClusterVPC(ctx context.Context, spec *api.ClusterC
 	return vpc.UseFromClusterStack(ctx, c.AWSProvider, stack, spec, ignoreDrift)
 }
 
+// LoadPodSubnets loads the pod subnets used for custom networking from the cluster stack,
+// unless they are all specified by ID in spec.
+func (c *ClusterProvider) LoadPodSubnets(ctx context.Context, spec *api.ClusterConfig, stackManager manager.StackManager) error {
+	if !spec.HasPodSubnets() {
+		return nil
+	}
+	if subnets := spec.VPC.PodSubnets.Subnets; len(subnets) > 0 && len(subnets.WithIDs()) == len(subnets) {
+		return nil
+	}
+	stack, err := stackManager.DescribeClusterStackIfExists(ctx)
+	if err != nil {
+		return err
+	}
+	if stack == nil {
+		return fmt.Errorf("vpc.podSubnets.subnets must be specified by ID as cluster %q was not created by eksctl", spec.Metadata.Name)
+	}
+	return c.LoadClusterVPC(ctx, spec, stack, true)
+}
+
 // GetCluster display details of an EKS cluster in your account
 func (c *ClusterProvider) GetCluster(ctx context.Context, clusterName string) (*ekstypes.Cluster, error) {
 	input := &awseks.DescribeClusterInput{
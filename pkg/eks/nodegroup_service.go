@@ -68,14 +68,20 @@ func (n *NodeGroupService) Normalize(ctx context.Context, nodePools []api.NodePo
 					api.IsWindowsImage(ng.AMIFamily)
 
 			if !hasNativeAMIFamilySupport && !api.IsAMI(ng.AMI) {
-				if err := ResolveAMI(ctx, n.provider, clusterConfig.Metadata.Version, np); err != nil {
+				if err := ResolveAMI(ctx, n.provider, clusterConfig.Metadata.Version, np, clusterConfig.AMIResolution); err != nil {
+					return err
+				}
+			} else if clusterConfig.AMIResolution != nil && ng.AMI == "" && ng.LaunchTemplate == nil && ng.ReleaseVersion == "" && ng.AMIFamily == api.NodeImageFamilyAmazonLinux2023 {
+				// AmazonLinux2023 is the only family EKS-optimized AMIs can be replaced with for managed nodegroups
+				// without setting overrideBootstrapCommand, as eksctl generates the nodeadm config for custom AMIs
+				if err := ResolveCustomAMI(ctx, n.provider, clusterConfig.Metadata.Version, np, clusterConfig.AMIResolution); err != nil {
 					return err
 				}
 			}
 
 		case *api.NodeGroup:
 			if !api.IsAMI(ng.AMI) {
-				if err := ResolveAMI(ctx, n.provider, clusterConfig.Metadata.Version, ng); err != nil {
+				if err := ResolveAMI(ctx, n.provider, clusterConfig.Metadata.Version, ng, clusterConfig.AMIResolution); err != nil {
 					return err
 				}
 			}
@@ -14,19 +14,18 @@ const (
 )
 
 // PrintProfiles formats the provided profiles in the provided printer type
-// ("table", "json", "yaml") and prints them to the provided writer.
+// ("table", "csv", "markdown", "json", "yaml", "jsonpath=...", "go-template=...")
+// and prints them to the provided writer.
 func PrintProfiles(profiles []*api.FargateProfile, writer io.Writer, printerType printers.Type) error {
 	printer, err := printers.NewPrinter(printerType)
 	if err != nil {
 		return err
 	}
-	switch printerType {
-	case printers.TableType:
-		addFargateProfileColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addFargateProfileColumns(columnPrinter)
 		return printer.PrintObjWithKind(kindFargateProfiles, toTable(profiles), writer)
-	default:
-		return printer.PrintObjWithKind(kindFargateProfiles, profiles, writer)
 	}
+	return printer.PrintObjWithKind(kindFargateProfiles, profiles, writer)
 }
 
 type row struct {
@@ -55,7 +54,7 @@ func toTable(profiles []*api.FargateProfile) []*row {
 	return table
 }
 
-func addFargateProfileColumns(printer *printers.TablePrinter) {
+func addFargateProfileColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("NAME", func(r *row) string {
 		return r.Name
 	})
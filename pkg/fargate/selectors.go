@@ -0,0 +1,80 @@
+package fargate
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ProfileLabel is the label EKS sets on pods scheduled onto Fargate, whose
+// value is the name of the Fargate profile the pod was scheduled with.
+const ProfileLabel = "eks.amazonaws.com/fargate-profile"
+
+// DiffSelectors returns the selectors of the replacement which are not
+// selectors of the current profile, and the selectors of the current profile
+// which are not selectors of the replacement.
+func DiffSelectors(current, replacement []api.FargateProfileSelector) (gained, lost []api.FargateProfileSelector) {
+	for _, selector := range replacement {
+		if !containsSelector(current, selector) {
+			gained = append(gained, selector)
+		}
+	}
+	for _, selector := range current {
+		if !containsSelector(replacement, selector) {
+			lost = append(lost, selector)
+		}
+	}
+	return gained, lost
+}
+
+func containsSelector(selectors []api.FargateProfileSelector, selector api.FargateProfileSelector) bool {
+	for _, s := range selectors {
+		if s.Namespace == selector.Namespace && (len(s.Labels) == 0 && len(selector.Labels) == 0 || reflect.DeepEqual(s.Labels, selector.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectorsMatch returns true if any of the provided selectors matches a pod
+// in the provided namespace with the provided labels. Like EKS, the namespace
+// and labels of selectors may contain the '*' and '?' wildcards.
+func SelectorsMatch(selectors []api.FargateProfileSelector, namespace string, labels map[string]string) bool {
+	for _, selector := range selectors {
+		if selectorMatches(selector, namespace, labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectorMatches(selector api.FargateProfileSelector, namespace string, labels map[string]string) bool {
+	if !wildcardMatch(selector.Namespace, namespace) {
+		return false
+	}
+	for key, value := range selector.Labels {
+		found := false
+		for podKey, podValue := range labels {
+			if wildcardMatch(key, podKey) && wildcardMatch(value, podValue) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func wildcardMatch(pattern, s string) bool {
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == s
+	}
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\*`, ".*")
+	expr = strings.ReplaceAll(expr, `\?`, ".")
+	return regexp.MustCompile("^" + expr + "$").MatchString(s)
+}
@@ -0,0 +1,86 @@
+package fargate
+
+import (
+	"errors"
+	"fmt"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// UpdateOptions groups the parameters required to update a Fargate profile.
+type UpdateOptions struct {
+	ProfileName string
+	// +optional
+	ProfileSelectorNamespace string
+	// +optional
+	ProfileSelectorLabels map[string]string
+	// +optional
+	Subnets []string
+	// +optional
+	Tags map[string]string
+	// RestartPods restarts the pods matched by the selectors of the updated
+	// profile which are not running on Fargate, once it is updated. The pods
+	// matched only by the removed selectors are deleted by EKS with the existing
+	// profile instead.
+	RestartPods bool
+	// Plan only reports the changes the update would make.
+	Plan bool
+}
+
+// Validate validates this Options object's fields.
+func (o *UpdateOptions) Validate() error {
+	if o.ProfileName == "" {
+		return errors.New("invalid Fargate profile: empty name")
+	}
+	if o.ProfileSelectorNamespace == "" && len(o.ProfileSelectorLabels) > 0 {
+		return fmt.Errorf("invalid Fargate profile %q: selector labels require a selector namespace", o.ProfileName)
+	}
+	return nil
+}
+
+// ToFargateProfile creates a FargateProfile object from this Options object.
+// Fields which are not set are left empty, and default to the ones of the
+// profile being replaced.
+func (o UpdateOptions) ToFargateProfile() *api.FargateProfile {
+	profile := &api.FargateProfile{
+		Name:    o.ProfileName,
+		Subnets: o.Subnets,
+		Tags:    o.Tags,
+	}
+	if o.ProfileSelectorNamespace != "" {
+		profile.Selectors = []api.FargateProfileSelector{
+			{
+				Namespace: o.ProfileSelectorNamespace,
+				Labels:    o.ProfileSelectorLabels,
+			},
+		}
+	}
+	return profile
+}
+
+// temporaryProfileSuffix is appended to the name of a profile to name the
+// profile temporarily replacing it while it is recreated.
+const temporaryProfileSuffix = "-eksctl-update"
+
+// FindProfile returns the profile with the provided name, or if an update by
+// 'eksctl update fargateprofile' was interrupted after the profile was deleted,
+// the profile temporarily replacing it.
+func FindProfile(profiles []*api.FargateProfile, name string) *api.FargateProfile {
+	var temporary *api.FargateProfile
+	for _, profile := range profiles {
+		switch profile.Name {
+		case name:
+			return profile
+		case TemporaryProfileName(name):
+			temporary = profile
+		}
+	}
+	return temporary
+}
+
+// TemporaryProfileName returns the name of the profile temporarily replacing
+// the profile with the provided name while it is recreated with the desired
+// configuration, as profile names must be unique.
+func TemporaryProfileName(name string) string {
+	return name + temporaryProfileSuffix
+}
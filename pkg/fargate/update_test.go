@@ -0,0 +1,60 @@
+package fargate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/fargate"
+)
+
+var _ = Describe("Fargate profile update", func() {
+	profiles := func(names ...string) []*api.FargateProfile {
+		var out []*api.FargateProfile
+		for _, name := range names {
+			out = append(out, &api.FargateProfile{Name: name})
+		}
+		return out
+	}
+
+	DescribeTable("FindProfile", func(existing []string, name, expected string) {
+		profile := fargate.FindProfile(profiles(existing...), name)
+		if expected == "" {
+			Expect(profile).To(BeNil())
+			return
+		}
+		Expect(profile.Name).To(Equal(expected))
+	},
+		Entry("exact name", []string{"fp-dev", "fp-prod"}, "fp-dev", "fp-dev"),
+		Entry("exact name and temporary profile", []string{"fp-dev-eksctl-update", "fp-dev"}, "fp-dev", "fp-dev"),
+		Entry("temporary profile", []string{"fp-dev-eksctl-update", "fp-prod"}, "fp-dev", "fp-dev-eksctl-update"),
+		Entry("other profile", []string{"fp-dev-test"}, "fp-dev", ""),
+		Entry("no profiles", nil, "fp-dev", ""),
+	)
+
+	It("diffs the selectors of two profiles", func() {
+		current := []api.FargateProfileSelector{
+			{Namespace: "default"},
+			{Namespace: "dev", Labels: map[string]string{"env": "dev"}},
+		}
+		replacement := []api.FargateProfileSelector{
+			{Namespace: "default", Labels: map[string]string{}},
+			{Namespace: "dev", Labels: map[string]string{"env": "dev", "team": "a"}},
+			{Namespace: "staging"},
+		}
+		gained, lost := fargate.DiffSelectors(current, replacement)
+		Expect(gained).To(Equal(replacement[1:]))
+		Expect(lost).To(Equal(current[1:]))
+	})
+
+	DescribeTable("SelectorsMatch", func(selector api.FargateProfileSelector, namespace string, labels map[string]string, expected bool) {
+		Expect(fargate.SelectorsMatch([]api.FargateProfileSelector{selector}, namespace, labels)).To(Equal(expected))
+	},
+		Entry("namespace", api.FargateProfileSelector{Namespace: "dev"}, "dev", nil, true),
+		Entry("other namespace", api.FargateProfileSelector{Namespace: "dev"}, "prod", nil, false),
+		Entry("namespace wildcard", api.FargateProfileSelector{Namespace: "dev-*"}, "dev-team-a", nil, true),
+		Entry("labels", api.FargateProfileSelector{Namespace: "dev", Labels: map[string]string{"env": "dev"}}, "dev", map[string]string{"env": "dev", "app": "web"}, true),
+		Entry("missing label", api.FargateProfileSelector{Namespace: "dev", Labels: map[string]string{"env": "dev"}}, "dev", map[string]string{"app": "web"}, false),
+		Entry("label wildcard", api.FargateProfileSelector{Namespace: "dev", Labels: map[string]string{"env": "dev?"}}, "dev", map[string]string{"env": "dev1"}, true),
+	)
+})
@@ -0,0 +1,526 @@
+// Package policy generates the IAM policy required by the principal that runs eksctl for a given ClusterConfig.
+package policy
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+const anyResource = "*"
+
+// Generate returns a policy document that allows eksctl to create, update and delete the cluster described by cfg and
+// the resources declared in it. Resources are scoped to the names eksctl gives them where the AWS service supports it.
+// accountID is the ID of the AWS account the cluster is created in, or "*" if unknown.
+func Generate(cfg *api.ClusterConfig, accountID string) *api.IAMPolicyDocument {
+	region := cfg.Metadata.Region
+	if region == "" {
+		region = anyResource
+	}
+	// config files are not defaulted, so fill in the fields the generator reads without modifying the config
+	cfgCopy := *cfg
+	if cfgCopy.IAM == nil {
+		cfgCopy.IAM = &api.ClusterIAM{}
+	}
+	if cfgCopy.AccessConfig == nil {
+		cfgCopy.AccessConfig = &api.AccessConfig{}
+	}
+	g := &generator{
+		cfg:         &cfgCopy,
+		partition:   api.Partitions.ForRegion(cfg.Metadata.Region),
+		region:      region,
+		accountID:   accountID,
+		clusterName: cfg.Metadata.Name,
+		stackPrefix: manager.MakeStackNamePrefix(cfg.Metadata.Name),
+		statements:  map[string]*api.IAMStatement{},
+	}
+
+	for _, add := range []func(){
+		g.addCloudFormation,
+		g.addEKS,
+		g.addEC2,
+		g.addIAM,
+		g.addSSM,
+		g.addAutoScaling,
+		g.addELB,
+		g.addKMS,
+		g.addLogs,
+		g.addOutposts,
+		g.addKarpenter,
+	} {
+		add()
+	}
+	return g.document()
+}
+
+type generator struct {
+	cfg         *api.ClusterConfig
+	partition   string
+	region      string
+	accountID   string
+	clusterName string
+	stackPrefix string
+
+	sids       []string
+	statements map[string]*api.IAMStatement
+}
+
+// allow adds actions on resources to the statement with the specified ID, creating it if necessary.
+func (g *generator) allow(sid string, resources []string, actions ...string) {
+	s, ok := g.statements[sid]
+	if !ok {
+		s = &api.IAMStatement{
+			Sid:    sid,
+			Effect: "Allow",
+		}
+		g.statements[sid] = s
+		g.sids = append(g.sids, sid)
+	}
+	s.Action = append(s.Action, actions...)
+	s.Resource = append(s.Resource, resources...)
+}
+
+func (g *generator) document() *api.IAMPolicyDocument {
+	doc := &api.IAMPolicyDocument{
+		Version: "2012-10-17",
+	}
+	for _, sid := range g.sids {
+		s := g.statements[sid]
+		s.Action = sortedUnique(s.Action)
+		s.Resource = sortedUnique(s.Resource)
+		doc.Statements = append(doc.Statements, *s)
+	}
+	return doc
+}
+
+func sortedUnique(values []string) []string {
+	return sets.List(sets.New[string](values...))
+}
+
+func (g *generator) arn(service, region, accountID, resource string) string {
+	return fmt.Sprintf("arn:%s:%s:%s:%s:%s", g.partition, service, region, accountID, resource)
+}
+
+func (g *generator) iamARN(resource string) string {
+	return g.arn("iam", "", g.accountID, resource)
+}
+
+func (g *generator) hasNodeGroups() bool {
+	return len(g.cfg.NodeGroups) > 0 || len(g.cfg.ManagedNodeGroups) > 0
+}
+
+func (g *generator) addCloudFormation() {
+	g.allow("CloudFormationStacks", []string{g.arn("cloudformation", g.region, g.accountID, fmt.Sprintf("stack/%s*/*", g.stackPrefix))},
+		"cloudformation:CreateStack",
+		"cloudformation:DeleteStack",
+		"cloudformation:DescribeStacks",
+		"cloudformation:DescribeStackEvents",
+		"cloudformation:DescribeStackResource",
+		"cloudformation:DescribeStackResources",
+		"cloudformation:GetTemplate",
+		"cloudformation:ListStackResources",
+		"cloudformation:UpdateStack",
+		"cloudformation:CreateChangeSet",
+		"cloudformation:DescribeChangeSet",
+		"cloudformation:ExecuteChangeSet",
+		"cloudformation:DeleteChangeSet",
+		"cloudformation:TagResource",
+	)
+	g.allow("CloudFormationList", []string{anyResource}, "cloudformation:ListStacks", "sts:GetCallerIdentity")
+}
+
+func (g *generator) addEKS() {
+	clusterResource := func(resourceType, suffix string) string {
+		return g.arn("eks", g.region, g.accountID, fmt.Sprintf("%s/%s%s", resourceType, g.clusterName, suffix))
+	}
+	g.allow("EKSCluster", []string{clusterResource("cluster", "")},
+		"eks:CreateCluster",
+		"eks:DeleteCluster",
+		"eks:DescribeCluster",
+		"eks:DescribeUpdate",
+		"eks:ListUpdates",
+		"eks:TagResource",
+		"eks:UntagResource",
+		"eks:UpdateClusterConfig",
+		"eks:UpdateClusterVersion",
+		"eks:ListNodegroups",
+		"eks:ListAddons",
+		"eks:ListFargateProfiles",
+		"eks:ListAccessEntries",
+		"eks:ListPodIdentityAssociations",
+		"eks:ListIdentityProviderConfigs",
+	)
+	g.allow("EKSList", []string{anyResource}, "eks:ListClusters", "eks:DescribeClusterVersions", "eks:DescribeAddonVersions")
+
+	if g.cfg.SecretsEncryption != nil && g.cfg.SecretsEncryption.KeyARN != "" {
+		g.allow("EKSCluster", nil, "eks:AssociateEncryptionConfig")
+	}
+
+	if len(g.cfg.ManagedNodeGroups) > 0 {
+		g.allow("EKSNodegroups", []string{clusterResource("nodegroup", "/*/*")},
+			"eks:CreateNodegroup",
+			"eks:DeleteNodegroup",
+			"eks:DescribeNodegroup",
+			"eks:UpdateNodegroupConfig",
+			"eks:UpdateNodegroupVersion",
+			"eks:TagResource",
+		)
+	}
+
+	if len(g.cfg.Addons) > 0 || !g.cfg.AddonsConfig.DisableDefaultAddons {
+		g.allow("EKSAddons", []string{clusterResource("addon", "/*/*")},
+			"eks:CreateAddon",
+			"eks:DeleteAddon",
+			"eks:DescribeAddon",
+			"eks:UpdateAddon",
+			"eks:TagResource",
+		)
+		g.allow("EKSList", nil, "eks:DescribeAddonConfiguration")
+	}
+
+	if len(g.cfg.FargateProfiles) > 0 {
+		g.allow("EKSFargateProfiles", []string{clusterResource("fargateprofile", "/*/*")},
+			"eks:CreateFargateProfile",
+			"eks:DeleteFargateProfile",
+			"eks:DescribeFargateProfile",
+			"eks:TagResource",
+		)
+	}
+
+	if len(g.cfg.AccessConfig.AccessEntries) > 0 || g.hasNodeGroups() {
+		g.allow("EKSAccessEntries", []string{clusterResource("access-entry", "/*")},
+			"eks:CreateAccessEntry",
+			"eks:DeleteAccessEntry",
+			"eks:DescribeAccessEntry",
+			"eks:UpdateAccessEntry",
+			"eks:AssociateAccessPolicy",
+			"eks:DisassociateAccessPolicy",
+			"eks:ListAssociatedAccessPolicies",
+		)
+		g.allow("EKSAccessEntries", []string{g.arn("eks", "", "aws", "cluster-access-policy/*")})
+	}
+
+	if len(g.cfg.IAM.PodIdentityAssociations) > 0 || g.hasAddonPodIdentityAssociations() {
+		g.allow("EKSPodIdentityAssociations", []string{clusterResource("podidentityassociation", "/*")},
+			"eks:CreatePodIdentityAssociation",
+			"eks:DeletePodIdentityAssociation",
+			"eks:DescribePodIdentityAssociation",
+			"eks:UpdatePodIdentityAssociation",
+			"eks:TagResource",
+		)
+	}
+}
+
+func (g *generator) hasAddonPodIdentityAssociations() bool {
+	for _, addon := range g.cfg.Addons {
+		if addon.PodIdentityAssociations != nil && len(*addon.PodIdentityAssociations) > 0 || addon.UseDefaultPodIdentityAssociations {
+			return true
+		}
+	}
+	return g.cfg.AddonsConfig.AutoApplyPodIdentityAssociations
+}
+
+// addEC2 adds the EC2 permissions. EC2 resources created by eksctl are named by CloudFormation and cannot be scoped
+// by name.
+func (g *generator) addEC2() {
+	g.allow("EC2Describe", []string{anyResource},
+		"ec2:DescribeAvailabilityZones",
+		"ec2:DescribeSubnets",
+		"ec2:DescribeVpcs",
+		"ec2:DescribeSecurityGroups",
+		"ec2:DescribeRouteTables",
+		"ec2:DescribeInternetGateways",
+		"ec2:DescribeNatGateways",
+		"ec2:DescribeAddresses",
+		"ec2:DescribeNetworkInterfaces",
+		"ec2:DescribeTags",
+	)
+	g.allow("EC2SecurityGroups", []string{anyResource},
+		"ec2:CreateSecurityGroup",
+		"ec2:DeleteSecurityGroup",
+		"ec2:AuthorizeSecurityGroupIngress",
+		"ec2:AuthorizeSecurityGroupEgress",
+		"ec2:RevokeSecurityGroupIngress",
+		"ec2:RevokeSecurityGroupEgress",
+		"ec2:CreateTags",
+		"ec2:DeleteTags",
+	)
+
+	if g.cfg.VPC == nil || g.cfg.VPC.ID == "" {
+		g.allow("EC2Networking", []string{anyResource},
+			"ec2:CreateVpc",
+			"ec2:DeleteVpc",
+			"ec2:ModifyVpcAttribute",
+			"ec2:DescribeVpcAttribute",
+			"ec2:CreateSubnet",
+			"ec2:DeleteSubnet",
+			"ec2:ModifySubnetAttribute",
+			"ec2:CreateRouteTable",
+			"ec2:DeleteRouteTable",
+			"ec2:CreateRoute",
+			"ec2:DeleteRoute",
+			"ec2:AssociateRouteTable",
+			"ec2:DisassociateRouteTable",
+			"ec2:CreateInternetGateway",
+			"ec2:DeleteInternetGateway",
+			"ec2:AttachInternetGateway",
+			"ec2:DetachInternetGateway",
+			"ec2:CreateNatGateway",
+			"ec2:DeleteNatGateway",
+			"ec2:AllocateAddress",
+			"ec2:ReleaseAddress",
+			"ec2:DisassociateAddress",
+		)
+		if g.cfg.IPv6Enabled() {
+			g.allow("EC2Networking", nil,
+				"ec2:AssociateVpcCidrBlock",
+				"ec2:DisassociateVpcCidrBlock",
+				"ec2:AssociateSubnetCidrBlock",
+				"ec2:CreateEgressOnlyInternetGateway",
+				"ec2:DeleteEgressOnlyInternetGateway",
+			)
+		}
+	}
+
+	if g.cfg.IsFullyPrivate() && !g.cfg.PrivateCluster.SkipEndpointCreation {
+		g.allow("EC2VPCEndpoints", []string{anyResource},
+			"ec2:CreateVpcEndpoint",
+			"ec2:DeleteVpcEndpoints",
+			"ec2:DescribeVpcEndpoints",
+			"ec2:DescribeVpcEndpointServices",
+			"ec2:ModifyVpcEndpoint",
+		)
+	}
+
+	if g.hasNodeGroups() {
+		g.allow("EC2Instances", []string{anyResource},
+			"ec2:CreateLaunchTemplate",
+			"ec2:CreateLaunchTemplateVersion",
+			"ec2:DeleteLaunchTemplate",
+			"ec2:DescribeLaunchTemplates",
+			"ec2:DescribeLaunchTemplateVersions",
+			"ec2:DescribeImages",
+			"ec2:DescribeInstances",
+			"ec2:DescribeInstanceTypes",
+			"ec2:DescribeInstanceTypeOfferings",
+			"ec2:DescribeKeyPairs",
+			"ec2:RunInstances",
+		)
+	}
+	if g.importsSSHKeys() {
+		g.allow("EC2Instances", nil, "ec2:ImportKeyPair")
+	}
+}
+
+func (g *generator) importsSSHKeys() bool {
+	for _, ng := range g.cfg.AllNodeGroups() {
+		if ng.SSH != nil && api.IsEnabled(ng.SSH.Allow) && (ng.SSH.PublicKeyPath != nil || ng.SSH.PublicKey != nil) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) addIAM() {
+	g.allow("IAMRoles", []string{g.iamARN(fmt.Sprintf("role/%s*", g.stackPrefix))},
+		"iam:CreateRole",
+		"iam:DeleteRole",
+		"iam:GetRole",
+		"iam:TagRole",
+		"iam:UntagRole",
+		"iam:UpdateAssumeRolePolicy",
+		"iam:AttachRolePolicy",
+		"iam:DetachRolePolicy",
+		"iam:PutRolePolicy",
+		"iam:DeleteRolePolicy",
+		"iam:GetRolePolicy",
+		"iam:ListAttachedRolePolicies",
+		"iam:ListRolePolicies",
+		"iam:PutRolePermissionsBoundary",
+		"iam:PassRole",
+	)
+	g.allow("IAMServiceLinkedRoles", []string{g.iamARN("role/aws-service-role/*")}, "iam:CreateServiceLinkedRole", "iam:GetRole")
+
+	if len(g.cfg.NodeGroups) > 0 {
+		g.allow("IAMInstanceProfiles", []string{g.iamARN(fmt.Sprintf("instance-profile/%s*", g.stackPrefix))},
+			"iam:CreateInstanceProfile",
+			"iam:DeleteInstanceProfile",
+			"iam:GetInstanceProfile",
+			"iam:AddRoleToInstanceProfile",
+			"iam:RemoveRoleFromInstanceProfile",
+			"iam:TagInstanceProfile",
+		)
+	}
+
+	if api.IsEnabled(g.cfg.IAM.WithOIDC) || len(g.cfg.IAM.ServiceAccounts) > 0 {
+		g.allow("IAMOIDCProvider", []string{g.iamARN("oidc-provider/*")},
+			"iam:CreateOpenIDConnectProvider",
+			"iam:DeleteOpenIDConnectProvider",
+			"iam:GetOpenIDConnectProvider",
+			"iam:TagOpenIDConnectProvider",
+		)
+		g.allow("IAMOIDCProviderList", []string{anyResource}, "iam:ListOpenIDConnectProviders")
+	}
+
+	if passRoles := g.userRoleARNs(); len(passRoles) > 0 {
+		g.allow("IAMPassRole", passRoles, "iam:PassRole", "iam:GetRole")
+	}
+}
+
+// userRoleARNs returns the ARNs of the IAM roles created outside of eksctl that are passed to AWS services.
+func (g *generator) userRoleARNs() []string {
+	var roleARNs []string
+	add := func(roleARN string) {
+		if roleARN != "" {
+			roleARNs = append(roleARNs, roleARN)
+		}
+	}
+	add(aws.ToString(g.cfg.IAM.ServiceRoleARN))
+	add(aws.ToString(g.cfg.IAM.FargatePodExecutionRoleARN))
+	if g.cfg.IsAutoModeEnabled() && !g.cfg.AutoModeConfig.NodeRoleARN.IsZero() {
+		add(g.cfg.AutoModeConfig.NodeRoleARN.String())
+	}
+	for _, ng := range g.cfg.AllNodeGroups() {
+		if ng.IAM != nil {
+			add(ng.IAM.InstanceRoleARN)
+		}
+	}
+	for _, fp := range g.cfg.FargateProfiles {
+		add(fp.PodExecutionRoleARN)
+	}
+	for _, pia := range g.cfg.IAM.PodIdentityAssociations {
+		add(pia.RoleARN)
+	}
+	for _, addon := range g.cfg.Addons {
+		add(addon.ServiceAccountRoleARN)
+	}
+	sort.Strings(roleARNs)
+	return roleARNs
+}
+
+func (g *generator) addSSM() {
+	if !g.hasNodeGroups() && g.cfg.Karpenter == nil {
+		return
+	}
+	g.allow("SSMAMIParameters", []string{g.arn("ssm", g.region, "", "parameter/aws/service/*")},
+		"ssm:GetParameter",
+		"ssm:GetParameters",
+	)
+}
+
+func (g *generator) addAutoScaling() {
+	if len(g.cfg.NodeGroups) == 0 && len(g.cfg.ManagedNodeGroups) == 0 {
+		return
+	}
+	g.allow("AutoScalingDescribe", []string{anyResource},
+		"autoscaling:DescribeAutoScalingGroups",
+		"autoscaling:DescribeScalingActivities",
+		"autoscaling:DescribeLaunchConfigurations",
+	)
+	g.allow("AutoScalingGroups", []string{g.arn("autoscaling", g.region, g.accountID, fmt.Sprintf("autoScalingGroup:*:autoScalingGroupName/%s*", g.stackPrefix))},
+		"autoscaling:CreateAutoScalingGroup",
+		"autoscaling:DeleteAutoScalingGroup",
+		"autoscaling:UpdateAutoScalingGroup",
+		"autoscaling:SuspendProcesses",
+		"autoscaling:ResumeProcesses",
+		"autoscaling:CreateOrUpdateTags",
+	)
+}
+
+// addELB adds the permissions used to clean up load balancers left behind by Kubernetes services when the cluster is
+// deleted.
+func (g *generator) addELB() {
+	g.allow("ELBCleanup", []string{anyResource},
+		"elasticloadbalancing:DescribeLoadBalancers",
+		"elasticloadbalancing:DescribeTags",
+		"elasticloadbalancing:DeleteLoadBalancer",
+	)
+}
+
+func (g *generator) addKMS() {
+	if g.cfg.SecretsEncryption == nil || g.cfg.SecretsEncryption.KeyARN == "" {
+		return
+	}
+	g.allow("KMSSecretsEncryption", []string{g.cfg.SecretsEncryption.KeyARN},
+		"kms:CreateGrant",
+		"kms:DescribeKey",
+	)
+}
+
+func (g *generator) addLogs() {
+	if !g.cfg.HasClusterCloudWatchLogging() {
+		return
+	}
+	g.allow("CloudWatchLogs", []string{g.arn("logs", g.region, g.accountID, fmt.Sprintf("log-group:/aws/eks/%s/cluster:*", g.clusterName))},
+		"logs:CreateLogGroup",
+		"logs:DescribeLogGroups",
+		"logs:PutRetentionPolicy",
+	)
+}
+
+func (g *generator) addOutposts() {
+	var outpostARNs []string
+	if g.cfg.IsControlPlaneOnOutposts() {
+		outpostARNs = append(outpostARNs, g.cfg.Outpost.ControlPlaneOutpostARN)
+	}
+	if outpostARN, found := g.cfg.FindNodeGroupOutpostARN(); found {
+		outpostARNs = append(outpostARNs, outpostARN)
+	}
+	if len(outpostARNs) == 0 {
+		return
+	}
+	g.allow("Outposts", outpostARNs,
+		"outposts:GetOutpost",
+		"outposts:GetOutpostInstanceTypes",
+	)
+}
+
+func (g *generator) addKarpenter() {
+	if g.cfg.Karpenter == nil {
+		return
+	}
+	instanceProfileName := fmt.Sprintf("eksctl-%s-%s", builder.KarpenterNodeInstanceProfile, g.clusterName)
+	if g.cfg.Karpenter.DefaultInstanceProfile != nil {
+		instanceProfileName = *g.cfg.Karpenter.DefaultInstanceProfile
+	}
+	g.allow("IAMRoles", []string{g.iamARN(fmt.Sprintf("role/eksctl-%s-%s", builder.KarpenterNodeRoleName, g.clusterName))})
+	g.allow("IAMInstanceProfiles", []string{g.iamARN("instance-profile/" + instanceProfileName)},
+		"iam:CreateInstanceProfile",
+		"iam:DeleteInstanceProfile",
+		"iam:GetInstanceProfile",
+		"iam:AddRoleToInstanceProfile",
+		"iam:RemoveRoleFromInstanceProfile",
+		"iam:TagInstanceProfile",
+	)
+	g.allow("IAMKarpenterPolicy", []string{g.iamARN(fmt.Sprintf("policy/eksctl-%s-%s", builder.KarpenterManagedPolicy, g.clusterName))},
+		"iam:CreatePolicy",
+		"iam:DeletePolicy",
+		"iam:GetPolicy",
+		"iam:ListPolicyVersions",
+		"iam:CreatePolicyVersion",
+		"iam:DeletePolicyVersion",
+	)
+
+	if api.IsEnabled(g.cfg.Karpenter.WithSpotInterruptionQueue) {
+		g.allow("KarpenterInterruptionQueue", []string{g.arn("sqs", g.region, g.accountID, g.clusterName)},
+			"sqs:CreateQueue",
+			"sqs:DeleteQueue",
+			"sqs:GetQueueAttributes",
+			"sqs:SetQueueAttributes",
+			"sqs:TagQueue",
+		)
+		g.allow("KarpenterInterruptionRules", []string{g.arn("events", g.region, g.accountID, fmt.Sprintf("rule/%s*", g.stackPrefix))},
+			"events:PutRule",
+			"events:DeleteRule",
+			"events:DescribeRule",
+			"events:PutTargets",
+			"events:RemoveTargets",
+		)
+	}
+}
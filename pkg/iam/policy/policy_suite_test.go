@@ -0,0 +1,11 @@
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestPolicy(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
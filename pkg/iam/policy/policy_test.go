@@ -0,0 +1,77 @@
+package policy_test
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/iam/policy"
+)
+
+var _ = Describe("Generate", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "dev"
+		cfg.Metadata.Region = "us-west-2"
+	})
+
+	statement := func(doc *api.IAMPolicyDocument, sid string) *api.IAMStatement {
+		for _, s := range doc.Statements {
+			if s.Sid == sid {
+				return &s
+			}
+		}
+		return nil
+	}
+
+	It("scopes stacks, roles and the cluster to the cluster name", func() {
+		doc := policy.Generate(cfg, "111122223333")
+		Expect(doc.Version).To(Equal("2012-10-17"))
+
+		stacks := statement(doc, "CloudFormationStacks")
+		Expect(stacks).NotTo(BeNil())
+		Expect(stacks.Resource).To(ConsistOf("arn:aws:cloudformation:us-west-2:111122223333:stack/eksctl-dev-*/*"))
+		Expect(stacks.Action).To(ContainElements("cloudformation:CreateStack", "cloudformation:DeleteStack"))
+
+		Expect(statement(doc, "EKSCluster").Resource).To(ConsistOf("arn:aws:eks:us-west-2:111122223333:cluster/dev"))
+		Expect(statement(doc, "IAMRoles").Resource).To(ConsistOf("arn:aws:iam::111122223333:role/eksctl-dev-*"))
+		Expect(statement(doc, "EC2Networking")).NotTo(BeNil())
+		for _, sid := range []string{"EKSNodegroups", "AutoScalingGroups", "KMSSecretsEncryption", "EC2VPCEndpoints", "Outposts", "IAMOIDCProvider", "IAMKarpenterPolicy"} {
+			Expect(statement(doc, sid)).To(BeNil(), sid)
+		}
+	})
+
+	It("adds permissions for the features the config enables", func() {
+		cfg.Metadata.Region = "cn-north-1"
+		cfg.VPC.ID = "vpc-1234"
+		cfg.PrivateCluster = &api.PrivateCluster{Enabled: true}
+		cfg.IAM.WithOIDC = aws.Bool(true)
+		cfg.SecretsEncryption = &api.SecretsEncryption{KeyARN: "arn:aws-cn:kms:cn-north-1:111122223333:key/abc"}
+		cfg.Outpost = &api.Outpost{ControlPlaneOutpostARN: "arn:aws-cn:outposts:cn-north-1:111122223333:outpost/op-1"}
+		cfg.Karpenter = &api.Karpenter{Version: "v0.37.0", WithSpotInterruptionQueue: aws.Bool(true)}
+		ng := api.NewManagedNodeGroup()
+		ng.Name = "ng-1"
+		ng.IAM.InstanceRoleARN = "arn:aws-cn:iam::111122223333:role/node-role"
+		cfg.ManagedNodeGroups = []*api.ManagedNodeGroup{ng}
+
+		doc := policy.Generate(cfg, "*")
+		Expect(statement(doc, "EC2Networking")).To(BeNil())
+		Expect(statement(doc, "EC2VPCEndpoints")).NotTo(BeNil())
+		Expect(statement(doc, "EKSCluster").Action).To(ContainElement("eks:AssociateEncryptionConfig"))
+		Expect(statement(doc, "EKSNodegroups").Resource).To(ConsistOf("arn:aws-cn:eks:cn-north-1:*:nodegroup/dev/*/*"))
+		Expect(statement(doc, "KMSSecretsEncryption").Resource).To(ConsistOf(cfg.SecretsEncryption.KeyARN))
+		Expect(statement(doc, "Outposts").Resource).To(ConsistOf(cfg.Outpost.ControlPlaneOutpostARN))
+		Expect(statement(doc, "IAMOIDCProvider")).NotTo(BeNil())
+		Expect(statement(doc, "IAMPassRole").Resource).To(ConsistOf(ng.IAM.InstanceRoleARN))
+		Expect(statement(doc, "IAMRoles").Resource).To(ConsistOf(
+			"arn:aws-cn:iam::*:role/eksctl-dev-*",
+			"arn:aws-cn:iam::*:role/eksctl-KarpenterNodeRole-dev",
+		))
+		Expect(statement(doc, "IAMKarpenterPolicy").Resource).To(ConsistOf("arn:aws-cn:iam::*:policy/eksctl-KarpenterControllerPolicy-dev"))
+		Expect(statement(doc, "KarpenterInterruptionQueue").Resource).To(ConsistOf("arn:aws-cn:sqs:cn-north-1:*:dev"))
+		Expect(statement(doc, "SSMAMIParameters").Resource).To(ConsistOf("arn:aws-cn:ssm:cn-north-1::parameter/aws/service/*"))
+	})
+})
@@ -4,11 +4,24 @@ package fakes
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/weaveworks/eksctl/pkg/karpenter"
 )
 
 type FakeChartInstaller struct {
+	ApplyNodePoolsStub        func(context.Context, string) error
+	applyNodePoolsMutex       sync.RWMutex
+	applyNodePoolsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	applyNodePoolsReturns struct {
+		result1 error
+	}
+	applyNodePoolsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InstallStub        func(context.Context, string, string) error
 	installMutex       sync.RWMutex
 	installArgsForCall []struct {
@@ -22,10 +35,98 @@ type FakeChartInstaller struct {
 	installReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UninstallStub        func(context.Context, string, time.Duration) error
+	uninstallMutex       sync.RWMutex
+	uninstallArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}
+	uninstallReturns struct {
+		result1 error
+	}
+	uninstallReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpgradeStub        func(context.Context, string, string) error
+	upgradeMutex       sync.RWMutex
+	upgradeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	upgradeReturns struct {
+		result1 error
+	}
+	upgradeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeChartInstaller) ApplyNodePools(arg1 context.Context, arg2 string) error {
+	fake.applyNodePoolsMutex.Lock()
+	ret, specificReturn := fake.applyNodePoolsReturnsOnCall[len(fake.applyNodePoolsArgsForCall)]
+	fake.applyNodePoolsArgsForCall = append(fake.applyNodePoolsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ApplyNodePoolsStub
+	fakeReturns := fake.applyNodePoolsReturns
+	fake.recordInvocation("ApplyNodePools", []interface{}{arg1, arg2})
+	fake.applyNodePoolsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeChartInstaller) ApplyNodePoolsCallCount() int {
+	fake.applyNodePoolsMutex.RLock()
+	defer fake.applyNodePoolsMutex.RUnlock()
+	return len(fake.applyNodePoolsArgsForCall)
+}
+
+func (fake *FakeChartInstaller) ApplyNodePoolsCalls(stub func(context.Context, string) error) {
+	fake.applyNodePoolsMutex.Lock()
+	defer fake.applyNodePoolsMutex.Unlock()
+	fake.ApplyNodePoolsStub = stub
+}
+
+func (fake *FakeChartInstaller) ApplyNodePoolsArgsForCall(i int) (context.Context, string) {
+	fake.applyNodePoolsMutex.RLock()
+	defer fake.applyNodePoolsMutex.RUnlock()
+	argsForCall := fake.applyNodePoolsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeChartInstaller) ApplyNodePoolsReturns(result1 error) {
+	fake.applyNodePoolsMutex.Lock()
+	defer fake.applyNodePoolsMutex.Unlock()
+	fake.ApplyNodePoolsStub = nil
+	fake.applyNodePoolsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeChartInstaller) ApplyNodePoolsReturnsOnCall(i int, result1 error) {
+	fake.applyNodePoolsMutex.Lock()
+	defer fake.applyNodePoolsMutex.Unlock()
+	fake.ApplyNodePoolsStub = nil
+	if fake.applyNodePoolsReturnsOnCall == nil {
+		fake.applyNodePoolsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.applyNodePoolsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeChartInstaller) Install(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.installMutex.Lock()
 	ret, specificReturn := fake.installReturnsOnCall[len(fake.installArgsForCall)]
@@ -48,6 +149,8 @@ func (fake *FakeChartInstaller) Install(arg1 context.Context, arg2 string, arg3
 }
 
 func (fake *FakeChartInstaller) InstallCallCount() int {
+	fake.applyNodePoolsMutex.RLock()
+	defer fake.applyNodePoolsMutex.RUnlock()
 	fake.installMutex.RLock()
 	defer fake.installMutex.RUnlock()
 	return len(fake.installArgsForCall)
@@ -89,11 +192,143 @@ func (fake *FakeChartInstaller) InstallReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeChartInstaller) Uninstall(arg1 context.Context, arg2 string, arg3 time.Duration) error {
+	fake.uninstallMutex.Lock()
+	ret, specificReturn := fake.uninstallReturnsOnCall[len(fake.uninstallArgsForCall)]
+	fake.uninstallArgsForCall = append(fake.uninstallArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.UninstallStub
+	fakeReturns := fake.uninstallReturns
+	fake.recordInvocation("Uninstall", []interface{}{arg1, arg2, arg3})
+	fake.uninstallMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeChartInstaller) UninstallCallCount() int {
+	fake.uninstallMutex.RLock()
+	defer fake.uninstallMutex.RUnlock()
+	return len(fake.uninstallArgsForCall)
+}
+
+func (fake *FakeChartInstaller) UninstallCalls(stub func(context.Context, string, time.Duration) error) {
+	fake.uninstallMutex.Lock()
+	defer fake.uninstallMutex.Unlock()
+	fake.UninstallStub = stub
+}
+
+func (fake *FakeChartInstaller) UninstallArgsForCall(i int) (context.Context, string, time.Duration) {
+	fake.uninstallMutex.RLock()
+	defer fake.uninstallMutex.RUnlock()
+	argsForCall := fake.uninstallArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeChartInstaller) UninstallReturns(result1 error) {
+	fake.uninstallMutex.Lock()
+	defer fake.uninstallMutex.Unlock()
+	fake.UninstallStub = nil
+	fake.uninstallReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeChartInstaller) UninstallReturnsOnCall(i int, result1 error) {
+	fake.uninstallMutex.Lock()
+	defer fake.uninstallMutex.Unlock()
+	fake.UninstallStub = nil
+	if fake.uninstallReturnsOnCall == nil {
+		fake.uninstallReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uninstallReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeChartInstaller) Upgrade(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.upgradeMutex.Lock()
+	ret, specificReturn := fake.upgradeReturnsOnCall[len(fake.upgradeArgsForCall)]
+	fake.upgradeArgsForCall = append(fake.upgradeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.UpgradeStub
+	fakeReturns := fake.upgradeReturns
+	fake.recordInvocation("Upgrade", []interface{}{arg1, arg2, arg3})
+	fake.upgradeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeChartInstaller) UpgradeCallCount() int {
+	fake.upgradeMutex.RLock()
+	defer fake.upgradeMutex.RUnlock()
+	return len(fake.upgradeArgsForCall)
+}
+
+func (fake *FakeChartInstaller) UpgradeCalls(stub func(context.Context, string, string) error) {
+	fake.upgradeMutex.Lock()
+	defer fake.upgradeMutex.Unlock()
+	fake.UpgradeStub = stub
+}
+
+func (fake *FakeChartInstaller) UpgradeArgsForCall(i int) (context.Context, string, string) {
+	fake.upgradeMutex.RLock()
+	defer fake.upgradeMutex.RUnlock()
+	argsForCall := fake.upgradeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeChartInstaller) UpgradeReturns(result1 error) {
+	fake.upgradeMutex.Lock()
+	defer fake.upgradeMutex.Unlock()
+	fake.UpgradeStub = nil
+	fake.upgradeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeChartInstaller) UpgradeReturnsOnCall(i int, result1 error) {
+	fake.upgradeMutex.Lock()
+	defer fake.upgradeMutex.Unlock()
+	fake.UpgradeStub = nil
+	if fake.upgradeReturnsOnCall == nil {
+		fake.upgradeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.upgradeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeChartInstaller) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.applyNodePoolsMutex.RLock()
+	defer fake.applyNodePoolsMutex.RUnlock()
 	fake.installMutex.RLock()
 	defer fake.installMutex.RUnlock()
+	fake.uninstallMutex.RLock()
+	defer fake.uninstallMutex.RUnlock()
+	fake.upgradeMutex.RLock()
+	defer fake.upgradeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,9 +3,11 @@ package karpenter
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kris-nova/logger"
 	"helm.sh/helm/v3/pkg/registry"
+	"k8s.io/client-go/dynamic"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/karpenter/providers"
@@ -37,14 +39,19 @@ type Options struct {
 	HelmInstaller providers.HelmInstaller
 	Namespace     string
 	ClusterConfig *api.ClusterConfig
+	// DynamicClient is used to apply karpenter.nodePools, it is only required if any are defined
+	DynamicClient dynamic.Interface
 }
 
-// ChartInstaller defines a functionality to install Karpenter.
+// ChartInstaller defines a functionality to install, upgrade and uninstall Karpenter.
 //
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 //counterfeiter:generate -o fakes/fake_chart_installer.go . ChartInstaller
 type ChartInstaller interface {
 	Install(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error
+	Upgrade(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error
+	Uninstall(ctx context.Context, installedVersion string, timeout time.Duration) error
+	ApplyNodePools(ctx context.Context, instanceProfileName string) error
 }
 
 // Installer implements the Karpenter installer functionality.
@@ -59,11 +66,67 @@ func NewKarpenterInstaller(opts Options) *Installer {
 	}
 }
 
-// Install adds Karpenter to a configured cluster in a separate CloudFormation stack.
+// Install adds Karpenter to a configured cluster in a separate CloudFormation stack,
+// followed by the NodePools and EC2NodeClasses defined in karpenter.nodePools.
 func (k *Installer) Install(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error {
 	logger.Info("adding Karpenter to cluster %s", k.ClusterConfig.Metadata.Name)
 	logger.Debug("cluster endpoint used by Karpenter: %s", k.ClusterConfig.Status.Endpoint)
 
+	options, err := k.chartOptions(serviceAccountRoleARN, instanceProfileName)
+	if err != nil {
+		return err
+	}
+	logger.Debug("the following chartOptions will be applied to the install: %+v", options)
+
+	if err := k.HelmInstaller.InstallChart(ctx, options); err != nil {
+		return fmt.Errorf("failed to install Karpenter chart: %w", err)
+	}
+
+	if len(k.ClusterConfig.Karpenter.NodePools) > 0 {
+		return k.ApplyNodePools(ctx, instanceProfileName)
+	}
+	return nil
+}
+
+// Upgrade upgrades Karpenter to the version in the cluster config along with its CRDs,
+// then applies the NodePools and EC2NodeClasses defined in karpenter.nodePools.
+func (k *Installer) Upgrade(ctx context.Context, serviceAccountRoleARN string, instanceProfileName string) error {
+	logger.Info("upgrading Karpenter on cluster %s to version %s", k.ClusterConfig.Metadata.Name, k.ClusterConfig.Karpenter.Version)
+
+	options, err := k.chartOptions(serviceAccountRoleARN, instanceProfileName)
+	if err != nil {
+		return err
+	}
+	logger.Debug("the following chartOptions will be applied to the upgrade: %+v", options)
+
+	if err := k.HelmInstaller.UpgradeChart(ctx, options); err != nil {
+		return fmt.Errorf("failed to upgrade Karpenter chart: %w", err)
+	}
+
+	if len(k.ClusterConfig.Karpenter.NodePools) > 0 {
+		return k.ApplyNodePools(ctx, instanceProfileName)
+	}
+	return nil
+}
+
+// Uninstall deletes all NodePools and waits for Karpenter to drain and terminate their nodes, then deletes
+// the node classes and uninstalls the Karpenter chart. installedVersion determines the Karpenter APIs used.
+func (k *Installer) Uninstall(ctx context.Context, installedVersion string, timeout time.Duration) error {
+	logger.Info("removing Karpenter from cluster %s", k.ClusterConfig.Metadata.Name)
+
+	if err := k.deleteNodePools(ctx, installedVersion, timeout); err != nil {
+		return err
+	}
+	if err := k.HelmInstaller.UninstallChart(ctx, releaseName); err != nil {
+		return fmt.Errorf("failed to uninstall Karpenter chart: %w", err)
+	}
+	return nil
+}
+
+// chartOptions returns the options to install or upgrade the Karpenter chart. The values depend on the
+// Karpenter version, as the settings of the chart were moved out of settings.aws in v0.33.0, and the
+// default instance profile was replaced by the instance profile of EC2NodeClasses in v1.0.0.
+func (k *Installer) chartOptions(serviceAccountRoleARN string, instanceProfileName string) (providers.InstallChartOpts, error) {
 	serviceAccountMap := map[string]interface{}{
 		create: api.IsEnabled(k.ClusterConfig.Karpenter.CreateServiceAccount),
 		serviceAccountAnnotation: map[string]interface{}{
@@ -88,21 +151,24 @@ func (k *Installer) Install(ctx context.Context, serviceAccountRoleARN string, i
 	}
 
 	version := k.ClusterConfig.Karpenter.Version
-	compareVersions, err := utils.CompareVersions(version, "0.33.0")
-	if err == nil && compareVersions < 0 {
+	if compareVersions, err := utils.CompareVersions(version, "0.33.0"); err == nil && compareVersions < 0 {
 		values[settings] = map[string]interface{}{
 			aws: values[settings],
 		}
 	}
+	if compareVersions, err := utils.CompareVersions(version, "1.0.0"); err == nil && compareVersions >= 0 {
+		delete(values, aws)
+		delete(values[settings].(map[string]interface{}), defaultInstanceProfile)
+	}
 
 	registryClient, err := registry.NewClient(
 		registry.ClientOptEnableCache(true),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create registry client: %w", err)
+		return providers.InstallChartOpts{}, fmt.Errorf("failed to create registry client: %w", err)
 	}
 
-	options := providers.InstallChartOpts{
+	return providers.InstallChartOpts{
 		ChartName:       helmChartName,
 		CreateNamespace: true,
 		Namespace:       DefaultNamespace,
@@ -110,12 +176,5 @@ func (k *Installer) Install(ctx context.Context, serviceAccountRoleARN string, i
 		Values:          values,
 		Version:         version,
 		RegistryClient:  registryClient,
-	}
-
-	logger.Debug("the following chartOptions will be applied to the install: %+v", options)
-
-	if err := k.HelmInstaller.InstallChart(ctx, options); err != nil {
-		return fmt.Errorf("failed to install Karpenter chart: %w", err)
-	}
-	return nil
+	}, nil
 }
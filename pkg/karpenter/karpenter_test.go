@@ -139,4 +139,61 @@ var _ = Describe("Install", func() {
 			})
 		})
 	})
+
+	Context("Upgrade", func() {
+
+		var (
+			fakeHelmInstaller  *fakes.FakeHelmInstaller
+			installerUnderTest *Installer
+			cfg                *api.ClusterConfig
+		)
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.Metadata.Name = "test-cluster"
+			cfg.Karpenter = &api.Karpenter{
+				Version:              "1.2.1",
+				CreateServiceAccount: api.Enabled(),
+			}
+			cfg.Status = &api.ClusterStatus{
+				Endpoint: "https://endpoint.com",
+			}
+			fakeHelmInstaller = &fakes.FakeHelmInstaller{}
+			installerUnderTest = &Installer{
+				Options: Options{
+					HelmInstaller: fakeHelmInstaller,
+					Namespace:     "karpenter",
+					ClusterConfig: cfg,
+				},
+			}
+		})
+
+		It("upgrades the chart without the values removed in v1.0.0", func() {
+			Expect(installerUnderTest.Upgrade(context.Background(), "role-arn", "role/profile")).To(Succeed())
+			Expect(fakeHelmInstaller.UpgradeChartCallCount()).To(Equal(1))
+			Expect(fakeHelmInstaller.InstallChartCallCount()).To(BeZero())
+			_, opts := fakeHelmInstaller.UpgradeChartArgsForCall(0)
+			Expect(opts.ReleaseName).To(Equal("karpenter"))
+			Expect(opts.Version).To(Equal("1.2.1"))
+			Expect(opts.Values).NotTo(HaveKey(aws))
+			Expect(opts.Values[settings]).To(Equal(map[string]interface{}{
+				clusterName:           cfg.Metadata.Name,
+				clusterEndpoint:       cfg.Status.Endpoint,
+				interruptionQueueName: cfg.Metadata.Name,
+			}))
+			Expect(opts.Values[serviceAccount]).To(HaveKeyWithValue(create, true))
+		})
+
+		When("upgrade chart fails", func() {
+
+			BeforeEach(func() {
+				fakeHelmInstaller.UpgradeChartReturns(errors.New("nope"))
+			})
+
+			It("errors", func() {
+				Expect(installerUnderTest.Upgrade(context.Background(), "role-arn", "role/profile")).
+					To(MatchError("failed to upgrade Karpenter chart: nope"))
+			})
+		})
+	})
 })
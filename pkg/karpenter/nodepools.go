@@ -0,0 +1,374 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kris-nova/logger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+const (
+	// DiscoveryTag is the tag Karpenter uses to discover the subnets and security groups of a cluster.
+	// When it is set in metadata.tags, EC2NodeClasses select resources by this tag instead of by the cluster VPC
+	DiscoveryTag = "karpenter.sh/discovery"
+
+	// eksClusterNameTag is set by EKS on the cluster security group
+	eksClusterNameTag = "aws:eks:cluster-name"
+	defaultAMIAlias   = "al2023@latest"
+	fieldManager      = "eksctl"
+
+	nodePoolKind     = "NodePool"
+	ec2NodeClassKind = "EC2NodeClass"
+)
+
+var (
+	nodePoolGVR     = schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1", Resource: "nodepools"}
+	ec2NodeClassGVR = schema.GroupVersionResource{Group: "karpenter.k8s.aws", Version: "v1", Resource: "ec2nodeclasses"}
+	nodeGVR         = schema.GroupVersionResource{Version: "v1", Resource: "nodes"}
+
+	// pollInterval is the interval at which the deletion of nodes and node classes is checked
+	pollInterval = 10 * time.Second
+)
+
+// nodePoolsAPI describes the resources used by a Karpenter version to provision nodes.
+type nodePoolsAPI struct {
+	nodePools   schema.GroupVersionResource
+	nodeClasses schema.GroupVersionResource
+	// nodeLabel is the label of the nodes launched for a NodePool
+	nodeLabel string
+}
+
+// nodePoolsAPIForVersion returns the NodePool API of a Karpenter version. NodePools and EC2NodeClasses replaced
+// Provisioners and AWSNodeTemplates in v0.32.0, and were promoted to v1 in v1.0.0.
+func nodePoolsAPIForVersion(version string) nodePoolsAPI {
+	if c, err := utils.CompareVersions(version, "1.0.0"); err != nil || c >= 0 {
+		return nodePoolsAPI{
+			nodePools:   nodePoolGVR,
+			nodeClasses: ec2NodeClassGVR,
+			nodeLabel:   "karpenter.sh/nodepool",
+		}
+	}
+	if c, _ := utils.CompareVersions(version, "0.32.0"); c >= 0 {
+		return nodePoolsAPI{
+			nodePools:   schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1beta1", Resource: "nodepools"},
+			nodeClasses: schema.GroupVersionResource{Group: "karpenter.k8s.aws", Version: "v1beta1", Resource: "ec2nodeclasses"},
+			nodeLabel:   "karpenter.sh/nodepool",
+		}
+	}
+	return nodePoolsAPI{
+		nodePools:   schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1alpha5", Resource: "provisioners"},
+		nodeClasses: schema.GroupVersionResource{Group: "karpenter.k8s.aws", Version: "v1alpha1", Resource: "awsnodetemplates"},
+		nodeLabel:   "karpenter.sh/provisioner-name",
+	}
+}
+
+// deleteNodePools deletes all NodePools and waits for Karpenter to drain and terminate their nodes, before
+// deleting all node classes. Karpenter must still be running for nodes and node classes to be deleted.
+func (k *Installer) deleteNodePools(ctx context.Context, installedVersion string, timeout time.Duration) error {
+	resources := nodePoolsAPIForVersion(installedVersion)
+	if err := k.deleteAll(ctx, resources.nodePools); err != nil {
+		return err
+	}
+
+	logger.Info("waiting for Karpenter to drain and terminate its nodes")
+	if err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		nodes, err := k.DynamicClient.Resource(nodeGVR).List(ctx, metav1.ListOptions{LabelSelector: resources.nodeLabel})
+		if err != nil {
+			return false, fmt.Errorf("failed to list nodes: %w", err)
+		}
+		logger.Debug("%d node(s) launched by Karpenter remaining", len(nodes.Items))
+		return len(nodes.Items) == 0, nil
+	}); err != nil {
+		return fmt.Errorf("waiting for nodes launched by Karpenter to be deleted: %w", err)
+	}
+
+	if err := k.deleteAll(ctx, resources.nodeClasses); err != nil {
+		return err
+	}
+	if err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		list, err := k.DynamicClient.Resource(resources.nodeClasses).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("failed to list %s: %w", resources.nodeClasses.Resource, err)
+		}
+		return len(list.Items) == 0, nil
+	}); err != nil {
+		return fmt.Errorf("waiting for %s to be deleted: %w", resources.nodeClasses.Resource, err)
+	}
+	return nil
+}
+
+// deleteAll deletes all objects of a cluster-scoped resource, if the resource is served.
+func (k *Installer) deleteAll(ctx context.Context, gvr schema.GroupVersionResource) error {
+	list, err := k.DynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("%s are not served, skipping deletion", gvr.String())
+			return nil
+		}
+		return fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+	}
+	for _, item := range list.Items {
+		logger.Info("deleting Karpenter %s %q", item.GetKind(), item.GetName())
+		if err := k.DynamicClient.Resource(gvr).Delete(ctx, item.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %q: %w", item.GetKind(), item.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// ApplyNodePools creates or updates the NodePools and EC2NodeClasses defined in karpenter.nodePools.
+func (k *Installer) ApplyNodePools(ctx context.Context, instanceProfileName string) error {
+	objects, err := makeNodePoolObjects(k.ClusterConfig, instanceProfileName)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		gvr := nodePoolGVR
+		if obj.GetKind() == ec2NodeClassKind {
+			gvr = ec2NodeClassGVR
+		}
+		if _, err := k.DynamicClient.Resource(gvr).Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{
+			FieldManager: fieldManager,
+			Force:        true,
+		}); err != nil {
+			return fmt.Errorf("failed to apply %s %q: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		logger.Info("applied Karpenter %s %q", obj.GetKind(), obj.GetName())
+	}
+	return nil
+}
+
+// makeNodePoolObjects renders an EC2NodeClass followed by a NodePool for each of karpenter.nodePools.
+func makeNodePoolObjects(clusterConfig *api.ClusterConfig, instanceProfileName string) ([]*unstructured.Unstructured, error) {
+	var defaultSubnetSelectorTerms []interface{}
+	securityGroupSelectorTerms := makeSecurityGroupSelectorTerms(clusterConfig)
+
+	var objects []*unstructured.Unstructured
+	for _, np := range clusterConfig.Karpenter.NodePools {
+		subnetSelectorTerms := makeSubnetIDSelectorTerms(np.Subnets)
+		if len(subnetSelectorTerms) == 0 {
+			if defaultSubnetSelectorTerms == nil {
+				var err error
+				if defaultSubnetSelectorTerms, err = makeSubnetSelectorTerms(clusterConfig); err != nil {
+					return nil, err
+				}
+			}
+			subnetSelectorTerms = defaultSubnetSelectorTerms
+		}
+		nodeClass := newObject(ec2NodeClassGVR, ec2NodeClassKind, np.Name)
+		nodeClass.Object["spec"] = map[string]interface{}{
+			"instanceProfile":            instanceProfileName,
+			"amiSelectorTerms":           makeAMISelectorTerms(np.AMISelectorTerms),
+			"subnetSelectorTerms":        subnetSelectorTerms,
+			"securityGroupSelectorTerms": securityGroupSelectorTerms,
+		}
+		objects = append(objects, nodeClass, makeNodePool(np))
+	}
+	return objects, nil
+}
+
+func makeNodePool(np api.KarpenterNodePool) *unstructured.Unstructured {
+	requirements := []interface{}{}
+	for _, r := range np.Requirements {
+		requirement := map[string]interface{}{
+			"key":      r.Key,
+			"operator": r.Operator,
+		}
+		if len(r.Values) > 0 {
+			requirement["values"] = toInterfaceSlice(r.Values)
+		}
+		requirements = append(requirements, requirement)
+	}
+	templateSpec := map[string]interface{}{
+		"requirements": requirements,
+		"nodeClassRef": map[string]interface{}{
+			"group": ec2NodeClassGVR.Group,
+			"kind":  ec2NodeClassKind,
+			"name":  np.Name,
+		},
+	}
+	if len(np.Taints) > 0 {
+		taints := []interface{}{}
+		for _, t := range np.Taints {
+			taint := map[string]interface{}{
+				"key":    t.Key,
+				"effect": string(t.Effect),
+			}
+			if t.Value != "" {
+				taint["value"] = t.Value
+			}
+			taints = append(taints, taint)
+		}
+		templateSpec["taints"] = taints
+	}
+	template := map[string]interface{}{
+		"spec": templateSpec,
+	}
+	if len(np.Labels) > 0 {
+		template["metadata"] = map[string]interface{}{
+			"labels": toInterfaceMap(np.Labels),
+		}
+	}
+	spec := map[string]interface{}{
+		"template": template,
+	}
+
+	if len(np.Limits) > 0 {
+		limits := map[string]interface{}{}
+		for name, value := range np.Limits {
+			limits[name] = value
+		}
+		spec["limits"] = limits
+	}
+
+	if d := np.Disruption; d != nil {
+		// expireAfter is part of the node template in the v1 API
+		if d.ExpireAfter != "" {
+			templateSpec["expireAfter"] = d.ExpireAfter
+		}
+		disruption := map[string]interface{}{}
+		if d.ConsolidationPolicy != "" {
+			disruption["consolidationPolicy"] = d.ConsolidationPolicy
+		}
+		if d.ConsolidateAfter != "" {
+			disruption["consolidateAfter"] = d.ConsolidateAfter
+		}
+		if len(d.Budgets) > 0 {
+			budgets := []interface{}{}
+			for _, b := range d.Budgets {
+				budget := map[string]interface{}{
+					"nodes": b.Nodes,
+				}
+				if b.Schedule != "" {
+					budget["schedule"] = b.Schedule
+					budget["duration"] = b.Duration
+				}
+				budgets = append(budgets, budget)
+			}
+			disruption["budgets"] = budgets
+		}
+		if len(disruption) > 0 {
+			spec["disruption"] = disruption
+		}
+	}
+
+	nodePool := newObject(nodePoolGVR, nodePoolKind, np.Name)
+	nodePool.Object["spec"] = spec
+	return nodePool
+}
+
+func makeAMISelectorTerms(terms []api.KarpenterAMISelectorTerm) []interface{} {
+	if len(terms) == 0 {
+		return []interface{}{
+			map[string]interface{}{"alias": defaultAMIAlias},
+		}
+	}
+	var selectorTerms []interface{}
+	for _, t := range terms {
+		term := map[string]interface{}{}
+		for field, value := range map[string]string{
+			"alias": t.Alias,
+			"id":    t.ID,
+			"name":  t.Name,
+			"owner": t.Owner,
+		} {
+			if value != "" {
+				term[field] = value
+			}
+		}
+		if len(t.Tags) > 0 {
+			term["tags"] = toInterfaceMap(t.Tags)
+		}
+		selectorTerms = append(selectorTerms, term)
+	}
+	return selectorTerms
+}
+
+// makeSubnetSelectorTerms selects the subnets tagged with the discovery tag, or else the private subnets
+// of the cluster VPC, falling back to its public subnets for clusters without private subnets.
+func makeSubnetSelectorTerms(clusterConfig *api.ClusterConfig) ([]interface{}, error) {
+	if value, ok := clusterConfig.Metadata.Tags[DiscoveryTag]; ok {
+		return []interface{}{
+			map[string]interface{}{"tags": map[string]interface{}{DiscoveryTag: value}},
+		}, nil
+	}
+
+	subnets := clusterConfig.VPC.Subnets.Private
+	if len(subnets) == 0 {
+		subnets = clusterConfig.VPC.Subnets.Public
+	}
+	var ids []string
+	for name, subnet := range subnets {
+		if subnet.ID == "" {
+			return nil, fmt.Errorf("ID of subnet %q is unknown", name)
+		}
+		ids = append(ids, subnet.ID)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no subnets found for Karpenter nodes, set the %q tag in metadata.tags to select subnets by tag", DiscoveryTag)
+	}
+	sort.Strings(ids)
+	return makeSubnetIDSelectorTerms(ids), nil
+}
+
+func makeSubnetIDSelectorTerms(ids []string) []interface{} {
+	var terms []interface{}
+	for _, id := range ids {
+		terms = append(terms, map[string]interface{}{"id": id})
+	}
+	return terms
+}
+
+// makeSecurityGroupSelectorTerms selects the security groups tagged with the discovery tag, or else the
+// cluster security group created by EKS and the security group shared by all nodes, if any.
+func makeSecurityGroupSelectorTerms(clusterConfig *api.ClusterConfig) []interface{} {
+	if value, ok := clusterConfig.Metadata.Tags[DiscoveryTag]; ok {
+		return []interface{}{
+			map[string]interface{}{"tags": map[string]interface{}{DiscoveryTag: value}},
+		}
+	}
+	terms := []interface{}{
+		map[string]interface{}{"tags": map[string]interface{}{eksClusterNameTag: clusterConfig.Metadata.Name}},
+	}
+	if sg := clusterConfig.VPC.SharedNodeSecurityGroup; sg != "" {
+		terms = append(terms, map[string]interface{}{"id": sg})
+	}
+	return terms
+}
+
+func newObject(gvr schema.GroupVersionResource, kind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAPIVersion(gvr.GroupVersion().String())
+	obj.SetKind(kind)
+	obj.SetName(name)
+	return obj
+}
+
+func toInterfaceSlice(values []string) []interface{} {
+	var out []interface{}
+	for _, v := range values {
+		out = append(out, v)
+	}
+	return out
+}
+
+func toInterfaceMap(values map[string]string) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range values {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,308 @@
+package karpenter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	core "k8s.io/client-go/testing"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/karpenter/providers/fakes"
+)
+
+var _ = Describe("NodePools", func() {
+	var (
+		cfg                *api.ClusterConfig
+		fakeDynamicClient  *dynamicfake.FakeDynamicClient
+		installerUnderTest *Installer
+		applied            []map[string]interface{}
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Status = &api.ClusterStatus{
+			Endpoint: "https://endpoint.com",
+		}
+		cfg.VPC.SharedNodeSecurityGroup = "sg-shared"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Private: api.AZSubnetMapping{
+				"us-west-2b": api.AZSubnetSpec{ID: "subnet-private-b"},
+				"us-west-2a": api.AZSubnetSpec{ID: "subnet-private-a"},
+			},
+			Public: api.AZSubnetMapping{
+				"us-west-2a": api.AZSubnetSpec{ID: "subnet-public-a"},
+			},
+		}
+		cfg.Karpenter = &api.Karpenter{
+			Version: "1.2.1",
+			NodePools: []api.KarpenterNodePool{
+				{
+					Name: "default",
+					Requirements: []api.KarpenterRequirement{
+						{Key: "karpenter.sh/capacity-type", Operator: "In", Values: []string{"spot"}},
+						{Key: "karpenter.k8s.aws/instance-generation", Operator: "Gt", Values: []string{"2"}},
+					},
+					Limits: map[string]string{"cpu": "100"},
+					Disruption: &api.KarpenterDisruption{
+						ConsolidationPolicy: "WhenEmptyOrUnderutilized",
+						ConsolidateAfter:    "1m",
+						ExpireAfter:         "720h",
+						Budgets:             []api.KarpenterDisruptionBudget{{Nodes: "10%"}},
+					},
+				},
+			},
+		}
+
+		applied = nil
+		fakeDynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+		fakeDynamicClient.PrependReactor("patch", "*", func(action core.Action) (bool, runtime.Object, error) {
+			patchAction := action.(core.PatchAction)
+			Expect(patchAction.GetPatchType()).To(Equal(types.ApplyPatchType))
+			obj := map[string]interface{}{}
+			Expect(json.Unmarshal(patchAction.GetPatch(), &obj)).To(Succeed())
+			applied = append(applied, obj)
+			return true, &unstructured.Unstructured{Object: obj}, nil
+		})
+		installerUnderTest = NewKarpenterInstaller(Options{
+			HelmInstaller: &fakes.FakeHelmInstaller{},
+			Namespace:     DefaultNamespace,
+			ClusterConfig: cfg,
+			DynamicClient: fakeDynamicClient,
+		})
+	})
+
+	It("applies an EC2NodeClass and a NodePool after installing the chart", func() {
+		Expect(installerUnderTest.Install(context.Background(), "role-arn", "eksctl-KarpenterNodeInstanceProfile-test-cluster")).To(Succeed())
+		Expect(applied).To(HaveLen(2))
+
+		nodeClass := applied[0]
+		Expect(nodeClass["apiVersion"]).To(Equal("karpenter.k8s.aws/v1"))
+		Expect(nodeClass["kind"]).To(Equal("EC2NodeClass"))
+		Expect(nodeClass["spec"]).To(Equal(map[string]interface{}{
+			"instanceProfile": "eksctl-KarpenterNodeInstanceProfile-test-cluster",
+			"amiSelectorTerms": []interface{}{
+				map[string]interface{}{"alias": "al2023@latest"},
+			},
+			"subnetSelectorTerms": []interface{}{
+				map[string]interface{}{"id": "subnet-private-a"},
+				map[string]interface{}{"id": "subnet-private-b"},
+			},
+			"securityGroupSelectorTerms": []interface{}{
+				map[string]interface{}{"tags": map[string]interface{}{"aws:eks:cluster-name": "test-cluster"}},
+				map[string]interface{}{"id": "sg-shared"},
+			},
+		}))
+
+		nodePool := applied[1]
+		Expect(nodePool["apiVersion"]).To(Equal("karpenter.sh/v1"))
+		Expect(nodePool["kind"]).To(Equal("NodePool"))
+		Expect(nodePool["spec"]).To(Equal(map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"requirements": []interface{}{
+						map[string]interface{}{"key": "karpenter.sh/capacity-type", "operator": "In", "values": []interface{}{"spot"}},
+						map[string]interface{}{"key": "karpenter.k8s.aws/instance-generation", "operator": "Gt", "values": []interface{}{"2"}},
+					},
+					"nodeClassRef": map[string]interface{}{
+						"group": "karpenter.k8s.aws",
+						"kind":  "EC2NodeClass",
+						"name":  "default",
+					},
+					"expireAfter": "720h",
+				},
+			},
+			"limits": map[string]interface{}{"cpu": "100"},
+			"disruption": map[string]interface{}{
+				"consolidationPolicy": "WhenEmptyOrUnderutilized",
+				"consolidateAfter":    "1m",
+				"budgets": []interface{}{
+					map[string]interface{}{"nodes": "10%"},
+				},
+			},
+		}))
+	})
+
+	It("selects subnets and security groups by the discovery tag", func() {
+		cfg.Metadata.Tags = map[string]string{DiscoveryTag: "test-cluster"}
+		cfg.Karpenter.NodePools[0].AMISelectorTerms = []api.KarpenterAMISelectorTerm{
+			{Name: "my-ami-*", Owner: "self"},
+		}
+		objects, err := makeNodePoolObjects(cfg, "profile")
+		Expect(err).NotTo(HaveOccurred())
+		discoveryTerms := []interface{}{
+			map[string]interface{}{"tags": map[string]interface{}{DiscoveryTag: "test-cluster"}},
+		}
+		spec := objects[0].Object["spec"].(map[string]interface{})
+		Expect(spec["subnetSelectorTerms"]).To(Equal(discoveryTerms))
+		Expect(spec["securityGroupSelectorTerms"]).To(Equal(discoveryTerms))
+		Expect(spec["amiSelectorTerms"]).To(Equal([]interface{}{
+			map[string]interface{}{"name": "my-ami-*", "owner": "self"},
+		}))
+	})
+
+	It("uses public subnets if the cluster has no private subnets", func() {
+		cfg.VPC.Subnets.Private = nil
+		objects, err := makeNodePoolObjects(cfg, "profile")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects[0].Object["spec"].(map[string]interface{})["subnetSelectorTerms"]).To(Equal([]interface{}{
+			map[string]interface{}{"id": "subnet-public-a"},
+		}))
+	})
+
+	It("sets labels, taints and subnets of the nodes", func() {
+		cfg.Karpenter.NodePools[0].Labels = map[string]string{"role": "workers"}
+		cfg.Karpenter.NodePools[0].Taints = []api.NodeGroupTaint{
+			{Key: "dedicated", Value: "workers", Effect: "NoSchedule"},
+			{Key: "gpu", Effect: "NoExecute"},
+		}
+		cfg.Karpenter.NodePools[0].Subnets = []string{"subnet-1", "subnet-2"}
+		objects, err := makeNodePoolObjects(cfg, "profile")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(objects[0].Object["spec"].(map[string]interface{})["subnetSelectorTerms"]).To(Equal([]interface{}{
+			map[string]interface{}{"id": "subnet-1"},
+			map[string]interface{}{"id": "subnet-2"},
+		}))
+		template := objects[1].Object["spec"].(map[string]interface{})["template"].(map[string]interface{})
+		Expect(template["metadata"]).To(Equal(map[string]interface{}{
+			"labels": map[string]interface{}{"role": "workers"},
+		}))
+		Expect(template["spec"].(map[string]interface{})["taints"]).To(Equal([]interface{}{
+			map[string]interface{}{"key": "dedicated", "value": "workers", "effect": "NoSchedule"},
+			map[string]interface{}{"key": "gpu", "effect": "NoExecute"},
+		}))
+	})
+
+	It("does not apply anything without nodePools", func() {
+		cfg.Karpenter.NodePools = nil
+		Expect(installerUnderTest.Install(context.Background(), "role-arn", "profile")).To(Succeed())
+		Expect(fakeDynamicClient.Actions()).To(BeEmpty())
+	})
+
+	When("applying a NodePool fails", func() {
+		BeforeEach(func() {
+			fakeDynamicClient.PrependReactor("patch", "nodepools", func(action core.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("nope")
+			})
+		})
+
+		It("errors", func() {
+			Expect(installerUnderTest.Install(context.Background(), "role-arn", "profile")).
+				To(MatchError(`failed to apply NodePool "default": nope`))
+		})
+	})
+})
+
+var _ = Describe("Uninstall", func() {
+	var (
+		cfg                *api.ClusterConfig
+		fakeHelmInstaller  *fakes.FakeHelmInstaller
+		fakeDynamicClient  *dynamicfake.FakeDynamicClient
+		installerUnderTest *Installer
+	)
+
+	newObject := func(gvr schema.GroupVersionResource, kind, name string, labels map[string]string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(gvr.GroupVersion().String())
+		obj.SetKind(kind)
+		obj.SetName(name)
+		obj.SetLabels(labels)
+		return obj
+	}
+
+	BeforeEach(func() {
+		pollInterval = time.Millisecond
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Karpenter = &api.Karpenter{}
+		fakeHelmInstaller = &fakes.FakeHelmInstaller{}
+		fakeDynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+			nodePoolGVR:     "NodePoolList",
+			ec2NodeClassGVR: "EC2NodeClassList",
+			nodeGVR:         "NodeList",
+		},
+			newObject(nodePoolGVR, "NodePool", "default", nil),
+			newObject(ec2NodeClassGVR, "EC2NodeClass", "default", nil),
+			newObject(nodeGVR, "Node", "karpenter-node", map[string]string{"karpenter.sh/nodepool": "default"}),
+			newObject(nodeGVR, "Node", "managed-node", nil),
+		)
+		// Karpenter terminates the nodes of a NodePool once it is deleted
+		fakeDynamicClient.PrependReactor("delete", "nodepools", func(action core.Action) (bool, runtime.Object, error) {
+			Expect(fakeDynamicClient.Tracker().Delete(nodeGVR, "", "karpenter-node")).To(Succeed())
+			return false, nil, nil
+		})
+		installerUnderTest = NewKarpenterInstaller(Options{
+			HelmInstaller: fakeHelmInstaller,
+			Namespace:     DefaultNamespace,
+			ClusterConfig: cfg,
+			DynamicClient: fakeDynamicClient,
+		})
+	})
+
+	AfterEach(func() {
+		pollInterval = 10 * time.Second
+	})
+
+	It("deletes NodePools and their nodes before uninstalling the chart", func() {
+		Expect(installerUnderTest.Uninstall(context.Background(), "1.2.1", time.Second)).To(Succeed())
+
+		for _, gvr := range []schema.GroupVersionResource{nodePoolGVR, ec2NodeClassGVR} {
+			list, err := fakeDynamicClient.Resource(gvr).List(context.Background(), metav1.ListOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(list.Items).To(BeEmpty())
+		}
+		nodes, err := fakeDynamicClient.Resource(nodeGVR).List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes.Items).To(HaveLen(1))
+		Expect(nodes.Items[0].GetName()).To(Equal("managed-node"))
+
+		Expect(fakeHelmInstaller.UninstallChartCallCount()).To(Equal(1))
+		_, releaseName := fakeHelmInstaller.UninstallChartArgsForCall(0)
+		Expect(releaseName).To(Equal("karpenter"))
+	})
+
+	It("uses the Provisioner API of versions before v0.32.0", func() {
+		Expect(nodePoolsAPIForVersion("0.31.0")).To(Equal(nodePoolsAPI{
+			nodePools:   schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1alpha5", Resource: "provisioners"},
+			nodeClasses: schema.GroupVersionResource{Group: "karpenter.k8s.aws", Version: "v1alpha1", Resource: "awsnodetemplates"},
+			nodeLabel:   "karpenter.sh/provisioner-name",
+		}))
+		Expect(nodePoolsAPIForVersion("0.32.0").nodePools.Version).To(Equal("v1beta1"))
+		Expect(nodePoolsAPIForVersion("1.0.0").nodePools).To(Equal(nodePoolGVR))
+	})
+
+	When("the nodes are not drained in time", func() {
+		BeforeEach(func() {
+			fakeDynamicClient.PrependReactor("delete", "nodepools", func(action core.Action) (bool, runtime.Object, error) {
+				return true, nil, nil
+			})
+		})
+
+		It("does not uninstall the chart", func() {
+			Expect(installerUnderTest.Uninstall(context.Background(), "1.2.1", 20*time.Millisecond)).
+				To(MatchError(ContainSubstring("waiting for nodes launched by Karpenter to be deleted")))
+			Expect(fakeHelmInstaller.UninstallChartCallCount()).To(BeZero())
+		})
+	})
+
+	When("uninstalling the chart fails", func() {
+		BeforeEach(func() {
+			fakeHelmInstaller.UninstallChartReturns(errors.New("nope"))
+		})
+
+		It("errors", func() {
+			Expect(installerUnderTest.Uninstall(context.Background(), "1.2.1", time.Second)).
+				To(MatchError("failed to uninstall Karpenter chart: nope"))
+		})
+	})
+})
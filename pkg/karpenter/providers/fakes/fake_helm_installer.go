@@ -33,6 +33,30 @@ type FakeHelmInstaller struct {
 	installChartReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UninstallChartStub        func(context.Context, string) error
+	uninstallChartMutex       sync.RWMutex
+	uninstallChartArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	uninstallChartReturns struct {
+		result1 error
+	}
+	uninstallChartReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpgradeChartStub        func(context.Context, providers.InstallChartOpts) error
+	upgradeChartMutex       sync.RWMutex
+	upgradeChartArgsForCall []struct {
+		arg1 context.Context
+		arg2 providers.InstallChartOpts
+	}
+	upgradeChartReturns struct {
+		result1 error
+	}
+	upgradeChartReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -161,6 +185,130 @@ func (fake *FakeHelmInstaller) InstallChartReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeHelmInstaller) UninstallChart(arg1 context.Context, arg2 string) error {
+	fake.uninstallChartMutex.Lock()
+	ret, specificReturn := fake.uninstallChartReturnsOnCall[len(fake.uninstallChartArgsForCall)]
+	fake.uninstallChartArgsForCall = append(fake.uninstallChartArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UninstallChartStub
+	fakeReturns := fake.uninstallChartReturns
+	fake.recordInvocation("UninstallChart", []interface{}{arg1, arg2})
+	fake.uninstallChartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeHelmInstaller) UninstallChartCallCount() int {
+	fake.uninstallChartMutex.RLock()
+	defer fake.uninstallChartMutex.RUnlock()
+	return len(fake.uninstallChartArgsForCall)
+}
+
+func (fake *FakeHelmInstaller) UninstallChartCalls(stub func(context.Context, string) error) {
+	fake.uninstallChartMutex.Lock()
+	defer fake.uninstallChartMutex.Unlock()
+	fake.UninstallChartStub = stub
+}
+
+func (fake *FakeHelmInstaller) UninstallChartArgsForCall(i int) (context.Context, string) {
+	fake.uninstallChartMutex.RLock()
+	defer fake.uninstallChartMutex.RUnlock()
+	argsForCall := fake.uninstallChartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHelmInstaller) UninstallChartReturns(result1 error) {
+	fake.uninstallChartMutex.Lock()
+	defer fake.uninstallChartMutex.Unlock()
+	fake.UninstallChartStub = nil
+	fake.uninstallChartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHelmInstaller) UninstallChartReturnsOnCall(i int, result1 error) {
+	fake.uninstallChartMutex.Lock()
+	defer fake.uninstallChartMutex.Unlock()
+	fake.UninstallChartStub = nil
+	if fake.uninstallChartReturnsOnCall == nil {
+		fake.uninstallChartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uninstallChartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHelmInstaller) UpgradeChart(arg1 context.Context, arg2 providers.InstallChartOpts) error {
+	fake.upgradeChartMutex.Lock()
+	ret, specificReturn := fake.upgradeChartReturnsOnCall[len(fake.upgradeChartArgsForCall)]
+	fake.upgradeChartArgsForCall = append(fake.upgradeChartArgsForCall, struct {
+		arg1 context.Context
+		arg2 providers.InstallChartOpts
+	}{arg1, arg2})
+	stub := fake.UpgradeChartStub
+	fakeReturns := fake.upgradeChartReturns
+	fake.recordInvocation("UpgradeChart", []interface{}{arg1, arg2})
+	fake.upgradeChartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartCallCount() int {
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
+	return len(fake.upgradeChartArgsForCall)
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartCalls(stub func(context.Context, providers.InstallChartOpts) error) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = stub
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartArgsForCall(i int) (context.Context, providers.InstallChartOpts) {
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
+	argsForCall := fake.upgradeChartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartReturns(result1 error) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = nil
+	fake.upgradeChartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHelmInstaller) UpgradeChartReturnsOnCall(i int, result1 error) {
+	fake.upgradeChartMutex.Lock()
+	defer fake.upgradeChartMutex.Unlock()
+	fake.UpgradeChartStub = nil
+	if fake.upgradeChartReturnsOnCall == nil {
+		fake.upgradeChartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.upgradeChartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeHelmInstaller) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -168,6 +316,10 @@ func (fake *FakeHelmInstaller) Invocations() map[string][][]interface{} {
 	defer fake.addRepoMutex.RUnlock()
 	fake.installChartMutex.RLock()
 	defer fake.installChartMutex.RUnlock()
+	fake.uninstallChartMutex.RLock()
+	defer fake.uninstallChartMutex.RUnlock()
+	fake.upgradeChartMutex.RLock()
+	defer fake.upgradeChartMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
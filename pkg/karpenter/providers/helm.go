@@ -35,4 +35,10 @@ type HelmInstaller interface {
 	// InstallChart takes a releaseName's name and a chart name and installs it. If namespace is not empty
 	// it will install into that namespace and create the namespace. Version is required.
 	InstallChart(ctx context.Context, opts InstallChartOpts) error
+	// UpgradeChart upgrades an installed release to the chart version and values in opts. The CRDs of the chart,
+	// which Helm only creates on install, are created or replaced before the release is upgraded.
+	UpgradeChart(ctx context.Context, opts InstallChartOpts) error
+	// UninstallChart uninstalls a release and waits for its resources to be deleted. It does not fail if the
+	// release is not found. CRDs are kept, like Helm does.
+	UninstallChart(ctx context.Context, releaseName string) error
 }
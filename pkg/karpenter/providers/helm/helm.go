@@ -1,6 +1,7 @@
 package helm
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
@@ -74,3 +75,56 @@ func (i *Installer) InstallChart(ctx context.Context, opts providers.InstallChar
 	logger.Debug("successfully installed %s helm chart: %s/%s", release.Name, opts.ChartName, opts.Version)
 	return nil
 }
+
+// UpgradeChart takes a release name and a chart name and upgrades the release to the given version of the chart,
+// replacing its values. CRDs of the chart are created or replaced first, as Helm does not upgrade CRDs.
+func (i *Installer) UpgradeChart(ctx context.Context, opts providers.InstallChartOpts) error {
+	i.ActionConfig.RegistryClient = opts.RegistryClient
+	client := action.NewUpgrade(i.ActionConfig)
+	client.Wait = true
+	client.Namespace = opts.Namespace
+	client.Version = opts.Version
+	client.Timeout = 10 * time.Minute
+
+	chartPath, err := client.ChartPathOptions.LocateChart(opts.ChartName, i.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to locate chart: %w", err)
+	}
+
+	ch, err := loader.Load(chartPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chart: %w", err)
+	}
+
+	for _, crd := range ch.CRDObjects() {
+		resources, err := i.ActionConfig.KubeClient.Build(bytes.NewBuffer(crd.File.Data), false)
+		if err != nil {
+			return fmt.Errorf("failed to build CRD %s: %w", crd.Filename, err)
+		}
+		// with force, existing resources are replaced and missing ones are created
+		if _, err := i.ActionConfig.KubeClient.Update(resources, resources, true); err != nil {
+			return fmt.Errorf("failed to update CRD %s: %w", crd.Filename, err)
+		}
+	}
+
+	release, err := client.RunWithContext(ctx, opts.ReleaseName, ch, opts.Values)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade chart: %w", err)
+	}
+	logger.Debug("successfully upgraded %s helm chart: %s/%s", release.Name, opts.ChartName, opts.Version)
+	return nil
+}
+
+// UninstallChart uninstalls a release and waits for its resources to be deleted.
+func (i *Installer) UninstallChart(_ context.Context, releaseName string) error {
+	client := action.NewUninstall(i.ActionConfig)
+	client.Wait = true
+	client.IgnoreNotFound = true
+	client.Timeout = 10 * time.Minute
+
+	if _, err := client.Run(releaseName); err != nil {
+		return fmt.Errorf("failed to uninstall chart: %w", err)
+	}
+	logger.Debug("successfully uninstalled helm release %s", releaseName)
+	return nil
+}
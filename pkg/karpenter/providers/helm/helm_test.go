@@ -11,11 +11,13 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/otiai10/copy"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/getter"
 	kubefake "helm.sh/helm/v3/pkg/kube/fake"
 	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/release"
 	"helm.sh/helm/v3/pkg/storage"
 	"helm.sh/helm/v3/pkg/storage/driver"
 
@@ -174,6 +176,45 @@ var _ = Describe("HelmInstaller", func() {
 			})
 		})
 	})
+
+	Context("UninstallChart", func() {
+
+		var (
+			store              *storage.Storage
+			installerUnderTest *Installer
+		)
+
+		BeforeEach(func() {
+			store = storage.Init(driver.NewMemory())
+			installerUnderTest = &Installer{
+				ActionConfig: &action.Configuration{
+					Releases:     store,
+					KubeClient:   &fakes.PrintingKubeClient{Out: io.Discard},
+					Capabilities: chartutil.DefaultCapabilities,
+					Log:          func(format string, v ...interface{}) {},
+				},
+			}
+		})
+
+		It("uninstalls a release", func() {
+			Expect(store.Create(&release.Release{
+				Name:      "karpenter",
+				Namespace: "karpenter",
+				Version:   1,
+				Info:      &release.Info{Status: release.StatusDeployed},
+				Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: "karpenter", Version: "1.2.1"}},
+			})).To(Succeed())
+			Expect(installerUnderTest.UninstallChart(context.Background(), "karpenter")).To(Succeed())
+			_, err := store.Deployed("karpenter")
+			Expect(err).To(HaveOccurred())
+		})
+
+		When("the release does not exist", func() {
+			It("does not error", func() {
+				Expect(installerUnderTest.UninstallChart(context.Background(), "karpenter")).To(Succeed())
+			})
+		})
+	})
 })
 
 var expectedRepositoryYaml = `apiVersion: ""
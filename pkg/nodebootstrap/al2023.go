@@ -165,7 +165,7 @@ func ToKubeletConfig(kubeletExtraConfig api.InlineDocument) (map[string]runtime.
 
 func stringToNodeConfig(overrideBootstrapCommand string) (*nodeadm.NodeConfig, error) {
 	var config nodeadm.NodeConfig
-	err := yaml.Unmarshal([]byte(overrideBootstrapCommand), &config)
+	err := yaml.UnmarshalStrict([]byte(overrideBootstrapCommand), &config)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshalling \"overrideBootstrapCommand\" into \"nodeadm.NodeConfig\": %w", err)
 	}
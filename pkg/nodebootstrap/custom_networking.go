@@ -0,0 +1,64 @@
+package nodebootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/awsapi"
+)
+
+// CustomNetworkingMaxPods returns the maximum number of pods for an instance type with custom networking,
+// where pods are not assigned IPs from the primary ENI of the node. The two additional pods account for
+// the pods using host networking, i.e. aws-node and kube-proxy.
+func CustomNetworkingMaxPods(maxENIs, ipv4AddressesPerENI int) int {
+	return (maxENIs-1)*(ipv4AddressesPerENI-1) + 2
+}
+
+// SetCustomNetworkingMaxPods sets maxPodsPerNode for nodegroups of a cluster using custom networking, as the
+// default max pods of an instance type assumes that pods can be assigned IPs from the primary ENI of the node.
+// For nodegroups with multiple instance types, the lowest max pods is used.
+func SetCustomNetworkingMaxPods(ctx context.Context, ec2API awsapi.EC2, clusterConfig *api.ClusterConfig, np api.NodePool) error {
+	ng := np.BaseNodeGroup()
+	if !clusterConfig.HasPodSubnets() || ng.MaxPodsPerNode != 0 || api.IsWindowsImage(ng.AMIFamily) {
+		return nil
+	}
+	if mng, ok := np.(*api.ManagedNodeGroup); ok && (mng.LaunchTemplate != nil || mng.AMI != "") {
+		// maxPodsPerNode cannot be set for managed nodegroups using a launch template or a custom AMI
+		return nil
+	}
+	instanceTypes := np.InstanceTypeList()
+	if len(instanceTypes) == 0 {
+		return nil
+	}
+
+	input := &ec2.DescribeInstanceTypesInput{}
+	for _, it := range instanceTypes {
+		input.InstanceTypes = append(input.InstanceTypes, ec2types.InstanceType(it))
+	}
+	output, err := ec2API.DescribeInstanceTypes(ctx, input)
+	if err != nil {
+		return fmt.Errorf("couldn't retrieve instance type description for %v: %w", instanceTypes, err)
+	}
+
+	maxPods := 0
+	for _, it := range output.InstanceTypes {
+		if it.NetworkInfo == nil {
+			continue
+		}
+		itMaxPods := CustomNetworkingMaxPods(int(aws.ToInt32(it.NetworkInfo.MaximumNetworkInterfaces)), int(aws.ToInt32(it.NetworkInfo.Ipv4AddressesPerInterface)))
+		if maxPods == 0 || itMaxPods < maxPods {
+			maxPods = itMaxPods
+		}
+	}
+	if maxPods > 0 {
+		logger.Info("nodegroup %q: setting maxPodsPerNode to %d as custom networking is enabled", ng.Name, maxPods)
+		ng.MaxPodsPerNode = maxPods
+	}
+	return nil
+}
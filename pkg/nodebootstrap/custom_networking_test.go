@@ -0,0 +1,77 @@
+package nodebootstrap_test
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Custom networking", func() {
+	var (
+		clusterConfig *api.ClusterConfig
+		provider      *mockprovider.MockProvider
+	)
+
+	BeforeEach(func() {
+		clusterConfig = api.NewClusterConfig()
+		clusterConfig.VPC.PodSubnets = &api.PodSubnets{}
+		provider = mockprovider.NewMockProvider()
+		provider.MockEC2().On("DescribeInstanceTypes", mock.Anything, mock.Anything).Return(&ec2.DescribeInstanceTypesOutput{
+			InstanceTypes: []ec2types.InstanceTypeInfo{
+				{
+					InstanceType: ec2types.InstanceTypeM5Large,
+					NetworkInfo: &ec2types.NetworkInfo{
+						MaximumNetworkInterfaces:  aws.Int32(3),
+						Ipv4AddressesPerInterface: aws.Int32(10),
+					},
+				},
+				{
+					InstanceType: ec2types.InstanceTypeT3Medium,
+					NetworkInfo: &ec2types.NetworkInfo{
+						MaximumNetworkInterfaces:  aws.Int32(3),
+						Ipv4AddressesPerInterface: aws.Int32(6),
+					},
+				},
+			},
+		}, nil)
+	})
+
+	It("calculates max pods without the primary ENI", func() {
+		Expect(nodebootstrap.CustomNetworkingMaxPods(3, 10)).To(Equal(20))
+		Expect(nodebootstrap.CustomNetworkingMaxPods(15, 50)).To(Equal(688))
+	})
+
+	It("sets max pods to the lowest value of the instance types", func() {
+		ng := api.NewManagedNodeGroup()
+		ng.Name = "mng"
+		ng.InstanceTypes = []string{"m5.large", "t3.medium"}
+		Expect(nodebootstrap.SetCustomNetworkingMaxPods(context.Background(), provider.EC2(), clusterConfig, ng)).To(Succeed())
+		Expect(ng.MaxPodsPerNode).To(Equal(12))
+	})
+
+	It("does not override max pods set by the user", func() {
+		ng := api.NewNodeGroup()
+		ng.InstanceType = "m5.large"
+		ng.MaxPodsPerNode = 50
+		Expect(nodebootstrap.SetCustomNetworkingMaxPods(context.Background(), provider.EC2(), clusterConfig, ng)).To(Succeed())
+		Expect(ng.MaxPodsPerNode).To(Equal(50))
+	})
+
+	It("does not set max pods if custom networking is not enabled", func() {
+		clusterConfig.VPC.PodSubnets = nil
+		ng := api.NewNodeGroup()
+		ng.InstanceType = "m5.large"
+		Expect(nodebootstrap.SetCustomNetworkingMaxPods(context.Background(), provider.EC2(), clusterConfig, ng)).To(Succeed())
+		Expect(ng.MaxPodsPerNode).To(BeZero())
+		provider.MockEC2().AssertNotCalled(GinkgoT(), "DescribeInstanceTypes", mock.Anything, mock.Anything)
+	})
+})
@@ -1,10 +1,15 @@
 package nodebootstrap
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -22,8 +27,13 @@ const (
 	envFile               = "kubelet.env"
 	extraKubeConfFile     = "kubelet-extra.json"
 	commonLinuxBootScript = "bootstrap.helper.sh"
+
+	// MaxUserDataSize is the maximum size of userdata accepted by EC2, before it is base64-encoded
+	MaxUserDataSize = 16 * 1024
 )
 
+var gzipMagic = []byte{0x1f, 0x8b}
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 //counterfeiter:generate -o fakes/fake_bootstrapper.go . Bootstrapper
@@ -219,8 +229,8 @@ func makeBootstrapEnv(clusterConfig *api.ClusterConfig, np api.NodePool, cluster
 
 func makeKeyValues(kv map[string]string, separator string) string {
 	var params []string
-	for k, v := range kv {
-		params = append(params, fmt.Sprintf("%s=%s", k, v))
+	for _, k := range slices.Sorted(maps.Keys(kv)) {
+		params = append(params, fmt.Sprintf("%s=%s", k, kv[k]))
 	}
 
 	return strings.Join(params, separator)
@@ -246,3 +256,40 @@ func addFilesAndScripts(config *cloudconfig.CloudConfig, files []cloudconfig.Fil
 
 	return nil
 }
+
+// ValidateUserDataSize checks that the base64-encoded userdata of a nodegroup does not exceed the EC2 limit
+func ValidateUserDataSize(userData string, ng *api.NodeGroupBase) error {
+	data, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return fmt.Errorf("decoding base64 user data: %w", err)
+	}
+	if len(data) > MaxUserDataSize {
+		return fmt.Errorf("userdata of nodegroup %q is %d bytes, which exceeds the EC2 limit of %d bytes; "+
+			"reduce the size of preBootstrapCommands, overrideBootstrapCommand, kubeletExtraConfig or bottlerocket.settings",
+			ng.Name, len(data), MaxUserDataSize)
+	}
+	return nil
+}
+
+// DecodeUserData decodes base64-encoded userdata, as it appears in a launch template, and decompresses it if it is
+// gzipped, returning the MIME message, cloud-config, TOML or PowerShell script that is passed to the instance
+func DecodeUserData(userData string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(userData)
+	if err != nil {
+		return "", fmt.Errorf("decoding base64 user data: %w", err)
+	}
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return string(data), nil
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("decompressing user data: %w", err)
+	}
+	defer gr.Close()
+	data, err = io.ReadAll(gr)
+	if err != nil {
+		return "", fmt.Errorf("decompressing user data: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,53 @@
+package nodebootstrap_test
+
+import (
+	"encoding/base64"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cloudconfig"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+)
+
+var _ = Describe("DecodeUserData", func() {
+	It("decodes base64-encoded user data", func() {
+		userData := base64.StdEncoding.EncodeToString([]byte("<powershell>\nWrite-Host hello\n</powershell>"))
+		decoded, err := nodebootstrap.DecodeUserData(userData)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(Equal("<powershell>\nWrite-Host hello\n</powershell>"))
+	})
+
+	It("decompresses gzipped cloud-config", func() {
+		config := cloudconfig.New()
+		config.AddShellCommand("echo hello")
+		userData, err := config.Encode()
+		Expect(err).NotTo(HaveOccurred())
+
+		decoded, err := nodebootstrap.DecodeUserData(userData)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(HavePrefix("#cloud-config\n"))
+		Expect(decoded).To(ContainSubstring("echo hello"))
+	})
+
+	It("returns an error for invalid user data", func() {
+		_, err := nodebootstrap.DecodeUserData("not base64!")
+		Expect(err).To(MatchError(ContainSubstring("decoding base64 user data")))
+	})
+})
+
+var _ = Describe("ValidateUserDataSize", func() {
+	ng := &api.NodeGroupBase{Name: "ng-1"}
+
+	It("accepts user data within the EC2 limit", func() {
+		userData := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", nodebootstrap.MaxUserDataSize)))
+		Expect(nodebootstrap.ValidateUserDataSize(userData, ng)).To(Succeed())
+	})
+
+	It("rejects user data exceeding the EC2 limit", func() {
+		userData := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", nodebootstrap.MaxUserDataSize+1)))
+		Expect(nodebootstrap.ValidateUserDataSize(userData, ng)).To(MatchError(ContainSubstring(`userdata of nodegroup "ng-1" is 16385 bytes, which exceeds the EC2 limit of 16384 bytes`)))
+	})
+})
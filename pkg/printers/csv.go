@@ -0,0 +1,58 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/kris-nova/logger"
+)
+
+// CSVPrinter is a printer that outputs an object formatted
+// as CSV, using the columns added to its TablePrinter
+type CSVPrinter struct {
+	*TablePrinter
+}
+
+// NewCSVPrinter creates a new CSVPrinter.
+func NewCSVPrinter() OutputPrinter {
+	return &CSVPrinter{NewTablePrinter().(*TablePrinter)}
+}
+
+// PrintObj will print the passed object formatted as CSV to
+// the supplied writer.
+func (c *CSVPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	rows, err := c.rows(obj)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(writer)
+	if err := w.Write(c.columnames); err != nil {
+		return err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// PrintObjWithKind will print the passed object formatted as CSV to
+// the supplied writer. This printer ignores kind argument.
+func (c *CSVPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	return c.PrintObj(obj, writer)
+}
+
+// LogObj will print the passed object formatted as CSV to
+// the logger.
+func (c *CSVPrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := c.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package printers_test
+
+import (
+	"bytes"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CSV Printer", func() {
+	var printer OutputPrinter
+
+	BeforeEach(func() {
+		printer = NewCSVPrinter()
+		printer.(ColumnPrinter).AddColumn("NAME", func(c *ekstypes.Cluster) string {
+			return *c.Name
+		})
+		printer.(ColumnPrinter).AddColumn("SUBNETS", func(c *ekstypes.Cluster) []string {
+			return c.ResourcesVpcConfig.SubnetIds
+		})
+	})
+
+	It("prints the table columns as CSV sorted like the table", func() {
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("clusters", []*ekstypes.Cluster{
+			{Name: aws.String("test-cluster-2"), ResourcesVpcConfig: &ekstypes.VpcConfigResponse{SubnetIds: []string{"sub3"}}},
+			{Name: aws.String("test-cluster-1"), ResourcesVpcConfig: &ekstypes.VpcConfigResponse{SubnetIds: []string{"sub1", "sub2"}}},
+		}, &out)).To(Succeed())
+		Expect(out.String()).To(Equal("NAME,SUBNETS\ntest-cluster-1,\"[sub1, sub2]\"\ntest-cluster-2,[sub3]\n"))
+	})
+
+	It("prints only the header for an empty slice", func() {
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("clusters", []*ekstypes.Cluster{}, &out)).To(Succeed())
+		Expect(out.String()).To(Equal("NAME,SUBNETS\n"))
+	})
+
+	It("returns an error when given an object that is not a slice", func() {
+		Expect(printer.PrintObj(&ekstypes.Cluster{}, &bytes.Buffer{})).To(MatchError(ContainSubstring("expects a slice")))
+	})
+
+	It("returns an error when no columns are defined", func() {
+		Expect(NewCSVPrinter().PrintObj([]string{"a"}, &bytes.Buffer{})).To(MatchError(ContainSubstring("no columns are defined")))
+	})
+})
@@ -0,0 +1,60 @@
+package printers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/kris-nova/logger"
+)
+
+// GoTemplatePrinter is a printer that outputs the result of
+// executing a Go template against the JSON form of an object
+type GoTemplatePrinter struct {
+	template *template.Template
+}
+
+// NewGoTemplatePrinter creates a new GoTemplatePrinter for the
+// given template text.
+func NewGoTemplatePrinter(text string) (OutputPrinter, error) {
+	t, err := template.New("output").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parsing go-template: %w", err)
+	}
+	return &GoTemplatePrinter{template: t}, nil
+}
+
+// PrintObj will print the result of executing the template
+// against the passed object to the supplied writer.
+func (g *GoTemplatePrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	data, err := toJSONValue(obj)
+	if err != nil {
+		return err
+	}
+	if err := g.template.Execute(writer, data); err != nil {
+		return fmt.Errorf("executing go-template: %w", err)
+	}
+	return nil
+}
+
+// PrintObjWithKind will print the result of executing the template
+// against the passed object to the supplied writer. This printer
+// ignores kind argument.
+func (g *GoTemplatePrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	return g.PrintObj(obj, writer)
+}
+
+// LogObj will print the result of executing the template against
+// the passed object to the logger.
+func (g *GoTemplatePrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := g.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package printers_test
+
+import (
+	"bytes"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Go template Printer", func() {
+	It("executes the template against the JSON form of the object", func() {
+		printer, err := NewPrinter(`go-template={{range .}}{{.name}}: {{.replicas}}{{"\n"}}{{end}}`)
+		Expect(err).NotTo(HaveOccurred())
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("nodegroups", printerTestItems, &out)).To(Succeed())
+		Expect(out.String()).To(Equal("ng-1: 2\nng-2: 3\n"))
+	})
+
+	It("returns an error for an invalid template", func() {
+		_, err := NewPrinter("go-template={{range .}")
+		Expect(err).To(MatchError(ContainSubstring("parsing go-template")))
+	})
+
+	It("returns an error for a printer type that does not take an argument", func() {
+		_, err := NewPrinter("csv=foo")
+		Expect(err).To(MatchError(ContainSubstring("unknown output printer type")))
+	})
+})
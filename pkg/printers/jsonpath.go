@@ -0,0 +1,82 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPathPrinter is a printer that outputs the result of
+// evaluating a JSONPath expression against the JSON form of an object
+type JSONPathPrinter struct {
+	parser *jsonpath.JSONPath
+}
+
+// NewJSONPathPrinter creates a new JSONPathPrinter for the given
+// expression. The expression uses the kubectl JSONPath syntax; the
+// enclosing braces may be omitted, e.g. "{[*].Name}" or "[*].Name".
+func NewJSONPathPrinter(expression string) (OutputPrinter, error) {
+	if !strings.Contains(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+	parser := jsonpath.New("output").AllowMissingKeys(true)
+	if err := parser.Parse(expression); err != nil {
+		return nil, fmt.Errorf("parsing jsonpath expression %q: %w", expression, err)
+	}
+	return &JSONPathPrinter{parser: parser}, nil
+}
+
+// PrintObj will print the result of evaluating the JSONPath
+// expression against the passed object to the supplied writer.
+func (j *JSONPathPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	data, err := toJSONValue(obj)
+	if err != nil {
+		return err
+	}
+	if err := j.parser.Execute(writer, data); err != nil {
+		return fmt.Errorf("evaluating jsonpath expression: %w", err)
+	}
+	return nil
+}
+
+// PrintObjWithKind will print the result of evaluating the JSONPath
+// expression against the passed object to the supplied writer. This
+// printer ignores kind argument.
+func (j *JSONPathPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	return j.PrintObj(obj, writer)
+}
+
+// LogObj will print the result of evaluating the JSONPath
+// expression against the passed object to the logger.
+func (j *JSONPathPrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := j.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
+
+// toJSONValue converts obj to the generic form produced by decoding
+// its JSON representation, so that expressions and templates refer to
+// fields by the same names as the JSON output.
+func toJSONValue(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,41 @@
+package printers_test
+
+import (
+	"bytes"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type printerTestItem struct {
+	Name     string `json:"name"`
+	Replicas int    `json:"replicas"`
+}
+
+var printerTestItems = []printerTestItem{{Name: "ng-1", Replicas: 2}, {Name: "ng-2", Replicas: 3}}
+
+var _ = Describe("JSONPath Printer", func() {
+	DescribeTable("evaluates the expression against the JSON form of the object", func(printerType Type, expected string) {
+		printer, err := NewPrinter(printerType)
+		Expect(err).NotTo(HaveOccurred())
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("nodegroups", printerTestItems, &out)).To(Succeed())
+		Expect(out.String()).To(Equal(expected))
+	},
+		Entry("with braces", "jsonpath={[*].name}", "ng-1 ng-2"),
+		Entry("without braces", "jsonpath=[1].replicas", "3"),
+		Entry("with range", `jsonpath={range [*]}{.name}={.replicas}{"\n"}{end}`, "ng-1=2\nng-2=3\n"),
+	)
+
+	It("returns an error for an invalid expression", func() {
+		_, err := NewPrinter("jsonpath={[*")
+		Expect(err).To(MatchError(ContainSubstring("parsing jsonpath expression")))
+	})
+
+	It("returns an error when the expression is missing", func() {
+		_, err := NewPrinter("jsonpath=")
+		Expect(err).To(MatchError(ContainSubstring("requires an expression")))
+	})
+})
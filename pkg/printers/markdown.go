@@ -0,0 +1,72 @@
+package printers
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kris-nova/logger"
+)
+
+var markdownCellEscaper = strings.NewReplacer("|", `\|`, "\n", "<br>")
+
+// MarkdownPrinter is a printer that outputs an object formatted
+// as a Markdown table, using the columns added to its TablePrinter
+type MarkdownPrinter struct {
+	*TablePrinter
+}
+
+// NewMarkdownPrinter creates a new MarkdownPrinter.
+func NewMarkdownPrinter() OutputPrinter {
+	return &MarkdownPrinter{NewTablePrinter().(*TablePrinter)}
+}
+
+// PrintObj will print the passed object formatted as a Markdown
+// table to the supplied writer.
+func (m *MarkdownPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	rows, err := m.rows(obj)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(writer)
+	writeRow := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = markdownCellEscaper.Replace(cell)
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+	}
+
+	writeRow(m.columnames)
+	separators := make([]string, len(m.columnames))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeRow(separators)
+	for _, row := range rows {
+		writeRow(row)
+	}
+	return w.Flush()
+}
+
+// PrintObjWithKind will print the passed object formatted as a Markdown
+// table to the supplied writer. This printer ignores kind argument.
+func (m *MarkdownPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	return m.PrintObj(obj, writer)
+}
+
+// LogObj will print the passed object formatted as a Markdown table to
+// the logger.
+func (m *MarkdownPrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := m.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package printers_test
+
+import (
+	"bytes"
+
+	ekstypes "github.com/aws/aws-sdk-go-v2/service/eks/types"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Markdown Printer", func() {
+	It("prints the table columns as a Markdown table", func() {
+		printer := NewMarkdownPrinter()
+		printer.(ColumnPrinter).AddColumn("NAME", func(c *ekstypes.Cluster) string {
+			return *c.Name
+		})
+		printer.(ColumnPrinter).AddColumn("ARN", func(c *ekstypes.Cluster) string {
+			return *c.Arn
+		})
+
+		var out bytes.Buffer
+		Expect(printer.PrintObjWithKind("clusters", []*ekstypes.Cluster{
+			{Name: aws.String("test-cluster-2"), Arn: aws.String("arn-87654321")},
+			{Name: aws.String("test-cluster-1"), Arn: aws.String("arn|12345678")},
+		}, &out)).To(Succeed())
+		Expect(out.String()).To(Equal(`| NAME | ARN |
+| --- | --- |
+| test-cluster-1 | arn\|12345678 |
+| test-cluster-2 | arn-87654321 |
+`))
+	})
+})
@@ -3,6 +3,7 @@ package printers
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/kris-nova/logger"
 )
@@ -17,6 +18,14 @@ const (
 	JSONType = Type("json")
 	// TableType represents a printer of Table type.
 	TableType = Type("table")
+	// CSVType represents a printer of CSV type.
+	CSVType = Type("csv")
+	// MarkdownType represents a printer of Markdown type.
+	MarkdownType = Type("markdown")
+	// JSONPathType represents a printer of JSONPath type, used as jsonpath=<expression>.
+	JSONPathType = Type("jsonpath")
+	// GoTemplateType represents a printer of Go template type, used as go-template=<template>.
+	GoTemplateType = Type("go-template")
 )
 
 // OutputPrinter is the interface that printer must implement. This allows
@@ -27,10 +36,34 @@ type OutputPrinter interface {
 	LogObj(log logger.LoggerFunc, msgFmt string, obj interface{}) error
 }
 
+// ColumnPrinter is implemented by printers that output the columns added
+// with AddColumn, i.e. the table, CSV and Markdown printers.
+type ColumnPrinter interface {
+	OutputPrinter
+	AddColumn(name string, getter interface{})
+}
+
 // NewPrinter creates a new printer based in the printer type requested.
 func NewPrinter(printerType Type) (OutputPrinter, error) {
 	var printer OutputPrinter
 
+	name, arg, hasArg := strings.Cut(printerType, "=")
+	switch name {
+	case JSONPathType:
+		if arg == "" {
+			return nil, fmt.Errorf("%s output requires an expression, e.g. %s='{[*].Name}'", JSONPathType, JSONPathType)
+		}
+		return NewJSONPathPrinter(arg)
+	case GoTemplateType:
+		if arg == "" {
+			return nil, fmt.Errorf("%s output requires a template, e.g. %s='{{range .}}{{.Name}}{{\"\\n\"}}{{end}}'", GoTemplateType, GoTemplateType)
+		}
+		return NewGoTemplatePrinter(arg)
+	}
+	if hasArg {
+		return nil, errInvalidPrinterType(printerType)
+	}
+
 	switch printerType {
 	case YAMLType:
 		printer = NewYAMLPrinter()
@@ -38,6 +71,10 @@ func NewPrinter(printerType Type) (OutputPrinter, error) {
 		printer = NewJSONPrinter()
 	case TableType:
 		printer = NewTablePrinter()
+	case CSVType:
+		printer = NewCSVPrinter()
+	case MarkdownType:
+		printer = NewMarkdownPrinter()
 	default:
 		return nil, errInvalidPrinterType(printerType)
 	}
@@ -46,5 +83,6 @@ func NewPrinter(printerType Type) (OutputPrinter, error) {
 }
 
 func errInvalidPrinterType(printerType Type) error {
-	return fmt.Errorf("unknown output printer type: expected {%q,%q,%q} but got %q", YAMLType, JSONType, TableType, printerType)
+	return fmt.Errorf("unknown output printer type: expected {%q,%q,%q,%q,%q,\"%s=...\",\"%s=...\"} but got %q",
+		YAMLType, JSONType, TableType, CSVType, MarkdownType, JSONPathType, GoTemplateType, printerType)
 }
@@ -3,12 +3,15 @@ package printers
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/kris-nova/logger"
+	"k8s.io/kops/util/pkg/reflectutils"
 	"k8s.io/kops/util/pkg/tables"
 )
 
@@ -17,6 +20,7 @@ import (
 type TablePrinter struct {
 	table      *tables.Table
 	columnames []string
+	getters    []reflect.Value
 }
 
 // NewTablePrinter creates a new TablePrinter with defaults.
@@ -65,5 +69,37 @@ func (t *TablePrinter) LogObj(log logger.LoggerFunc, msgFmt string, obj interfac
 // AddColumn adds a column to the table that will be printed
 func (t *TablePrinter) AddColumn(name string, getter interface{}) {
 	t.columnames = append(t.columnames, name)
+	t.getters = append(t.getters, reflect.ValueOf(getter))
 	t.table.AddColumn(name, getter)
 }
+
+// rows returns the column values for each item in obj, sorted in
+// the same order as the rows of the table.
+func (t *TablePrinter) rows(obj interface{}) ([][]string, error) {
+	itemsValue := reflect.ValueOf(obj)
+	if itemsValue.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("printer expects a slice but the kind was %v", itemsValue.Kind())
+	}
+	if len(t.columnames) == 0 {
+		return nil, errors.New("no columns are defined for this output")
+	}
+
+	rows := make([][]string, itemsValue.Len())
+	for i := range rows {
+		item := itemsValue.Index(i)
+		row := make([]string, len(t.getters))
+		for j, getter := range t.getters {
+			row[j] = reflectutils.ValueAsString(getter.Call([]reflect.Value{item})[0])
+		}
+		rows[i] = row
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k := range rows[i] {
+			if rows[i][k] != rows[j][k] {
+				return rows[i][k] < rows[j][k]
+			}
+		}
+		return false
+	})
+	return rows, nil
+}
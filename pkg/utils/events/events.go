@@ -0,0 +1,101 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// OutputNDJSON writes one JSON object per event, separated by newlines.
+const OutputNDJSON = "ndjson"
+
+// Type is the type of an event.
+type Type string
+
+const (
+	// TaskStarted is emitted when a task of a TaskTree starts.
+	TaskStarted Type = "task-started"
+	// TaskCompleted is emitted when a task of a TaskTree completes successfully.
+	TaskCompleted Type = "task-completed"
+	// TaskFailed is emitted when a task of a TaskTree fails.
+	TaskFailed Type = "task-failed"
+	// StackResource is emitted for each CloudFormation stack event, i.e. each status change of a stack or one of its resources.
+	StackResource Type = "stack-resource"
+)
+
+// Event describes the progress of an operation.
+type Event struct {
+	// Type is the type of the event.
+	Type Type `json:"type"`
+	// Time is the time the event was emitted.
+	Time time.Time `json:"time"`
+
+	// Task is the description of the task.
+	Task string `json:"task,omitempty"`
+	// StartTime is the time the task started, set for task events.
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// EndTime is the time the task completed or failed.
+	EndTime *time.Time `json:"endTime,omitempty"`
+	// Error is the error the task failed with.
+	Error string `json:"error,omitempty"`
+
+	// StackName is the name of the CloudFormation stack.
+	StackName string `json:"stackName,omitempty"`
+	// EventID is the ID of the CloudFormation stack event.
+	EventID string `json:"eventId,omitempty"`
+	// LogicalResourceID is the logical ID of the resource in the stack template.
+	LogicalResourceID string `json:"logicalResourceId,omitempty"`
+	// PhysicalResourceID is the ID of the resource.
+	PhysicalResourceID string `json:"physicalResourceId,omitempty"`
+	// ResourceType is the CloudFormation type of the resource.
+	ResourceType string `json:"resourceType,omitempty"`
+	// Status is the status of the resource.
+	Status string `json:"status,omitempty"`
+	// Reason is the reason for the status of the resource.
+	Reason string `json:"reason,omitempty"`
+	// Timestamp is the time CloudFormation recorded the status of the resource.
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	encoder *json.Encoder
+)
+
+// SetOutput configures events to be written to w in the given format. An empty format disables events.
+func SetOutput(format string, w io.Writer) error {
+	mu.Lock()
+	defer mu.Unlock()
+	switch format {
+	case "":
+		encoder = nil
+	case OutputNDJSON:
+		encoder = json.NewEncoder(w)
+	default:
+		return fmt.Errorf("unsupported events output %q; valid options: %s", format, OutputNDJSON)
+	}
+	return nil
+}
+
+// Enabled reports whether events are written anywhere, so that callers can skip work only needed to produce events.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return encoder != nil
+}
+
+// Emit writes e to the configured output; it does nothing if events are disabled.
+func Emit(e Event) {
+	mu.Lock()
+	defer mu.Unlock()
+	if encoder == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	// encoding errors are ignored, as failing to report progress must not fail the operation
+	_ = encoder.Encode(e)
+}
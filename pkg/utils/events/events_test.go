@@ -0,0 +1,76 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/events"
+)
+
+var _ = Describe("Events", func() {
+	var out *bytes.Buffer
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		Expect(events.SetOutput("", nil)).To(Succeed())
+	})
+
+	It("writes one JSON object per line", func() {
+		Expect(events.SetOutput(events.OutputNDJSON, out)).To(Succeed())
+		Expect(events.Enabled()).To(BeTrue())
+
+		timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		events.Emit(events.Event{Type: events.TaskStarted, Task: "create cluster control plane"})
+		events.Emit(events.Event{
+			Type:              events.StackResource,
+			StackName:         "eksctl-test-cluster",
+			LogicalResourceID: "ControlPlane",
+			ResourceType:      "AWS::EKS::Cluster",
+			Status:            "CREATE_FAILED",
+			Reason:            "Resource creation cancelled",
+			Timestamp:         &timestamp,
+		})
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		var started map[string]interface{}
+		Expect(json.Unmarshal([]byte(lines[0]), &started)).To(Succeed())
+		Expect(started).To(HaveKeyWithValue("type", "task-started"))
+		Expect(started).To(HaveKeyWithValue("task", "create cluster control plane"))
+		Expect(started).To(HaveKey("time"))
+		Expect(started).NotTo(HaveKey("stackName"))
+
+		var resource map[string]interface{}
+		Expect(json.Unmarshal([]byte(lines[1]), &resource)).To(Succeed())
+		Expect(resource).To(Equal(map[string]interface{}{
+			"type":              "stack-resource",
+			"time":              resource["time"],
+			"stackName":         "eksctl-test-cluster",
+			"logicalResourceId": "ControlPlane",
+			"resourceType":      "AWS::EKS::Cluster",
+			"status":            "CREATE_FAILED",
+			"reason":            "Resource creation cancelled",
+			"timestamp":         "2024-01-02T03:04:05Z",
+		}))
+	})
+
+	It("does nothing when no output is set", func() {
+		Expect(events.Enabled()).To(BeFalse())
+		events.Emit(events.Event{Type: events.TaskStarted})
+		Expect(out.Len()).To(BeZero())
+	})
+
+	It("rejects unsupported formats", func() {
+		Expect(events.SetOutput("xml", out)).To(MatchError(`unsupported events output "xml"; valid options: ndjson`))
+		Expect(events.Enabled()).To(BeFalse())
+	})
+})
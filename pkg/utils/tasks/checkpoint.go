@@ -0,0 +1,260 @@
+package tasks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kris-nova/logger"
+)
+
+// EksctlStateDirEnvName is the environment variable that overrides the directory checkpoint state files are written to.
+const EksctlStateDirEnvName = "EKSCTL_STATE_DIR"
+
+// Checkpoint records the tasks of one or more TaskTrees that have completed, in a local state file keyed by
+// cluster name and region, so that a failed operation can be resumed by skipping the tasks that already completed.
+// Tasks are identified by the name of their stack if they are a StackTask, and by their description otherwise;
+// a nil Checkpoint records nothing.
+type Checkpoint struct {
+	path         string
+	operation    string
+	resume       bool
+	prepareStack PrepareStackFunc
+
+	mu          sync.Mutex
+	state       checkpointState
+	completed   map[string]bool
+	occurrences map[string]int
+}
+
+// StackTask is implemented by tasks that create or delete a single CloudFormation stack. Unlike the order in which
+// descriptions occur in a tree, the name of the stack does not change between runs.
+type StackTask interface {
+	Task
+	StackName() string
+}
+
+// PrepareStackFunc makes way for the stack of a StackTask to be created again when resuming an operation, and reports
+// whether the stack already exists, in which case the task is recorded as completed instead of being run.
+type PrepareStackFunc func(stackName string) (exists bool, err error)
+
+type checkpointState struct {
+	Cluster    string              `json:"cluster"`
+	Region     string              `json:"region"`
+	Operations map[string][]string `json:"operations"`
+}
+
+// GetStateDir returns the directory checkpoint state files are written to.
+func GetStateDir() (string, error) {
+	if dir := os.Getenv(EksctlStateDirEnvName); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".eksctl", "state"), nil
+}
+
+// NewCheckpoint returns a Checkpoint for the given operation on a cluster. When resume is true, tasks recorded as
+// completed by a previous run of the same operation are skipped; otherwise any previous record of the operation is
+// discarded.
+func NewCheckpoint(clusterName, region, operation string, resume bool) (*Checkpoint, error) {
+	dir, err := GetStateDir()
+	if err != nil {
+		return nil, fmt.Errorf("determining state directory: %w", err)
+	}
+	c := &Checkpoint{
+		path:      filepath.Join(dir, region, clusterName+".json"),
+		operation: operation,
+		resume:    resume,
+		state: checkpointState{
+			Cluster:    clusterName,
+			Region:     region,
+			Operations: map[string][]string{},
+		},
+		completed:   map[string]bool{},
+		occurrences: map[string]int{},
+	}
+
+	data, err := os.ReadFile(c.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if resume {
+			logger.Warning("no saved state found for %s on cluster %q in %q, starting from the beginning", operation, clusterName, region)
+		}
+		return c, nil
+	case err != nil:
+		return nil, fmt.Errorf("reading state file: %w", err)
+	}
+	if err := json.Unmarshal(data, &c.state); err != nil {
+		return nil, fmt.Errorf("unable to parse state file %s: %w", c.path, err)
+	}
+	if c.state.Operations == nil {
+		c.state.Operations = map[string][]string{}
+	}
+
+	if !resume {
+		delete(c.state.Operations, operation)
+		return c, nil
+	}
+	for _, key := range c.state.Operations[operation] {
+		c.completed[key] = true
+	}
+	logger.Info("resuming %s on cluster %q using state file %s; %d task(s) already completed", operation, clusterName, c.path, len(c.completed))
+	return c, nil
+}
+
+// Path returns the path of the state file.
+func (c *Checkpoint) Path() string {
+	if c == nil {
+		return ""
+	}
+	return c.path
+}
+
+// Clear removes the record of the operation, deleting the state file once no operations are recorded in it.
+// It should be called once the operation has completed successfully.
+func (c *Checkpoint) Clear() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.completed = map[string]bool{}
+	delete(c.state.Operations, c.operation)
+	if len(c.state.Operations) > 0 {
+		return c.save()
+	}
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing state file: %w", err)
+	}
+	return nil
+}
+
+// SetPrepareStack sets the function called, when resuming, before running a StackTask that was not recorded as
+// completed, as a previous run may have left its stack behind.
+func (c *Checkpoint) SetPrepareStack(prepare PrepareStackFunc) {
+	if c != nil {
+		c.prepareStack = prepare
+	}
+}
+
+// IsCompleted reports whether the task identified by key was recorded as completed.
+func (c *Checkpoint) IsCompleted(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.completed[key]
+}
+
+// MarkCompleted records the task identified by key as completed and writes the state file.
+func (c *Checkpoint) MarkCompleted(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.completed[key] {
+		return nil
+	}
+	c.completed[key] = true
+	c.state.Operations[c.operation] = append(c.state.Operations[c.operation], key)
+	return c.save()
+}
+
+// keyFor returns the key identifying a task: the name of its stack, or its description. Keys that occur more than
+// once are told apart by the order in which they were encountered.
+func (c *Checkpoint) keyFor(task Task) string {
+	key := task.Describe()
+	if stackTask, ok := task.(StackTask); ok {
+		key = stackTask.StackName()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.occurrences[key]++
+	if n := c.occurrences[key]; n > 1 {
+		return fmt.Sprintf("%s (%d)", key, n)
+	}
+	return key
+}
+
+func (c *Checkpoint) save() error {
+	data, err := json.MarshalIndent(c.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("creating state directory: %w", err)
+	}
+	tmpPath := c.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("writing state file: %w", err)
+	}
+	return os.Rename(tmpPath, c.path)
+}
+
+// checkpointedTask skips the task it wraps if it was recorded as completed, and records it once it completes.
+type checkpointedTask struct {
+	Task
+	key        string
+	checkpoint *Checkpoint
+}
+
+func (t *checkpointedTask) Do(errs chan error) error {
+	if t.checkpoint.IsCompleted(t.key) {
+		logger.Info("skipping completed task: %s", t.Describe())
+		close(errs)
+		return nil
+	}
+	if stackTask, ok := t.Task.(StackTask); ok && t.checkpoint.resume && t.checkpoint.prepareStack != nil {
+		exists, err := t.checkpoint.prepareStack(stackTask.StackName())
+		if err != nil {
+			return fmt.Errorf("preparing to retry task %q: %w", t.Describe(), err)
+		}
+		if exists {
+			logger.Info("skipping task whose stack %q already exists: %s", stackTask.StackName(), t.Describe())
+			if err := t.checkpoint.MarkCompleted(t.key); err != nil {
+				logger.Warning("unable to record completion of task %q in %s: %v", t.key, t.checkpoint.Path(), err)
+			}
+			close(errs)
+			return nil
+		}
+	}
+
+	taskErrs := make(chan error)
+	if err := t.Task.Do(taskErrs); err != nil {
+		return err
+	}
+	go func() {
+		defer close(errs)
+		if err := <-taskErrs; err != nil {
+			errs <- err
+			return
+		}
+		if err := t.checkpoint.MarkCompleted(t.key); err != nil {
+			logger.Warning("unable to record completion of task %q in %s: %v", t.key, t.checkpoint.Path(), err)
+		}
+	}()
+	return nil
+}
+
+// attach wraps every task in the tree, including those in sub-trees, so that their completion is recorded.
+func (c *Checkpoint) attach(t *TaskTree) {
+	for i, task := range t.Tasks {
+		switch task := task.(type) {
+		case *TaskTree:
+			if task != nil {
+				c.attach(task)
+			}
+		case *checkpointedTask, nil:
+		default:
+			t.Tasks[i] = &checkpointedTask{
+				Task:       task,
+				key:        c.keyFor(task),
+				checkpoint: c,
+			}
+		}
+	}
+}
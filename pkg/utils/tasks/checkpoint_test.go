@@ -0,0 +1,223 @@
+package tasks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type stackTask struct {
+	Task
+	stackName string
+}
+
+func (t *stackTask) StackName() string { return t.stackName }
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		stateDir string
+		mu       sync.Mutex
+		ran      []string
+		failing  map[string]bool
+	)
+
+	newTask := func(info string) Task {
+		return &GenericTask{
+			Description: info,
+			Doer: func() error {
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, info)
+				if failing[info] {
+					return errors.New(info + " failed")
+				}
+				return nil
+			},
+		}
+	}
+
+	newStackTask := func(info, stackName string) Task {
+		return &stackTask{Task: newTask(info), stackName: stackName}
+	}
+
+	// newTree builds a sequential tree with a nested parallel sub-tree, which itself contains sequential sub-trees.
+	newTree := func(checkpoint *Checkpoint) *TaskTree {
+		tree := &TaskTree{Checkpoint: checkpoint}
+		tree.Append(newTask("create control plane"))
+		parallel := &TaskTree{Parallel: true, IsSubTask: true}
+		for _, ng := range []string{"ng-1", "ng-2"} {
+			sequential := &TaskTree{IsSubTask: true}
+			sequential.Append(newTask("create "+ng), newTask("wait"))
+			parallel.Append(sequential)
+		}
+		tree.Append(parallel)
+		tree.Append(newTask("install addons"))
+		return tree
+	}
+
+	BeforeEach(func() {
+		stateDir = GinkgoT().TempDir()
+		GinkgoT().Setenv(EksctlStateDirEnvName, stateDir)
+		ran = nil
+		failing = map[string]bool{}
+	})
+
+	It("skips the tasks that completed in a previous run when resuming", func() {
+		checkpoint, err := NewCheckpoint("test-cluster", "us-west-2", "create cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkpoint.Path()).To(Equal(filepath.Join(stateDir, "us-west-2", "test-cluster.json")))
+
+		failing["create ng-2"] = true
+		Expect(newTree(checkpoint).DoAllSync()).To(HaveLen(1))
+		// the parallel sibling of a failed sub-tree may still be running when the tree returns
+		Eventually(func() bool {
+			return checkpoint.IsCompleted("wait")
+		}).Should(BeTrue())
+		Expect(ran).To(ConsistOf("create control plane", "create ng-1", "wait", "create ng-2"))
+		Expect(checkpoint.Path()).To(BeAnExistingFile())
+
+		ran = nil
+		failing = map[string]bool{}
+		checkpoint, err = NewCheckpoint("test-cluster", "us-west-2", "create cluster", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newTree(checkpoint).DoAllSync()).To(BeEmpty())
+		Expect(ran).To(ConsistOf("create ng-2", "wait", "install addons"))
+
+		Expect(checkpoint.Clear()).To(Succeed())
+		Expect(checkpoint.Path()).NotTo(BeAnExistingFile())
+	})
+
+	It("does not run sequential tasks after a failed task", func() {
+		checkpoint, err := NewCheckpoint("test-cluster", "us-west-2", "create cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+
+		failing["create control plane"] = true
+		Expect(newTree(checkpoint).DoAllSync()).To(HaveLen(1))
+		Expect(ran).To(Equal([]string{"create control plane"}))
+
+		ran = nil
+		failing = map[string]bool{}
+		checkpoint, err = NewCheckpoint("test-cluster", "us-west-2", "create cluster", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newTree(checkpoint).DoAllSync()).To(BeEmpty())
+		Expect(ran).To(HaveLen(6))
+	})
+
+	It("runs every task when not resuming", func() {
+		checkpoint, err := NewCheckpoint("test-cluster", "us-west-2", "create cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+		failing["install addons"] = true
+		Expect(newTree(checkpoint).DoAllSync()).To(HaveLen(1))
+
+		ran = nil
+		failing = map[string]bool{}
+		checkpoint, err = NewCheckpoint("test-cluster", "us-west-2", "create cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newTree(checkpoint).DoAllSync()).To(BeEmpty())
+		Expect(ran).To(HaveLen(6))
+	})
+
+	It("keeps the state of other operations and clusters separate", func() {
+		checkpoint, err := NewCheckpoint("test-cluster", "us-west-2", "create cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+		failing["install addons"] = true
+		Expect(newTree(checkpoint).DoAllSync()).To(HaveLen(1))
+
+		ran = nil
+		failing = map[string]bool{}
+		for _, c := range []struct{ cluster, region, operation string }{
+			{"test-cluster", "us-west-2", "delete cluster"},
+			{"test-cluster", "eu-west-1", "create cluster"},
+			{"other-cluster", "us-west-2", "create cluster"},
+		} {
+			other, err := NewCheckpoint(c.cluster, c.region, c.operation, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(other.IsCompleted("create control plane")).To(BeFalse())
+		}
+
+		deleteCheckpoint, err := NewCheckpoint("test-cluster", "us-west-2", "delete cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleteCheckpoint.MarkCompleted("delete control plane")).To(Succeed())
+		Expect(deleteCheckpoint.Clear()).To(Succeed())
+		Expect(deleteCheckpoint.Path()).To(BeAnExistingFile())
+
+		checkpoint, err = NewCheckpoint("test-cluster", "us-west-2", "create cluster", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkpoint.IsCompleted("create control plane")).To(BeTrue())
+	})
+
+	It("tells apart tasks with the same description", func() {
+		checkpoint, err := NewCheckpoint("test-cluster", "us-west-2", "create cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newTree(checkpoint).DoAllSync()).To(BeEmpty())
+		Expect(checkpoint.IsCompleted("wait")).To(BeTrue())
+		Expect(checkpoint.IsCompleted("wait (2)")).To(BeTrue())
+	})
+
+	It("identifies stack tasks by the name of their stack", func() {
+		checkpoint, err := NewCheckpoint("test-cluster", "us-west-2", "delete cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+		failing["delete ng-2"] = true
+		tree := &TaskTree{Checkpoint: checkpoint}
+		tree.Append(newStackTask("delete ng-1", "eksctl-test-cluster-nodegroup-ng-1"), newStackTask("delete ng-2", "eksctl-test-cluster-nodegroup-ng-2"))
+		Expect(tree.DoAllSync()).To(HaveLen(1))
+		Expect(checkpoint.IsCompleted("eksctl-test-cluster-nodegroup-ng-1")).To(BeTrue())
+
+		By("resuming with a tree rebuilt from the stacks that are left")
+		ran = nil
+		failing = map[string]bool{}
+		checkpoint, err = NewCheckpoint("test-cluster", "us-west-2", "delete cluster", true)
+		Expect(err).NotTo(HaveOccurred())
+		tree = &TaskTree{Checkpoint: checkpoint}
+		tree.Append(newStackTask("delete ng-2", "eksctl-test-cluster-nodegroup-ng-2"))
+		Expect(tree.DoAllSync()).To(BeEmpty())
+		Expect(ran).To(Equal([]string{"delete ng-2"}))
+	})
+
+	It("prepares the stacks of tasks that did not complete when resuming", func() {
+		checkpoint, err := NewCheckpoint("test-cluster", "us-west-2", "create cluster", false)
+		Expect(err).NotTo(HaveOccurred())
+		var prepared []string
+		prepareStack := func(stackName string) (bool, error) {
+			prepared = append(prepared, stackName)
+			return stackName == "eksctl-test-cluster-nodegroup-ng-2", nil
+		}
+		checkpoint.SetPrepareStack(prepareStack)
+		failing["create ng-1"] = true
+		tree := &TaskTree{Checkpoint: checkpoint}
+		tree.Append(newStackTask("create cluster", "eksctl-test-cluster-cluster"), newStackTask("create ng-1", "eksctl-test-cluster-nodegroup-ng-1"))
+		Expect(tree.DoAllSync()).To(HaveLen(1))
+		Expect(prepared).To(BeEmpty())
+
+		ran = nil
+		failing = map[string]bool{}
+		checkpoint, err = NewCheckpoint("test-cluster", "us-west-2", "create cluster", true)
+		Expect(err).NotTo(HaveOccurred())
+		checkpoint.SetPrepareStack(prepareStack)
+		tree = &TaskTree{Checkpoint: checkpoint}
+		tree.Append(newStackTask("create cluster", "eksctl-test-cluster-cluster"), newStackTask("create ng-1", "eksctl-test-cluster-nodegroup-ng-1"), newStackTask("create ng-2", "eksctl-test-cluster-nodegroup-ng-2"))
+		Expect(tree.DoAllSync()).To(BeEmpty())
+		Expect(prepared).To(Equal([]string{"eksctl-test-cluster-nodegroup-ng-1", "eksctl-test-cluster-nodegroup-ng-2"}))
+		Expect(ran).To(Equal([]string{"create ng-1"}))
+		Expect(checkpoint.IsCompleted("eksctl-test-cluster-nodegroup-ng-2")).To(BeTrue())
+	})
+
+	It("returns an error for a malformed state file", func() {
+		path := filepath.Join(stateDir, "us-west-2", "test-cluster.json")
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("{"), 0600)).To(Succeed())
+		_, err := NewCheckpoint("test-cluster", "us-west-2", "create cluster", true)
+		Expect(err).To(MatchError(ContainSubstring("unable to parse state file")))
+	})
+
+	It("does nothing when nil", func() {
+		var checkpoint *Checkpoint
+		Expect(checkpoint.Clear()).To(Succeed())
+		Expect(checkpoint.Path()).To(BeEmpty())
+		Expect((&TaskTree{Tasks: []Task{newTask("t1")}}).DoAllSync()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,77 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/events"
+)
+
+var _ = Describe("TaskTree events", func() {
+	var out *bytes.Buffer
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		Expect(events.SetOutput(events.OutputNDJSON, out)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(events.SetOutput("", nil)).To(Succeed())
+	})
+
+	It("emits an event when each task starts, completes and fails", func() {
+		tree := &TaskTree{}
+		sub := &TaskTree{IsSubTask: true}
+		sub.Append(&GenericTask{Description: "create addons", Doer: func() error { return nil }})
+		tree.Append(sub)
+		tree.Append(&GenericTask{Description: "create nodegroup", Doer: func() error { return errors.New("out of capacity") }})
+		Expect(tree.DoAllSync()).To(HaveLen(1))
+
+		var emitted []events.Event
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var e events.Event
+			Expect(json.Unmarshal([]byte(line), &e)).To(Succeed())
+			emitted = append(emitted, e)
+		}
+		Expect(emitted).To(HaveLen(4))
+
+		Expect(emitted[0].Type).To(Equal(events.TaskStarted))
+		Expect(emitted[0].Task).To(Equal("create addons"))
+		Expect(emitted[0].StartTime).NotTo(BeNil())
+		Expect(emitted[1].Type).To(Equal(events.TaskCompleted))
+		Expect(emitted[1].Task).To(Equal("create addons"))
+		Expect(emitted[1].EndTime).NotTo(BeNil())
+		Expect(emitted[2].Type).To(Equal(events.TaskStarted))
+		Expect(emitted[2].Task).To(Equal("create nodegroup"))
+		Expect(emitted[3].Type).To(Equal(events.TaskFailed))
+		Expect(emitted[3].Task).To(Equal("create nodegroup"))
+		Expect(emitted[3].Error).To(Equal("out of capacity"))
+		Expect(*emitted[3].EndTime).NotTo(BeTemporally("<", *emitted[3].StartTime))
+	})
+
+	It("sets the stack name of stack tasks", func() {
+		tree := &TaskTree{}
+		tree.Append(&stackTask{
+			Task:      &GenericTask{Description: "create nodegroup", Doer: func() error { return nil }},
+			stackName: "eksctl-my-cluster-nodegroup-ng-1",
+		})
+		tree.Append(&GenericTask{Description: "create addons", Doer: func() error { return nil }})
+		Expect(tree.DoAllSync()).To(BeEmpty())
+
+		var emitted []events.Event
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			var e events.Event
+			Expect(json.Unmarshal([]byte(line), &e)).To(Succeed())
+			emitted = append(emitted, e)
+		}
+		Expect(emitted).To(HaveLen(4))
+		Expect(emitted[0].StackName).To(Equal("eksctl-my-cluster-nodegroup-ng-1"))
+		Expect(emitted[1].StackName).To(Equal("eksctl-my-cluster-nodegroup-ng-1"))
+		Expect(emitted[2].StackName).To(BeEmpty())
+	})
+})
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/kris-nova/logger"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/weaveworks/eksctl/pkg/utils/events"
 )
 
 // Task is a common interface for the stack manager tasks.
@@ -52,6 +55,9 @@ type TaskTree struct {
 	PlanMode  bool
 	IsSubTask bool
 	Limit     int
+	// Checkpoint, when set, records the tasks that complete and skips those recorded by a previous run.
+	// It only needs to be set on the root of the tree.
+	Checkpoint *Checkpoint
 }
 
 // Append new tasks to the set
@@ -145,6 +151,9 @@ func (t *TaskTree) Do(allErrs chan error) error {
 		close(allErrs)
 		return nil
 	}
+	if t.Checkpoint != nil {
+		t.Checkpoint.attach(t)
+	}
 
 	errs := make(chan error)
 
@@ -175,6 +184,9 @@ func (t *TaskTree) DoAllSync() []error {
 		logger.Debug("no actual tasks")
 		return nil
 	}
+	if t.Checkpoint != nil {
+		t.Checkpoint.attach(t)
+	}
 
 	errs := make(chan error)
 
@@ -198,19 +210,70 @@ func (t *TaskTree) DoAllSync() []error {
 func doSingleTask(allErrs chan error, task Task) bool {
 	desc := task.Describe()
 	logger.Debug("started task: %s", desc)
+	startTime := emitTaskStarted(task)
 	errs := make(chan error)
 	if err := task.Do(errs); err != nil {
+		emitTaskFinished(task, startTime, err)
 		allErrs <- err
 		return false
 	}
 	if err := <-errs; err != nil {
+		emitTaskFinished(task, startTime, err)
 		allErrs <- err
 		return false
 	}
+	emitTaskFinished(task, startTime, nil)
 	logger.Debug("completed task: %s", desc)
 	return true
 }
 
+// emitTaskStarted emits an event for the start of a task, unless the task is a sub-tree, whose tasks emit their own events.
+func emitTaskStarted(task Task) time.Time {
+	startTime := time.Now().UTC()
+	if _, isTree := task.(*TaskTree); !isTree {
+		events.Emit(events.Event{
+			Type:      events.TaskStarted,
+			Time:      startTime,
+			Task:      task.Describe(),
+			StackName: stackNameOf(task),
+			StartTime: &startTime,
+		})
+	}
+	return startTime
+}
+
+func emitTaskFinished(task Task, startTime time.Time, err error) {
+	if _, isTree := task.(*TaskTree); isTree {
+		return
+	}
+	endTime := time.Now().UTC()
+	e := events.Event{
+		Type:      events.TaskCompleted,
+		Time:      endTime,
+		Task:      task.Describe(),
+		StackName: stackNameOf(task),
+		StartTime: &startTime,
+		EndTime:   &endTime,
+	}
+	if err != nil {
+		e.Type = events.TaskFailed
+		e.Error = err.Error()
+	}
+	events.Emit(e)
+}
+
+// stackNameOf returns the name of the stack of a StackTask, including one wrapped by a Checkpoint, so that task events
+// can be linked to the stack events of the task.
+func stackNameOf(task Task) string {
+	if t, ok := task.(*checkpointedTask); ok {
+		task = t.Task
+	}
+	if stackTask, ok := task.(StackTask); ok {
+		return stackTask.StackName()
+	}
+	return ""
+}
+
 func doParallelTasks(allErrs chan error, tasks []Task) {
 	wg := &sync.WaitGroup{}
 	wg.Add(len(tasks))
@@ -0,0 +1,11 @@
+package tasks
+
+import (
+	"testing"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func TestTasks(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
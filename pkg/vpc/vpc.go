@@ -94,6 +94,65 @@ func SetSubnets(vpc *api.ClusterVPC, availabilityZones, localZones []string) err
 	return nil
 }
 
+// SetPodSubnets defines CIDRs for the pod subnets used for custom networking,
+// it must be called after SetSubnets.
+func SetPodSubnets(vpc *api.ClusterVPC, availabilityZones []string) error {
+	podSubnets := vpc.PodSubnets
+	if podSubnets == nil {
+		return nil
+	}
+	if podSubnets.CIDR == nil {
+		cidr := api.DefaultPodSubnetsCIDR()
+		podSubnets.CIDR = &cidr
+	}
+
+	if len(podSubnets.Subnets) > 0 {
+		podSubnets.Subnets = api.AZSubnetMappingFromMap(podSubnets.Subnets)
+		return checkPodSubnetsZones(podSubnets, availabilityZones)
+	}
+
+	subnetSize, networkLength, err := getSubnetNetworkSize(podSubnets.CIDR.IPNet, len(availabilityZones))
+	if err != nil {
+		return err
+	}
+	zoneCIDRs, err := SplitInto(&podSubnets.CIDR.IPNet, subnetSize, networkLength)
+	if err != nil {
+		return err
+	}
+
+	podSubnets.Subnets = api.NewAZSubnetMapping()
+	for i, zone := range availabilityZones {
+		podSubnets.Subnets[zone] = api.AZSubnetSpec{
+			AZ:        zone,
+			CIDR:      &ipnet.IPNet{IPNet: *zoneCIDRs[i]},
+			CIDRIndex: i,
+		}
+		logger.Info("pod subnet for %s - %s", zone, zoneCIDRs[i])
+	}
+	return nil
+}
+
+// checkPodSubnetsZones ensures that there is exactly one pod subnet in each of the availability zones,
+// as the ENIConfig used by nodes is selected by their zone
+func checkPodSubnetsZones(podSubnets *api.PodSubnets, availabilityZones []string) error {
+	subnetsByZone := map[string]string{}
+	for name, subnet := range podSubnets.Subnets {
+		if !slices.Contains(availabilityZones, subnet.AZ) {
+			return fmt.Errorf("pod subnet %q is in %q, which is not one of the cluster's availability zones %v", name, subnet.AZ, availabilityZones)
+		}
+		if other, ok := subnetsByZone[subnet.AZ]; ok {
+			return fmt.Errorf("only one pod subnet can be specified per availability zone; found both %q and %q in %q", other, name, subnet.AZ)
+		}
+		subnetsByZone[subnet.AZ] = name
+	}
+	for _, zone := range availabilityZones {
+		if _, ok := subnetsByZone[zone]; !ok {
+			return fmt.Errorf("a pod subnet must be specified for availability zone %q", zone)
+		}
+	}
+	return nil
+}
+
 // A SubnetPair represents a pair of public and private subnets.
 type SubnetPair struct {
 	Public  []api.AZSubnetSpec
@@ -382,6 +441,15 @@ func UseFromClusterStack(ctx context.Context, provider api.ClusterProvider, stac
 		outputs.ClusterSubnetsPublicExtended: func(v string) error {
 			return ImportSubnetsByIDsWithAlias(ctx, provider.EC2(), spec, spec.VPC.Subnets.Public, splitOutputValue(v), MakeExtendedSubnetAliasFunc())
 		},
+		outputs.ClusterSubnetsPod: func(v string) error {
+			if spec.VPC.PodSubnets == nil {
+				spec.VPC.PodSubnets = &api.PodSubnets{}
+			}
+			if spec.VPC.PodSubnets.Subnets == nil {
+				spec.VPC.PodSubnets.Subnets = api.NewAZSubnetMapping()
+			}
+			return importSubnetsFromIDList(spec.VPC.PodSubnets.Subnets, v)
+		},
 		outputs.ClusterFullyPrivate: func(v string) error {
 			spec.PrivateCluster.Enabled = v == "true"
 			return nil
@@ -677,7 +745,36 @@ func ImportSubnetsFromSpec(ctx context.Context, ec2API awsapi.EC2, spec *api.Clu
 	}
 	// to clean up invalid subnets based on AZ after importing both private and public subnets
 	cleanupSubnets(spec)
-	return nil
+	return importPodSubnets(ctx, ec2API, spec)
+}
+
+// importPodSubnets will update spec with the pod subnets used for custom networking,
+// which must be in the VPC and availability zones of the cluster
+func importPodSubnets(ctx context.Context, ec2API awsapi.EC2, spec *api.ClusterConfig) error {
+	if !spec.HasPodSubnets() {
+		return nil
+	}
+	podSubnets := spec.VPC.PodSubnets
+	subnets, err := describeSubnets(ctx, ec2API, spec.VPC.ID, podSubnets.Subnets.WithIDs(), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	localSubnetConfig := api.AZSubnetMapping{}
+	for k, v := range podSubnets.Subnets {
+		localSubnetConfig[k] = v
+	}
+	for _, sn := range subnets {
+		if *sn.VpcId != spec.VPC.ID {
+			return fmt.Errorf("given pod subnet %s is in %s, not in %s", *sn.SubnetId, *sn.VpcId, spec.VPC.ID)
+		}
+		if err := api.ImportSubnet(podSubnets.Subnets, localSubnetConfig, &sn, func(subnet *ec2types.Subnet) string {
+			return *subnet.AvailabilityZone
+		}); err != nil {
+			return fmt.Errorf("could not import pod subnet %s: %w", *sn.SubnetId, err)
+		}
+	}
+	return checkPodSubnetsZones(podSubnets, spec.AvailabilityZones)
 }
 
 // UseEndpointAccessFromCluster retrieves the Cluster's endpoint access configuration via the SDK
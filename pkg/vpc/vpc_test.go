@@ -423,6 +423,72 @@ var _ = Describe("VPC", func() {
 		}),
 	)
 
+	type setPodSubnetsEntry struct {
+		podSubnets        *api.PodSubnets
+		availabilityZones []string
+
+		expectedSubnets api.AZSubnetMapping
+		expectedErr     string
+	}
+
+	DescribeTable("SetPodSubnets", func(e setPodSubnetsEntry) {
+		vpc := api.NewClusterVPC(false)
+		vpc.PodSubnets = e.podSubnets
+		err := SetPodSubnets(vpc, e.availabilityZones)
+		if e.expectedErr != "" {
+			Expect(err).To(MatchError(e.expectedErr))
+			return
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vpc.PodSubnets.Subnets).To(Equal(e.expectedSubnets))
+	},
+		Entry("splits the default CIDR", setPodSubnetsEntry{
+			podSubnets:        &api.PodSubnets{},
+			availabilityZones: []string{"us-west-2a", "us-west-2b", "us-west-2c"},
+
+			expectedSubnets: api.AZSubnetMapping{
+				"us-west-2a": {AZ: "us-west-2a", CIDR: ipnet.MustParseCIDR("100.64.0.0/19"), CIDRIndex: 0},
+				"us-west-2b": {AZ: "us-west-2b", CIDR: ipnet.MustParseCIDR("100.64.32.0/19"), CIDRIndex: 1},
+				"us-west-2c": {AZ: "us-west-2c", CIDR: ipnet.MustParseCIDR("100.64.64.0/19"), CIDRIndex: 2},
+			},
+		}),
+		Entry("keeps the specified subnets", setPodSubnetsEntry{
+			podSubnets: &api.PodSubnets{
+				CIDR: ipnet.MustParseCIDR("100.64.0.0/16"),
+				Subnets: api.AZSubnetMapping{
+					"us-west-2a": {CIDR: ipnet.MustParseCIDR("100.64.0.0/17")},
+					"us-west-2b": {CIDR: ipnet.MustParseCIDR("100.64.128.0/17")},
+				},
+			},
+			availabilityZones: []string{"us-west-2a", "us-west-2b"},
+
+			expectedSubnets: api.AZSubnetMapping{
+				"us-west-2a": {AZ: "us-west-2a", CIDR: ipnet.MustParseCIDR("100.64.0.0/17")},
+				"us-west-2b": {AZ: "us-west-2b", CIDR: ipnet.MustParseCIDR("100.64.128.0/17")},
+			},
+		}),
+		Entry("returns an error if an AZ has no pod subnet", setPodSubnetsEntry{
+			podSubnets: &api.PodSubnets{
+				Subnets: api.AZSubnetMapping{
+					"us-west-2a": {CIDR: ipnet.MustParseCIDR("100.64.0.0/17")},
+				},
+			},
+			availabilityZones: []string{"us-west-2a", "us-west-2b"},
+
+			expectedErr: `a pod subnet must be specified for availability zone "us-west-2b"`,
+		}),
+		Entry("returns an error if a pod subnet is not in the cluster's AZs", setPodSubnetsEntry{
+			podSubnets: &api.PodSubnets{
+				Subnets: api.AZSubnetMapping{
+					"us-west-2d": {CIDR: ipnet.MustParseCIDR("100.64.0.0/17")},
+				},
+			},
+			availabilityZones: []string{"us-west-2a"},
+
+			expectedErr: `pod subnet "us-west-2d" is in "us-west-2d", which is not one of the cluster's availability zones [us-west-2a]`,
+		}),
+	)
+
 	DescribeTable("Use from Cluster",
 		func(clusterCase useFromClusterCase) {
 			p := mockprovider.NewMockProvider()